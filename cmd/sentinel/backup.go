@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sxwebdev/sentinel/internal/backup"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/urfave/cli/v3"
+)
+
+func backupCMD() *cli.Command {
+	return &cli.Command{
+		Name:  "backup",
+		Usage: "back up the SQLite database, the server may keep running",
+		Flags: []cli.Flag{
+			cfgPathsFlag(),
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "backup file path. by default a new file is created in the backup directory",
+			},
+		},
+		Action: func(ctx context.Context, cl *cli.Command) error {
+			conf, err := config.Load(cl.String("config"))
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if conf.Database.Type != string(storage.StorageTypeSQLite) {
+				return fmt.Errorf("backups are only supported by sqlite storage, use pg_dump for postgres")
+			}
+
+			store, err := storage.NewSQLiteStorage(conf.Database.Path)
+			if err != nil {
+				return fmt.Errorf("failed to open database: %w", err)
+			}
+			defer store.Stop(ctx)
+
+			path := cl.String("output")
+			if path == "" {
+				path, err = backup.Create(ctx, store, conf.Backup.Directory)
+			} else {
+				err = store.Backup(ctx, path)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("database backed up to %s\n", path)
+			return nil
+		},
+	}
+}
+
+func restoreCMD() *cli.Command {
+	return &cli.Command{
+		Name:  "restore",
+		Usage: "restore the SQLite database from a backup, the server must be stopped",
+		Flags: []cli.Flag{
+			cfgPathsFlag(),
+			&cli.StringFlag{
+				Name:     "file",
+				Aliases:  []string{"f"},
+				Usage:    "backup file path",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cl *cli.Command) error {
+			conf, err := config.Load(cl.String("config"))
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if conf.Database.Type != string(storage.StorageTypeSQLite) {
+				return fmt.Errorf("restore is only supported by sqlite storage, use pg_restore for postgres")
+			}
+
+			version, err := storage.RestoreSQLite(ctx, cl.String("file"), conf.Database.Path)
+			if err != nil {
+				return fmt.Errorf("failed to restore database: %w", err)
+			}
+
+			fmt.Printf("database restored from %s, schema version %d\n", cl.String("file"), version)
+			if version < storage.SchemaVersion() {
+				fmt.Printf("the schema will be migrated to version %d on the next start\n", storage.SchemaVersion())
+			}
+			return nil
+		},
+	}
+}
@@ -51,6 +51,9 @@ func main() {
 		Commands: []*cli.Command{
 			startCMD(),
 			configCMD(),
+			backupCMD(),
+			restoreCMD(),
+			servicesCMD(),
 			versionCMD(),
 		},
 	}
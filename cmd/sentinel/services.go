@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/importers"
+	"github.com/sxwebdev/sentinel/internal/servicesync"
+	"github.com/urfave/cli/v3"
+)
+
+func servicesCMD() *cli.Command {
+	return &cli.Command{
+		Name:  "services",
+		Usage: "export and import services of a running server",
+		Commands: []*cli.Command{
+			{
+				Name:  "export",
+				Usage: "export all services",
+				Flags: append(apiFlags(),
+					&cli.StringFlag{
+						Name:  "format",
+						Value: "yaml",
+						Usage: "document format: json or yaml",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "output file path. by default the document is written to stdout",
+					},
+				),
+				Action: func(ctx context.Context, cl *cli.Command) error {
+					format, err := servicesync.ParseFormat(cl.String("format"))
+					if err != nil {
+						return err
+					}
+
+					query := url.Values{"format": {string(format)}}
+					data, err := callAPI(ctx, cl, http.MethodGet, "/services/export?"+query.Encode(), nil)
+					if err != nil {
+						return err
+					}
+
+					if cl.String("output") == "" {
+						_, err = os.Stdout.Write(data)
+						return err
+					}
+
+					if err := os.WriteFile(cl.String("output"), data, 0o600); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:  "import",
+				Usage: "create and update services matched by external ID",
+				Flags: append(apiFlags(),
+					&cli.StringFlag{
+						Name:     "file",
+						Aliases:  []string{"f"},
+						Usage:    "JSON or YAML document path, - reads from stdin",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "source",
+						Value: "sentinel",
+						Usage: "document source: sentinel, uptime-kuma (JSON backup) or gatus (YAML config)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "only print the changes",
+					},
+				),
+				Action: func(ctx context.Context, cl *cli.Command) error {
+					var (
+						data []byte
+						err  error
+					)
+					if cl.String("file") == "-" {
+						data, err = io.ReadAll(os.Stdin)
+					} else {
+						data, err = os.ReadFile(cl.String("file"))
+					}
+					if err != nil {
+						return fmt.Errorf("failed to read document: %w", err)
+					}
+
+					if cl.String("source") != "sentinel" {
+						data, err = convertDocument(cl.String("source"), data)
+						if err != nil {
+							return err
+						}
+					}
+
+					query := url.Values{"dry_run": {fmt.Sprint(cl.Bool("dry-run"))}}
+					body, apiErr := callAPI(ctx, cl, http.MethodPost, "/services/import?"+query.Encode(), data)
+
+					// A failed import reports the changes applied before the error
+					var res servicesync.ImportResult
+					if err := json.Unmarshal(body, &res); err != nil || (apiErr != nil && len(res.Changes) == 0) {
+						if apiErr != nil {
+							return apiErr
+						}
+						return fmt.Errorf("failed to parse response: %w", err)
+					}
+
+					for _, change := range res.Changes {
+						line := fmt.Sprintf("%-9s %s (%s)", change.Action, change.Name, change.ExternalID)
+						if len(change.Fields) > 0 {
+							line += ": " + strings.Join(change.Fields, ", ")
+						}
+						fmt.Println(line)
+					}
+
+					prefix := "imported"
+					if res.DryRun {
+						prefix = "dry run"
+					}
+					if apiErr != nil {
+						prefix = "applied before the error"
+					}
+					fmt.Printf("%s: %d created, %d updated, %d unchanged\n", prefix, res.Created, res.Updated, res.Unchanged)
+
+					return apiErr
+				},
+			},
+		},
+	}
+}
+
+// convertDocument converts a configuration of another tool to a services document,
+// features that cannot be converted are printed to stderr
+func convertDocument(sourceName string, data []byte) ([]byte, error) {
+	source, err := importers.ParseSource(sourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	conv, err := importers.Convert(source, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range conv.Issues {
+		fmt.Fprintf(os.Stderr, "unsupported: %s\n", issue)
+	}
+
+	doc := servicesync.Document{
+		Version:  servicesync.DocumentVersion,
+		Services: conv.Services,
+	}
+
+	return doc.Marshal(servicesync.FormatJSON)
+}
+
+// apiFlags returns flags to connect to the server API
+func apiFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "url",
+			Value:   "http://localhost:8080",
+			Usage:   "server URL",
+			Sources: cli.EnvVars("SENTINEL_URL"),
+		},
+		&cli.StringFlag{
+			Name:    "username",
+			Usage:   "basic auth username",
+			Sources: cli.EnvVars("SENTINEL_USERNAME"),
+		},
+		&cli.StringFlag{
+			Name:    "password",
+			Usage:   "basic auth password",
+			Sources: cli.EnvVars("SENTINEL_PASSWORD"),
+		},
+	}
+}
+
+// callAPI sends a request to the server API and returns the response body, also with the error of a failed request
+func callAPI(ctx context.Context, cl *cli.Command, method, path string, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	endpoint := strings.TrimSuffix(cl.String("url"), "/") + "/api/v1" + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if cl.String("username") != "" {
+		req.SetBasicAuth(cl.String("username"), cl.String("password"))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(data, &errResp); err == nil && errResp.Error != "" {
+			return data, fmt.Errorf("server returned %s: %s", resp.Status, errResp.Error)
+		}
+		return data, fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	return data, nil
+}
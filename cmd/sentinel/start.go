@@ -6,14 +6,21 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/sxwebdev/sentinel/internal/backup"
 	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/digest"
+	"github.com/sxwebdev/sentinel/internal/escalation"
+	"github.com/sxwebdev/sentinel/internal/history"
 	"github.com/sxwebdev/sentinel/internal/monitor"
 	"github.com/sxwebdev/sentinel/internal/notifier"
 	"github.com/sxwebdev/sentinel/internal/receiver"
 	"github.com/sxwebdev/sentinel/internal/scheduler"
+	"github.com/sxwebdev/sentinel/internal/servicesync"
+	"github.com/sxwebdev/sentinel/internal/slo"
 	"github.com/sxwebdev/sentinel/internal/storage"
 	"github.com/sxwebdev/sentinel/internal/upgrader"
 	"github.com/sxwebdev/sentinel/internal/web"
+	"github.com/sxwebdev/sentinel/internal/webhook"
 	"github.com/tkcrm/mx/launcher"
 	"github.com/tkcrm/mx/logger"
 	"github.com/tkcrm/mx/service"
@@ -57,25 +64,31 @@ func startCMD() *cli.Command {
 			}
 
 			// Initialize storage
-			store, err := storage.NewStorage(storage.StorageTypeSQLite, conf.Database.Path)
+			store, err := storage.NewStorage(storage.StorageType(conf.Database.Type), storage.Options{
+				Path:            conf.Database.Path,
+				DSN:             conf.Database.DSN,
+				MaxOpenConns:    conf.Database.MaxOpenConns,
+				MaxIdleConns:    conf.Database.MaxIdleConns,
+				ConnMaxLifetime: conf.Database.ConnMaxLifetime,
+			})
 			if err != nil {
 				return fmt.Errorf("failed to initialize storage: %w", err)
 			}
+			l.Infof("storage: %s", store.Name())
 
 			// Print SQLite version if using SQLite storage
 			sqliteVersion, err := store.GetSQLiteVersion(ctx)
 			if err != nil {
 				return fmt.Errorf("failed to get SQLite version: %w", err)
 			}
-			l.Infof("SQLite version: %s", sqliteVersion)
-
-			// Initialize notifier
-			var notif *notifier.Notifier
-			if conf.Notifications.Enabled {
-				notif, err = notifier.New(l, conf.Notifications.URLs)
-				if err != nil {
-					return fmt.Errorf("failed to initialize notifier: %w", err)
-				}
+			if sqliteVersion != "" {
+				l.Infof("SQLite version: %s", sqliteVersion)
+			}
+
+			// Initialize notifier, channels managed through the API are notified without notifications in the config
+			notif, err := notifier.New(l, conf, store)
+			if err != nil {
+				return fmt.Errorf("failed to initialize notifier: %w", err)
 			}
 
 			// Init receiver
@@ -91,7 +104,13 @@ func startCMD() *cli.Command {
 			monitorService := monitor.NewMonitorService(store, conf, notif, rc)
 
 			// Initialize scheduler
-			sched := scheduler.New(l, monitorService, rc)
+			sched := scheduler.New(l, conf.Monitoring.Scheduler, monitorService, rc)
+
+			// Initialize check history rollups and retention
+			hist := history.New(l, conf.Monitoring.History, store)
+
+			// Initialize SLO evaluation and burn rate alerts
+			sloEvaluator := slo.New(l, conf.Monitoring.SLO, conf.Monitoring.History.Retention, store, notif)
 
 			webServer, err := web.NewServer(l, conf, web.ServerInfo{
 				Version:       version,
@@ -112,12 +131,35 @@ func startCMD() *cli.Command {
 				service.New(service.WithService(store)),
 				service.New(service.WithService(rc)),
 				service.New(service.WithService(sched)),
+				service.New(service.WithService(hist)),
+				service.New(service.WithService(sloEvaluator)),
+				service.New(service.WithService(webhook.New(l, conf.Webhooks, store, rc))),
 				service.New(service.WithService(webServer)),
 			)
 
-			if notif != nil {
+			ln.ServicesRunner().Register(
+				service.New(service.WithService(notif)),
+				service.New(service.WithService(escalation.New(l, conf.Notifications.Escalation, store, notif))),
+			)
+
+			// Initialize the daily digest
+			if conf.Notifications.Digest.Enabled {
+				ln.ServicesRunner().Register(
+					service.New(service.WithService(digest.New(l, conf.Notifications.Digest, store, notif))),
+				)
+			}
+
+			// Initialize scheduled backups
+			if conf.Backup.Enabled {
+				ln.ServicesRunner().Register(
+					service.New(service.WithService(backup.NewScheduler(l, conf.Backup, store))),
+				)
+			}
+
+			// Initialize declared services sync
+			if conf.ServicesSyncEnabled() {
 				ln.ServicesRunner().Register(
-					service.New(service.WithService(notif)),
+					service.New(service.WithService(servicesync.New(l, conf, cl.String("config"), monitorService))),
 				)
 			}
 
@@ -225,7 +225,7 @@ func setupTestSuite() (*TestSuite, error) {
 	l := logger.Default()
 
 	// Initialize storage
-	stor, err := storage.NewStorage(storage.StorageTypeSQLite, dbPath)
+	stor, err := storage.NewStorage(storage.StorageTypeSQLite, storage.Options{Path: dbPath})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize storage: %w", err)
 	}
@@ -24,38 +24,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/dashboard/stats": {
-            "get": {
-                "description": "Returns statistics for the dashboard",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "dashboard"
-                ],
-                "summary": "Get dashboard statistics",
-                "responses": {
-                    "200": {
-                        "description": "Dashboard statistics",
-                        "schema": {
-                            "$ref": "#/definitions/web.DashboardStats"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/incidents": {
-            "get": {
-                "description": "Returns a list of recent incidents across all services",
+        "/alerts": {
+            "post": {
+                "description": "Receives alerts of other monitoring systems in a generic format. Firing alerts open incidents on the given services and the services they map to by their labels, resolved alerts resolve them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -63,88 +34,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "alerts"
                 ],
-                "summary": "Get recent incidents",
+                "summary": "Receive alerts",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by service ID or incident ID",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by resolved status",
-                        "name": "resolved",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page (default 100)",
-                        "name": "page_size",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of incidents",
-                        "schema": {
-                            "$ref": "#/definitions/dbutils.FindResponseWithCount-storage_Incident"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                        "description": "Alerts",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/web.ErrorResponse"
+                            "$ref": "#/definitions/web.AlertsRequest"
                         }
                     }
-                }
-            }
-        },
-        "/incidents/stats": {
-            "get": {
-                "description": "Returns the stats of incidents by date range",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "incidents"
-                ],
-                "summary": "Get incidents stats by date range",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Start time (RFC3339 format)",
-                        "name": "start_time",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End time (RFC3339 format)",
-                        "name": "end_time",
-                        "in": "query",
-                        "required": true
-                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Incidents stats by date range",
+                        "description": "Processed alerts",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/web.getIncidentsStatsItem"
-                            }
+                            "$ref": "#/definitions/monitor.AlertsResult"
                         }
                     },
                     "400": {
@@ -162,9 +70,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/server/health": {
-            "get": {
-                "description": "Checks the health of the server",
+        "/alerts/alertmanager": {
+            "post": {
+                "description": "Webhook receiver for Prometheus Alertmanager. Firing alerts open incidents on the services they map to by their labels, resolved alerts resolve them.\nThe title of an incident is the alertname label, its description the summary or description annotation.",
                 "consumes": [
                     "application/json"
                 ],
@@ -172,43 +80,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "server"
+                    "alerts"
                 ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "Health check successful",
-                        "schema": {
-                            "$ref": "#/definitions/web.healthCheckResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                "summary": "Receive Alertmanager alerts",
+                "parameters": [
+                    {
+                        "description": "Alertmanager notification",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/web.ErrorResponse"
+                            "$ref": "#/definitions/web.AlertmanagerRequest"
                         }
                     }
-                }
-            }
-        },
-        "/server/info": {
-            "get": {
-                "description": "Returns basic information about the server",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "server"
                 ],
-                "summary": "Get server info",
                 "responses": {
                     "200": {
-                        "description": "Server information",
+                        "description": "Processed alerts",
                         "schema": {
-                            "$ref": "#/definitions/web.ServerInfoResponse"
+                            "$ref": "#/definitions/monitor.AlertsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
@@ -220,9 +116,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/server/upgrade": {
+        "/dashboard/stats": {
             "get": {
-                "description": "Triggers a manual upgrade of the server",
+                "description": "Returns statistics for the dashboard",
                 "consumes": [
                     "application/json"
                 ],
@@ -230,14 +126,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "server"
+                    "dashboard"
                 ],
-                "summary": "Manual upgrade",
+                "summary": "Get dashboard statistics",
                 "responses": {
                     "200": {
-                        "description": "Upgrade initiated successfully",
+                        "description": "Dashboard statistics",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "$ref": "#/definitions/web.DashboardStats"
                         }
                     },
                     "500": {
@@ -249,9 +145,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/services": {
+        "/escalation-policies": {
             "get": {
-                "description": "Returns a list of all services with their current states",
+                "description": "Returns all escalation policies ordered by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -259,68 +155,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "services"
-                ],
-                "summary": "Get all services",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by service name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by service tags",
-                        "name": "tags",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by service status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by enabled status",
-                        "name": "is_enabled",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by protocol",
-                        "name": "protocol",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order by field",
-                        "name": "order_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (for pagination)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page (default 20)",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "notifications"
                 ],
+                "summary": "Get escalation policies",
                 "responses": {
                     "200": {
-                        "description": "List of services with states",
+                        "description": "List of escalation policies",
                         "schema": {
-                            "$ref": "#/definitions/dbutils.FindResponseWithCount-web_ServiceDTO"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/web.EscalationPolicyDTO"
+                            }
                         }
                     },
                     "500": {
@@ -332,7 +177,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new service for monitoring",
+                "description": "Creates a policy notifying the channels of each step about incidents of matching services still unacknowledged after the step delay,\nand reminding of them every repeat interval until they are acknowledged or resolved.",
                 "consumes": [
                     "application/json"
                 ],
@@ -340,25 +185,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "services"
+                    "notifications"
                 ],
-                "summary": "Create new service",
+                "summary": "Create escalation policy",
                 "parameters": [
                     {
-                        "description": "Service configuration",
-                        "name": "service",
+                        "description": "Escalation policy",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/web.CreateUpdateServiceRequest"
+                            "$ref": "#/definitions/web.CreateUpdateEscalationPolicyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Service created",
+                        "description": "Created escalation policy",
                         "schema": {
-                            "$ref": "#/definitions/web.ServiceDTO"
+                            "$ref": "#/definitions/web.EscalationPolicyDTO"
                         }
                     },
                     "400": {
@@ -376,9 +221,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{id}": {
+        "/escalation-policies/{id}": {
             "get": {
-                "description": "Returns detailed information about a specific service",
+                "description": "Returns an escalation policy",
                 "consumes": [
                     "application/json"
                 ],
@@ -386,13 +231,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "services"
+                    "notifications"
                 ],
-                "summary": "Get service details",
+                "summary": "Get escalation policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Escalation policy ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -400,19 +245,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service details with state",
+                        "description": "Escalation policy",
                         "schema": {
-                            "$ref": "#/definitions/web.ServiceDTO"
+                            "$ref": "#/definitions/web.EscalationPolicyDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "404": {
+                        "description": "Escalation policy not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Service not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -420,7 +265,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing service",
+                "description": "Updates an escalation policy, incidents keep the steps already notified",
                 "consumes": [
                     "application/json"
                 ],
@@ -428,32 +273,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "services"
+                    "notifications"
                 ],
-                "summary": "Update service",
+                "summary": "Update escalation policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Escalation policy ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "New service configuration",
-                        "name": "service",
+                        "description": "Escalation policy",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/web.CreateUpdateServiceRequest"
+                            "$ref": "#/definitions/web.CreateUpdateEscalationPolicyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service updated",
+                        "description": "Updated escalation policy",
                         "schema": {
-                            "$ref": "#/definitions/web.ServiceDTO"
+                            "$ref": "#/definitions/web.EscalationPolicyDTO"
                         }
                     },
                     "400": {
@@ -463,7 +308,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Service not found",
+                        "description": "Escalation policy not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -477,7 +322,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a service from the monitoring system",
+                "description": "Deletes an escalation policy with the escalation state of its incidents",
                 "consumes": [
                     "application/json"
                 ],
@@ -485,13 +330,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "services"
+                    "notifications"
                 ],
-                "summary": "Delete service",
+                "summary": "Delete escalation policy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Escalation policy ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -499,10 +344,10 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Service deleted"
+                        "description": "Escalation policy deleted"
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "404": {
+                        "description": "Escalation policy not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -516,9 +361,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{id}/check": {
-            "post": {
-                "description": "Triggers a manual check of service status",
+        "/incidents": {
+            "get": {
+                "description": "Returns a list of recent incidents across all services",
                 "consumes": [
                     "application/json"
                 ],
@@ -526,23 +371,90 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "services"
+                    "incidents"
                 ],
-                "summary": "Trigger service check",
+                "summary": "Get recent incidents",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by service ID, incident ID or title",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by resolved status",
+                        "name": "resolved",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by severity",
+                        "name": "severity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 100)",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Check triggered successfully",
+                        "description": "List of incidents",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "$ref": "#/definitions/dbutils.FindResponseWithCount-storage_Incident"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an incident for outages checks did not detect or planned degradations. Manual incidents are only resolved by users and do not send notifications.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Create incident",
+                "parameters": [
+                    {
+                        "description": "Incident",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateIncidentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created incident",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Incident"
                         }
                     },
                     "400": {
@@ -566,9 +478,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{id}/incidents": {
+        "/incidents/stats": {
             "get": {
-                "description": "Returns a list of incidents for a specific service",
+                "description": "Returns the stats of incidents by date range",
                 "consumes": [
                     "application/json"
                 ],
@@ -578,45 +490,31 @@ const docTemplate = `{
                 "tags": [
                     "incidents"
                 ],
-                "summary": "Get service incidents",
+                "summary": "Get incidents stats by date range",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Start time (RFC3339 format)",
+                        "name": "start_time",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Filter by incident ID",
-                        "name": "incident_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by resolved status",
-                        "name": "resolved",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (for pagination)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page (default 20)",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "End time (RFC3339 format)",
+                        "name": "end_time",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of incidents",
+                        "description": "Incidents stats by date range",
                         "schema": {
-                            "$ref": "#/definitions/dbutils.FindResponseWithCount-storage_Incident"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/web.getIncidentsStatsItem"
+                            }
                         }
                     },
                     "400": {
@@ -634,9 +532,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{id}/incidents/{incidentId}": {
-            "delete": {
-                "description": "Deletes a specific incident for a service",
+        "/notification-channels": {
+            "get": {
+                "description": "Returns the notification channels managed through the API ordered by name, with masked URLs",
                 "consumes": [
                     "application/json"
                 ],
@@ -644,28 +542,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "notifications"
                 ],
-                "summary": "Delete incident",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Service ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "Get notification channels",
+                "responses": {
+                    "200": {
+                        "description": "List of notification channels",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.NotificationChannel"
+                            }
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a named set of notification provider URLs targeted by notification rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Create notification channel",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Incident ID",
-                        "name": "incidentId",
-                        "in": "path",
-                        "required": true
+                        "description": "Notification channel",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateNotificationChannelRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Incident deleted"
+                    "201": {
+                        "description": "Created notification channel",
+                        "schema": {
+                            "$ref": "#/definitions/storage.NotificationChannel"
+                        }
                     },
                     "400": {
                         "description": "Bad request",
@@ -673,8 +599,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Incident not found",
+                    "409": {
+                        "description": "Notification channel already exists",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -688,9 +614,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{id}/resolve": {
-            "post": {
-                "description": "Forcefully resolves all active incidents for a service",
+        "/notification-channels/{id}": {
+            "get": {
+                "description": "Returns a notification channel with masked URLs",
                 "consumes": [
                     "application/json"
                 ],
@@ -698,13 +624,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "notifications"
                 ],
-                "summary": "Resolve service incidents",
+                "summary": "Get notification channel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Notification channel ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -712,13 +638,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Incidents resolved successfully",
+                        "description": "Notification channel",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "$ref": "#/definitions/storage.NotificationChannel"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "404": {
+                        "description": "Notification channel not found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -730,11 +656,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/services/{id}/stats": {
-            "get": {
-                "description": "Returns service statistics for the specified period",
+            },
+            "put": {
+                "description": "Updates a notification channel, the change applies to the next notification.\nThe URLs are kept when omitted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -742,29 +666,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "statistics"
+                    "notifications"
                 ],
-                "summary": "Get service statistics",
+                "summary": "Update notification channel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Notification channel ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of days (default 30)",
-                        "name": "days",
-                        "in": "query"
+                        "description": "Notification channel",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.UpdateNotificationChannelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service statistics",
+                        "description": "Updated notification channel",
                         "schema": {
-                            "$ref": "#/definitions/storage.ServiceStats"
+                            "$ref": "#/definitions/storage.NotificationChannel"
                         }
                     },
                     "400": {
@@ -773,6 +700,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Notification channel not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Notification channel already exists",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -780,11 +719,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tags": {
-            "get": {
-                "description": "Retrieves all unique tags used across services",
+            },
+            "delete": {
+                "description": "Deletes a notification channel, rules targeting it skip it",
                 "consumes": [
                     "application/json"
                 ],
@@ -792,17 +729,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "notifications"
+                ],
+                "summary": "Delete notification channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all tags",
                 "responses": {
-                    "200": {
-                        "description": "List of unique tags",
+                    "204": {
+                        "description": "Notification channel deleted"
+                    },
+                    "404": {
+                        "description": "Notification channel not found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
@@ -814,9 +760,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags/count": {
-            "get": {
-                "description": "Retrieves all unique tags along with their usage count across services",
+        "/notification-channels/{id}/test": {
+            "post": {
+                "description": "Sends a test notification to the URLs of a notification channel, also when it is disabled",
                 "consumes": [
                     "application/json"
                 ],
@@ -824,392 +770,4999 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "notifications"
+                ],
+                "summary": "Send test notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification channel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all tags with usage count",
                 "responses": {
                     "200": {
-                        "description": "Map of tags with their usage count",
+                        "description": "Test notification sent",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification channel not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Failed to send the test notification",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification-deliveries": {
+            "get": {
+                "description": "Returns deliveries of the notification outbox, one per notification and provider URL, latest first, with masked URLs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification deliveries",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "sent",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by channel name, empty for the default URLs",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by service ID",
+                        "name": "service_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by incident ID",
+                        "name": "incident_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of notification deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/dbutils.FindResponseWithCount-storage_NotificationDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification-deliveries/{id}/retry": {
+            "post": {
+                "description": "Moves a dead-lettered delivery back to the outbox with a new set of attempts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Retry notification delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification delivery",
+                        "schema": {
+                            "$ref": "#/definitions/storage.NotificationDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "The delivery is not dead-lettered",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification-rules": {
+            "get": {
+                "description": "Returns all notification routing rules ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification rules",
+                "responses": {
+                    "200": {
+                        "description": "List of notification rules",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.NotificationRule"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/web.ErrorResponse"
-                        }
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a rule routing notifications of matching incidents to notification channels.\nNotifications are sent to the channels of all enabled matching rules, notifications matching no rule are sent to the default URLs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Create notification rule",
+                "parameters": [
+                    {
+                        "description": "Notification rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUpdateNotificationRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created notification rule",
+                        "schema": {
+                            "$ref": "#/definitions/storage.NotificationRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification-rules/{id}": {
+            "get": {
+                "description": "Returns a notification routing rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification rule",
+                        "schema": {
+                            "$ref": "#/definitions/storage.NotificationRule"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a notification routing rule, the change applies to the next notification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update notification rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUpdateNotificationRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated notification rule",
+                        "schema": {
+                            "$ref": "#/definitions/storage.NotificationRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a notification routing rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Delete notification rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Notification rule deleted"
+                    },
+                    "404": {
+                        "description": "Notification rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oncall": {
+            "get": {
+                "description": "Returns the current shift of every on-call schedule with somebody on call",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Get current on-call",
+                "responses": {
+                    "200": {
+                        "description": "Current on-call shifts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.OnCallShift"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oncall-schedules": {
+            "get": {
+                "description": "Returns all on-call schedules ordered by name, with masked user URLs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Get on-call schedules",
+                "responses": {
+                    "200": {
+                        "description": "List of on-call schedules",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.OnCallSchedule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a weekly rotation of users in the configured timezone. Notification rules and escalation steps\ntarget the user on call with the oncall:\u003cschedule name\u003e channel.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Create on-call schedule",
+                "parameters": [
+                    {
+                        "description": "On-call schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUpdateOnCallScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created on-call schedule",
+                        "schema": {
+                            "$ref": "#/definitions/storage.OnCallSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "On-call schedule name is taken",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oncall-schedules/{id}": {
+            "get": {
+                "description": "Returns an on-call schedule with masked user URLs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Get on-call schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "On-call schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "On-call schedule",
+                        "schema": {
+                            "$ref": "#/definitions/storage.OnCallSchedule"
+                        }
+                    },
+                    "404": {
+                        "description": "On-call schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an on-call schedule, the URLs of existing users are kept when omitted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Update on-call schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "On-call schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "On-call schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUpdateOnCallScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated on-call schedule",
+                        "schema": {
+                            "$ref": "#/definitions/storage.OnCallSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "On-call schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "On-call schedule name is taken",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an on-call schedule, its on-call channels are skipped afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Delete on-call schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "On-call schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "On-call schedule deleted"
+                    },
+                    "404": {
+                        "description": "On-call schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oncall-schedules/{id}/oncall": {
+            "get": {
+                "description": "Returns the shift of an on-call schedule at the given time, now by default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Get on-call shift",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "On-call schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "On-call shift",
+                        "schema": {
+                            "$ref": "#/definitions/storage.OnCallShift"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "On-call schedule not found or nobody is on call",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oncall-schedules/{id}/overrides": {
+            "post": {
+                "description": "Puts a user of the schedule on call for a period instead of the rotation, expired overrides are removed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Create on-call override",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "On-call schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "On-call override",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.OnCallOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Updated on-call schedule",
+                        "schema": {
+                            "$ref": "#/definitions/storage.OnCallSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "On-call schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oncall-schedules/{id}/overrides/{overrideId}": {
+            "delete": {
+                "description": "Removes an override, the rotation applies again for its period",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oncall"
+                ],
+                "summary": "Delete on-call override",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "On-call schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "On-call override ID",
+                        "name": "overrideId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "On-call override deleted"
+                    },
+                    "404": {
+                        "description": "On-call schedule or override not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/server/backup": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates a consistent snapshot of the SQLite database and returns it as a file. Requires authentication to be enabled.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Back up database",
+                "responses": {
+                    "200": {
+                        "description": "Database snapshot",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Authentication is not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Backups are not supported by the storage",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/server/health": {
+            "get": {
+                "description": "Checks the health of the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "Health check successful",
+                        "schema": {
+                            "$ref": "#/definitions/web.healthCheckResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/server/info": {
+            "get": {
+                "description": "Returns basic information about the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Get server info",
+                "responses": {
+                    "200": {
+                        "description": "Server information",
+                        "schema": {
+                            "$ref": "#/definitions/web.ServerInfoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/server/upgrade": {
+            "get": {
+                "description": "Triggers a manual upgrade of the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "server"
+                ],
+                "summary": "Manual upgrade",
+                "responses": {
+                    "200": {
+                        "description": "Upgrade initiated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "description": "Returns a list of all services with their current states",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Get all services",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by service name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by service tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by service status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by enabled status",
+                        "name": "is_enabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by protocol",
+                        "name": "protocol",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (for pagination)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 20)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of services with states",
+                        "schema": {
+                            "$ref": "#/definitions/dbutils.FindResponseWithCount-web_ServiceDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new service for monitoring",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Create new service",
+                "parameters": [
+                    {
+                        "description": "Service configuration",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUpdateServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Service created",
+                        "schema": {
+                            "$ref": "#/definitions/web.ServiceDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/export": {
+            "get": {
+                "description": "Exports all services with their config and tags. Services without an external ID are exported with their ID as the external ID.",
+                "produces": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Export services",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "yaml"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Document format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Services document",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/import": {
+            "post": {
+                "description": "Creates and updates services of a document in JSON or YAML format, as returned by the export. Services are matched by external ID. With dry_run the changes are only returned. An invalid document changes nothing, if applying a service fails the error response lists the changes applied before it.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Import services",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only return the changes",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Services document",
+                        "name": "document",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import result",
+                        "schema": {
+                            "$ref": "#/definitions/servicesync.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "External ID already exists, with the changes applied before the error",
+                        "schema": {
+                            "$ref": "#/definitions/servicesync.ImportResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error, with the changes applied before the error",
+                        "schema": {
+                            "$ref": "#/definitions/servicesync.ImportResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}": {
+            "get": {
+                "description": "Returns detailed information about a specific service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Get service details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service details with state",
+                        "schema": {
+                            "$ref": "#/definitions/web.ServiceDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Update service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New service configuration",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUpdateServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service updated",
+                        "schema": {
+                            "$ref": "#/definitions/web.ServiceDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Service is read-only",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a service from the monitoring system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Delete service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Service deleted"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Service is read-only",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/check": {
+            "post": {
+                "description": "Triggers a manual check of service status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Trigger service check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Check triggered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/checks": {
+            "get": {
+                "description": "Returns individual check results of a service, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statistics"
+                ],
+                "summary": "Get service check history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of check results",
+                        "schema": {
+                            "$ref": "#/definitions/dbutils.FindResponseWithCount-storage_CheckResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/incidents": {
+            "get": {
+                "description": "Returns a list of incidents for a specific service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Get service incidents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by incident ID",
+                        "name": "incident_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by resolved status",
+                        "name": "resolved",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by severity",
+                        "name": "severity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (for pagination)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 20)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of incidents",
+                        "schema": {
+                            "$ref": "#/definitions/dbutils.FindResponseWithCount-storage_Incident"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/incidents/{incidentId}": {
+            "delete": {
+                "description": "Deletes a specific incident for a service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Delete incident",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Incident ID",
+                        "name": "incidentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Incident deleted"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Edits the title and postmortem of any incident, also after it is resolved. Description, severity, services and end time are only editable on manual incidents, setting the end time resolves the incident.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Update incident",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Incident ID",
+                        "name": "incidentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Edited fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.UpdateIncidentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated incident",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Incident"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Field is not editable on incidents of the monitor",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/incidents/{incidentId}/acknowledge": {
+            "post": {
+                "description": "Marks an active incident as taken by the user, repeat alerts are not sent for acknowledged incidents",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Acknowledge incident",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Incident ID",
+                        "name": "incidentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Author",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/web.IncidentAuthorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Acknowledged incident",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Incident"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Incident is resolved or already acknowledged",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/incidents/{incidentId}/errors": {
+            "get": {
+                "description": "Returns the distinct errors of an incident in the order they were seen, with the time of their first and last occurrence and the number of occurrences. A new entry is added each time the error changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Get incident errors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Incident ID",
+                        "name": "incidentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Incident errors, oldest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.IncidentError"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/incidents/{incidentId}/events": {
+            "get": {
+                "description": "Returns events of an incident: opened, error changes, acknowledgement, notes, sent notifications and resolution",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Get incident timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Incident ID",
+                        "name": "incidentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Incident events, oldest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.IncidentEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/incidents/{incidentId}/notes": {
+            "post": {
+                "description": "Adds a note of the user to the incident timeline",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Add incident note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Incident ID",
+                        "name": "incidentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.AddIncidentNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created note",
+                        "schema": {
+                            "$ref": "#/definitions/storage.IncidentEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/maintenance": {
+            "get": {
+                "description": "Returns maintenance windows of a service, they are excluded from the service uptime",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Get service maintenance windows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of maintenance windows",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.ServicePause"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Schedules a maintenance window of a service, it is excluded from the service uptime",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Create maintenance window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Maintenance window",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateMaintenanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/storage.ServicePause"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/maintenance/{windowId}": {
+            "delete": {
+                "description": "Deletes a maintenance window of a service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "Delete maintenance window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maintenance window ID",
+                        "name": "windowId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Maintenance window deleted"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Maintenance window not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/resolve": {
+            "post": {
+                "description": "Forcefully resolves all active incidents for a service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Resolve service incidents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Incidents resolved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/stats": {
+            "get": {
+                "description": "Returns service uptime statistics for the specified period.\nThe period is set by start_time and end_time or by the number of days until now.\nIf bucket is not set, it is chosen by the period: hourly up to 2 days, daily up to 90 days, weekly up to a year, monthly otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statistics"
+                ],
+                "summary": "Get service statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days (default 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Uptime bucket size",
+                        "name": "bucket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service statistics",
+                        "schema": {
+                            "$ref": "#/definitions/storage.ServiceStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/timeseries": {
+            "get": {
+                "description": "Returns latency and status time series of a service for charts.\nIf resolution is not set, it is chosen by the time range: raw up to 1 day, hourly up to 31 days, daily otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statistics"
+                ],
+                "summary": "Get service time series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time series resolution",
+                        "name": "resolution",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time series",
+                        "schema": {
+                            "$ref": "#/definitions/web.TimeSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slos": {
+            "get": {
+                "description": "Returns all SLOs with their SLI, remaining error budget and burn rates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slos"
+                ],
+                "summary": "Get SLOs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by service ID",
+                        "name": "service_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of SLOs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/web.SLOStatusDTO"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an SLO of a service or of all services with a tag.\nCheck counts are backfilled from the available check history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slos"
+                ],
+                "summary": "Create SLO",
+                "parameters": [
+                    {
+                        "description": "SLO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUpdateSLORequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created SLO",
+                        "schema": {
+                            "$ref": "#/definitions/web.SLODTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slos/{id}": {
+            "get": {
+                "description": "Returns an SLO with its SLI, remaining error budget and burn rates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slos"
+                ],
+                "summary": "Get SLO",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SLO ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SLO",
+                        "schema": {
+                            "$ref": "#/definitions/web.SLOStatusDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "SLO not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an SLO, check counts are recomputed from the available check history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slos"
+                ],
+                "summary": "Update SLO",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SLO ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "SLO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUpdateSLORequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated SLO",
+                        "schema": {
+                            "$ref": "#/definitions/web.SLODTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "SLO not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an SLO with its collected check counts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slos"
+                ],
+                "summary": "Delete SLO",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SLO ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "SLO deleted"
+                    },
+                    "404": {
+                        "description": "SLO not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Retrieves all unique tags used across services",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get all tags",
+                "responses": {
+                    "200": {
+                        "description": "List of unique tags",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/count": {
+            "get": {
+                "description": "Retrieves all unique tags along with their usage count across services",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get all tags with usage count",
+                "responses": {
+                    "200": {
+                        "description": "Map of tags with their usage count",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Returns the outbound webhooks ordered by name, without their secrets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhooks",
+                "responses": {
+                    "200": {
+                        "description": "List of webhooks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.Webhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a webhook receiving the subscribed events as signed JSON requests.\nA random secret is generated when omitted, the secret is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created webhook",
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Webhook already exists",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Returns a webhook without its secret",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a webhook, pending deliveries are sent to the new URL with the new secret.\nThe secret is kept when omitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated webhook",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Webhook already exists",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a webhook with its deliveries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Webhook deleted"
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Returns the deliveries of a webhook with their payloads and last response status, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "sent",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by event",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of webhook deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/dbutils.FindResponseWithCount-storage_WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{deliveryId}/retry": {
+            "post": {
+                "description": "Moves a dead-lettered webhook delivery back to the queue with a new set of attempts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook delivery",
+                        "schema": {
+                            "$ref": "#/definitions/storage.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "The delivery is not dead-lettered",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dbutils.FindResponseWithCount-storage_CheckResult": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.CheckResult"
+                    }
+                }
+            }
+        },
+        "dbutils.FindResponseWithCount-storage_Incident": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.Incident"
+                    }
+                }
+            }
+        },
+        "dbutils.FindResponseWithCount-storage_NotificationDelivery": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.NotificationDelivery"
+                    }
+                }
+            }
+        },
+        "dbutils.FindResponseWithCount-storage_WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.WebhookDelivery"
+                    }
+                }
+            }
+        },
+        "dbutils.FindResponseWithCount-web_ServiceDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.ServiceDTO"
+                    }
+                }
+            }
+        },
+        "monitor.AlertsResult": {
+            "type": "object",
+            "properties": {
+                "ignored": {
+                    "description": "Ignored alerts matched no services or resolved without an open incident",
+                    "type": "integer",
+                    "example": 0
+                },
+                "opened": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "resolved": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "monitors.Config": {
+            "type": "object",
+            "properties": {
+                "grpc": {
+                    "$ref": "#/definitions/monitors.GRPCConfig"
+                },
+                "http": {
+                    "$ref": "#/definitions/monitors.HTTPConfig"
+                },
+                "tcp": {
+                    "$ref": "#/definitions/monitors.TCPConfig"
+                }
+            }
+        },
+        "monitors.EndpointConfig": {
+            "type": "object",
+            "required": [
+                "expected_status",
+                "method",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "expected_status": {
+                    "type": "integer",
+                    "maximum": 599,
+                    "minimum": 100
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "json_path": {
+                    "description": "Path to extract value from JSON response",
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "POST",
+                        "PUT",
+                        "DELETE",
+                        "HEAD",
+                        "OPTIONS"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Basic Auth password",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Basic Auth username",
+                    "type": "string"
+                }
+            }
+        },
+        "monitors.GRPCConfig": {
+            "type": "object",
+            "required": [
+                "check_type",
+                "endpoint"
+            ],
+            "properties": {
+                "check_type": {
+                    "type": "string",
+                    "enum": [
+                        "health",
+                        "reflection",
+                        "connectivity"
+                    ]
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "insecure_tls": {
+                    "type": "boolean"
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "tls": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "monitors.HTTPConfig": {
+            "type": "object",
+            "required": [
+                "endpoints"
+            ],
+            "properties": {
+                "condition": {
+                    "type": "string"
+                },
+                "endpoints": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/monitors.EndpointConfig"
+                    }
+                },
+                "timeout": {
+                    "type": "integer",
+                    "example": 30000
+                }
+            }
+        },
+        "monitors.TCPConfig": {
+            "type": "object",
+            "required": [
+                "endpoint"
+            ],
+            "properties": {
+                "endpoint": {
+                    "type": "string"
+                },
+                "expect_data": {
+                    "type": "string"
+                },
+                "send_data": {
+                    "type": "string"
+                }
+            }
+        },
+        "servicesync.ImportAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "unchanged"
+            ],
+            "x-enum-varnames": [
+                "ImportActionCreate",
+                "ImportActionUpdate",
+                "ImportActionUnchanged"
+            ]
+        },
+        "servicesync.ImportChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/servicesync.ImportAction"
+                        }
+                    ],
+                    "example": "update"
+                },
+                "external_id": {
+                    "type": "string",
+                    "example": "api-gateway"
+                },
+                "fields": {
+                    "description": "changed fields of updated services",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "interval",
+                        "tags"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "API Gateway"
+                },
+                "service_id": {
+                    "type": "string",
+                    "example": "01K0AJ7Y0ZP7S5J1E9ZK4V1H2X"
+                }
+            }
+        },
+        "servicesync.ImportResult": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/servicesync.ImportChange"
+                    }
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "error": {
+                    "description": "Error stopped the import, the changes are the ones applied before it",
+                    "type": "string",
+                    "example": "failed to create service web: service with external ID web already exists"
+                },
+                "unchanged": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "storage.CheckErrorClass": {
+            "type": "string",
+            "enum": [
+                "timeout",
+                "connection",
+                "assertion"
+            ],
+            "x-enum-varnames": [
+                "CheckErrorClassTimeout",
+                "CheckErrorClassConnection",
+                "CheckErrorClassAssertion"
+            ]
+        },
+        "storage.CheckResult": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.EndpointCheck"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "response_time": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"up\", \"degraded\", \"down\"",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.ServiceStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "storage.EndpointCheck": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "storage.Incident": {
+            "type": "object",
+            "properties": {
+                "acknowledged_at": {
+                    "description": "AcknowledgedAt is set when a user takes the incident, repeat alerts are not sent after it",
+                    "type": "string"
+                },
+                "acknowledged_by": {
+                    "type": "string"
+                },
+                "affected_services": {
+                    "description": "AffectedServices lists services of a manual incident, ServiceID is the first of them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "alert_key": {
+                    "description": "AlertKey identifies the external alert of an incident opened by an alert",
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "postmortem": {
+                    "description": "Postmortem is editable after the incident is resolved",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.Postmortem"
+                        }
+                    ]
+                },
+                "resolved": {
+                    "type": "boolean"
+                },
+                "service_id": {
+                    "type": "string"
+                },
+                "severity": {
+                    "$ref": "#/definitions/storage.IncidentSeverity"
+                },
+                "source": {
+                    "$ref": "#/definitions/storage.IncidentSource"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.IncidentError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "type": "integer"
+                }
+            }
+        },
+        "storage.IncidentEvent": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "user of acknowledgements and notes",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/storage.IncidentEventType"
+                }
+            }
+        },
+        "storage.IncidentEventType": {
+            "type": "string",
+            "enum": [
+                "opened",
+                "error_changed",
+                "acknowledged",
+                "note_added",
+                "notification_sent",
+                "resolved",
+                "updated"
+            ],
+            "x-enum-varnames": [
+                "IncidentEventOpened",
+                "IncidentEventErrorChanged",
+                "IncidentEventAcknowledged",
+                "IncidentEventNoteAdded",
+                "IncidentEventNotificationSent",
+                "IncidentEventResolved",
+                "IncidentEventUpdated"
+            ]
+        },
+        "storage.IncidentSeverity": {
+            "type": "string",
+            "enum": [
+                "critical",
+                "warning"
+            ],
+            "x-enum-varnames": [
+                "IncidentSeverityCritical",
+                "IncidentSeverityWarning"
+            ]
+        },
+        "storage.IncidentSource": {
+            "type": "string",
+            "enum": [
+                "monitor",
+                "manual",
+                "alert"
+            ],
+            "x-enum-varnames": [
+                "IncidentSourceMonitor",
+                "IncidentSourceManual",
+                "IncidentSourceAlert"
+            ]
+        },
+        "storage.NotificationChannel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Format is the markup of the templates, used by providers supporting it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.NotificationFormat"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "templates": {
+                    "description": "Templates are Go message templates by event, escaped for the html format, events without a template use the default message",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "storage.NotificationDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "channel": {
+                    "description": "Channel is the name of the channel of the URL, empty for the default URLs",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/storage.NotificationEvent"
+                },
+                "format": {
+                    "$ref": "#/definitions/storage.NotificationFormat"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "service_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/storage.NotificationDeliveryStatus"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.NotificationDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "NotificationDeliveryStatusPending",
+                "NotificationDeliveryStatusSent",
+                "NotificationDeliveryStatusDead"
+            ]
+        },
+        "storage.NotificationEvent": {
+            "type": "string",
+            "enum": [
+                "alert",
+                "degraded",
+                "recovery",
+                "update",
+                "escalation",
+                "reminder"
+            ],
+            "x-enum-varnames": [
+                "NotificationEventAlert",
+                "NotificationEventDegraded",
+                "NotificationEventRecovery",
+                "NotificationEventUpdate",
+                "NotificationEventEscalation",
+                "NotificationEventReminder"
+            ]
+        },
+        "storage.NotificationFormat": {
+            "type": "string",
+            "enum": [
+                "text",
+                "markdown",
+                "html"
+            ],
+            "x-enum-varnames": [
+                "NotificationFormatText",
+                "NotificationFormatMarkdown",
+                "NotificationFormatHTML"
+            ]
+        },
+        "storage.NotificationRule": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.NotificationEvent"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "protocols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.ServiceProtocolType"
+                    }
+                },
+                "service_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.IncidentSeverity"
+                    }
+                },
+                "tags": {
+                    "description": "the service has any of the tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.OnCallOverride": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.OnCallSchedule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "handoff_time": {
+                    "description": "HandoffTime is the time of handoffs, HH:MM",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.OnCallOverride"
+                    }
+                },
+                "start_date": {
+                    "description": "StartDate is the date of the first shift of the first user, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "users": {
+                    "description": "Users are on call in turn, in this order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.OnCallUser"
+                    }
+                }
+            }
+        },
+        "storage.OnCallShift": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "override": {
+                    "description": "Override is set when the shift is an override of the rotation",
+                    "type": "boolean"
+                },
+                "schedule_id": {
+                    "type": "string"
+                },
+                "schedule_name": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.OnCallUser": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "storage.PauseReason": {
+            "type": "string",
+            "enum": [
+                "disabled",
+                "maintenance"
+            ],
+            "x-enum-varnames": [
+                "PauseReasonDisabled",
+                "PauseReasonMaintenance"
+            ]
+        },
+        "storage.Postmortem": {
+            "type": "object",
+            "properties": {
+                "action_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Alert on pool usage"
+                    ]
+                },
+                "impact": {
+                    "type": "string",
+                    "example": "Checkout was unavailable for 12 minutes"
+                },
+                "root_cause": {
+                    "type": "string",
+                    "example": "Connection pool exhausted after the deploy"
+                }
+            }
+        },
+        "storage.RetryStrategy": {
+            "type": "string",
+            "enum": [
+                "fixed",
+                "linear",
+                "exponential"
+            ],
+            "x-enum-varnames": [
+                "RetryStrategyFixed",
+                "RetryStrategyLinear",
+                "RetryStrategyExponential"
+            ]
+        },
+        "storage.SLOWindow": {
+            "type": "string",
+            "enum": [
+                "7d",
+                "28d",
+                "30d"
+            ],
+            "x-enum-varnames": [
+                "SLOWindow7d",
+                "SLOWindow28d",
+                "SLOWindow30d"
+            ]
+        },
+        "storage.ServicePause": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "$ref": "#/definitions/storage.PauseReason"
+                },
+                "service_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.ServiceProtocolType": {
+            "type": "string",
+            "enum": [
+                "http",
+                "tcp",
+                "grpc"
+            ],
+            "x-enum-varnames": [
+                "ServiceProtocolTypeHTTP",
+                "ServiceProtocolTypeTCP",
+                "ServiceProtocolTypeGRPC"
+            ]
+        },
+        "storage.ServiceStats": {
+            "type": "object",
+            "properties": {
+                "avg_response_time": {
+                    "type": "integer"
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.UptimeBucket"
+                    }
+                },
+                "check_uptime_percentage": {
+                    "type": "number"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "excluded_time": {
+                    "type": "integer"
+                },
+                "monitored_time": {
+                    "description": "MonitoredTime is the period without the time before the service was created,\ndisabled periods and maintenance windows",
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "total_checks": {
+                    "description": "TotalChecks and CheckUptimePercentage are computed from the check history",
+                    "type": "integer"
+                },
+                "total_downtime": {
+                    "type": "integer"
+                },
+                "total_incidents": {
+                    "type": "integer"
+                },
+                "uptime_percentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "storage.ServiceStatus": {
+            "type": "string",
+            "enum": [
+                "unknown",
+                "up",
+                "degraded",
+                "down",
+                "maintenance"
+            ],
+            "x-enum-varnames": [
+                "StatusUnknown",
+                "StatusUp",
+                "StatusDegraded",
+                "StatusDown",
+                "StatusMaintenance"
+            ]
+        },
+        "storage.UptimeBucket": {
+            "type": "object",
+            "properties": {
+                "downtime": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "incidents": {
+                    "type": "integer"
+                },
+                "monitored_time": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "uptime_percentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "storage.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Events the webhook is subscribed to, empty for all events",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.WebhookEvent"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/storage.WebhookEvent"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Payload is the JSON body of the request",
+                    "type": "string"
+                },
+                "response_status": {
+                    "description": "ResponseStatus is the HTTP status of the last attempt, 0 if no response was received",
+                    "type": "integer"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/storage.WebhookDeliveryStatus"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "storage.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryStatusPending",
+                "WebhookDeliveryStatusSent",
+                "WebhookDeliveryStatusDead"
+            ]
+        },
+        "storage.WebhookEvent": {
+            "type": "string",
+            "enum": [
+                "service.created",
+                "service.updated",
+                "service.deleted",
+                "service.state_changed",
+                "incident.opened",
+                "incident.updated",
+                "incident.resolved"
+            ],
+            "x-enum-varnames": [
+                "WebhookEventServiceCreated",
+                "WebhookEventServiceUpdated",
+                "WebhookEventServiceDeleted",
+                "WebhookEventServiceStateChanged",
+                "WebhookEventIncidentOpened",
+                "WebhookEventIncidentUpdated",
+                "WebhookEventIncidentResolved"
+            ]
+        },
+        "web.AddIncidentNoteRequest": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "author": {
+                    "description": "Author is required when authentication is disabled, otherwise the authenticated user is used",
+                    "type": "string",
+                    "example": "alice"
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 4000,
+                    "example": "Rolling back the last deploy"
+                }
+            }
+        },
+        "web.AlertRequest": {
+            "type": "object",
+            "required": [
+                "key",
+                "services",
+                "status",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 4000,
+                    "example": "Replica is 30s behind"
+                },
+                "ends_at": {
+                    "description": "EndsAt defaults to now for resolved alerts",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Key identifies the alert, the firing and resolved notifications of an alert must use the same key",
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "db-replication-lag"
+                },
+                "labels": {
+                    "description": "Labels are matched against the service label and the alert mappings",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "services": {
+                    "description": "Services are names, IDs or external IDs of affected services in addition to the mapped ones",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severity": {
+                    "enum": [
+                        "critical",
+                        "warning"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.IncidentSeverity"
+                        }
+                    ],
+                    "example": "critical"
+                },
+                "starts_at": {
+                    "description": "StartsAt defaults to now",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "firing",
+                        "resolved"
+                    ],
+                    "example": "firing"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Replication lag"
+                }
+            }
+        },
+        "web.AlertmanagerAlert": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "annotations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string",
+                    "example": "c5a6f3b1e2d4a7f8"
+                },
+                "generatorURL": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "firing",
+                        "resolved"
+                    ],
+                    "example": "firing"
+                }
+            }
+        },
+        "web.AlertmanagerRequest": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "$ref": "#/definitions/web.AlertmanagerAlert"
+                    }
+                },
+                "commonAnnotations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "commonLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "externalURL": {
+                    "type": "string"
+                },
+                "groupKey": {
+                    "type": "string"
+                },
+                "groupLabels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "receiver": {
+                    "type": "string",
+                    "example": "sentinel"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "firing"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "4"
+                }
+            }
+        },
+        "web.AlertsRequest": {
+            "type": "object",
+            "required": [
+                "alerts"
+            ],
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/web.AlertRequest"
+                    }
+                }
+            }
+        },
+        "web.AvailableUpdate": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "is_available_manual": {
+                    "type": "boolean"
+                },
+                "tag_name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.BurnRateAlertDTO": {
+            "type": "object",
+            "properties": {
+                "long_window": {
+                    "type": "integer",
+                    "maximum": 86400000,
+                    "example": 21600000
+                },
+                "short_window": {
+                    "type": "integer",
+                    "minimum": 60000,
+                    "example": 3600000
+                },
+                "threshold": {
+                    "type": "number",
+                    "example": 6
+                }
+            }
+        },
+        "web.CreateIncidentRequest": {
+            "type": "object",
+            "required": [
+                "service_ids",
+                "title"
+            ],
+            "properties": {
+                "author": {
+                    "description": "Author is required when authentication is disabled, otherwise the authenticated user is used",
+                    "type": "string",
+                    "example": "alice"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 4000,
+                    "example": "Card payments fail at the provider"
+                },
+                "end_time": {
+                    "description": "EndTime records an already resolved incident",
+                    "type": "string"
+                },
+                "service_ids": {
+                    "description": "ServiceIDs are the affected services, the first one is the primary service of the incident",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severity": {
+                    "enum": [
+                        "critical",
+                        "warning"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.IncidentSeverity"
+                        }
+                    ],
+                    "example": "critical"
+                },
+                "start_time": {
+                    "description": "StartTime defaults to now",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Payment provider outage"
+                }
+            }
+        },
+        "web.CreateMaintenanceRequest": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "start_time"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.CreateNotificationChannelRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "urls"
+            ],
+            "properties": {
+                "format": {
+                    "description": "Format is the markup of the templates, text by default",
+                    "enum": [
+                        "text",
+                        "markdown",
+                        "html"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.NotificationFormat"
+                        }
+                    ],
+                    "example": "markdown"
+                },
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "oncall"
+                },
+                "templates": {
+                    "description": "Templates are Go message templates by event, escaped for the html format: alert, degraded, recovery, update, escalation or reminder",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "urls": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "telegram://token@telegram?chats=@oncall"
+                    ]
+                }
+            }
+        },
+        "web.CreateUpdateEscalationPolicyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "service_ids",
+                "tags"
+            ],
+            "properties": {
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Production on-call"
+                },
+                "repeat_interval": {
+                    "description": "RepeatInterval is the interval of reminders in milliseconds, 0 disables them",
+                    "type": "integer",
+                    "minimum": 60000,
+                    "example": 1800000
+                },
+                "service_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.IncidentSeverity"
+                    }
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.EscalationStepDTO"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "production"
+                    ]
+                }
+            }
+        },
+        "web.CreateUpdateNotificationRuleRequest": {
+            "type": "object",
+            "required": [
+                "channels",
+                "name",
+                "service_ids",
+                "tags"
+            ],
+            "properties": {
+                "channels": {
+                    "description": "Channels are names of notification channels of the configuration or the API, or oncall:\u003cschedule name\u003e for the user on call",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "database"
+                    ]
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.NotificationEvent"
+                    }
+                },
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Database team"
+                },
+                "protocols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.ServiceProtocolType"
+                    }
+                },
+                "service_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.IncidentSeverity"
                     }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "database"
+                    ]
                 }
             }
-        }
-    },
-    "definitions": {
-        "dbutils.FindResponseWithCount-storage_Incident": {
+        },
+        "web.CreateUpdateOnCallScheduleRequest": {
             "type": "object",
+            "required": [
+                "handoff_time",
+                "name",
+                "start_date",
+                "users"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "handoff_time": {
+                    "type": "string",
+                    "example": "09:00"
                 },
-                "items": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "primary"
+                },
+                "overrides": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/storage.Incident"
+                        "$ref": "#/definitions/web.OnCallOverrideRequest"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "users": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/web.OnCallUserRequest"
                     }
                 }
             }
         },
-        "dbutils.FindResponseWithCount-web_ServiceDTO": {
+        "web.CreateUpdateSLORequest": {
             "type": "object",
+            "required": [
+                "name",
+                "window"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "burn_rate_alert": {
+                    "$ref": "#/definitions/web.BurnRateAlertDTO"
                 },
-                "items": {
+                "latency_target": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "name": {
+                    "type": "string",
+                    "example": "API availability"
+                },
+                "service_id": {
+                    "type": "string",
+                    "example": "service-1"
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "production"
+                },
+                "target": {
+                    "type": "number",
+                    "example": 99.9
+                },
+                "window": {
+                    "enum": [
+                        "7d",
+                        "28d",
+                        "30d"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.SLOWindow"
+                        }
+                    ],
+                    "example": "30d"
+                }
+            }
+        },
+        "web.CreateUpdateServiceRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/monitors.Config"
+                },
+                "external_id": {
+                    "type": "string",
+                    "example": "web-server"
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 60000
+                },
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "latency_warning": {
+                    "type": "integer",
+                    "example": 2000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Web Server"
+                },
+                "protocol": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.ServiceProtocolType"
+                        }
+                    ],
+                    "example": "http"
+                },
+                "retries": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "retry_policy": {
+                    "$ref": "#/definitions/web.RetryPolicyDTO"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/web.ServiceDTO"
+                        "type": "string"
+                    },
+                    "example": [
+                        "web",
+                        "production"
+                    ]
+                },
+                "threshold_policy": {
+                    "$ref": "#/definitions/web.ThresholdPolicyDTO"
+                },
+                "timeout": {
+                    "type": "integer",
+                    "example": 10000
+                }
+            }
+        },
+        "web.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "description": "Events are the subscribed events, all events when empty",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.WebhookEvent"
+                    },
+                    "example": [
+                        "incident.opened",
+                        "incident.resolved"
+                    ]
+                },
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "chatops"
+                },
+                "secret": {
+                    "description": "Secret signs the requests, a random secret is generated when omitted",
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16,
+                    "example": "7f9c2ba4e88f827d616045507605853e"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/hooks/sentinel"
+                }
+            }
+        },
+        "web.CreateWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Events the webhook is subscribed to, empty for all events",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.WebhookEvent"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "7f9c2ba4e88f827d616045507605853e"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.DashboardStats": {
+            "description": "Dashboard statistics",
+            "type": "object",
+            "properties": {
+                "active_incidents": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "avg_response_time": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "checks_per_minute": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "last_check_time": {
+                    "type": "string"
+                },
+                "protocols": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
                     }
+                },
+                "services_degraded": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "services_down": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "services_unknown": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "services_up": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "slos_alerting": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "slos_breached": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slos_total": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "total_checks": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "total_services": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "uptime_percentage": {
+                    "type": "number",
+                    "example": 95.5
                 }
             }
         },
-        "monitors.Config": {
+        "web.ErrorResponse": {
+            "description": "Error response",
             "type": "object",
             "properties": {
-                "grpc": {
-                    "$ref": "#/definitions/monitors.GRPCConfig"
-                },
-                "http": {
-                    "$ref": "#/definitions/monitors.HTTPConfig"
-                },
-                "tcp": {
-                    "$ref": "#/definitions/monitors.TCPConfig"
+                "error": {
+                    "type": "string",
+                    "example": "Error description"
                 }
             }
         },
-        "monitors.EndpointConfig": {
+        "web.EscalationPolicyDTO": {
             "type": "object",
-            "required": [
-                "expected_status",
-                "method",
-                "name",
-                "url"
-            ],
             "properties": {
-                "body": {
-                    "type": "string"
-                },
-                "expected_status": {
-                    "type": "integer",
-                    "maximum": 599,
-                    "minimum": 100
-                },
-                "headers": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "json_path": {
-                    "description": "Path to extract value from JSON response",
+                "created_at": {
                     "type": "string"
                 },
-                "method": {
+                "id": {
                     "type": "string",
-                    "enum": [
-                        "GET",
-                        "POST",
-                        "PUT",
-                        "DELETE",
-                        "HEAD",
-                        "OPTIONS"
-                    ]
+                    "example": "01HXYZ1234567890ABCDEF"
+                },
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Production on-call"
                 },
-                "password": {
-                    "description": "Basic Auth password",
-                    "type": "string"
+                "repeat_interval": {
+                    "type": "integer",
+                    "example": 1800000
                 },
-                "url": {
-                    "type": "string"
+                "service_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "username": {
-                    "description": "Basic Auth username",
-                    "type": "string"
-                }
-            }
-        },
-        "monitors.GRPCConfig": {
-            "type": "object",
-            "required": [
-                "check_type",
-                "endpoint"
-            ],
-            "properties": {
-                "check_type": {
-                    "type": "string",
-                    "enum": [
-                        "health",
-                        "reflection",
-                        "connectivity"
-                    ]
+                "severities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.IncidentSeverity"
+                    }
                 },
-                "endpoint": {
-                    "type": "string"
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.EscalationStepDTO"
+                    }
                 },
-                "insecure_tls": {
-                    "type": "boolean"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "production"
+                    ]
                 },
-                "service_name": {
+                "updated_at": {
                     "type": "string"
-                },
-                "tls": {
-                    "type": "boolean"
                 }
             }
         },
-        "monitors.HTTPConfig": {
+        "web.EscalationStepDTO": {
             "type": "object",
             "required": [
-                "endpoints"
+                "channels"
             ],
             "properties": {
-                "condition": {
-                    "type": "string"
-                },
-                "endpoints": {
+                "channels": {
+                    "description": "Channels are names of notification channels of the configuration or the API, or oncall:\u003cschedule name\u003e for the user on call",
                     "type": "array",
                     "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/monitors.EndpointConfig"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "oncall"
+                    ]
                 },
-                "timeout": {
+                "delay": {
+                    "description": "Delay is the time since the start of the incident in milliseconds",
                     "type": "integer",
-                    "example": 30000
+                    "minimum": 60000,
+                    "example": 900000
                 }
             }
         },
-        "monitors.TCPConfig": {
+        "web.IncidentAuthorRequest": {
             "type": "object",
-            "required": [
-                "endpoint"
-            ],
             "properties": {
-                "endpoint": {
-                    "type": "string"
-                },
-                "expect_data": {
-                    "type": "string"
-                },
-                "send_data": {
-                    "type": "string"
+                "author": {
+                    "description": "Author is required when authentication is disabled, otherwise the authenticated user is used",
+                    "type": "string",
+                    "example": "alice"
                 }
             }
         },
-        "storage.Incident": {
+        "web.OnCallOverrideRequest": {
             "type": "object",
+            "required": [
+                "end",
+                "start",
+                "user"
+            ],
             "properties": {
-                "duration": {
-                    "type": "integer"
-                },
-                "end_time": {
-                    "type": "string"
-                },
-                "error": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "resolved": {
-                    "type": "boolean"
+                "end": {
+                    "type": "string",
+                    "example": "2025-01-11T09:00:00Z"
                 },
-                "service_id": {
-                    "type": "string"
+                "start": {
+                    "type": "string",
+                    "example": "2025-01-10T18:00:00Z"
                 },
-                "start_time": {
-                    "type": "string"
+                "user": {
+                    "type": "string",
+                    "example": "bob"
                 }
             }
         },
-        "storage.ServiceProtocolType": {
-            "type": "string",
-            "enum": [
-                "http",
-                "tcp",
-                "grpc"
-            ],
-            "x-enum-varnames": [
-                "ServiceProtocolTypeHTTP",
-                "ServiceProtocolTypeTCP",
-                "ServiceProtocolTypeGRPC"
-            ]
-        },
-        "storage.ServiceStats": {
+        "web.OnCallUserRequest": {
             "type": "object",
+            "required": [
+                "name",
+                "urls"
+            ],
             "properties": {
-                "avg_response_time": {
-                    "type": "integer"
-                },
-                "period": {
-                    "type": "integer"
-                },
-                "service_id": {
-                    "type": "string"
-                },
-                "total_downtime": {
-                    "type": "integer"
-                },
-                "total_incidents": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "alice"
                 },
-                "uptime_percentage": {
-                    "type": "number"
+                "urls": {
+                    "description": "URLs are personal notification provider URLs, kept for an existing user when omitted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "telegram://token@telegram?chats=@alice"
+                    ]
                 }
             }
         },
-        "storage.ServiceStatus": {
-            "type": "string",
-            "enum": [
-                "unknown",
-                "up",
-                "down",
-                "maintenance"
-            ],
-            "x-enum-varnames": [
-                "StatusUnknown",
-                "StatusUp",
-                "StatusDown",
-                "StatusMaintenance"
-            ]
-        },
-        "web.AvailableUpdate": {
+        "web.RetryPolicyDTO": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "base_delay": {
+                    "type": "integer",
+                    "example": 500
                 },
-                "is_available_manual": {
-                    "type": "boolean"
+                "max_delay": {
+                    "type": "integer",
+                    "example": 30000
                 },
-                "tag_name": {
-                    "type": "string"
+                "retry_on": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.CheckErrorClass"
+                    },
+                    "example": [
+                        "timeout",
+                        "connection"
+                    ]
                 },
-                "url": {
-                    "type": "string"
+                "strategy": {
+                    "enum": [
+                        "fixed",
+                        "linear",
+                        "exponential"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.RetryStrategy"
+                        }
+                    ],
+                    "example": "exponential"
                 }
             }
         },
-        "web.CreateUpdateServiceRequest": {
+        "web.SLODTO": {
             "type": "object",
             "properties": {
-                "config": {
-                    "$ref": "#/definitions/monitors.Config"
+                "alerting": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "interval": {
-                    "type": "integer",
-                    "example": 60000
+                "burn_rate_alert": {
+                    "$ref": "#/definitions/web.BurnRateAlertDTO"
                 },
-                "is_enabled": {
-                    "type": "boolean",
-                    "example": true
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HXYZ1234567890ABCDEF"
+                },
+                "latency_target": {
+                    "type": "integer",
+                    "example": 500
                 },
                 "name": {
                     "type": "string",
-                    "example": "Web Server"
+                    "example": "API availability"
                 },
-                "protocol": {
+                "service_id": {
+                    "type": "string",
+                    "example": "service-1"
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "production"
+                },
+                "target": {
+                    "type": "number",
+                    "example": 99.9
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "window": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/storage.ServiceProtocolType"
+                            "$ref": "#/definitions/storage.SLOWindow"
                         }
                     ],
-                    "example": "http"
-                },
-                "retries": {
-                    "type": "integer",
-                    "example": 5
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "web",
-                        "production"
-                    ]
-                },
-                "timeout": {
-                    "type": "integer",
-                    "example": 10000
+                    "example": "30d"
                 }
             }
         },
-        "web.DashboardStats": {
-            "description": "Dashboard statistics",
+        "web.SLOStatusDTO": {
+            "description": "SLO compliance and error budget",
             "type": "object",
             "properties": {
-                "active_incidents": {
-                    "type": "integer",
-                    "example": 2
-                },
-                "avg_response_time": {
-                    "type": "integer",
-                    "example": 150
+                "burn_rate_long": {
+                    "type": "number",
+                    "example": 0.3
                 },
-                "checks_per_minute": {
-                    "type": "integer",
-                    "example": 60
+                "burn_rate_short": {
+                    "type": "number",
+                    "example": 0.5
                 },
-                "last_check_time": {
-                    "type": "string"
+                "compliant": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "protocols": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
-                    }
+                "error_budget_remaining": {
+                    "type": "number",
+                    "example": 76.8
                 },
-                "services_down": {
+                "good_checks": {
                     "type": "integer",
-                    "example": 1
+                    "example": 43190
                 },
-                "services_unknown": {
-                    "type": "integer",
-                    "example": 1
+                "sli": {
+                    "type": "number",
+                    "example": 99.97
                 },
-                "services_up": {
-                    "type": "integer",
-                    "example": 8
+                "slo": {
+                    "$ref": "#/definitions/web.SLODTO"
                 },
                 "total_checks": {
                     "type": "integer",
-                    "example": 1000
+                    "example": 43200
                 },
-                "total_services": {
-                    "type": "integer",
-                    "example": 10
+                "window_end": {
+                    "type": "string"
                 },
-                "uptime_percentage": {
-                    "type": "number",
-                    "example": 95.5
-                }
-            }
-        },
-        "web.ErrorResponse": {
-            "description": "Error response",
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Error description"
+                "window_start": {
+                    "type": "string"
                 }
             }
         },
@@ -1267,6 +5820,10 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 5
                 },
+                "external_id": {
+                    "type": "string",
+                    "example": "web-server"
+                },
                 "id": {
                     "type": "string",
                     "example": "service-1"
@@ -1279,6 +5836,10 @@ const docTemplate = `{
                     "type": "boolean",
                     "example": true
                 },
+                "is_flapping": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "last_check": {
                     "type": "string",
                     "example": "2023-10-01T12:00:00Z"
@@ -1287,6 +5848,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Connection timeout"
                 },
+                "latency_warning": {
+                    "type": "integer",
+                    "example": 2000
+                },
+                "managed_by": {
+                    "type": "string",
+                    "example": "file"
+                },
                 "name": {
                     "type": "string",
                     "example": "Web Server"
@@ -1303,6 +5872,10 @@ const docTemplate = `{
                     ],
                     "example": "http"
                 },
+                "read_only": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "response_time": {
                     "type": "integer",
                     "example": 150000000
@@ -1311,13 +5884,16 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 5
                 },
+                "retry_policy": {
+                    "$ref": "#/definitions/web.RetryPolicyDTO"
+                },
                 "status": {
                     "allOf": [
                         {
                             "$ref": "#/definitions/storage.ServiceStatus"
                         }
                     ],
-                    "example": "up / down / unknown"
+                    "example": "up / degraded / down / unknown"
                 },
                 "tags": {
                     "type": "array",
@@ -1329,6 +5905,9 @@ const docTemplate = `{
                         "production"
                     ]
                 },
+                "threshold_policy": {
+                    "$ref": "#/definitions/web.ThresholdPolicyDTO"
+                },
                 "timeout": {
                     "type": "integer",
                     "example": 10000
@@ -1353,6 +5932,252 @@ const docTemplate = `{
                 }
             }
         },
+        "web.ThresholdPolicyDTO": {
+            "type": "object",
+            "properties": {
+                "fail_threshold": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 3
+                },
+                "flap_threshold": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 6
+                },
+                "flap_window": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 20
+                },
+                "recovery_threshold": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 2
+                },
+                "window": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 5
+                }
+            }
+        },
+        "web.TimeSeriesPoint": {
+            "description": "Service check time series point",
+            "type": "object",
+            "properties": {
+                "avg_response_time": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "degraded_checks": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "down_checks": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_response_time": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "min_response_time": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "p95_response_time": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "p99_response_time": {
+                    "type": "integer",
+                    "example": 850
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_checks": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "up_checks": {
+                    "type": "integer",
+                    "example": 58
+                },
+                "uptime_percentage": {
+                    "type": "number",
+                    "example": 98.3
+                }
+            }
+        },
+        "web.TimeSeriesResponse": {
+            "description": "Service check time series",
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.TimeSeriesPoint"
+                    }
+                },
+                "resolution": {
+                    "type": "string",
+                    "example": "hour"
+                },
+                "service_id": {
+                    "type": "string",
+                    "example": "service-1"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.UpdateIncidentRequest": {
+            "type": "object",
+            "required": [
+                "service_ids"
+            ],
+            "properties": {
+                "author": {
+                    "description": "Author is required when authentication is disabled, otherwise the authenticated user is used",
+                    "type": "string",
+                    "example": "alice"
+                },
+                "description": {
+                    "description": "Description, severity, services and end time are only editable on manual incidents",
+                    "type": "string",
+                    "maxLength": 4000
+                },
+                "end_time": {
+                    "description": "EndTime resolves the incident",
+                    "type": "string"
+                },
+                "postmortem": {
+                    "$ref": "#/definitions/storage.Postmortem"
+                },
+                "service_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severity": {
+                    "enum": [
+                        "critical",
+                        "warning"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.IncidentSeverity"
+                        }
+                    ],
+                    "example": "warning"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Payment provider outage"
+                }
+            }
+        },
+        "web.UpdateNotificationChannelRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "urls"
+            ],
+            "properties": {
+                "format": {
+                    "description": "Format is the markup of the templates, text by default",
+                    "enum": [
+                        "text",
+                        "markdown",
+                        "html"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/storage.NotificationFormat"
+                        }
+                    ],
+                    "example": "markdown"
+                },
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "oncall"
+                },
+                "templates": {
+                    "description": "Templates are Go message templates by event, escaped for the html format: alert, degraded, recovery, update, escalation or reminder",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "telegram://token@telegram?chats=@oncall"
+                    ]
+                }
+            }
+        },
+        "web.UpdateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.WebhookEvent"
+                    },
+                    "example": [
+                        "incident.opened",
+                        "incident.resolved"
+                    ]
+                },
+                "is_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "chatops"
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16,
+                    "example": "7f9c2ba4e88f827d616045507605853e"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/hooks/sentinel"
+                }
+            }
+        },
         "web.getIncidentsStatsItem": {
             "type": "object",
             "properties": {
@@ -11,7 +11,13 @@ import {
   TooltipProvider,
   TooltipTrigger,
 } from "@/shared/components/ui";
-import { CheckIcon, CircleAlertIcon, TrashIcon, CopyIcon } from "lucide-react";
+import {
+  CheckIcon,
+  CircleAlertIcon,
+  TrashIcon,
+  CopyIcon,
+  HandIcon,
+} from "lucide-react";
 import { cn } from "@/shared/lib/utils";
 import { ExpandableText } from "@/shared/components/expandableText";
 import { formatDuration } from "@/shared/utils";
@@ -28,6 +34,7 @@ interface IncidentsListProps {
   filters: GetServicesIdIncidentsParams;
   setFilters: (filters: Partial<GetServicesIdIncidentsParams>) => void;
   setDeleteIncident: (incident: StorageIncident) => void;
+  onAcknowledgeIncident: (incidentId: string) => void;
 }
 
 export const IncidentsList = ({
@@ -36,6 +43,7 @@ export const IncidentsList = ({
   filters,
   setFilters,
   setDeleteIncident,
+  onAcknowledgeIncident,
 }: IncidentsListProps) => {
   // State to track copied incident IDs
   const [copiedIncidents, setCopiedIncidents] = useState<Set<string>>(
@@ -150,6 +158,18 @@ export const IncidentsList = ({
                     >
                       {incident.resolved ? "Resolved" : "Active"}
                     </Badge>
+
+                    {incident.acknowledged_by && (
+                      <Badge
+                        variant="outline"
+                        className="text-xs font-medium"
+                        title={new Date(
+                          incident.acknowledged_at ?? ""
+                        ).toLocaleString()}
+                      >
+                        Acknowledged by {incident.acknowledged_by}
+                      </Badge>
+                    )}
                   </div>
 
                   <div className="text-sm text-muted-foreground">
@@ -191,8 +211,19 @@ export const IncidentsList = ({
                   </div>
                 </div>
 
-                {/* Action Button */}
-                <div className="flex-shrink-0">
+                {/* Action Buttons */}
+                <div className="flex-shrink-0 flex items-center gap-1">
+                  {!incident.resolved && !incident.acknowledged_by && (
+                    <Button
+                      size="sm"
+                      variant="ghost"
+                      className="h-8 px-2 text-xs"
+                      onClick={() => onAcknowledgeIncident(incident.id ?? "")}
+                    >
+                      <HandIcon className="h-3.5 w-3.5" />
+                      Acknowledge
+                    </Button>
+                  )}
                   <Button
                     size="sm"
                     variant="ghost"
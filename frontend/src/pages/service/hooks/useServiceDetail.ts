@@ -29,6 +29,7 @@ export const useServiceDetail = (serviceID: string) => {
   const {
     getServicesIdIncidents,
     deleteServicesIdIncidentsIncidentId,
+    postServicesIdIncidentsIncidentIdAcknowledge,
     postServicesIdResolve,
   } = getIncidents();
 
@@ -91,6 +92,28 @@ export const useServiceDetail = (serviceID: string) => {
       });
   };
 
+  // Acknowledge incident, the author is asked only when authentication is disabled
+  const onAcknowledgeIncident = async (incidentId: string, author?: string) => {
+    await postServicesIdIncidentsIncidentIdAcknowledge(
+      serviceID ?? "",
+      incidentId,
+      author ? { author } : undefined
+    )
+      .then(() => {
+        getAllIncidents();
+        toast.success("Incident acknowledged");
+      })
+      .catch((err) => {
+        const error = err.response?.data?.error;
+        if (!author && error === "author is required") {
+          const name = window.prompt("Acknowledge as");
+          if (name) onAcknowledgeIncident(incidentId, name);
+          return;
+        }
+        toast.error(error);
+      });
+  };
+
   // Resolve incident
   const onResolveIncident = async () => {
     await postServicesIdResolve(serviceID ?? "")
@@ -151,6 +174,7 @@ export const useServiceDetail = (serviceID: string) => {
     filters,
     onCheckService,
     onDeleteIncident,
+    onAcknowledgeIncident,
     onResolveIncident,
     setFilters,
     setDeleteIncident,
@@ -109,13 +109,20 @@ export const useServiceTable = () => {
         accessorKey: "service",
         cell: ({ row }) => {
           return (
-            <Link
-              to="/service/$service_id"
-              params={{ service_id: row.original.id || "" }}
-              className="cursor-pointer font-bold text-sm hover:underline"
-            >
-              {row.original.name}
-            </Link>
+            <div className="flex items-center gap-2">
+              <Link
+                to="/service/$service_id"
+                params={{ service_id: row.original.id || "" }}
+                className="cursor-pointer font-bold text-sm hover:underline"
+              >
+                {row.original.name}
+              </Link>
+              {row.original.read_only && (
+                <Badge variant="outline" className="text-xs">
+                  read-only
+                </Badge>
+              )}
+            </div>
           );
         },
       },
@@ -254,6 +261,7 @@ export const useServiceTable = () => {
                     <span>Check</span>
                   </DropdownMenuItem>
                   <DropdownMenuItem
+                    disabled={row.original?.read_only}
                     onClick={() => setUpdateServiceId(row.original?.id ?? null)}
                   >
                     <PencilIcon /> <span>Edit</span>
@@ -266,6 +274,7 @@ export const useServiceTable = () => {
                   <DropdownMenuSeparator />
                   <DropdownMenuItem
                     className="text-destructive focus:text-destructive"
+                    disabled={row.original?.read_only}
                     onClick={() => setDeleteServiceId(row.original?.id ?? null)}
                   >
                     <TrashIcon className="group-hover:text-white" />
@@ -21,6 +21,7 @@ const ServiceDetail = ({ serviceID }: ServiceDetailProps) => {
     onCheckService,
     setDeleteIncident,
     onDeleteIncident,
+    onAcknowledgeIncident,
     setResolveIncident,
     onResolveIncident,
   } = useServiceDetail(serviceID);
@@ -65,6 +66,7 @@ const ServiceDetail = ({ serviceID }: ServiceDetailProps) => {
           filters={filters}
           setFilters={setFilters}
           setDeleteIncident={setDeleteIncident}
+          onAcknowledgeIncident={onAcknowledgeIncident}
         />
       </div>
     </>
@@ -10,8 +10,15 @@ import type {
   GetIncidentsParams,
   GetIncidentsStatsParams,
   GetServicesIdIncidentsParams,
+  StorageIncident,
+  StorageIncidentError,
+  StorageIncidentEvent,
+  WebAddIncidentNoteRequest,
+  WebCreateIncidentRequest,
   WebGetIncidentsStatsItem,
+  WebIncidentAuthorRequest,
   WebSuccessResponse,
+  WebUpdateIncidentRequest,
 } from "../../types/model";
 
 import { customFetcher } from ".././baseApi";
@@ -28,6 +35,18 @@ export const getIncidents = () => {
       params,
     });
   };
+  /**
+   * Creates an incident for outages checks did not detect or planned degradations. Manual incidents are only resolved by users and do not send notifications.
+   * @summary Create incident
+   */
+  const postIncidents = (webCreateIncidentRequest: WebCreateIncidentRequest) => {
+    return customFetcher<StorageIncident>({
+      url: `/incidents`,
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      data: webCreateIncidentRequest,
+    });
+  };
   /**
    * Returns the stats of incidents by date range
    * @summary Get incidents stats by date range
@@ -53,6 +72,22 @@ export const getIncidents = () => {
       params,
     });
   };
+  /**
+   * Edits the title and postmortem of any incident, also after it is resolved. Description, severity, services and end time are only editable on manual incidents, setting the end time resolves the incident.
+   * @summary Update incident
+   */
+  const patchServicesIdIncidentsIncidentId = (
+    id: string,
+    incidentId: string,
+    webUpdateIncidentRequest: WebUpdateIncidentRequest
+  ) => {
+    return customFetcher<StorageIncident>({
+      url: `/services/${id}/incidents/${incidentId}`,
+      method: "PATCH",
+      headers: { "Content-Type": "application/json" },
+      data: webUpdateIncidentRequest,
+    });
+  };
   /**
    * Deletes a specific incident for a service
    * @summary Delete incident
@@ -66,6 +101,64 @@ export const getIncidents = () => {
       method: "DELETE",
     });
   };
+  /**
+   * Marks an active incident as taken by the user, repeat alerts are not sent for acknowledged incidents
+   * @summary Acknowledge incident
+   */
+  const postServicesIdIncidentsIncidentIdAcknowledge = (
+    id: string,
+    incidentId: string,
+    webIncidentAuthorRequest?: WebIncidentAuthorRequest
+  ) => {
+    return customFetcher<StorageIncident>({
+      url: `/services/${id}/incidents/${incidentId}/acknowledge`,
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      data: webIncidentAuthorRequest,
+    });
+  };
+  /**
+   * Returns events of an incident: opened, error changes, acknowledgement, notes, sent notifications and resolution
+   * @summary Get incident timeline
+   */
+  const getServicesIdIncidentsIncidentIdEvents = (
+    id: string,
+    incidentId: string
+  ) => {
+    return customFetcher<StorageIncidentEvent[]>({
+      url: `/services/${id}/incidents/${incidentId}/events`,
+      method: "GET",
+    });
+  };
+  /**
+   * Returns the distinct errors of an incident in the order they were seen, with the time of their first and last occurrence and the number of occurrences. A new entry is added each time the error changes.
+   * @summary Get incident errors
+   */
+  const getServicesIdIncidentsIncidentIdErrors = (
+    id: string,
+    incidentId: string
+  ) => {
+    return customFetcher<StorageIncidentError[]>({
+      url: `/services/${id}/incidents/${incidentId}/errors`,
+      method: "GET",
+    });
+  };
+  /**
+   * Adds a note of the user to the incident timeline
+   * @summary Add incident note
+   */
+  const postServicesIdIncidentsIncidentIdNotes = (
+    id: string,
+    incidentId: string,
+    webAddIncidentNoteRequest: WebAddIncidentNoteRequest
+  ) => {
+    return customFetcher<StorageIncidentEvent>({
+      url: `/services/${id}/incidents/${incidentId}/notes`,
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      data: webAddIncidentNoteRequest,
+    });
+  };
   /**
    * Forcefully resolves all active incidents for a service
    * @summary Resolve service incidents
@@ -78,9 +171,15 @@ export const getIncidents = () => {
   };
   return {
     getIncidents,
+    postIncidents,
     getIncidentsStats,
     getServicesIdIncidents,
+    patchServicesIdIncidentsIncidentId,
     deleteServicesIdIncidentsIncidentId,
+    postServicesIdIncidentsIncidentIdAcknowledge,
+    getServicesIdIncidentsIncidentIdEvents,
+    getServicesIdIncidentsIncidentIdErrors,
+    postServicesIdIncidentsIncidentIdNotes,
     postServicesIdResolve,
   };
 };
@@ -6,15 +6,21 @@
  * OpenAPI spec version: 1.0
  */
 
+import type { GetIncidentsSource } from "./getIncidentsSource";
+
 export type GetIncidentsParams = {
   /**
-   * Filter by service ID or incident ID
+   * Filter by service ID, incident ID or title
    */
   search?: string;
   /**
    * Filter by resolved status
    */
   resolved?: boolean;
+  /**
+   * Filter by source
+   */
+  source?: GetIncidentsSource;
   /**
    * Page number (default 1)
    */
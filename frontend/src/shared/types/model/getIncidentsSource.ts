@@ -0,0 +1,17 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+export type GetIncidentsSource =
+  (typeof GetIncidentsSource)[keyof typeof GetIncidentsSource];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const GetIncidentsSource = {
+  monitor: "monitor",
+  manual: "manual",
+  alert: "alert",
+} as const;
@@ -10,6 +10,7 @@ export * from "./dbutilsFindResponseWithCountStorageIncident";
 export * from "./dbutilsFindResponseWithCountWebIncident";
 export * from "./dbutilsFindResponseWithCountWebServiceDTO";
 export * from "./getIncidentsParams";
+export * from "./getIncidentsSource";
 export * from "./getIncidentsStatsParams";
 export * from "./getServicesIdIncidentsParams";
 export * from "./getServicesIdStatsParams";
@@ -24,10 +25,18 @@ export * from "./monitorsGRPCConfigCheckType";
 export * from "./monitorsHTTPConfig";
 export * from "./monitorsTCPConfig";
 export * from "./storageIncident";
+export * from "./storageIncidentError";
+export * from "./storageIncidentEvent";
+export * from "./storageIncidentEventType";
+export * from "./storageIncidentSeverity";
+export * from "./storageIncidentSource";
+export * from "./storagePostmortem";
 export * from "./storageServiceProtocolType";
 export * from "./storageServiceStats";
 export * from "./storageServiceStatus";
+export * from "./webAddIncidentNoteRequest";
 export * from "./webAvailableUpdate";
+export * from "./webCreateIncidentRequest";
 export * from "./webCreateUpdateServiceRequest";
 export * from "./webDashboardStats";
 export * from "./webDashboardStatsProtocols";
@@ -35,7 +44,9 @@ export * from "./webErrorResponse";
 export * from "./webGetIncidentsStatsItem";
 export * from "./webHealthCheckResponse";
 export * from "./webIncident";
+export * from "./webIncidentAuthorRequest";
 export * from "./webServerInfoResponse";
 export * from "./webServiceDTO";
 export * from "./webServiceStats";
 export * from "./webSuccessResponse";
+export * from "./webUpdateIncidentRequest";
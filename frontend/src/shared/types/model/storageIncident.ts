@@ -6,12 +6,27 @@
  * OpenAPI spec version: 1.0
  */
 
+import type { StorageIncidentSeverity } from "./storageIncidentSeverity";
+import type { StorageIncidentSource } from "./storageIncidentSource";
+import type { StoragePostmortem } from "./storagePostmortem";
+
 export interface StorageIncident {
+  acknowledged_at?: string;
+  acknowledged_by?: string;
+  /** AffectedServices lists services of a manual incident, ServiceID is the first of them */
+  affected_services?: string[];
+  /** AlertKey identifies the external alert of an incident opened by an alert */
+  alert_key?: string;
   duration?: number;
   end_time?: string;
   error?: string;
   id?: string;
+  /** Postmortem is editable after the incident is resolved */
+  postmortem?: StoragePostmortem;
   resolved?: boolean;
   service_id?: string;
+  severity?: StorageIncidentSeverity;
+  source?: StorageIncidentSource;
   start_time?: string;
+  title?: string;
 }
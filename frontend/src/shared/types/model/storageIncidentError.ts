@@ -0,0 +1,16 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+export interface StorageIncidentError {
+  error?: string;
+  first_seen_at?: string;
+  id?: string;
+  incident_id?: string;
+  last_seen_at?: string;
+  occurrences?: number;
+}
@@ -0,0 +1,17 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+import type { StorageIncidentEventType } from "./storageIncidentEventType";
+
+export interface StorageIncidentEvent {
+  author?: string;
+  created_at?: string;
+  id?: string;
+  incident_id?: string;
+  message?: string;
+  type?: StorageIncidentEventType;
+}
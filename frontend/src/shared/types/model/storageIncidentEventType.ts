@@ -0,0 +1,21 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+export type StorageIncidentEventType =
+  (typeof StorageIncidentEventType)[keyof typeof StorageIncidentEventType];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const StorageIncidentEventType = {
+  opened: "opened",
+  error_changed: "error_changed",
+  acknowledged: "acknowledged",
+  note_added: "note_added",
+  notification_sent: "notification_sent",
+  resolved: "resolved",
+  updated: "updated",
+} as const;
@@ -0,0 +1,16 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+export type StorageIncidentSeverity =
+  (typeof StorageIncidentSeverity)[keyof typeof StorageIncidentSeverity];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const StorageIncidentSeverity = {
+  critical: "critical",
+  warning: "warning",
+} as const;
@@ -0,0 +1,17 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+export type StorageIncidentSource =
+  (typeof StorageIncidentSource)[keyof typeof StorageIncidentSource];
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const StorageIncidentSource = {
+  monitor: "monitor",
+  manual: "manual",
+  alert: "alert",
+} as const;
@@ -0,0 +1,13 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+export interface StoragePostmortem {
+  action_items?: string[];
+  impact?: string;
+  root_cause?: string;
+}
@@ -0,0 +1,23 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+import type { StorageIncidentSeverity } from "./storageIncidentSeverity";
+
+export interface WebCreateIncidentRequest {
+  /** Author is required when authentication is disabled, otherwise the authenticated user is used */
+  author?: string;
+  description?: string;
+  /** EndTime records an already resolved incident */
+  end_time?: string;
+  /** ServiceIDs are the affected services, the first one is the primary service of the incident */
+  service_ids: string[];
+  severity?: StorageIncidentSeverity;
+  /** StartTime defaults to now */
+  start_time?: string;
+  title: string;
+}
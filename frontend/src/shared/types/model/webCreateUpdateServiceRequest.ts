@@ -10,6 +10,7 @@ import type { StorageServiceProtocolType } from "./storageServiceProtocolType";
 
 export interface WebCreateUpdateServiceRequest {
   config?: MonitorsConfig;
+  external_id?: string;
   interval?: number;
   is_enabled?: boolean;
   name?: string;
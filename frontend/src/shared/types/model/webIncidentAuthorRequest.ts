@@ -0,0 +1,12 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+export interface WebIncidentAuthorRequest {
+  /** Author is required when authentication is disabled, otherwise the authenticated user is used */
+  author?: string;
+}
@@ -14,14 +14,17 @@ export interface WebServiceDTO {
   config?: MonitorsConfig;
   consecutive_fails?: number;
   consecutive_success?: number;
+  external_id?: string;
   id?: string;
   interval?: number;
   is_enabled?: boolean;
   last_check?: string;
   last_error?: string;
+  managed_by?: string;
   name?: string;
   next_check?: string;
   protocol?: StorageServiceProtocolType;
+  read_only?: boolean;
   response_time?: number;
   retries?: number;
   status?: StorageServiceStatus;
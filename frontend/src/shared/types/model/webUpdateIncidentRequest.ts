@@ -0,0 +1,23 @@
+/**
+ * Generated by orval v7.11.1 🍺
+ * Do not edit manually.
+ * Sentinel Monitoring API
+ * API for service monitoring and incident management
+ * OpenAPI spec version: 1.0
+ */
+
+import type { StorageIncidentSeverity } from "./storageIncidentSeverity";
+import type { StoragePostmortem } from "./storagePostmortem";
+
+export interface WebUpdateIncidentRequest {
+  /** Author is required when authentication is disabled, otherwise the authenticated user is used */
+  author?: string;
+  /** Description, severity, services and end time are only editable on manual incidents */
+  description?: string;
+  /** EndTime resolves the incident */
+  end_time?: string;
+  postmortem?: StoragePostmortem;
+  service_ids?: string[];
+  severity?: StorageIncidentSeverity;
+  title?: string;
+}
@@ -0,0 +1,163 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+const (
+	filePrefix = "sentinel-"
+	fileExt    = ".sqlite"
+	timeLayout = "20060102-150405"
+)
+
+// FileName returns the backup file name for the given time
+func FileName(t time.Time) string {
+	return filePrefix + t.UTC().Format(timeLayout) + fileExt
+}
+
+// Create writes a new backup of the storage to the directory and returns its path
+func Create(ctx context.Context, store storage.Storage, dir string) (string, error) {
+	path := filepath.Join(dir, FileName(time.Now()))
+	if err := store.Backup(ctx, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// List returns backups in the directory ordered from oldest to newest
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	files := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	// Names contain the backup time, lexical order is chronological
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// Rotate deletes the oldest backups in the directory, keeping the given number of backups
+func Rotate(dir string, keep int) ([]string, error) {
+	files, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) <= keep {
+		return nil, nil
+	}
+
+	deleted := files[:len(files)-keep]
+	for _, file := range deleted {
+		if err := os.Remove(file); err != nil {
+			return nil, fmt.Errorf("failed to delete backup %s: %w", file, err)
+		}
+	}
+
+	return deleted, nil
+}
+
+// Scheduler makes periodic backups of the storage and rotates old ones
+type Scheduler struct {
+	logger  logger.Logger
+	config  config.BackupConfig
+	storage storage.Storage
+
+	stop chan struct{}
+}
+
+// NewScheduler creates a new backup scheduler
+func NewScheduler(l logger.Logger, cfg config.BackupConfig, store storage.Storage) *Scheduler {
+	return &Scheduler{
+		logger:  l,
+		config:  cfg,
+		storage: store,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Name returns the name of the service
+func (s *Scheduler) Name() string { return "backup-scheduler" }
+
+// Start makes backups periodically until the service is stopped.
+// The first backup is made once the interval has passed since the latest backup.
+func (s *Scheduler) Start(ctx context.Context) error {
+	timer := time.NewTimer(s.nextBackupIn())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-timer.C:
+		}
+
+		s.run(ctx)
+		timer.Reset(s.config.Interval)
+	}
+}
+
+// Stop stops the service
+func (s *Scheduler) Stop(_ context.Context) error {
+	close(s.stop)
+	return nil
+}
+
+// nextBackupIn returns the delay until the next backup
+func (s *Scheduler) nextBackupIn() time.Duration {
+	files, err := List(s.config.Directory)
+	if err != nil || len(files) == 0 {
+		return 0
+	}
+
+	info, err := os.Stat(files[len(files)-1])
+	if err != nil {
+		return 0
+	}
+
+	return max(s.config.Interval-time.Since(info.ModTime()), 0)
+}
+
+// run makes a backup and deletes the oldest ones
+func (s *Scheduler) run(ctx context.Context) {
+	path, err := Create(ctx, s.storage, s.config.Directory)
+	if err != nil {
+		s.logger.Errorf("failed to back up database: %v", err)
+		return
+	}
+	s.logger.Infof("database backed up to %s", path)
+
+	deleted, err := Rotate(s.config.Directory, s.config.Keep)
+	if err != nil {
+		s.logger.Errorf("failed to rotate backups: %v", err)
+		return
+	}
+	for _, file := range deleted {
+		s.logger.Debugf("deleted old backup %s", file)
+	}
+}
@@ -0,0 +1,46 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var names []string
+	for i := range 5 {
+		name := FileName(start.Add(time.Duration(i) * time.Hour))
+		names = append(names, name)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	// Unrelated files are never touched
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644))
+
+	deleted, err := Rotate(dir, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, names[0]),
+		filepath.Join(dir, names[1]),
+		filepath.Join(dir, names[2]),
+	}, deleted)
+
+	files, err := List(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, names[3]),
+		filepath.Join(dir, names[4]),
+	}, files)
+	assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+
+	files, err = List(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/sxwebdev/sentinel/internal/storage"
 	"github.com/tkcrm/mx/logger"
 	"github.com/tkcrm/mx/ops"
 )
@@ -14,9 +16,15 @@ type Config struct {
 	Server        ServerConfig        `yaml:"server"`
 	Monitoring    MonitoringConfig    `yaml:"monitoring"`
 	Database      DatabaseConfig      `yaml:"database"`
+	Backup        BackupConfig        `yaml:"backup"`
 	Notifications NotificationsConfig `yaml:"notifications"`
+	Webhooks      WebhooksConfig      `yaml:"webhooks"`
+	Alerts        AlertsConfig        `yaml:"alerts"`
 	Timezone      string              `yaml:"timezone"`
 	Upgrader      Upgrader            `yaml:"upgrader"`
+	// Services are declared services synced into storage, see ServicesSyncConfig
+	Services     []storage.CreateUpdateServiceRequest `yaml:"services,omitempty"`
+	ServicesSync ServicesSyncConfig                   `yaml:"services_sync"`
 }
 
 // ServerConfig holds web server configuration
@@ -28,6 +36,15 @@ type ServerConfig struct {
 	Auth     AuthConfig     `yaml:"auth"`
 }
 
+// BaseURL returns the public URL of the web interface, assuming HTTPS for production domains
+func (s ServerConfig) BaseURL() string {
+	protocol := "http"
+	if s.BaseHost != "localhost:8080" && s.BaseHost != "127.0.0.1:8080" {
+		protocol = "https"
+	}
+	return fmt.Sprintf("%s://%s", protocol, s.BaseHost)
+}
+
 // AuthConfig holds authentication settings
 type AuthConfig struct {
 	Enabled bool       `yaml:"enabled"`
@@ -48,7 +65,10 @@ type FrontendConfig struct {
 
 // MonitoringConfig holds global monitoring settings
 type MonitoringConfig struct {
-	Global GlobalConfig `yaml:"global"`
+	Global    GlobalConfig    `yaml:"global"`
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+	History   HistoryConfig   `yaml:"history"`
+	SLO       SLOConfig       `yaml:"slo"`
 }
 
 // GlobalConfig holds default monitoring parameters
@@ -58,15 +78,189 @@ type GlobalConfig struct {
 	DefaultRetries  int           `yaml:"default_retries"`
 }
 
+// SchedulerConfig holds check scheduling limits
+type SchedulerConfig struct {
+	// MaxConcurrency limits the number of checks running at the same time
+	MaxConcurrency int `yaml:"max_concurrency"`
+	// ProtocolLimits optionally limits concurrent checks per protocol (http, tcp, grpc)
+	ProtocolLimits map[string]int `yaml:"protocol_limits"`
+	// HostLimit optionally limits concurrent checks against the same host (0 - unlimited)
+	HostLimit int `yaml:"host_limit"`
+	// MaxStartupJitter is the upper bound of the delay applied to initial checks on startup.
+	// The actual delay is derived from the service ID and never exceeds the service interval.
+	MaxStartupJitter time.Duration `yaml:"max_startup_jitter"`
+}
+
+// HistoryConfig holds check history retention and rollup settings
+type HistoryConfig struct {
+	// Retention is how long individual check results are kept
+	Retention time.Duration `yaml:"retention"`
+	// HourlyRetention is how long hourly rollups are kept
+	HourlyRetention time.Duration `yaml:"hourly_retention"`
+	// DailyRetention is how long daily rollups are kept
+	DailyRetention time.Duration `yaml:"daily_retention"`
+	// RollupInterval is how often check results are rolled up and purged
+	RollupInterval time.Duration `yaml:"rollup_interval"`
+}
+
+// SLOConfig holds service level objective settings
+type SLOConfig struct {
+	// EvaluationInterval is how often SLO check counts are updated and burn rate alerts are evaluated
+	EvaluationInterval time.Duration `yaml:"evaluation_interval"`
+}
+
 // DatabaseConfig holds database settings
 type DatabaseConfig struct {
+	// Type is the storage backend: sqlite or postgres
+	Type string `yaml:"type"`
+	// Path is the SQLite database file
 	Path string `yaml:"path"`
+	// DSN is the PostgreSQL connection string
+	DSN string `yaml:"dsn"`
+	// MaxOpenConns limits open PostgreSQL connections, SQLite always uses a single connection
+	MaxOpenConns int `yaml:"max_open_conns"`
+	// MaxIdleConns limits idle PostgreSQL connections
+	MaxIdleConns int `yaml:"max_idle_conns"`
+	// ConnMaxLifetime is how long a PostgreSQL connection may be reused
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+}
+
+// BackupConfig holds scheduled SQLite backup settings
+type BackupConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Directory is where backups are written
+	Directory string `yaml:"directory"`
+	// Interval is how often backups are made
+	Interval time.Duration `yaml:"interval"`
+	// Keep is the number of most recent backups kept in the directory
+	Keep int `yaml:"keep"`
+}
+
+// ServicesSyncConfig holds settings of declared services sync.
+// The sync is enabled when the services section or Path is set.
+type ServicesSyncConfig struct {
+	// Path is a YAML file or a directory of YAML files with a services section
+	Path string `yaml:"path"`
+	// Prune deletes services of the files that are no longer declared, services created in the API are kept
+	Prune bool `yaml:"prune"`
+	// WatchInterval is how often declared services are checked for changes
+	WatchInterval time.Duration `yaml:"watch_interval"`
 }
 
 // NotificationsConfig holds notification settings for multiple providers
 type NotificationsConfig struct {
+	// Enabled sends notifications to the URLs and channels of the config,
+	// channels managed through the API are notified regardless
 	Enabled bool     `yaml:"enabled"`
 	URLs    []string `yaml:"urls"`
+	// WarningURLs receive notifications of degraded services, defaults to URLs
+	WarningURLs []string `yaml:"warning_urls"`
+	// NotifyErrorChanges sends an update when the error of an ongoing incident changes to one not seen before in it
+	NotifyErrorChanges bool `yaml:"notify_error_changes"`
+	// Channels are named groups of provider URLs targeted by notification rules
+	Channels []NotificationChannel `yaml:"channels"`
+	// Format is the markup of the templates of the default URLs: text, markdown or html
+	Format storage.NotificationFormat `yaml:"format"`
+	// Templates are message templates of the default URLs by event: alert, degraded, recovery, update, escalation or reminder
+	Templates map[storage.NotificationEvent]string `yaml:"templates"`
+	// Delivery configures retries of the notification outbox
+	Delivery DeliveryConfig `yaml:"delivery"`
+	// Escalation configures the evaluation of escalation policies
+	Escalation EscalationConfig `yaml:"escalation"`
+	// Grouping configures the batching of incident notifications
+	Grouping GroupingConfig `yaml:"grouping"`
+	// RateLimit limits the messages sent to each provider URL of every channel and the default URLs
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	// Digest configures the daily summary of incidents, uptime and response times
+	Digest DigestConfig `yaml:"digest"`
+}
+
+// GroupingConfig holds settings of incident notification grouping
+type GroupingConfig struct {
+	// Window is how long incident notifications are held to be combined with the following ones
+	// into a single message per provider URL (0 - disabled)
+	Window time.Duration `yaml:"window"`
+	// MaxSize is the maximum number of notifications combined into a message
+	MaxSize int `yaml:"max_size"`
+}
+
+// RateLimitConfig limits the messages sent to a provider URL, messages over the limit are deferred, not dropped
+type RateLimitConfig struct {
+	// Messages is the number of messages allowed per period (0 - unlimited)
+	Messages int `yaml:"messages"`
+	// Period is the sliding window of the limit
+	Period time.Duration `yaml:"period"`
+}
+
+// DigestConfig holds settings of the daily digest
+type DigestConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Time is the local time of day the digest is sent at, in HH:MM format
+	Time string `yaml:"time"`
+	// Channels receive the digest, defaults to the notification URLs
+	Channels []string `yaml:"channels"`
+}
+
+// WebhooksConfig holds settings of outbound webhooks, the webhooks are managed through the API
+type WebhooksConfig struct {
+	// Timeout is the timeout of a webhook request
+	Timeout time.Duration `yaml:"timeout"`
+	// Delivery configures retries of webhook deliveries
+	Delivery DeliveryConfig `yaml:"delivery"`
+}
+
+// AlertsConfig holds settings of inbound alerts received from Alertmanager and other monitoring systems
+type AlertsConfig struct {
+	// ServiceLabel is the label holding the name, ID or external ID of the service of an alert
+	ServiceLabel string `yaml:"service_label"`
+	// SeverityLabel is the label holding the severity of an alert, warning and info alerts open warning incidents
+	SeverityLabel string `yaml:"severity_label"`
+	// Mappings map alerts to services by their labels
+	Mappings []AlertMapping `yaml:"mappings"`
+}
+
+// AlertMapping maps the alerts having all of the labels to services by name, ID or external ID and to services with any of the tags
+type AlertMapping struct {
+	Labels   map[string]string `yaml:"labels"`
+	Services []string          `yaml:"services"`
+	Tags     []string          `yaml:"tags"`
+}
+
+// EscalationConfig holds settings of escalation policies
+type EscalationConfig struct {
+	// EvaluationInterval is how often unacknowledged incidents are checked for escalations and reminders
+	EvaluationInterval time.Duration `yaml:"evaluation_interval"`
+}
+
+// DeliveryConfig holds retry settings of notification and webhook deliveries
+type DeliveryConfig struct {
+	// MaxAttempts is the number of attempts before a delivery is dead-lettered
+	MaxAttempts int `yaml:"max_attempts"`
+	// InitialBackoff is the delay before the first retry, doubled on each further retry
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+	// Retention is how long sent and dead-lettered deliveries are kept
+	Retention time.Duration `yaml:"retention"`
+}
+
+// Backoff returns the delay before the next attempt of a delivery after the given number of attempts
+func (c DeliveryConfig) Backoff(attempts int) time.Duration {
+	delay := c.InitialBackoff
+	for i := 1; i < attempts && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.MaxBackoff)
+}
+
+// NotificationChannel is a named group of provider URLs
+type NotificationChannel struct {
+	Name      string                               `yaml:"name"`
+	URLs      []string                             `yaml:"urls"`
+	Format    storage.NotificationFormat           `yaml:"format"`
+	Templates map[storage.NotificationEvent]string `yaml:"templates"`
+	// RateLimit overrides the rate limit of the notifications for the URLs of the channel
+	RateLimit *RateLimitConfig `yaml:"rate_limit"`
 }
 
 type Upgrader struct {
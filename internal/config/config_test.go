@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryConfigBackoff(t *testing.T) {
+	c := DeliveryConfig{InitialBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	tests := []struct {
+		attempts int
+		delay    time.Duration
+	}{
+		{attempts: 1, delay: 30 * time.Second},
+		{attempts: 2, delay: time.Minute},
+		{attempts: 3, delay: 2 * time.Minute},
+		{attempts: 4, delay: 4 * time.Minute},
+		{attempts: 5, delay: 5 * time.Minute},
+		{attempts: 100, delay: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.delay, c.Backoff(tt.attempts), "attempts %d", tt.attempts)
+	}
+}
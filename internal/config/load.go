@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/sxwebdev/sentinel/internal/storage"
 )
 
 // Load reads and parses the configuration file
@@ -72,13 +74,7 @@ func (c *Config) setDefaults() error {
 
 	// Frontend defaults
 	if c.Server.Frontend.BaseURL == "" {
-		// Auto-detect protocol based on BaseHost or use HTTP as default
-		protocol := "http"
-		if c.Server.BaseHost != "localhost:8080" && c.Server.BaseHost != "127.0.0.1:8080" {
-			// For production domains, assume HTTPS
-			protocol = "https"
-		}
-		c.Server.Frontend.BaseURL = fmt.Sprintf("%s://%s/api/v1", protocol, c.Server.BaseHost)
+		c.Server.Frontend.BaseURL = c.Server.BaseURL() + "/api/v1"
 	}
 	if c.Server.Frontend.SocketURL == "" {
 		// Auto-detect protocol based on BaseHost or use WS as default
@@ -90,6 +86,61 @@ func (c *Config) setDefaults() error {
 		c.Server.Frontend.SocketURL = fmt.Sprintf("%s://%s/ws", protocol, c.Server.BaseHost)
 	}
 
+	// Notification delivery defaults
+	if c.Notifications.Delivery.MaxAttempts == 0 {
+		c.Notifications.Delivery.MaxAttempts = 8
+	}
+	if c.Notifications.Delivery.InitialBackoff == 0 {
+		c.Notifications.Delivery.InitialBackoff = 30 * time.Second
+	}
+	if c.Notifications.Delivery.MaxBackoff == 0 {
+		c.Notifications.Delivery.MaxBackoff = time.Hour
+	}
+	if c.Notifications.Delivery.Retention == 0 {
+		c.Notifications.Delivery.Retention = 7 * 24 * time.Hour
+	}
+	// Webhook defaults
+	if c.Webhooks.Timeout == 0 {
+		c.Webhooks.Timeout = 10 * time.Second
+	}
+	if c.Webhooks.Delivery.MaxAttempts == 0 {
+		c.Webhooks.Delivery.MaxAttempts = 8
+	}
+	if c.Webhooks.Delivery.InitialBackoff == 0 {
+		c.Webhooks.Delivery.InitialBackoff = 30 * time.Second
+	}
+	if c.Webhooks.Delivery.MaxBackoff == 0 {
+		c.Webhooks.Delivery.MaxBackoff = time.Hour
+	}
+	if c.Webhooks.Delivery.Retention == 0 {
+		c.Webhooks.Delivery.Retention = 7 * 24 * time.Hour
+	}
+	// Alert defaults
+	if c.Alerts.ServiceLabel == "" {
+		c.Alerts.ServiceLabel = "service"
+	}
+	if c.Alerts.SeverityLabel == "" {
+		c.Alerts.SeverityLabel = "severity"
+	}
+
+	if c.Notifications.Escalation.EvaluationInterval == 0 {
+		c.Notifications.Escalation.EvaluationInterval = 30 * time.Second
+	}
+	if c.Notifications.Grouping.MaxSize == 0 {
+		c.Notifications.Grouping.MaxSize = 20
+	}
+	if c.Notifications.RateLimit.Period == 0 {
+		c.Notifications.RateLimit.Period = time.Minute
+	}
+	for i := range c.Notifications.Channels {
+		if limit := c.Notifications.Channels[i].RateLimit; limit != nil && limit.Period == 0 {
+			limit.Period = time.Minute
+		}
+	}
+	if c.Notifications.Digest.Time == "" {
+		c.Notifications.Digest.Time = "09:00"
+	}
+
 	// Monitoring defaults
 	if c.Monitoring.Global.DefaultInterval == 0 {
 		c.Monitoring.Global.DefaultInterval = time.Minute
@@ -101,10 +152,65 @@ func (c *Config) setDefaults() error {
 		c.Monitoring.Global.DefaultRetries = 5
 	}
 
+	// Scheduler defaults
+	if c.Monitoring.Scheduler.MaxConcurrency == 0 {
+		c.Monitoring.Scheduler.MaxConcurrency = 50
+	}
+	if c.Monitoring.Scheduler.MaxStartupJitter == 0 {
+		c.Monitoring.Scheduler.MaxStartupJitter = 5 * time.Minute
+	}
+
+	// Check history defaults
+	if c.Monitoring.History.Retention == 0 {
+		c.Monitoring.History.Retention = 7 * 24 * time.Hour
+	}
+	if c.Monitoring.History.HourlyRetention == 0 {
+		c.Monitoring.History.HourlyRetention = 90 * 24 * time.Hour
+	}
+	if c.Monitoring.History.DailyRetention == 0 {
+		c.Monitoring.History.DailyRetention = 2 * 365 * 24 * time.Hour
+	}
+	if c.Monitoring.History.RollupInterval == 0 {
+		c.Monitoring.History.RollupInterval = 5 * time.Minute
+	}
+
+	// SLO defaults
+	if c.Monitoring.SLO.EvaluationInterval == 0 {
+		c.Monitoring.SLO.EvaluationInterval = time.Minute
+	}
+
 	// Database defaults
+	if c.Database.Type == "" {
+		c.Database.Type = "sqlite"
+	}
 	if c.Database.Path == "" {
 		c.Database.Path = "./data/db.sqlite"
 	}
+	if c.Database.MaxOpenConns == 0 {
+		c.Database.MaxOpenConns = 10
+	}
+	if c.Database.MaxIdleConns == 0 {
+		c.Database.MaxIdleConns = 5
+	}
+	if c.Database.ConnMaxLifetime == 0 {
+		c.Database.ConnMaxLifetime = time.Hour
+	}
+
+	// Backup defaults
+	if c.Backup.Directory == "" {
+		c.Backup.Directory = "./data/backups"
+	}
+	if c.Backup.Interval == 0 {
+		c.Backup.Interval = 24 * time.Hour
+	}
+	if c.Backup.Keep == 0 {
+		c.Backup.Keep = 7
+	}
+
+	// Services sync defaults
+	if c.ServicesSync.WatchInterval == 0 {
+		c.ServicesSync.WatchInterval = 10 * time.Second
+	}
 
 	// Timezone defaults
 	if c.Timezone == "" {
@@ -118,15 +224,161 @@ func (c *Config) setDefaults() error {
 func (c *Config) validate() error {
 	// Validate notifications config if enabled
 	if c.Notifications.Enabled {
-		if len(c.Notifications.URLs) == 0 {
-			return fmt.Errorf("notification URLs are required when notifications are enabled")
-		}
-
 		for i, url := range c.Notifications.URLs {
 			if url == "" {
 				return fmt.Errorf("notification URL at index %d cannot be empty", i)
 			}
 		}
+
+		for i, url := range c.Notifications.WarningURLs {
+			if url == "" {
+				return fmt.Errorf("notification warning URL at index %d cannot be empty", i)
+			}
+		}
+
+		channels := make(map[string]struct{}, len(c.Notifications.Channels))
+		for i, channel := range c.Notifications.Channels {
+			if channel.Name == "" {
+				return fmt.Errorf("notification channel at index %d must have a name", i)
+			}
+			if _, ok := channels[channel.Name]; ok {
+				return fmt.Errorf("notification channel %s is declared more than once", channel.Name)
+			}
+			if strings.HasPrefix(channel.Name, storage.OnCallChannelPrefix) {
+				return fmt.Errorf("notification channel %s cannot use the reserved %s prefix", channel.Name, storage.OnCallChannelPrefix)
+			}
+			channels[channel.Name] = struct{}{}
+
+			if len(channel.URLs) == 0 {
+				return fmt.Errorf("notification channel %s must have URLs", channel.Name)
+			}
+			for j, url := range channel.URLs {
+				if url == "" {
+					return fmt.Errorf("URL at index %d of notification channel %s cannot be empty", j, channel.Name)
+				}
+			}
+
+			if err := validateNotificationTemplates(channel.Format, channel.Templates); err != nil {
+				return fmt.Errorf("notification channel %s: %w", channel.Name, err)
+			}
+
+			if channel.RateLimit != nil {
+				if err := validateRateLimit(*channel.RateLimit); err != nil {
+					return fmt.Errorf("notification channel %s: %w", channel.Name, err)
+				}
+			}
+		}
+
+		if err := validateNotificationTemplates(c.Notifications.Format, c.Notifications.Templates); err != nil {
+			return fmt.Errorf("notifications: %w", err)
+		}
+
+		if err := validateDelivery(c.Notifications.Delivery); err != nil {
+			return fmt.Errorf("notification %w", err)
+		}
+
+		if c.Notifications.Escalation.EvaluationInterval < time.Second {
+			return fmt.Errorf("notification escalation evaluation_interval must be at least 1s")
+		}
+
+		grouping := c.Notifications.Grouping
+		if grouping.Window < 0 || grouping.Window > time.Hour {
+			return fmt.Errorf("notification grouping window must be between 0 and 1h")
+		}
+		if grouping.MaxSize < 1 {
+			return fmt.Errorf("notification grouping max_size must be at least 1")
+		}
+
+		if err := validateRateLimit(c.Notifications.RateLimit); err != nil {
+			return fmt.Errorf("notifications: %w", err)
+		}
+
+		if _, err := time.Parse("15:04", c.Notifications.Digest.Time); err != nil {
+			return fmt.Errorf("notification digest time must be in HH:MM format: %w", err)
+		}
+	}
+
+	// Validate webhooks
+	if c.Webhooks.Timeout < time.Second {
+		return fmt.Errorf("webhooks timeout must be at least 1s")
+	}
+	if err := validateDelivery(c.Webhooks.Delivery); err != nil {
+		return fmt.Errorf("webhook %w", err)
+	}
+
+	// Validate alert mappings
+	for i, mapping := range c.Alerts.Mappings {
+		if len(mapping.Labels) == 0 {
+			return fmt.Errorf("alert mapping %d must have labels", i+1)
+		}
+		if len(mapping.Services) == 0 && len(mapping.Tags) == 0 {
+			return fmt.Errorf("alert mapping %d must have services or tags", i+1)
+		}
+	}
+
+	// Validate scheduler limits
+	if c.Monitoring.Scheduler.MaxConcurrency < 0 {
+		return fmt.Errorf("scheduler max_concurrency cannot be negative")
+	}
+	if c.Monitoring.Scheduler.HostLimit < 0 {
+		return fmt.Errorf("scheduler host_limit cannot be negative")
+	}
+	if c.Monitoring.Scheduler.MaxStartupJitter < 0 {
+		return fmt.Errorf("scheduler max_startup_jitter cannot be negative")
+	}
+	for protocol, limit := range c.Monitoring.Scheduler.ProtocolLimits {
+		if limit < 0 {
+			return fmt.Errorf("scheduler protocol limit for %s cannot be negative", protocol)
+		}
+	}
+
+	// Validate check history retention, daily rollups are computed from raw check results
+	if c.Monitoring.History.Retention < 48*time.Hour {
+		return fmt.Errorf("history retention must be at least 48h")
+	}
+	if c.Monitoring.History.HourlyRetention < 0 || c.Monitoring.History.DailyRetention < 0 {
+		return fmt.Errorf("history rollup retention cannot be negative")
+	}
+	if c.Monitoring.History.RollupInterval < time.Minute || c.Monitoring.History.RollupInterval > time.Hour {
+		return fmt.Errorf("history rollup_interval must be between 1m and 1h")
+	}
+	if c.Monitoring.SLO.EvaluationInterval < 10*time.Second || c.Monitoring.SLO.EvaluationInterval > time.Hour {
+		return fmt.Errorf("slo evaluation_interval must be between 10s and 1h")
+	}
+
+	// Validate database
+	switch c.Database.Type {
+	case "sqlite":
+	case "postgres":
+		if c.Database.DSN == "" {
+			return fmt.Errorf("database dsn is required for postgres storage")
+		}
+	default:
+		return fmt.Errorf("unsupported database type: %s", c.Database.Type)
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 || c.Database.ConnMaxLifetime < 0 {
+		return fmt.Errorf("database connection pool settings cannot be negative")
+	}
+
+	// Validate scheduled backups
+	if c.Backup.Enabled {
+		if c.Database.Type != "sqlite" {
+			return fmt.Errorf("scheduled backups are only supported by sqlite storage")
+		}
+		if c.Backup.Interval < time.Minute {
+			return fmt.Errorf("backup interval must be at least 1m")
+		}
+		if c.Backup.Keep < 1 {
+			return fmt.Errorf("backup keep must be at least 1")
+		}
+	}
+
+	// Validate declared services
+	if err := validateServices(c.Services); err != nil {
+		return fmt.Errorf("invalid services: %w", err)
+	}
+	if c.ServicesSync.WatchInterval < time.Second {
+		return fmt.Errorf("services_sync watch_interval must be at least 1s")
 	}
 
 	// Validate timezone
@@ -136,3 +388,48 @@ func (c *Config) validate() error {
 
 	return nil
 }
+
+// validateNotificationTemplates checks the format and the events of notification templates
+func validateNotificationTemplates(format storage.NotificationFormat, templates map[storage.NotificationEvent]string) error {
+	switch format {
+	case "", storage.NotificationFormatText, storage.NotificationFormatMarkdown, storage.NotificationFormatHTML:
+	default:
+		return fmt.Errorf("unknown format %s, must be text, markdown or html", format)
+	}
+
+	for event := range templates {
+		switch event {
+		case storage.NotificationEventAlert, storage.NotificationEventDegraded, storage.NotificationEventRecovery, storage.NotificationEventUpdate,
+			storage.NotificationEventEscalation, storage.NotificationEventReminder:
+		default:
+			return fmt.Errorf("unknown template event %s, must be alert, degraded, recovery, update, escalation or reminder", event)
+		}
+	}
+
+	return nil
+}
+
+// validateRateLimit checks the number of messages and the period of a rate limit
+func validateRateLimit(limit RateLimitConfig) error {
+	if limit.Messages < 0 {
+		return fmt.Errorf("rate limit messages cannot be negative")
+	}
+	if limit.Messages > 0 && limit.Period < time.Second {
+		return fmt.Errorf("rate limit period must be at least 1s")
+	}
+	return nil
+}
+
+// validateDelivery checks the retry settings of deliveries
+func validateDelivery(delivery DeliveryConfig) error {
+	if delivery.MaxAttempts < 1 {
+		return fmt.Errorf("delivery max_attempts must be at least 1")
+	}
+	if delivery.InitialBackoff < time.Second || delivery.MaxBackoff < delivery.InitialBackoff {
+		return fmt.Errorf("delivery initial_backoff must be at least 1s and not exceed max_backoff")
+	}
+	if delivery.Retention < time.Hour {
+		return fmt.Errorf("delivery retention must be at least 1h")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// servicesFile is a YAML file with declared services
+type servicesFile struct {
+	Services []storage.CreateUpdateServiceRequest `yaml:"services"`
+}
+
+// ServicesSyncEnabled reports whether declared services are synced into storage
+func (c *Config) ServicesSyncEnabled() bool {
+	return c.Services != nil || c.ServicesSync.Path != ""
+}
+
+// LoadServices reads declared services from the services section of the config file
+// and from the services sync path. Service names must be unique.
+func LoadServices(configPath string, cfg ServicesSyncConfig) ([]storage.CreateUpdateServiceRequest, error) {
+	files := []string{}
+	if _, err := os.Stat(configPath); err == nil {
+		files = append(files, configPath)
+	}
+
+	if cfg.Path != "" {
+		info, err := os.Stat(cfg.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read services path: %w", err)
+		}
+
+		if !info.IsDir() {
+			files = append(files, cfg.Path)
+		} else {
+			entries, err := os.ReadDir(cfg.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read services directory: %w", err)
+			}
+
+			for _, entry := range entries {
+				ext := strings.ToLower(filepath.Ext(entry.Name()))
+				if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+					continue
+				}
+				files = append(files, filepath.Join(cfg.Path, entry.Name()))
+			}
+		}
+	}
+
+	services := []storage.CreateUpdateServiceRequest{}
+	for _, file := range files {
+		items, err := readServicesFile(file)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, items...)
+	}
+
+	if err := validateServices(services); err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+// readServicesFile reads the services section of a YAML file
+func readServicesFile(path string) ([]storage.CreateUpdateServiceRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read services file %s: %w", path, err)
+	}
+
+	var file servicesFile
+	if err := yaml.Unmarshal([]byte(expandEnvVars(string(data))), &file); err != nil {
+		return nil, fmt.Errorf("failed to parse services file %s: %w", path, err)
+	}
+
+	return file.Services, nil
+}
+
+// validateServices checks required fields of declared services and that their names are unique
+func validateServices(services []storage.CreateUpdateServiceRequest) error {
+	names := make([]string, 0, len(services))
+	for i, svc := range services {
+		if svc.Name == "" {
+			return fmt.Errorf("service at index %d: name is required", i)
+		}
+		if svc.Protocol == "" {
+			return fmt.Errorf("service %s: protocol is required", svc.Name)
+		}
+		if slices.Contains(names, svc.Name) {
+			return fmt.Errorf("service %s is declared more than once", svc.Name)
+		}
+		names = append(names, svc.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package digest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/notifier"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+	"github.com/tkcrm/mx/logger"
+)
+
+const (
+	// servicesPageSize is the number of services loaded at once
+	servicesPageSize = 100
+	// maxIncidents is the number of most recent incidents listed in the digest
+	maxIncidents = 10
+	// maxServices is the number of services listed by lowest uptime and by response time
+	maxServices = 5
+)
+
+// Reporter sends a daily digest of the incidents, uptime and slowest services of the last day
+type Reporter struct {
+	logger   logger.Logger
+	config   config.DigestConfig
+	storage  storage.Storage
+	notifier *notifier.Notifier
+
+	stop chan struct{}
+}
+
+// New creates a new digest reporter
+func New(l logger.Logger, cfg config.DigestConfig, store storage.Storage, notif *notifier.Notifier) *Reporter {
+	return &Reporter{
+		logger:   l,
+		config:   cfg,
+		storage:  store,
+		notifier: notif,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Name returns the name of the service
+func (r *Reporter) Name() string { return "digest-reporter" }
+
+// Start sends the digest every day at the configured time until the service is stopped
+func (r *Reporter) Start(ctx context.Context) error {
+	for {
+		next, err := nextRun(r.config.Time, time.Now())
+		if err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-r.stop:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		if err := r.send(ctx, next); err != nil {
+			r.logger.Errorf("failed to send digest: %v", err)
+		}
+	}
+}
+
+// Stop stops the service
+func (r *Reporter) Stop(_ context.Context) error {
+	close(r.stop)
+	return nil
+}
+
+// nextRun returns the next occurrence of a time of day in HH:MM format after now, in the location of now
+func nextRun(at string, now time.Time) (time.Time, error) {
+	clock, err := time.Parse("15:04", at)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid digest time: %w", err)
+	}
+
+	day := func(offset int) time.Time {
+		next := time.Date(now.Year(), now.Month(), now.Day()+offset, clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		// A time skipped by a DST change runs once the clock moves past it
+		for next.Hour()*60+next.Minute() < clock.Hour()*60+clock.Minute() {
+			next = next.Add(time.Minute)
+		}
+		return next
+	}
+
+	next := day(0)
+	if !next.After(now) {
+		next = day(1)
+	}
+
+	return next, nil
+}
+
+// send builds the digest of the day before the given time and sends it
+func (r *Reporter) send(ctx context.Context, to time.Time) error {
+	digest, err := r.build(ctx, to.AddDate(0, 0, -1), to)
+	if err != nil {
+		return err
+	}
+
+	return r.notifier.SendDigest(digest, r.config.Channels)
+}
+
+// build collects the incidents and the stats of enabled services for a period
+func (r *Reporter) build(ctx context.Context, from, to time.Time) (*notifier.Digest, error) {
+	digest := &notifier.Digest{
+		From:         from,
+		To:           to,
+		ServiceNames: map[string]string{},
+	}
+
+	incidents, err := r.storage.FindIncidents(ctx, storage.FindIncidentsParams{
+		StartTime: utils.Pointer(from),
+		PageSize:  utils.Pointer(uint32(maxIncidents)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find incidents: %w", err)
+	}
+	digest.Incidents = incidents.Items
+	digest.TotalIncidents = incidents.Count
+
+	digest.OngoingIncidents, err = r.storage.IncidentsCount(ctx, storage.FindIncidentsParams{Resolved: utils.Pointer(false)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count ongoing incidents: %w", err)
+	}
+
+	stats := []notifier.DigestService{}
+	for page := uint32(1); ; page++ {
+		services, err := r.storage.FindServices(ctx, storage.FindServicesParams{
+			IsEnabled: utils.Pointer(true),
+			OrderBy:   "created_at",
+			Page:      utils.Pointer(page),
+			PageSize:  utils.Pointer(uint32(servicesPageSize)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find services: %w", err)
+		}
+
+		for _, service := range services.Items {
+			digest.ServiceNames[service.ID] = service.Name
+
+			serviceStats, err := r.storage.GetServiceStats(ctx, storage.ServiceStatsParams{
+				ServiceID: service.ID,
+				StartTime: from,
+				EndTime:   to,
+			})
+			if err != nil {
+				r.logger.Errorf("failed to get digest stats of service %s: %v", service.ID, err)
+				continue
+			}
+
+			stats = append(stats, notifier.DigestService{
+				Name:             service.Name,
+				UptimePercentage: serviceStats.UptimePercentage,
+				AvgResponseTime:  serviceStats.AvgResponseTime,
+			})
+		}
+
+		if len(services.Items) < servicesPageSize {
+			break
+		}
+	}
+
+	digest.Services = len(digest.ServiceNames)
+
+	for _, service := range stats {
+		if service.UptimePercentage < 100 {
+			digest.LowestUptime = append(digest.LowestUptime, service)
+		}
+		if service.AvgResponseTime > 0 {
+			digest.Slowest = append(digest.Slowest, service)
+		}
+	}
+
+	slices.SortStableFunc(digest.LowestUptime, func(a, b notifier.DigestService) int {
+		return cmp.Compare(a.UptimePercentage, b.UptimePercentage)
+	})
+	slices.SortStableFunc(digest.Slowest, func(a, b notifier.DigestService) int {
+		return cmp.Compare(b.AvgResponseTime, a.AvgResponseTime)
+	})
+
+	digest.LowestUptime = digest.LowestUptime[:min(len(digest.LowestUptime), maxServices)]
+	digest.Slowest = digest.Slowest[:min(len(digest.Slowest), maxServices)]
+
+	return digest, nil
+}
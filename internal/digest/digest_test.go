@@ -0,0 +1,85 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextRun(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		at   string
+		now  time.Time
+		next time.Time
+	}{
+		{
+			name: "Later today",
+			at:   "09:00",
+			now:  time.Date(2025, 6, 10, 8, 30, 0, 0, time.UTC),
+			next: time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Passed today",
+			at:   "09:00",
+			now:  time.Date(2025, 6, 10, 9, 30, 0, 0, time.UTC),
+			next: time.Date(2025, 6, 11, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Exactly now runs tomorrow",
+			at:   "09:00",
+			now:  time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC),
+			next: time.Date(2025, 6, 11, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Month and year rollover",
+			at:   "00:15",
+			now:  time.Date(2025, 12, 31, 23, 30, 0, 0, time.UTC),
+			next: time.Date(2026, 1, 1, 0, 15, 0, 0, time.UTC),
+		},
+		{
+			name: "Day before DST ends is 25 hours long",
+			at:   "09:00",
+			now:  time.Date(2025, 11, 1, 9, 0, 0, 0, newYork),
+			next: time.Date(2025, 11, 2, 9, 0, 0, 0, newYork),
+		},
+		{
+			name: "Time skipped by DST start runs when the clock moves forward",
+			at:   "02:30",
+			now:  time.Date(2025, 3, 9, 1, 0, 0, 0, newYork),
+			next: time.Date(2025, 3, 9, 3, 0, 0, 0, newYork),
+		},
+		{
+			name: "Time skipped by DST start after the previous hour",
+			at:   "02:30",
+			now:  time.Date(2025, 3, 9, 1, 45, 0, 0, newYork),
+			next: time.Date(2025, 3, 9, 3, 0, 0, 0, newYork),
+		},
+		{
+			name: "Time repeated by DST end runs once",
+			at:   "01:30",
+			now:  time.Date(2025, 11, 2, 1, 45, 0, 0, newYork).Add(time.Hour),
+			next: time.Date(2025, 11, 3, 1, 30, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := nextRun(tt.at, tt.now)
+			require.NoError(t, err)
+			assert.True(t, tt.next.Equal(next), "expected %s, got %s", tt.next, next)
+		})
+	}
+
+	next, err := nextRun("09:00", time.Date(2025, 11, 1, 9, 0, 0, 0, newYork))
+	require.NoError(t, err)
+	assert.Equal(t, 25*time.Hour, next.Sub(time.Date(2025, 11, 1, 9, 0, 0, 0, newYork)))
+
+	_, err = nextRun("9am", time.Now())
+	assert.ErrorContains(t, err, "invalid digest time")
+}
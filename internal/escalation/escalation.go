@@ -0,0 +1,216 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/notifier"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+	"github.com/tkcrm/mx/logger"
+)
+
+// incidentsPageSize is the number of active incidents loaded at once
+const incidentsPageSize = 100
+
+// escalatedSources are the sources of escalated incidents. Manual incidents are declared
+// by the people handling them, so they are not escalated.
+var escalatedSources = []storage.IncidentSource{storage.IncidentSourceMonitor, storage.IncidentSourceAlert}
+
+// Engine escalates unacknowledged incidents through the steps of matching escalation policies
+// and reminds of them until they are acknowledged or resolved. The escalation state of incidents
+// is stored, so restarts do not reset the timers.
+type Engine struct {
+	logger   logger.Logger
+	config   config.EscalationConfig
+	storage  storage.Storage
+	notifier *notifier.Notifier
+
+	stop chan struct{}
+}
+
+// New creates a new escalation engine
+func New(l logger.Logger, cfg config.EscalationConfig, store storage.Storage, notif *notifier.Notifier) *Engine {
+	return &Engine{
+		logger:   l,
+		config:   cfg,
+		storage:  store,
+		notifier: notif,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Name returns the name of the service
+func (e *Engine) Name() string { return "escalation-engine" }
+
+// Start evaluates escalation policies periodically until the service is stopped
+func (e *Engine) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.config.EvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		e.run(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-e.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the service
+func (e *Engine) Stop(_ context.Context) error {
+	close(e.stop)
+	return nil
+}
+
+// run evaluates enabled escalation policies against active unacknowledged incidents
+func (e *Engine) run(ctx context.Context, now time.Time) {
+	policies, err := e.storage.FindEscalationPolicies(ctx, storage.FindEscalationPoliciesParams{IsEnabled: utils.Pointer(true)})
+	if err != nil {
+		e.logger.Errorf("failed to find escalation policies: %v", err)
+		return
+	}
+
+	if len(policies) == 0 {
+		return
+	}
+
+	for _, source := range escalatedSources {
+		e.runSource(ctx, source, policies, now)
+	}
+}
+
+// runSource evaluates escalation policies against active unacknowledged incidents of a source
+func (e *Engine) runSource(ctx context.Context, source storage.IncidentSource, policies []*storage.EscalationPolicy, now time.Time) {
+	for page := uint32(1); ; page++ {
+		incidents, err := e.storage.FindIncidents(ctx, storage.FindIncidentsParams{
+			Resolved: utils.Pointer(false),
+			Source:   source,
+			Page:     utils.Pointer(page),
+			PageSize: utils.Pointer(uint32(incidentsPageSize)),
+		})
+		if err != nil {
+			e.logger.Errorf("failed to find active %s incidents: %v", source, err)
+			return
+		}
+
+		for _, incident := range incidents.Items {
+			if incident.AcknowledgedAt != nil {
+				continue
+			}
+
+			if err := e.evaluate(ctx, incident, policies, now); err != nil {
+				e.logger.Errorf("failed to escalate incident %s: %v", incident.ID, err)
+			}
+		}
+
+		if len(incidents.Items) < incidentsPageSize {
+			return
+		}
+	}
+}
+
+// evaluate notifies the steps of matching policies reached by an incident since the last evaluation,
+// or sends a reminder once the repeat interval of a policy has passed since its last notification
+func (e *Engine) evaluate(ctx context.Context, incident *storage.Incident, policies []*storage.EscalationPolicy, now time.Time) error {
+	service, err := e.storage.GetServiceByID(ctx, incident.ServiceID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+
+	// Notifications of flapping services are muted
+	state, err := e.storage.GetServiceState(ctx, service.ID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("failed to get service state: %w", err)
+	}
+	if state != nil && state.IsFlapping {
+		return nil
+	}
+
+	// Escalation states are loaded once a policy matches
+	var escalations map[string]*storage.IncidentEscalation
+
+	for _, policy := range policies {
+		if !policy.Matches(service, incident.Severity) {
+			continue
+		}
+
+		if escalations == nil {
+			escalations, err = e.storage.FindIncidentEscalations(ctx, incident.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		escalation, ok := escalations[policy.ID]
+		if !ok {
+			escalation = &storage.IncidentEscalation{
+				IncidentID: incident.ID,
+				PolicyID:   policy.ID,
+				NotifiedAt: incident.StartTime,
+			}
+		}
+
+		// Steps removed from the policy after they were notified are ignored
+		level := min(escalation.Level, len(policy.Steps))
+		reached := level
+		for reached < len(policy.Steps) && now.Sub(incident.StartTime) >= policy.Steps[reached].Delay {
+			reached++
+		}
+
+		switch {
+		case reached > level:
+			if err := e.notifier.SendEscalation(service, incident, reached, stepChannels(policy.Steps[level:reached])); err != nil {
+				return fmt.Errorf("failed to send escalation of policy %s: %w", policy.Name, err)
+			}
+			e.recordEvent(ctx, incident.ID, fmt.Sprintf("escalated to level %d by policy %s", reached, policy.Name))
+		case policy.RepeatInterval > 0 && now.Sub(escalation.NotifiedAt) >= policy.RepeatInterval:
+			if err := e.notifier.SendReminder(service, incident, level, stepChannels(policy.Steps[:level])); err != nil {
+				return fmt.Errorf("failed to send reminder of policy %s: %w", policy.Name, err)
+			}
+			e.recordEvent(ctx, incident.ID, fmt.Sprintf("reminder sent by policy %s", policy.Name))
+		default:
+			continue
+		}
+
+		escalation.Level = reached
+		escalation.NotifiedAt = now
+
+		if err := e.storage.SaveIncidentEscalation(ctx, escalation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordEvent adds a sent notification to the incident timeline
+func (e *Engine) recordEvent(ctx context.Context, incidentID, message string) {
+	err := e.storage.CreateIncidentEvent(ctx, &storage.IncidentEvent{
+		IncidentID: incidentID,
+		Type:       storage.IncidentEventNotificationSent,
+		Message:    message,
+	})
+	if err != nil {
+		e.logger.Errorf("failed to record notification event of incident %s: %v", incidentID, err)
+	}
+}
+
+// stepChannels returns the channels of escalation steps
+func stepChannels(steps []storage.EscalationStep) []string {
+	channels := []string{}
+	for _, step := range steps {
+		channels = append(channels, step.Channels...)
+	}
+	return channels
+}
@@ -0,0 +1,195 @@
+package escalation
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/notifier"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+// newTestEngine returns an engine over the SQLite store at a path, the store is stopped with the test
+func newTestEngine(t *testing.T, path string) (*Engine, *storage.SQLiteStorage) {
+	t.Helper()
+	ctx := context.Background()
+
+	store, err := storage.NewSQLiteStorage(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	notif, err := notifier.New(logger.Default(), &config.Config{Notifications: config.NotificationsConfig{
+		Enabled: true,
+		URLs:    []string{"logger://"},
+		Channels: []config.NotificationChannel{
+			{Name: "team", URLs: []string{"logger://?team"}},
+			{Name: "manager", URLs: []string{"logger://?manager"}},
+		},
+		Delivery: config.DeliveryConfig{MaxAttempts: 1, InitialBackoff: time.Second, MaxBackoff: time.Second},
+	}}, store)
+	require.NoError(t, err)
+	require.NoError(t, notif.Start(ctx))
+	t.Cleanup(func() { _ = notif.Stop(ctx) })
+
+	return New(logger.Default(), config.EscalationConfig{EvaluationInterval: time.Minute}, store, notif), store
+}
+
+func TestEngine(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "db.sqlite")
+	engine, store := newTestEngine(t, path)
+
+	svc, err := store.CreateService(ctx, storage.CreateUpdateServiceRequest{
+		Name:      "API",
+		Protocol:  storage.ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Tags:      []string{},
+		Config:    map[string]any{"tcp": map[string]any{"endpoint": "localhost:1"}},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	policy := &storage.EscalationPolicy{
+		Name: "Default",
+		Steps: []storage.EscalationStep{
+			{Delay: 0, Channels: []string{"team"}},
+			{Delay: 10 * time.Minute, Channels: []string{"manager"}},
+		},
+		RepeatInterval: 30 * time.Minute,
+		IsEnabled:      true,
+	}
+	require.NoError(t, store.CreateEscalationPolicy(ctx, policy))
+
+	t0 := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+
+	newIncident := func(source storage.IncidentSource, alertKey string, acknowledgedAt *time.Time) *storage.Incident {
+		incident := &storage.Incident{
+			ID:             storage.GenerateULID(),
+			ServiceID:      svc.ID,
+			Source:         source,
+			AlertKey:       alertKey,
+			Error:          "connection refused",
+			Severity:       storage.IncidentSeverityCritical,
+			StartTime:      t0,
+			AcknowledgedAt: acknowledgedAt,
+		}
+		require.NoError(t, store.SaveIncident(ctx, incident))
+		return incident
+	}
+
+	monitorIncident := newIncident(storage.IncidentSourceMonitor, "", nil)
+	alertIncident := newIncident(storage.IncidentSourceAlert, "generic:lag", nil)
+	manualIncident := newIncident(storage.IncidentSourceManual, "", nil)
+	acknowledged := newIncident(storage.IncidentSourceMonitor, "", &t0)
+
+	// sentEvents returns the notifications recorded on the timeline of an incident
+	sentEvents := func(incident *storage.Incident) []string {
+		events, err := store.FindIncidentEvents(ctx, incident.ID)
+		require.NoError(t, err)
+
+		sent := []string{}
+		for _, event := range events {
+			if event.Type == storage.IncidentEventNotificationSent {
+				sent = append(sent, event.Message)
+			}
+		}
+		return sent
+	}
+
+	// level returns the stored escalation state of an incident
+	level := func(incident *storage.Incident) *storage.IncidentEscalation {
+		escalations, err := store.FindIncidentEscalations(ctx, incident.ID)
+		require.NoError(t, err)
+		require.Contains(t, escalations, policy.ID)
+		return escalations[policy.ID]
+	}
+
+	steps := []struct {
+		name   string
+		at     time.Duration
+		events []string
+		level  int
+	}{
+		{
+			name:   "First step is notified immediately",
+			at:     time.Minute,
+			events: []string{"escalated to level 1 by policy Default"},
+			level:  1,
+		},
+		{
+			name:   "Second step is not reached yet",
+			at:     5 * time.Minute,
+			events: []string{"escalated to level 1 by policy Default"},
+			level:  1,
+		},
+		{
+			name:   "Second step is reached",
+			at:     11 * time.Minute,
+			events: []string{"escalated to level 1 by policy Default", "escalated to level 2 by policy Default"},
+			level:  2,
+		},
+		{
+			name:   "Reminder is not due yet",
+			at:     40 * time.Minute,
+			events: []string{"escalated to level 1 by policy Default", "escalated to level 2 by policy Default"},
+			level:  2,
+		},
+		{
+			name:   "Reminder after the repeat interval",
+			at:     41 * time.Minute,
+			events: []string{"escalated to level 1 by policy Default", "escalated to level 2 by policy Default", "reminder sent by policy Default"},
+			level:  2,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			engine.run(ctx, t0.Add(step.at))
+
+			for _, incident := range []*storage.Incident{monitorIncident, alertIncident} {
+				assert.Equal(t, step.events, sentEvents(incident))
+				assert.Equal(t, step.level, level(incident).Level)
+			}
+		})
+	}
+
+	assert.True(t, t0.Add(41*time.Minute).Equal(level(monitorIncident).NotifiedAt))
+
+	// Manual and acknowledged incidents are not escalated
+	assert.Empty(t, sentEvents(manualIncident))
+	assert.Empty(t, sentEvents(acknowledged))
+
+	// The escalation state is kept across restarts
+	require.NoError(t, engine.notifier.Stop(ctx))
+	require.NoError(t, store.Stop(ctx))
+
+	engine, store = newTestEngine(t, path)
+
+	engine.run(ctx, t0.Add(50*time.Minute))
+	assert.Len(t, sentEvents(monitorIncident), 3)
+
+	// Steps removed after they were notified are ignored, reminders go to the remaining steps
+	policy.Steps = policy.Steps[:1]
+	require.NoError(t, store.UpdateEscalationPolicy(ctx, policy))
+
+	engine.run(ctx, t0.Add(71*time.Minute))
+
+	assert.Len(t, sentEvents(monitorIncident), 4)
+	assert.Equal(t, "reminder sent by policy Default", sentEvents(monitorIncident)[3])
+	assert.Equal(t, 1, level(monitorIncident).Level)
+
+	// Resolved incidents are no longer reminded of
+	alertIncident.Resolved = true
+	alertIncident.EndTime = &t0
+	require.NoError(t, store.UpdateIncident(ctx, alertIncident))
+
+	engine.run(ctx, t0.Add(2*time.Hour))
+	assert.Len(t, sentEvents(alertIncident), 4)
+	assert.Len(t, sentEvents(monitorIncident), 5)
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+// catchUpPeriod is how far back check results are rolled up on startup
+const catchUpPeriod = 24 * time.Hour
+
+// History rolls up check results into hourly and daily aggregates
+// and purges data older than the configured retention
+type History struct {
+	logger  logger.Logger
+	config  config.HistoryConfig
+	storage storage.Storage
+
+	lastRollup time.Time
+	stop       chan struct{}
+}
+
+// New creates a new check history service
+func New(l logger.Logger, cfg config.HistoryConfig, store storage.Storage) *History {
+	return &History{
+		logger:  l,
+		config:  cfg,
+		storage: store,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Name returns the name of the service
+func (h *History) Name() string { return "check-history" }
+
+// Start runs rollups and retention periodically until the service is stopped
+func (h *History) Start(ctx context.Context) error {
+	h.lastRollup = time.Now().Add(-catchUpPeriod)
+
+	ticker := time.NewTicker(h.config.RollupInterval)
+	defer ticker.Stop()
+
+	for {
+		h.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-h.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the service
+func (h *History) Stop(_ context.Context) error {
+	close(h.stop)
+	return nil
+}
+
+// run rolls up check results since the last run and purges expired data
+func (h *History) run(ctx context.Context) {
+	now := time.Now()
+
+	// Buckets of the last run are recomputed to include checks finished since then
+	for _, resolution := range []storage.RollupResolution{storage.RollupResolutionHour, storage.RollupResolutionDay} {
+		if err := h.storage.RollupCheckResults(ctx, resolution, h.lastRollup, now); err != nil {
+			h.logger.Errorf("failed to roll up check results by %s: %v", resolution, err)
+			return
+		}
+	}
+	h.lastRollup = now
+
+	deleted, err := h.storage.DeleteCheckResultsBefore(ctx, now.Add(-h.config.Retention))
+	if err != nil {
+		h.logger.Errorf("failed to purge check results: %v", err)
+	} else if deleted > 0 {
+		h.logger.Debugf("purged %d check results", deleted)
+	}
+
+	retention := map[storage.RollupResolution]time.Duration{
+		storage.RollupResolutionHour: h.config.HourlyRetention,
+		storage.RollupResolutionDay:  h.config.DailyRetention,
+	}
+	for resolution, period := range retention {
+		deleted, err := h.storage.DeleteCheckResultRollupsBefore(ctx, resolution, now.Add(-period))
+		if err != nil {
+			h.logger.Errorf("failed to purge %s rollups: %v", resolution, err)
+			continue
+		}
+		if deleted > 0 {
+			h.logger.Debugf("purged %d %s rollups", deleted, resolution)
+		}
+	}
+}
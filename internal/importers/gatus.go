@@ -0,0 +1,228 @@
+package importers
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/sxwebdev/sentinel/internal/monitors"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// Gatus defaults of endpoints
+const (
+	gatusDefaultInterval = time.Minute
+	gatusDefaultTimeout  = 10 * time.Second
+)
+
+// gatusConditionRe matches conditions like [STATUS] == 200 or [BODY].status == UP
+var gatusConditionRe = regexp.MustCompile(`^\[([A-Z_]+)\]((?:\.\w+|\[\d+\])*)\s*(==|!=|<=|>=|<|>)\s*(.+)$`)
+
+// gatusConfig is a Gatus configuration, older versions use services instead of endpoints
+type gatusConfig struct {
+	Alerting  map[string]any  `yaml:"alerting"`
+	Endpoints []gatusEndpoint `yaml:"endpoints"`
+	Services  []gatusEndpoint `yaml:"services"`
+}
+
+// gatusEndpoint is an endpoint of a Gatus configuration
+type gatusEndpoint struct {
+	Name       string            `yaml:"name"`
+	Group      string            `yaml:"group"`
+	Enabled    *bool             `yaml:"enabled"`
+	URL        string            `yaml:"url"`
+	Method     string            `yaml:"method"`
+	Body       string            `yaml:"body"`
+	Headers    map[string]string `yaml:"headers"`
+	Interval   string            `yaml:"interval"`
+	Conditions []string          `yaml:"conditions"`
+	Alerts     []any             `yaml:"alerts"`
+	Client     struct {
+		Timeout string `yaml:"timeout"`
+	} `yaml:"client"`
+}
+
+// FromGatus converts endpoints of a Gatus YAML configuration.
+// HTTP and TCP endpoints are supported, other endpoint types are skipped.
+func FromGatus(data []byte) (Conversion, error) {
+	res := Conversion{Services: []storage.CreateUpdateServiceRequest{}}
+
+	var conf gatusConfig
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		return res, fmt.Errorf("failed to parse Gatus config: %w", err)
+	}
+
+	endpoints := append(conf.Endpoints, conf.Services...)
+	if len(endpoints) == 0 {
+		return res, fmt.Errorf("failed to parse Gatus config: no endpoints found")
+	}
+
+	if len(conf.Alerting) > 0 {
+		res.report("", false, "alerting providers are not imported, configure notification URLs in Sentinel")
+	}
+
+	for _, e := range endpoints {
+		req, ok := e.convert(&res)
+		if ok {
+			res.Services = append(res.Services, req)
+		}
+	}
+
+	return res, nil
+}
+
+// convert converts an endpoint, reporting features that cannot be converted
+func (e gatusEndpoint) convert(res *Conversion) (storage.CreateUpdateServiceRequest, bool) {
+	req := storage.CreateUpdateServiceRequest{
+		Name:     e.Name,
+		Interval: gatusDefaultInterval,
+		Timeout:  gatusDefaultTimeout,
+		// Gatus makes a single attempt per check
+		Retries:    1,
+		Tags:       []string{},
+		IsEnabled:  e.Enabled == nil || *e.Enabled,
+		ExternalID: "gatus-" + gatusKey(e.Group, e.Name),
+	}
+
+	if e.Group != "" {
+		req.Tags = append(req.Tags, e.Group)
+	}
+
+	for _, value := range []struct {
+		name  string
+		raw   string
+		field *time.Duration
+	}{
+		{"interval", e.Interval, &req.Interval},
+		{"client timeout", e.Client.Timeout, &req.Timeout},
+	} {
+		if value.raw == "" {
+			continue
+		}
+
+		d, err := time.ParseDuration(value.raw)
+		if err != nil {
+			res.report(e.Name, true, "invalid %s: %s", value.name, value.raw)
+			return req, false
+		}
+		*value.field = d
+	}
+
+	if len(e.Alerts) > 0 {
+		res.report(e.Name, false, "alerts are not imported")
+	}
+
+	u, err := url.Parse(e.URL)
+	if err != nil {
+		res.report(e.Name, true, "invalid url: %s", e.URL)
+		return req, false
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		endpoint := monitors.EndpointConfig{
+			Name:           e.Name,
+			URL:            e.URL,
+			Method:         strings.ToUpper(e.Method),
+			Headers:        e.Headers,
+			Body:           e.Body,
+			ExpectedStatus: 200,
+		}
+		if endpoint.Method == "" {
+			endpoint.Method = "GET"
+		}
+
+		conditions := []string{}
+		for _, raw := range e.Conditions {
+			cond, status, ok := gatusHTTPCondition(e.Name, raw)
+			if !ok {
+				res.report(e.Name, false, "condition %s is not supported", raw)
+				continue
+			}
+			if status > 0 {
+				endpoint.ExpectedStatus = status
+			}
+			if cond != "" {
+				conditions = append(conditions, cond)
+			}
+		}
+
+		req.Protocol = storage.ServiceProtocolTypeHTTP
+		req.Config = httpConfig(endpoint, uint64(req.Timeout.Milliseconds()), conditions)
+	case "tcp":
+		for _, raw := range e.Conditions {
+			if strings.ReplaceAll(raw, " ", "") != "[CONNECTED]==true" {
+				res.report(e.Name, false, "condition %s is not supported", raw)
+			}
+		}
+
+		req.Protocol = storage.ServiceProtocolTypeTCP
+		req.Config = tcpConfig(u.Host)
+	default:
+		res.report(e.Name, true, "endpoint url %s is not supported, only http, https and tcp endpoints are", e.URL)
+		return req, false
+	}
+
+	return req, true
+}
+
+// gatusHTTPCondition converts a condition of an HTTP endpoint to an expected status
+// or a JavaScript condition which is truthy when the check fails
+func gatusHTTPCondition(endpoint, raw string) (cond string, status int, ok bool) {
+	m := gatusConditionRe.FindStringSubmatch(strings.TrimSpace(raw))
+	if m == nil {
+		return "", 0, false
+	}
+	placeholder, path, op, value := m[1], m[2], m[3], strings.TrimSpace(m[4])
+
+	switch {
+	case placeholder == "STATUS" && path == "" && op == "==":
+		status, err := strconv.Atoi(value)
+		if err != nil {
+			return "", 0, false
+		}
+		return "", status, true
+	case placeholder == "CONNECTED" && path == "" && op == "==" && value == "true":
+		return "", 0, true
+	case placeholder == "RESPONSE_TIME" && path == "":
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", 0, false
+		}
+		return fmt.Sprintf("!(%s.duration %s %s)", resultRef(endpoint), op, value), 0, true
+	case placeholder == "BODY" && path == "" && (op == "==" || op == "!="):
+		// Only contains patterns like pat(*keyword*) are supported
+		keyword, found := strings.CutPrefix(value, "pat(*")
+		keyword, suffix := strings.CutSuffix(keyword, "*)")
+		if !found || !suffix || strings.Contains(keyword, "*") {
+			return "", 0, false
+		}
+		return keywordCondition(endpoint, keyword, op == "!="), 0, true
+	case placeholder == "BODY" && strings.HasPrefix(path, "."):
+		return fmt.Sprintf("!(JSON.parse(%s.response)%s %s %s)", resultRef(endpoint), path, op, jsValue(value)), 0, true
+	default:
+		return "", 0, false
+	}
+}
+
+// jsValue returns a Gatus condition value as a JavaScript literal
+func jsValue(value string) string {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	if value == "true" || value == "false" {
+		return value
+	}
+	return strconv.Quote(strings.Trim(value, `"`))
+}
+
+// gatusKey returns the key Gatus identifies an endpoint with, without the leading
+// separator of endpoints without a group
+func gatusKey(group, name string) string {
+	replacer := strings.NewReplacer(" ", "-", "/", "-", "_", "-", ",", "-", ".", "-", "#", "-")
+	sanitize := func(s string) string { return replacer.Replace(strings.ToLower(s)) }
+	return strings.TrimPrefix(sanitize(group)+"_"+sanitize(name), "_")
+}
@@ -0,0 +1,110 @@
+// Package importers converts monitors of other uptime tools to Sentinel services
+package importers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sxwebdev/sentinel/internal/monitors"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// Source is the tool a configuration is imported from
+type Source string
+
+const (
+	SourceUptimeKuma Source = "uptime-kuma"
+	SourceGatus      Source = "gatus"
+)
+
+// Issue is a feature of the source configuration that was not converted
+type Issue struct {
+	Service string `json:"service,omitempty"` // empty for issues of the whole configuration
+	Message string `json:"message"`
+	Skipped bool   `json:"skipped"` // the service was not converted at all
+}
+
+// String returns the issue in a human readable form
+func (i Issue) String() string {
+	msg := i.Message
+	if i.Skipped {
+		msg += ", skipped"
+	}
+	if i.Service == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s: %s", i.Service, msg)
+}
+
+// Conversion is the result of converting a configuration
+type Conversion struct {
+	Services []storage.CreateUpdateServiceRequest
+	Issues   []Issue
+}
+
+// ParseSource returns the source with the given name
+func ParseSource(name string) (Source, error) {
+	switch Source(strings.ToLower(name)) {
+	case SourceUptimeKuma, "kuma":
+		return SourceUptimeKuma, nil
+	case SourceGatus:
+		return SourceGatus, nil
+	default:
+		return "", fmt.Errorf("unsupported source: %s", name)
+	}
+}
+
+// Convert converts a configuration of the source to services
+func Convert(source Source, data []byte) (Conversion, error) {
+	switch source {
+	case SourceUptimeKuma:
+		return FromUptimeKuma(data)
+	case SourceGatus:
+		return FromGatus(data)
+	default:
+		return Conversion{}, fmt.Errorf("unsupported source: %s", source)
+	}
+}
+
+// report adds an issue of a service
+func (c *Conversion) report(service string, skipped bool, format string, args ...any) {
+	c.Issues = append(c.Issues, Issue{
+		Service: service,
+		Message: fmt.Sprintf(format, args...),
+		Skipped: skipped,
+	})
+}
+
+// httpConfig returns the config of an HTTP service with a single endpoint
+func httpConfig(endpoint monitors.EndpointConfig, timeoutMS uint64, conditions []string) map[string]any {
+	return map[string]any{
+		string(storage.ServiceProtocolTypeHTTP): monitors.HTTPConfig{
+			Timeout:   timeoutMS,
+			Endpoints: []monitors.EndpointConfig{endpoint},
+			Condition: strings.Join(conditions, " || "),
+		},
+	}
+}
+
+// tcpConfig returns the config of a TCP service
+func tcpConfig(endpoint string) map[string]any {
+	return map[string]any{
+		string(storage.ServiceProtocolTypeTCP): monitors.TCPConfig{Endpoint: endpoint},
+	}
+}
+
+// resultRef returns the JavaScript reference to the endpoint result in an HTTP condition
+func resultRef(endpoint string) string {
+	return "results[" + strconv.Quote(endpoint) + "]"
+}
+
+// keywordCondition returns an HTTP condition failing when the response does not contain
+// the keyword, or when it contains it if inverted
+func keywordCondition(endpoint, keyword string, inverted bool) string {
+	cond := resultRef(endpoint) + ".response.includes(" + strconv.Quote(keyword) + ")"
+	if inverted {
+		return cond
+	}
+	return "!" + cond
+}
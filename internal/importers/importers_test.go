@@ -0,0 +1,149 @@
+package importers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/monitors"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// requireValid checks that converted services pass the monitor config validation
+func requireValid(t *testing.T, services []storage.CreateUpdateServiceRequest) {
+	t.Helper()
+	for _, svc := range services {
+		conf, err := monitors.ConvertFromMap(svc.Config)
+		require.NoError(t, err)
+		require.NoError(t, conf.Validate(svc.Protocol), svc.Name)
+	}
+}
+
+func TestFromUptimeKuma(t *testing.T) {
+	data := []byte(`{
+		"version": "1.23.16",
+		"notificationList": [{"id": 1, "name": "Slack"}],
+		"monitorList": [
+			{
+				"id": 1, "name": "Website", "type": "keyword", "active": 1,
+				"url": "https://example.com", "method": "GET", "interval": 60, "retryInterval": 30,
+				"maxretries": 2, "timeout": 48, "keyword": "Welcome", "invertKeyword": false,
+				"accepted_statuscodes": ["200-299"], "headers": "{\"X-Token\": \"secret\"}",
+				"tags": [{"name": "production", "value": ""}, {"name": "team", "value": "web"}]
+			},
+			{
+				"id": 2, "name": "Database", "type": "port", "active": true,
+				"hostname": "db.example.com", "port": 5432, "interval": 30, "maxretries": 0,
+				"accepted_statuscodes": ["200-299"], "tags": []
+			},
+			{"id": 3, "name": "Ping", "type": "ping", "active": true, "hostname": "example.com", "interval": 60},
+			{
+				"id": 4, "name": "Redirect", "type": "http", "active": false, "url": "https://example.com/old",
+				"method": "get", "interval": 60, "accepted_statuscodes": ["300-399"]
+			}
+		]
+	}`)
+
+	res, err := FromUptimeKuma(data)
+	require.NoError(t, err)
+	require.Len(t, res.Services, 3)
+	requireValid(t, res.Services)
+
+	website := res.Services[0]
+	assert.Equal(t, "uptime-kuma-1", website.ExternalID)
+	assert.Equal(t, storage.ServiceProtocolTypeHTTP, website.Protocol)
+	assert.Equal(t, time.Minute, website.Interval)
+	assert.Equal(t, 48*time.Second, website.Timeout)
+	assert.Equal(t, 1, website.Retries)
+	assert.Nil(t, website.RetryPolicy)
+	assert.Equal(t, &storage.ThresholdPolicy{FailThreshold: 3, RecoveryThreshold: 1}, website.ThresholdPolicy)
+	assert.Equal(t, []string{"production", "team:web"}, website.Tags)
+	assert.True(t, website.IsEnabled)
+
+	conf := website.Config["http"].(monitors.HTTPConfig)
+	assert.Equal(t, uint64(48000), conf.Timeout)
+	assert.Equal(t, `!results["Website"].response.includes("Welcome")`, conf.Condition)
+	assert.Equal(t, "secret", conf.Endpoints[0].Headers["X-Token"])
+	assert.Equal(t, 200, conf.Endpoints[0].ExpectedStatus)
+
+	database := res.Services[1]
+	assert.Equal(t, storage.ServiceProtocolTypeTCP, database.Protocol)
+	assert.Equal(t, "db.example.com:5432", database.Config["tcp"].(monitors.TCPConfig).Endpoint)
+	assert.Equal(t, 1, database.Retries)
+	assert.Nil(t, database.RetryPolicy)
+	assert.Nil(t, database.ThresholdPolicy)
+
+	redirect := res.Services[2]
+	assert.False(t, redirect.IsEnabled)
+	assert.Equal(t, "GET", redirect.Config["http"].(monitors.HTTPConfig).Endpoints[0].Method)
+	assert.Equal(t, 300, redirect.Config["http"].(monitors.HTTPConfig).Endpoints[0].ExpectedStatus)
+
+	assert.Equal(t, []Issue{
+		{Message: "notifications are not imported, configure notification URLs in Sentinel"},
+		{Service: "Website", Message: "retry interval is not supported, failed checks are repeated at the check interval"},
+		{Service: "Ping", Message: "monitor type ping is not supported", Skipped: true},
+		{Service: "Redirect", Message: "status code range 300-399 is checked as 300"},
+	}, res.Issues)
+}
+
+func TestFromGatus(t *testing.T) {
+	data := []byte(`
+endpoints:
+  - name: API
+    group: core
+    url: "https://api.example.com/health"
+    interval: 30s
+    client:
+      timeout: 5s
+    conditions:
+      - "[STATUS] == 201"
+      - "[BODY].status == UP"
+      - "[BODY].data[0].count > 1"
+      - "[RESPONSE_TIME] < 300"
+      - "[BODY] == pat(*ok*)"
+      - "[CERTIFICATE_EXPIRATION] > 48h"
+    alerts:
+      - type: slack
+  - name: Redis
+    url: "tcp://redis.example.com:6379"
+    enabled: false
+    conditions:
+      - "[CONNECTED] == true"
+  - name: DNS
+    url: "8.8.8.8"
+    dns:
+      query-name: example.com
+`)
+
+	res, err := FromGatus(data)
+	require.NoError(t, err)
+	require.Len(t, res.Services, 2)
+	requireValid(t, res.Services)
+
+	api := res.Services[0]
+	assert.Equal(t, "gatus-core_api", api.ExternalID)
+	assert.Equal(t, 30*time.Second, api.Interval)
+	assert.Equal(t, 5*time.Second, api.Timeout)
+	assert.Equal(t, 1, api.Retries)
+	assert.Equal(t, []string{"core"}, api.Tags)
+
+	conf := api.Config["http"].(monitors.HTTPConfig)
+	assert.Equal(t, 201, conf.Endpoints[0].ExpectedStatus)
+	assert.Equal(t, `!(JSON.parse(results["API"].response).status == "UP") || `+
+		`!(JSON.parse(results["API"].response).data[0].count > 1) || `+
+		`!(results["API"].duration < 300) || `+
+		`!results["API"].response.includes("ok")`, conf.Condition)
+
+	redis := res.Services[1]
+	assert.Equal(t, "gatus-redis", redis.ExternalID)
+	assert.False(t, redis.IsEnabled)
+	assert.Equal(t, time.Minute, redis.Interval)
+	assert.Equal(t, "redis.example.com:6379", redis.Config["tcp"].(monitors.TCPConfig).Endpoint)
+
+	assert.Equal(t, []Issue{
+		{Service: "API", Message: "alerts are not imported"},
+		{Service: "API", Message: "condition [CERTIFICATE_EXPIRATION] > 48h is not supported"},
+		{Service: "DNS", Message: "endpoint url 8.8.8.8 is not supported, only http, https and tcp endpoints are", Skipped: true},
+	}, res.Issues)
+}
@@ -0,0 +1,230 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/monitors"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// kumaDefaultStatusCodes are the accepted status codes of new Uptime Kuma monitors
+const kumaDefaultStatusCodes = "200-299"
+
+// kumaBackup is an Uptime Kuma JSON backup
+type kumaBackup struct {
+	Version          string        `json:"version"`
+	NotificationList []any         `json:"notificationList"`
+	MonitorList      []kumaMonitor `json:"monitorList"`
+}
+
+// kumaMonitor is a monitor of an Uptime Kuma backup
+type kumaMonitor struct {
+	ID                  int       `json:"id"`
+	Name                string    `json:"name"`
+	Type                string    `json:"type"`
+	Active              kumaBool  `json:"active"`
+	URL                 string    `json:"url"`
+	Method              string    `json:"method"`
+	Hostname            string    `json:"hostname"`
+	Port                int       `json:"port"`
+	Interval            int       `json:"interval"`
+	RetryInterval       int       `json:"retryInterval"`
+	MaxRetries          int       `json:"maxretries"`
+	Timeout             float64   `json:"timeout"`
+	Keyword             string    `json:"keyword"`
+	InvertKeyword       kumaBool  `json:"invertKeyword"`
+	UpsideDown          kumaBool  `json:"upsideDown"`
+	AcceptedStatusCodes []string  `json:"accepted_statuscodes"`
+	Headers             string    `json:"headers"`
+	Body                string    `json:"body"`
+	AuthMethod          string    `json:"authMethod"`
+	BasicAuthUser       string    `json:"basic_auth_user"`
+	BasicAuthPass       string    `json:"basic_auth_pass"`
+	Tags                []kumaTag `json:"tags"`
+}
+
+// kumaTag is a tag of an Uptime Kuma monitor
+type kumaTag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// kumaBool decodes booleans stored as numbers by older Uptime Kuma versions
+type kumaBool bool
+
+// UnmarshalJSON decodes true, false, 1, 0 and null
+func (b *kumaBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean: %s", data)
+	}
+	return nil
+}
+
+// FromUptimeKuma converts monitors of an Uptime Kuma JSON backup.
+// HTTP, keyword and port monitors are supported, other monitor types are skipped.
+func FromUptimeKuma(data []byte) (Conversion, error) {
+	res := Conversion{Services: []storage.CreateUpdateServiceRequest{}}
+
+	var backup kumaBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return res, fmt.Errorf("failed to parse Uptime Kuma backup: %w", err)
+	}
+
+	if backup.MonitorList == nil {
+		return res, fmt.Errorf("failed to parse Uptime Kuma backup: monitorList not found")
+	}
+
+	if len(backup.NotificationList) > 0 {
+		res.report("", false, "notifications are not imported, configure notification URLs in Sentinel")
+	}
+
+	for _, m := range backup.MonitorList {
+		req, ok := m.convert(&res)
+		if ok {
+			res.Services = append(res.Services, req)
+		}
+	}
+
+	return res, nil
+}
+
+// convert converts a monitor, reporting features that cannot be converted
+func (m kumaMonitor) convert(res *Conversion) (storage.CreateUpdateServiceRequest, bool) {
+	req := storage.CreateUpdateServiceRequest{
+		Name:     m.Name,
+		Interval: time.Duration(m.Interval) * time.Second,
+		Timeout:  time.Duration(m.Timeout * float64(time.Second)),
+		// Kuma makes a single attempt per check
+		Retries:    1,
+		Tags:       make([]string, 0, len(m.Tags)),
+		IsEnabled:  bool(m.Active),
+		ExternalID: "uptime-kuma-" + strconv.Itoa(m.ID),
+	}
+
+	// Kuma marks a monitor down after its retries of failed checks, which are consecutive failures in Sentinel
+	if m.MaxRetries > 0 {
+		req.ThresholdPolicy = &storage.ThresholdPolicy{FailThreshold: m.MaxRetries + 1, RecoveryThreshold: 1}
+
+		if m.RetryInterval > 0 && m.RetryInterval != m.Interval {
+			res.report(m.Name, false, "retry interval is not supported, failed checks are repeated at the check interval")
+		}
+	}
+
+	for _, tag := range m.Tags {
+		if tag.Value != "" {
+			req.Tags = append(req.Tags, tag.Name+":"+tag.Value)
+			continue
+		}
+		req.Tags = append(req.Tags, tag.Name)
+	}
+
+	if m.UpsideDown {
+		res.report(m.Name, true, "upside down mode is not supported")
+		return req, false
+	}
+
+	switch m.Type {
+	case "http", "keyword":
+		endpoint, ok := m.httpEndpoint(res)
+		if !ok {
+			return req, false
+		}
+
+		conditions := []string{}
+		if m.Type == "keyword" {
+			conditions = append(conditions, keywordCondition(endpoint.Name, m.Keyword, bool(m.InvertKeyword)))
+		}
+
+		req.Protocol = storage.ServiceProtocolTypeHTTP
+		req.Config = httpConfig(endpoint, uint64(req.Timeout.Milliseconds()), conditions)
+	case "port":
+		if m.Hostname == "" || m.Port == 0 {
+			res.report(m.Name, true, "hostname and port are required")
+			return req, false
+		}
+
+		req.Protocol = storage.ServiceProtocolTypeTCP
+		req.Config = tcpConfig(net.JoinHostPort(m.Hostname, strconv.Itoa(m.Port)))
+	default:
+		res.report(m.Name, true, "monitor type %s is not supported", m.Type)
+		return req, false
+	}
+
+	return req, true
+}
+
+// httpEndpoint converts the request of an HTTP monitor
+func (m kumaMonitor) httpEndpoint(res *Conversion) (monitors.EndpointConfig, bool) {
+	endpoint := monitors.EndpointConfig{
+		Name:   m.Name,
+		URL:    m.URL,
+		Method: strings.ToUpper(m.Method),
+		Body:   m.Body,
+	}
+	if endpoint.Method == "" {
+		endpoint.Method = "GET"
+	}
+
+	if m.Headers != "" {
+		if err := json.Unmarshal([]byte(m.Headers), &endpoint.Headers); err != nil {
+			res.report(m.Name, true, "invalid headers: %v", err)
+			return endpoint, false
+		}
+	}
+
+	switch m.AuthMethod {
+	case "", "basic":
+		endpoint.Username = m.BasicAuthUser
+		endpoint.Password = m.BasicAuthPass
+	default:
+		res.report(m.Name, false, "authentication method %s is not supported", m.AuthMethod)
+	}
+
+	status, err := m.expectedStatus(res)
+	if err != nil {
+		res.report(m.Name, true, "%v", err)
+		return endpoint, false
+	}
+	endpoint.ExpectedStatus = status
+
+	return endpoint, true
+}
+
+// expectedStatus returns a single status code for the accepted status codes.
+// The default 200-299 range is converted to 200, other ranges to their lower bound.
+func (m kumaMonitor) expectedStatus(res *Conversion) (int, error) {
+	if len(m.AcceptedStatusCodes) == 0 {
+		return 200, nil
+	}
+
+	if len(m.AcceptedStatusCodes) > 1 {
+		res.report(m.Name, false, "only the first of accepted status codes %s is checked", strings.Join(m.AcceptedStatusCodes, ", "))
+	}
+
+	codes := m.AcceptedStatusCodes[0]
+	if codes == kumaDefaultStatusCodes {
+		return 200, nil
+	}
+
+	lower, _, isRange := strings.Cut(codes, "-")
+	status, err := strconv.Atoi(lower)
+	if err != nil {
+		return 0, fmt.Errorf("invalid accepted status code: %s", codes)
+	}
+
+	if isRange {
+		res.report(m.Name, false, "status code range %s is checked as %d", codes, status)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,324 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+)
+
+// servicesPageSize is the number of services loaded at once to match alerts
+const servicesPageSize = 100
+
+// AlertStatus is the status of an external alert
+type AlertStatus string
+
+const (
+	AlertStatusFiring   AlertStatus = "firing"
+	AlertStatusResolved AlertStatus = "resolved"
+)
+
+// Alert is an alert received from an external monitoring system
+type Alert struct {
+	// Key identifies the alert, the firing and resolved notifications of an alert share it
+	Key    string
+	Status AlertStatus
+	Title  string
+	// Description is the error of the incident
+	Description string
+	// Severity overrides the severity label when set
+	Severity storage.IncidentSeverity
+	Labels   map[string]string
+	// Services are names, IDs or external IDs of services affected in addition to the mapped ones
+	Services []string
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// AlertsResult counts the incidents changed by received alerts
+type AlertsResult struct {
+	Opened   int `json:"opened" example:"1"`
+	Updated  int `json:"updated" example:"0"`
+	Resolved int `json:"resolved" example:"2"`
+	// Ignored alerts matched no services or resolved without an open incident
+	Ignored int `json:"ignored" example:"0"`
+}
+
+// ProcessAlerts opens incidents for firing alerts on the services they map to and resolves them
+// when the alerts resolve. A firing alert with an open incident updates its description and severity.
+// Incidents of alerts send alert and recovery notifications like incidents of checks.
+func (m *MonitorService) ProcessAlerts(ctx context.Context, alerts []Alert) (AlertsResult, error) {
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+
+	res := AlertsResult{}
+
+	services, err := m.alertCandidates(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	for _, alert := range alerts {
+		incident, err := m.alertIncident(ctx, alert.Key)
+		if err != nil {
+			return res, err
+		}
+
+		switch {
+		case alert.Status == AlertStatusResolved && incident == nil:
+			res.Ignored++
+		case alert.Status == AlertStatusResolved:
+			if err := m.resolveAlertIncident(ctx, incident, alert); err != nil {
+				return res, err
+			}
+			res.Resolved++
+		case incident != nil:
+			updated, err := m.updateAlertIncident(ctx, incident, alert)
+			if err != nil {
+				return res, err
+			}
+			if updated {
+				res.Updated++
+			}
+		default:
+			serviceIDs := alertServices(m.config.Alerts, alert, services)
+			if len(serviceIDs) == 0 {
+				res.Ignored++
+				continue
+			}
+
+			if err := m.openAlertIncident(ctx, alert, serviceIDs); err != nil {
+				return res, err
+			}
+			res.Opened++
+		}
+	}
+
+	return res, nil
+}
+
+// alertCandidates returns all services alerts can be mapped to
+func (m *MonitorService) alertCandidates(ctx context.Context) ([]*storage.Service, error) {
+	services := []*storage.Service{}
+	for page := uint32(1); ; page++ {
+		items, err := m.storage.FindServices(ctx, storage.FindServicesParams{
+			OrderBy:  "created_at",
+			Page:     utils.Pointer(page),
+			PageSize: utils.Pointer(uint32(servicesPageSize)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find services: %w", err)
+		}
+
+		services = append(services, items.Items...)
+
+		if len(items.Items) < servicesPageSize {
+			return services, nil
+		}
+	}
+}
+
+// alertIncident returns the open incident of an alert if any
+func (m *MonitorService) alertIncident(ctx context.Context, key string) (*storage.Incident, error) {
+	incidents, err := m.storage.FindIncidents(ctx, storage.FindIncidentsParams{
+		AlertKey: key,
+		Resolved: utils.Pointer(false),
+		Source:   storage.IncidentSourceAlert,
+		PageSize: utils.Pointer(uint32(1)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find incident of alert %s: %w", key, err)
+	}
+
+	if len(incidents.Items) == 0 {
+		return nil, nil
+	}
+
+	return incidents.Items[0], nil
+}
+
+// openAlertIncident opens an incident of a firing alert and sends an alert for its primary service
+func (m *MonitorService) openAlertIncident(ctx context.Context, alert Alert, serviceIDs []string) error {
+	startTime := alert.StartsAt
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+
+	incident := &storage.Incident{
+		ID:               storage.GenerateULID(),
+		ServiceID:        serviceIDs[0],
+		AffectedServices: serviceIDs,
+		Source:           storage.IncidentSourceAlert,
+		AlertKey:         alert.Key,
+		Title:            alert.Title,
+		Error:            alertDescription(alert),
+		Severity:         alertSeverity(m.config.Alerts, alert),
+		StartTime:        startTime,
+	}
+
+	if err := m.storage.SaveIncident(ctx, incident); err != nil {
+		return fmt.Errorf("failed to save incident of alert %s: %w", alert.Key, err)
+	}
+
+	m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventOpened, incident.Error)
+	m.publishIncident(receiver.TriggerIncidentEventTypeOpened, incident)
+
+	if m.notifier == nil {
+		return nil
+	}
+
+	svc, err := m.storage.GetServiceByID(ctx, incident.ServiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+
+	if err := m.sendAlert(ctx, svc, incident); err != nil {
+		log.Println(fmt.Errorf("failed to send alert notification for %s: %w", svc.Name, err))
+	}
+
+	return nil
+}
+
+// updateAlertIncident updates the description and severity of the incident of a firing alert,
+// reporting whether they changed
+func (m *MonitorService) updateAlertIncident(ctx context.Context, incident *storage.Incident, alert Alert) (bool, error) {
+	changed := []string{}
+
+	if description := alertDescription(alert); description != incident.Error {
+		incident.Error = description
+		changed = append(changed, "description")
+	}
+
+	if severity := alertSeverity(m.config.Alerts, alert); severity != incident.Severity {
+		incident.Severity = severity
+		changed = append(changed, "severity")
+	}
+
+	if len(changed) == 0 {
+		return false, nil
+	}
+
+	if err := m.storage.UpdateIncident(ctx, incident); err != nil {
+		return false, fmt.Errorf("failed to update incident of alert %s: %w", alert.Key, err)
+	}
+
+	m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventUpdated, strings.Join(changed, ", ")+" updated")
+	m.publishIncident(receiver.TriggerIncidentEventTypeUpdated, incident)
+
+	return true, nil
+}
+
+// resolveAlertIncident resolves the incident of a resolved alert at the end of the alert
+// and sends a recovery notification for its primary service
+func (m *MonitorService) resolveAlertIncident(ctx context.Context, incident *storage.Incident, alert Alert) error {
+	endTime := alert.EndsAt
+	if endTime.IsZero() || endTime.Before(incident.StartTime) || endTime.After(time.Now()) {
+		endTime = time.Now()
+	}
+
+	if err := resolveIncidentAt(incident, endTime); err != nil {
+		return err
+	}
+
+	if err := m.storage.UpdateIncident(ctx, incident); err != nil {
+		return fmt.Errorf("failed to resolve incident of alert %s: %w", alert.Key, err)
+	}
+
+	m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventResolved, "")
+	m.publishIncident(receiver.TriggerIncidentEventTypeResolved, incident)
+
+	if m.notifier == nil {
+		return nil
+	}
+
+	svc, err := m.storage.GetServiceByID(ctx, incident.ServiceID)
+	if err != nil {
+		log.Println(fmt.Errorf("failed to get service of incident %s: %w", incident.ID, err))
+		return nil
+	}
+
+	if err := m.sendRecovery(ctx, svc, incident); err != nil {
+		log.Println(fmt.Errorf("failed to send recovery notification for %s: %w", svc.Name, err))
+	}
+
+	return nil
+}
+
+// alertServices returns the IDs of the services of an alert: the services given by the alert and its service label,
+// and the services and tags of the mappings matching its labels
+func alertServices(cfg config.AlertsConfig, alert Alert, services []*storage.Service) []string {
+	refs := slices.Clone(alert.Services)
+	if ref := alert.Labels[cfg.ServiceLabel]; ref != "" {
+		refs = append(refs, ref)
+	}
+
+	tags := []string{}
+	for _, mapping := range cfg.Mappings {
+		if matchesLabels(mapping.Labels, alert.Labels) {
+			refs = append(refs, mapping.Services...)
+			tags = append(tags, mapping.Tags...)
+		}
+	}
+
+	serviceIDs := []string{}
+	add := func(id string) {
+		if !slices.Contains(serviceIDs, id) {
+			serviceIDs = append(serviceIDs, id)
+		}
+	}
+
+	for _, ref := range refs {
+		for _, svc := range services {
+			if svc.ID == ref || (svc.ExternalID != "" && svc.ExternalID == ref) || strings.EqualFold(svc.Name, ref) {
+				add(svc.ID)
+			}
+		}
+	}
+
+	for _, svc := range services {
+		if slices.ContainsFunc(svc.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			add(svc.ID)
+		}
+	}
+
+	return serviceIDs
+}
+
+// matchesLabels reports whether the labels of an alert have all of the wanted labels
+func matchesLabels(want, labels map[string]string) bool {
+	for name, value := range want {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// alertSeverity returns the incident severity of an alert, warning and info alerts are warnings
+func alertSeverity(cfg config.AlertsConfig, alert Alert) storage.IncidentSeverity {
+	if alert.Severity != "" {
+		return alert.Severity
+	}
+
+	switch strings.ToLower(alert.Labels[cfg.SeverityLabel]) {
+	case "warning", "info":
+		return storage.IncidentSeverityWarning
+	default:
+		return storage.IncidentSeverityCritical
+	}
+}
+
+// alertDescription returns the incident error of an alert, the title when the alert has no description
+func alertDescription(alert Alert) string {
+	if alert.Description != "" {
+		return alert.Description
+	}
+	return alert.Title
+}
@@ -0,0 +1,111 @@
+package monitor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+func TestProcessAlerts(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	ms := NewMonitorService(store, &config.Config{Alerts: config.AlertsConfig{
+		ServiceLabel:  "service",
+		SeverityLabel: "severity",
+		Mappings: []config.AlertMapping{
+			{Labels: map[string]string{"job": "postgres"}, Tags: []string{"database"}},
+		},
+	}}, nil, rc)
+
+	createService := func(name string, tags []string) *storage.Service {
+		svc, err := ms.CreateService(ctx, storage.CreateUpdateServiceRequest{
+			Name:      name,
+			Protocol:  storage.ServiceProtocolTypeTCP,
+			Interval:  time.Minute,
+			Timeout:   time.Second,
+			Tags:      tags,
+			Config:    map[string]any{"tcp": map[string]any{"endpoint": "localhost:1"}},
+			IsEnabled: true,
+		})
+		require.NoError(t, err)
+		return svc
+	}
+
+	api := createService("API", []string{})
+	primary := createService("Primary DB", []string{"database"})
+	replica := createService("Replica DB", []string{"database"})
+
+	startsAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	res, err := ms.ProcessAlerts(ctx, []Alert{
+		{Key: "high-latency", Status: AlertStatusFiring, Title: "HighLatency", Labels: map[string]string{"service": "api", "severity": "warning"}, StartsAt: startsAt},
+		{Key: "replication-lag", Status: AlertStatusFiring, Title: "ReplicationLag", Description: "lag is 30s", Labels: map[string]string{"job": "postgres"}},
+		{Key: "unknown", Status: AlertStatusFiring, Title: "Unknown", Labels: map[string]string{"service": "billing"}},
+		{Key: "never-fired", Status: AlertStatusResolved, Title: "NeverFired", Labels: map[string]string{"service": "api"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, AlertsResult{Opened: 2, Ignored: 2}, res)
+
+	incident, err := ms.alertIncident(ctx, "high-latency")
+	require.NoError(t, err)
+	require.NotNil(t, incident)
+	assert.Equal(t, api.ID, incident.ServiceID)
+	assert.Equal(t, storage.IncidentSourceAlert, incident.Source)
+	assert.Equal(t, storage.IncidentSeverityWarning, incident.Severity)
+	assert.Equal(t, "HighLatency", incident.Error)
+	assert.True(t, startsAt.Equal(incident.StartTime))
+
+	lag, err := ms.alertIncident(ctx, "replication-lag")
+	require.NoError(t, err)
+	require.NotNil(t, lag)
+	assert.Equal(t, []string{primary.ID, replica.ID}, lag.AffectedServices)
+	assert.Equal(t, storage.IncidentSeverityCritical, lag.Severity)
+
+	// Repeated notifications of a firing alert only update the incident when it changed
+	res, err = ms.ProcessAlerts(ctx, []Alert{
+		{Key: "high-latency", Status: AlertStatusFiring, Title: "HighLatency", Labels: map[string]string{"service": "api", "severity": "warning"}},
+		{Key: "replication-lag", Status: AlertStatusFiring, Title: "ReplicationLag", Description: "lag is 90s", Labels: map[string]string{"job": "postgres"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, AlertsResult{Updated: 1}, res)
+
+	res, err = ms.ProcessAlerts(ctx, []Alert{
+		{Key: "high-latency", Status: AlertStatusResolved, Title: "HighLatency", Labels: map[string]string{"service": "api"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, AlertsResult{Resolved: 1}, res)
+
+	resolved, err := store.GetIncidentByID(ctx, incident.ID)
+	require.NoError(t, err)
+	assert.True(t, resolved.Resolved)
+
+	// A resolved alert firing again opens a new incident
+	res, err = ms.ProcessAlerts(ctx, []Alert{
+		{Key: "high-latency", Status: AlertStatusFiring, Title: "HighLatency", Labels: map[string]string{"service": "api"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, AlertsResult{Opened: 1}, res)
+
+	// Checks of a service do not resolve incidents of alerts
+	require.NoError(t, ms.RecordSuccess(ctx, primary.ID, time.Millisecond))
+
+	lag, err = ms.alertIncident(ctx, "replication-lag")
+	require.NoError(t, err)
+	require.NotNil(t, lag)
+	assert.Equal(t, "lag is 90s", lag.Error)
+}
@@ -0,0 +1,246 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+)
+
+// ErrIncidentNotActive is returned when a resolved or already acknowledged incident is acknowledged
+var ErrIncidentNotActive = errors.New("incident is resolved or already acknowledged")
+
+// GetIncident returns an incident of a service or a manual incident affecting it
+func (m *MonitorService) GetIncident(ctx context.Context, serviceID, incidentID string) (*storage.Incident, error) {
+	incident, err := m.storage.GetIncidentByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if incident.ServiceID != serviceID && !slices.Contains(incident.AffectedServices, serviceID) {
+		return nil, storage.ErrNotFound
+	}
+
+	return incident, nil
+}
+
+// AcknowledgeIncident marks an active incident as taken by the author,
+// repeat alerts are not sent for acknowledged incidents
+func (m *MonitorService) AcknowledgeIncident(ctx context.Context, serviceID, incidentID, author string) (*storage.Incident, error) {
+	if _, err := m.GetIncident(ctx, serviceID, incidentID); err != nil {
+		return nil, err
+	}
+
+	err := m.storage.AcknowledgeIncident(ctx, incidentID, author, time.Now())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrIncidentNotActive
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.storage.CreateIncidentEvent(ctx, &storage.IncidentEvent{
+		IncidentID: incidentID,
+		Type:       storage.IncidentEventAcknowledged,
+		Author:     author,
+	}); err != nil {
+		return nil, err
+	}
+
+	incident, err := m.storage.GetIncidentByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.publishIncident(receiver.TriggerIncidentEventTypeUpdated, incident)
+
+	return incident, nil
+}
+
+// AddIncidentNote adds a note of the author to the incident timeline
+func (m *MonitorService) AddIncidentNote(ctx context.Context, serviceID, incidentID, author, message string) (*storage.IncidentEvent, error) {
+	if _, err := m.GetIncident(ctx, serviceID, incidentID); err != nil {
+		return nil, err
+	}
+
+	event := &storage.IncidentEvent{
+		IncidentID: incidentID,
+		Type:       storage.IncidentEventNoteAdded,
+		Message:    message,
+		Author:     author,
+	}
+
+	if err := m.storage.CreateIncidentEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// FindIncidentEvents returns the timeline of an incident of a service
+func (m *MonitorService) FindIncidentEvents(ctx context.Context, serviceID, incidentID string) ([]*storage.IncidentEvent, error) {
+	if _, err := m.GetIncident(ctx, serviceID, incidentID); err != nil {
+		return nil, err
+	}
+
+	return m.storage.FindIncidentEvents(ctx, incidentID)
+}
+
+// recordIncidentEvent adds an event to the incident timeline.
+// Errors are logged, the timeline must not break monitoring.
+func (m *MonitorService) recordIncidentEvent(ctx context.Context, incidentID string, eventType storage.IncidentEventType, message string) {
+	m.recordAuthoredIncidentEvent(ctx, incidentID, eventType, message, "")
+}
+
+// recordAuthoredIncidentEvent adds an event of a user to the incident timeline
+func (m *MonitorService) recordAuthoredIncidentEvent(ctx context.Context, incidentID string, eventType storage.IncidentEventType, message, author string) {
+	err := m.storage.CreateIncidentEvent(ctx, &storage.IncidentEvent{
+		IncidentID: incidentID,
+		Type:       eventType,
+		Message:    message,
+		Author:     author,
+	})
+	if err != nil {
+		log.Println(fmt.Errorf("failed to record %s event of incident %s: %w", eventType, incidentID, err))
+	}
+}
+
+// FindIncidentErrors returns the distinct errors of an incident of a service in the order they were seen
+func (m *MonitorService) FindIncidentErrors(ctx context.Context, serviceID, incidentID string) ([]*storage.IncidentError, error) {
+	if _, err := m.GetIncident(ctx, serviceID, incidentID); err != nil {
+		return nil, err
+	}
+
+	return m.storage.FindIncidentErrors(ctx, incidentID)
+}
+
+// trackIncidentError records the error of a check of a service with an active incident.
+// A changed error is added to the incident timeline and, if enabled, an update notification
+// is sent for errors not seen before in the incident. Errors are logged, tracking must not break monitoring.
+func (m *MonitorService) trackIncidentError(ctx context.Context, svc *storage.Service, checkErr error, notify bool) {
+	incidents, err := m.storage.FindIncidents(ctx, storage.FindIncidentsParams{
+		ServiceID: svc.ID,
+		Resolved:  utils.Pointer(false),
+		Source:    storage.IncidentSourceMonitor,
+		PageSize:  utils.Pointer(uint32(1)),
+	})
+	if err != nil {
+		log.Println(fmt.Errorf("failed to find active incident of %s: %w", svc.Name, err))
+		return
+	}
+
+	if len(incidents.Items) == 0 {
+		return
+	}
+
+	incident := incidents.Items[0]
+	message := checkErr.Error()
+
+	item := m.countIncidentError(ctx, incident.ID, message)
+	if item == nil || item.Occurrences > 1 {
+		return
+	}
+
+	seen, err := m.storage.FindIncidentErrors(ctx, incident.ID)
+	if err != nil {
+		log.Println(fmt.Errorf("failed to find errors of incident %s: %w", incident.ID, err))
+		return
+	}
+
+	// The first error of the incident is not a change
+	if len(seen) < 2 {
+		return
+	}
+
+	previous := seen[len(seen)-2].Error
+	m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventErrorChanged, fmt.Sprintf("%s (was: %s)", message, previous))
+
+	changed := *incident
+	changed.Error = message
+	m.publishIncident(receiver.TriggerIncidentEventTypeUpdated, &changed)
+
+	seenBefore := slices.ContainsFunc(seen[:len(seen)-1], func(e *storage.IncidentError) bool {
+		return e.Error == message
+	})
+
+	if !notify || seenBefore || !m.config.Notifications.NotifyErrorChanges || m.notifier == nil {
+		return
+	}
+
+	if err := m.sendIncidentUpdate(ctx, svc, incident, previous, message); err != nil {
+		log.Println(fmt.Errorf("failed to send update notification for %s: %w", svc.Name, err))
+	}
+}
+
+// publishIncident publishes a change of an incident to the receiver
+func (m *MonitorService) publishIncident(eventType receiver.TriggerIncidentEventType, incident *storage.Incident) {
+	m.receiver.TriggerIncident().Publish(*receiver.NewTriggerIncidentData(eventType, incident))
+}
+
+// countIncidentError records an occurrence of an incident error, errors are logged
+func (m *MonitorService) countIncidentError(ctx context.Context, incidentID, message string) *storage.IncidentError {
+	item, err := m.storage.RecordIncidentError(ctx, incidentID, message, time.Now())
+	if err != nil {
+		log.Println(fmt.Errorf("failed to record error of incident %s: %w", incidentID, err))
+		return nil
+	}
+
+	return item
+}
+
+// sendAlert sends an alert for an incident unless it is acknowledged
+func (m *MonitorService) sendAlert(ctx context.Context, svc *storage.Service, incident *storage.Incident) error {
+	if incident.AcknowledgedAt != nil {
+		return nil
+	}
+
+	if err := m.notifier.SendAlert(svc, incident); err != nil {
+		return err
+	}
+
+	m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventNotificationSent, fmt.Sprintf("%s alert sent", incident.Severity))
+
+	incident.AlertSent = true
+	m.recordIncidentNotifications(ctx, incident)
+	return nil
+}
+
+// sendIncidentUpdate sends a notification of a changed error unless the incident is acknowledged
+func (m *MonitorService) sendIncidentUpdate(ctx context.Context, svc *storage.Service, incident *storage.Incident, previousError, currentError string) error {
+	if incident.AcknowledgedAt != nil {
+		return nil
+	}
+
+	if err := m.notifier.SendIncidentUpdate(svc, incident, previousError, currentError); err != nil {
+		return err
+	}
+
+	m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventNotificationSent, "update notification sent")
+	return nil
+}
+
+// sendRecovery sends a recovery notification for a resolved incident
+func (m *MonitorService) sendRecovery(ctx context.Context, svc *storage.Service, incident *storage.Incident) error {
+	if err := m.notifier.SendRecovery(svc, incident); err != nil {
+		return err
+	}
+
+	m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventNotificationSent, "recovery notification sent")
+
+	incident.RecoverySent = true
+	m.recordIncidentNotifications(ctx, incident)
+	return nil
+}
+
+// recordIncidentNotifications stores the sent notifications of an incident, errors are logged
+func (m *MonitorService) recordIncidentNotifications(ctx context.Context, incident *storage.Incident) {
+	if err := m.storage.UpdateIncidentNotifications(ctx, incident); err != nil {
+		log.Println(fmt.Errorf("failed to record notifications of incident %s: %w", incident.ID, err))
+	}
+}
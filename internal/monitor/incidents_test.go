@@ -0,0 +1,213 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+func TestIncidentTimeline(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	ms := NewMonitorService(store, &config.Config{}, nil, rc)
+
+	svc, err := ms.CreateService(ctx, storage.CreateUpdateServiceRequest{
+		Name:      "API",
+		Protocol:  storage.ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Tags:      []string{},
+		Config:    map[string]any{"tcp": map[string]any{"endpoint": "localhost:1"}},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ms.RecordFailure(ctx, svc.ID, errors.New("connection refused"), time.Millisecond))
+
+	incidents, err := store.FindIncidents(ctx, storage.FindIncidentsParams{ServiceID: svc.ID})
+	require.NoError(t, err)
+	require.Len(t, incidents.Items, 1)
+	incidentID := incidents.Items[0].ID
+
+	incident, err := ms.AcknowledgeIncident(ctx, svc.ID, incidentID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", incident.AcknowledgedBy)
+
+	_, err = ms.AcknowledgeIncident(ctx, svc.ID, incidentID, "bob")
+	require.ErrorIs(t, err, ErrIncidentNotActive)
+
+	_, err = ms.AddIncidentNote(ctx, svc.ID, incidentID, "alice", "restarting the database")
+	require.NoError(t, err)
+
+	_, err = ms.AddIncidentNote(ctx, "other-service", incidentID, "alice", "wrong service")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	require.NoError(t, ms.RecordSuccess(ctx, svc.ID, time.Millisecond))
+
+	events, err := ms.FindIncidentEvents(ctx, svc.ID, incidentID)
+	require.NoError(t, err)
+
+	types := make([]storage.IncidentEventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []storage.IncidentEventType{
+		storage.IncidentEventOpened,
+		storage.IncidentEventAcknowledged,
+		storage.IncidentEventNoteAdded,
+		storage.IncidentEventResolved,
+	}, types)
+	assert.Equal(t, "connection refused", events[0].Message)
+	assert.Equal(t, "alice", events[2].Author)
+}
+
+func TestManualIncidents(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	ms := NewMonitorService(store, &config.Config{}, nil, rc)
+
+	svc, err := ms.CreateService(ctx, storage.CreateUpdateServiceRequest{
+		Name:      "API",
+		Protocol:  storage.ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Tags:      []string{},
+		Config:    map[string]any{"tcp": map[string]any{"endpoint": "localhost:1"}},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	_, err = ms.CreateManualIncident(ctx, CreateIncidentParams{Title: "Outage", ServiceIDs: []string{"missing"}, Author: "alice"})
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	incident, err := ms.CreateManualIncident(ctx, CreateIncidentParams{
+		Title:      "Planned database upgrade",
+		Severity:   storage.IncidentSeverityWarning,
+		ServiceIDs: []string{svc.ID, svc.ID},
+		Author:     "alice",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, storage.IncidentSourceManual, incident.Source)
+	assert.Equal(t, []string{svc.ID}, incident.AffectedServices)
+
+	// Checks do not resolve manual incidents
+	require.NoError(t, ms.RecordSuccess(ctx, svc.ID, time.Millisecond))
+	incident, err = ms.GetIncident(ctx, svc.ID, incident.ID)
+	require.NoError(t, err)
+	assert.False(t, incident.Resolved)
+
+	endTime := incident.StartTime.Add(-time.Minute)
+	_, err = ms.UpdateIncident(ctx, svc.ID, incident.ID, UpdateIncidentParams{EndTime: &endTime, Author: "alice"})
+	require.ErrorIs(t, err, ErrIncidentEndBeforeStart)
+
+	endTime = time.Now()
+	incident, err = ms.UpdateIncident(ctx, svc.ID, incident.ID, UpdateIncidentParams{EndTime: &endTime, Author: "alice"})
+	require.NoError(t, err)
+	assert.True(t, incident.Resolved)
+
+	// The postmortem is written after resolution
+	incident, err = ms.UpdateIncident(ctx, svc.ID, incident.ID, UpdateIncidentParams{
+		Postmortem: &storage.Postmortem{RootCause: "slow migration", Impact: "read only API for 5 minutes"},
+		Author:     "bob",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, incident.Postmortem)
+	assert.Equal(t, "slow migration", incident.Postmortem.RootCause)
+
+	events, err := ms.FindIncidentEvents(ctx, svc.ID, incident.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, storage.IncidentEventOpened, events[0].Type)
+	assert.Equal(t, storage.IncidentEventResolved, events[1].Type)
+	assert.Equal(t, "postmortem updated", events[2].Message)
+	assert.Equal(t, "bob", events[2].Author)
+
+	// Fields tracked by checks are not editable on incidents of the monitor
+	require.NoError(t, ms.RecordFailure(ctx, svc.ID, errors.New("connection refused"), time.Millisecond))
+	monitored, err := store.FindIncidents(ctx, storage.FindIncidentsParams{ServiceID: svc.ID, Source: storage.IncidentSourceMonitor})
+	require.NoError(t, err)
+	require.Len(t, monitored.Items, 1)
+
+	severity := storage.IncidentSeverityWarning
+	_, err = ms.UpdateIncident(ctx, svc.ID, monitored.Items[0].ID, UpdateIncidentParams{Severity: &severity})
+	require.ErrorIs(t, err, ErrIncidentNotManual)
+
+	title := "Database down"
+	incident, err = ms.UpdateIncident(ctx, svc.ID, monitored.Items[0].ID, UpdateIncidentParams{Title: &title})
+	require.NoError(t, err)
+	assert.Equal(t, title, incident.Title)
+}
+
+func TestIncidentErrorChanges(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	ms := NewMonitorService(store, &config.Config{}, nil, rc)
+
+	svc, err := ms.CreateService(ctx, storage.CreateUpdateServiceRequest{
+		Name:      "API",
+		Protocol:  storage.ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Tags:      []string{},
+		Config:    map[string]any{"tcp": map[string]any{"endpoint": "localhost:1"}},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	for _, message := range []string{"timeout", "timeout", "502 Bad Gateway", "timeout"} {
+		require.NoError(t, ms.RecordFailure(ctx, svc.ID, errors.New(message), time.Millisecond))
+	}
+
+	incidents, err := store.FindIncidents(ctx, storage.FindIncidentsParams{ServiceID: svc.ID})
+	require.NoError(t, err)
+	require.Len(t, incidents.Items, 1)
+	incident := incidents.Items[0]
+
+	// The incident keeps the error it was opened with
+	assert.Equal(t, "timeout", incident.Error)
+
+	items, err := ms.FindIncidentErrors(ctx, svc.ID, incident.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, 2, items[0].Occurrences)
+	assert.Equal(t, "502 Bad Gateway", items[1].Error)
+	assert.Equal(t, "timeout", items[2].Error)
+
+	events, err := ms.FindIncidentEvents(ctx, svc.ID, incident.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, storage.IncidentEventErrorChanged, events[1].Type)
+	assert.Equal(t, "502 Bad Gateway (was: timeout)", events[1].Message)
+}
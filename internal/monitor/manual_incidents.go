@@ -0,0 +1,236 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+var (
+	// ErrIncidentNotManual is returned when fields tracked by checks are edited on an incident opened by the monitor
+	ErrIncidentNotManual = errors.New("description, severity, services and end time are only editable on manual incidents")
+	// ErrIncidentEndBeforeStart is returned when an incident ends before it starts
+	ErrIncidentEndBeforeStart = errors.New("incident end time must be after its start time")
+	// ErrIncidentServicesRequired is returned when a manual incident affects no services
+	ErrIncidentServicesRequired = errors.New("at least one affected service is required")
+)
+
+// CreateIncidentParams holds the fields of an incident created by a user
+type CreateIncidentParams struct {
+	Title       string
+	Description string
+	Severity    storage.IncidentSeverity
+	ServiceIDs  []string
+	// StartTime defaults to now
+	StartTime time.Time
+	// EndTime records an already resolved incident
+	EndTime *time.Time
+	Author  string
+}
+
+// UpdateIncidentParams holds the edited fields of an incident, nil fields are kept
+type UpdateIncidentParams struct {
+	Title      *string
+	Postmortem *storage.Postmortem
+
+	// Fields of manual incidents, incidents of the monitor follow checks
+	Description *string
+	Severity    *storage.IncidentSeverity
+	ServiceIDs  []string
+	// EndTime resolves the incident or corrects the end of a resolved one
+	EndTime *time.Time
+
+	Author string
+}
+
+// CreateManualIncident creates an incident for outages checks did not detect or planned degradations.
+// The incident is not resolved by checks and does not send notifications.
+func (m *MonitorService) CreateManualIncident(ctx context.Context, params CreateIncidentParams) (*storage.Incident, error) {
+	serviceIDs, err := m.incidentServices(ctx, params.ServiceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := params.StartTime
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+
+	incident := &storage.Incident{
+		ID:               storage.GenerateULID(),
+		ServiceID:        serviceIDs[0],
+		AffectedServices: serviceIDs,
+		Source:           storage.IncidentSourceManual,
+		Title:            params.Title,
+		Error:            params.Description,
+		Severity:         params.Severity,
+		StartTime:        startTime,
+	}
+
+	if params.EndTime != nil {
+		if err := resolveIncidentAt(incident, *params.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.storage.SaveIncident(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to save incident: %w", err)
+	}
+
+	m.recordAuthoredIncidentEvent(ctx, incident.ID, storage.IncidentEventOpened, params.Title, params.Author)
+	if incident.Resolved {
+		m.recordAuthoredIncidentEvent(ctx, incident.ID, storage.IncidentEventResolved, "", params.Author)
+	}
+
+	created, err := m.storage.GetIncidentByID(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.publishIncident(receiver.TriggerIncidentEventTypeOpened, created)
+	if created.Resolved {
+		m.publishIncident(receiver.TriggerIncidentEventTypeResolved, created)
+	}
+
+	return created, nil
+}
+
+// UpdateIncident edits an incident of a service. The title and postmortem are editable on any incident,
+// also after it is resolved, other fields only on manual incidents.
+func (m *MonitorService) UpdateIncident(ctx context.Context, serviceID, incidentID string, params UpdateIncidentParams) (*storage.Incident, error) {
+	incident, err := m.GetIncident(ctx, serviceID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	manualOnly := params.Description != nil || params.Severity != nil || params.ServiceIDs != nil || params.EndTime != nil
+	if manualOnly && incident.Source != storage.IncidentSourceManual {
+		return nil, ErrIncidentNotManual
+	}
+
+	changed := []string{}
+	wasResolved := incident.Resolved
+
+	if params.Description != nil && *params.Description != incident.Error {
+		incident.Error = *params.Description
+		changed = append(changed, "description")
+	}
+
+	if params.Severity != nil && *params.Severity != incident.Severity {
+		incident.Severity = *params.Severity
+		changed = append(changed, "severity")
+	}
+
+	if params.ServiceIDs != nil {
+		serviceIDs, err := m.incidentServices(ctx, params.ServiceIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Equal(serviceIDs, incident.AffectedServices) {
+			incident.ServiceID = serviceIDs[0]
+			incident.AffectedServices = serviceIDs
+			changed = append(changed, "services")
+		}
+	}
+
+	if params.EndTime != nil && (incident.EndTime == nil || !params.EndTime.Equal(*incident.EndTime)) {
+		if err := resolveIncidentAt(incident, *params.EndTime); err != nil {
+			return nil, err
+		}
+		if wasResolved {
+			changed = append(changed, "end time")
+		}
+	}
+
+	stateChanged := len(changed) > 0 || incident.Resolved != wasResolved
+
+	detailsChanged := false
+	if params.Title != nil && *params.Title != incident.Title {
+		incident.Title = *params.Title
+		changed = append(changed, "title")
+		detailsChanged = true
+	}
+
+	if params.Postmortem != nil {
+		incident.Postmortem = params.Postmortem
+		changed = append(changed, "postmortem")
+		detailsChanged = true
+	}
+
+	if stateChanged {
+		if err := m.storage.UpdateIncident(ctx, incident); err != nil {
+			return nil, fmt.Errorf("failed to update incident: %w", err)
+		}
+	}
+
+	if detailsChanged {
+		if err := m.storage.UpdateIncidentDetails(ctx, incident); err != nil {
+			return nil, fmt.Errorf("failed to update incident details: %w", err)
+		}
+	}
+
+	if len(changed) > 0 {
+		m.recordAuthoredIncidentEvent(ctx, incident.ID, storage.IncidentEventUpdated, strings.Join(changed, ", ")+" updated", params.Author)
+	}
+
+	if incident.Resolved && !wasResolved {
+		m.recordAuthoredIncidentEvent(ctx, incident.ID, storage.IncidentEventResolved, "", params.Author)
+	}
+
+	updated, err := m.storage.GetIncidentByID(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changed) > 0 {
+		m.publishIncident(receiver.TriggerIncidentEventTypeUpdated, updated)
+	}
+	if updated.Resolved && !wasResolved {
+		m.publishIncident(receiver.TriggerIncidentEventTypeResolved, updated)
+	}
+
+	return updated, nil
+}
+
+// incidentServices returns the unique IDs of existing services affected by an incident
+func (m *MonitorService) incidentServices(ctx context.Context, ids []string) ([]string, error) {
+	serviceIDs := []string{}
+	for _, id := range ids {
+		if slices.Contains(serviceIDs, id) {
+			continue
+		}
+
+		if _, err := m.storage.GetServiceByID(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to get service %s: %w", id, err)
+		}
+
+		serviceIDs = append(serviceIDs, id)
+	}
+
+	if len(serviceIDs) == 0 {
+		return nil, ErrIncidentServicesRequired
+	}
+
+	return serviceIDs, nil
+}
+
+// resolveIncidentAt marks an incident as resolved at the end time
+func resolveIncidentAt(incident *storage.Incident, endTime time.Time) error {
+	if endTime.Before(incident.StartTime) {
+		return ErrIncidentEndBeforeStart
+	}
+
+	duration := endTime.Sub(incident.StartTime)
+	incident.EndTime = &endTime
+	incident.Duration = &duration
+	incident.Resolved = true
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/sxwebdev/sentinel/internal/config"
@@ -21,6 +22,9 @@ type MonitorService struct {
 	config   *config.Config
 	notifier *notifier.Notifier
 	receiver *receiver.Receiver
+
+	// alertsMu serializes received alerts, so an alert opens a single incident
+	alertsMu sync.Mutex
 }
 
 // NewMonitorService creates a new monitor service
@@ -118,27 +122,40 @@ func (m *MonitorService) RecordSuccess(ctx context.Context, serviceID string, re
 		return fmt.Errorf("failed to get service state: %w", err)
 	}
 
+	policy := thresholdPolicyOrDefault(service.ThresholdPolicy)
+
 	// Update state
 	now := time.Now()
-	serviceState.Status = storage.StatusUp
+	wasFlapping := serviceState.IsFlapping
+
 	serviceState.LastCheck = &now
 	serviceState.ResponseTimeNS = utils.Pointer(responseTime.Nanoseconds())
 	serviceState.ConsecutiveFails = 0
 	serviceState.ConsecutiveSuccess++
 	serviceState.TotalChecks++
 	serviceState.LastError = nil
+	serviceState.RecentResults = appendCheckResult(serviceState.RecentResults, true)
+	serviceState.IsFlapping = isFlapping(policy, serviceState.RecentResults)
+
+	// A down service stays down until the recovery threshold is reached
+	recovered := serviceState.Status != storage.StatusDown || recoveryThresholdReached(policy, serviceState)
+	if recovered {
+		serviceState.Status = storage.StatusUp
+	}
 
 	// Save to database
 	if err := m.storage.UpdateServiceState(ctx, serviceState); err != nil {
 		return fmt.Errorf("failed to update service state for %s: %w", service.Name, err)
 	}
 
-	// Resolve any active incidents
-	if err := m.resolveActiveIncidents(ctx, serviceID); err != nil {
-		return err
+	// Resolve any active incidents, notifications are muted while the service is flapping
+	if recovered {
+		if err := m.resolveActiveIncidents(ctx, serviceID, !serviceState.IsFlapping); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return m.handleFlappingChange(ctx, service, serviceState, wasFlapping)
 }
 
 // RecordFailure records a failed check for a service
@@ -155,51 +172,209 @@ func (m *MonitorService) RecordFailure(ctx context.Context, serviceID string, ch
 		return fmt.Errorf("failed to get service state: %w", err)
 	}
 
+	policy := thresholdPolicyOrDefault(service.ThresholdPolicy)
+
 	// Update state
 	now := time.Now()
-	wasUp := serviceState.Status == storage.StatusUp || serviceState.Status == storage.StatusUnknown
+	wasUp := serviceState.Status != storage.StatusDown
+	wasFlapping := serviceState.IsFlapping
 
-	serviceState.Status = storage.StatusDown
 	serviceState.LastCheck = &now
 	serviceState.ResponseTimeNS = &[]int64{responseTime.Nanoseconds()}[0]
 	serviceState.ConsecutiveFails++
 	serviceState.ConsecutiveSuccess = 0
 	serviceState.TotalChecks++
 	serviceState.LastError = utils.Pointer(checkErr.Error())
+	serviceState.RecentResults = appendCheckResult(serviceState.RecentResults, false)
+	serviceState.IsFlapping = isFlapping(policy, serviceState.RecentResults)
+
+	// An up service stays up until the fail threshold is reached
+	wentDown := wasUp && failThresholdReached(policy, serviceState)
+	if !wasUp || wentDown {
+		serviceState.Status = storage.StatusDown
+	}
 
 	// Save to database
 	if err := m.storage.UpdateServiceState(ctx, serviceState); err != nil {
 		return fmt.Errorf("failed to update service state for %s: %w", service.Name, err)
 	}
 
-	// Create incident if service was up before
-	if wasUp {
-		if err := m.createIncident(ctx, service, checkErr); err != nil {
+	// Create incident if service was up before, notifications are muted while the service is flapping
+	if wentDown {
+		if err := m.createIncident(ctx, service, checkErr, storage.IncidentSeverityCritical, !serviceState.IsFlapping); err != nil {
 			return fmt.Errorf("failed to create incident: %w", err)
 		}
+	} else if !wasUp {
+		m.trackIncidentError(ctx, service, checkErr, !serviceState.IsFlapping)
 	}
 
-	return nil
+	return m.handleFlappingChange(ctx, service, serviceState, wasFlapping)
+}
+
+// RecordDegraded records a successful check that reported a warning for a service
+func (m *MonitorService) RecordDegraded(ctx context.Context, serviceID string, warnErr error, responseTime time.Duration) error {
+	// Get current service from database
+	service, err := m.storage.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return fmt.Errorf("service %s not found in database: %w", serviceID, err)
+	}
+
+	// Get current service state
+	serviceState, err := m.storage.GetServiceState(ctx, serviceID)
+	if err != nil {
+		return fmt.Errorf("failed to get service state: %w", err)
+	}
+
+	policy := thresholdPolicyOrDefault(service.ThresholdPolicy)
+
+	// Update state, a degraded check counts as a success for thresholds
+	now := time.Now()
+	previousStatus := serviceState.Status
+	wasFlapping := serviceState.IsFlapping
+
+	serviceState.LastCheck = &now
+	serviceState.ResponseTimeNS = utils.Pointer(responseTime.Nanoseconds())
+	serviceState.ConsecutiveFails = 0
+	serviceState.ConsecutiveSuccess++
+	serviceState.TotalChecks++
+	serviceState.LastError = utils.Pointer(warnErr.Error())
+	serviceState.RecentResults = appendCheckResult(serviceState.RecentResults, true)
+	serviceState.IsFlapping = isFlapping(policy, serviceState.RecentResults)
+
+	// A down service stays down until the recovery threshold is reached
+	becameDegraded := previousStatus != storage.StatusDegraded &&
+		(previousStatus != storage.StatusDown || recoveryThresholdReached(policy, serviceState))
+	if becameDegraded {
+		serviceState.Status = storage.StatusDegraded
+	}
+
+	// Save to database
+	if err := m.storage.UpdateServiceState(ctx, serviceState); err != nil {
+		return fmt.Errorf("failed to update service state for %s: %w", service.Name, err)
+	}
+
+	if becameDegraded {
+		// Resolve the critical incident of a recovered service before opening the warning one
+		if previousStatus == storage.StatusDown {
+			if err := m.resolveActiveIncidents(ctx, serviceID, !serviceState.IsFlapping); err != nil {
+				return err
+			}
+		}
+
+		if err := m.createIncident(ctx, service, warnErr, storage.IncidentSeverityWarning, !serviceState.IsFlapping); err != nil {
+			return fmt.Errorf("failed to create incident: %w", err)
+		}
+	} else if previousStatus == storage.StatusDegraded {
+		m.trackIncidentError(ctx, service, warnErr, !serviceState.IsFlapping)
+	}
+
+	return m.handleFlappingChange(ctx, service, serviceState, wasFlapping)
 }
 
-// createIncident creates a new incident when a service goes down
-func (m *MonitorService) createIncident(ctx context.Context, svc *storage.Service, err error) error {
-	incident := &storage.Incident{
-		ID:        storage.GenerateULID(),
+// handleFlappingChange logs flapping transitions. When a service stops flapping, the recoveries
+// muted for incidents whose alert was sent are sent, then the muted alert of the active incident
+// unless it was already sent or the incident is acknowledged.
+func (m *MonitorService) handleFlappingChange(ctx context.Context, svc *storage.Service, state *storage.ServiceStateRecord, wasFlapping bool) error {
+	if wasFlapping == state.IsFlapping {
+		return nil
+	}
+
+	if state.IsFlapping {
+		log.Printf("service %s is flapping, notifications are muted", svc.Name)
+		return nil
+	}
+
+	log.Printf("service %s stopped flapping", svc.Name)
+
+	if m.notifier == nil {
+		return nil
+	}
+
+	muted, err := m.storage.FindIncidents(ctx, storage.FindIncidentsParams{
+		ServiceID:    svc.ID,
+		Resolved:     utils.Pointer(true),
+		Source:       storage.IncidentSourceMonitor,
+		AlertSent:    utils.Pointer(true),
+		RecoverySent: utils.Pointer(false),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find incidents with muted recoveries: %w", err)
+	}
+
+	for _, incident := range slices.Backward(muted.Items) {
+		if err := m.sendRecovery(ctx, svc, incident); err != nil {
+			log.Println(fmt.Errorf("failed to send recovery notification for %s: %w", svc.Name, err))
+		}
+	}
+
+	if state.Status != storage.StatusDown && state.Status != storage.StatusDegraded {
+		return nil
+	}
+
+	incidents, err := m.storage.FindIncidents(ctx, storage.FindIncidentsParams{
 		ServiceID: svc.ID,
-		StartTime: time.Now(),
-		Error:     err.Error(),
-		Resolved:  false,
+		Resolved:  utils.Pointer(false),
+		Source:    storage.IncidentSourceMonitor,
+		AlertSent: utils.Pointer(false),
+		PageSize:  utils.Pointer(uint32(1)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find active incidents: %w", err)
+	}
+
+	for _, incident := range incidents.Items {
+		if err := m.sendAlert(ctx, svc, incident); err != nil {
+			log.Println(fmt.Errorf("failed to send alert notification for %s: %w", svc.Name, err))
+		}
+	}
+
+	return nil
+}
+
+// createIncident creates a new incident when a service goes down or becomes degraded.
+// An active warning incident of a degraded service is escalated instead,
+// the alert is not repeated when the incident is acknowledged.
+func (m *MonitorService) createIncident(ctx context.Context, svc *storage.Service, err error, severity storage.IncidentSeverity, notify bool) error {
+	incident, findErr := m.activeWarningIncident(ctx, svc.ID)
+	if findErr != nil {
+		return findErr
 	}
 
-	// Save incident to storage
-	if err := m.storage.SaveIncident(ctx, incident); err != nil {
-		return fmt.Errorf("failed to save incident for %s: %w", svc.Name, err)
+	if incident != nil && severity == storage.IncidentSeverityCritical {
+		incident.Severity = severity
+		incident.Error = err.Error()
+
+		if err := m.storage.UpdateIncident(ctx, incident); err != nil {
+			return fmt.Errorf("failed to escalate incident for %s: %w", svc.Name, err)
+		}
+
+		m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventErrorChanged, "escalated to critical: "+incident.Error)
+		m.countIncidentError(ctx, incident.ID, incident.Error)
+		m.publishIncident(receiver.TriggerIncidentEventTypeUpdated, incident)
+	} else {
+		incident = &storage.Incident{
+			ID:        storage.GenerateULID(),
+			ServiceID: svc.ID,
+			Source:    storage.IncidentSourceMonitor,
+			StartTime: time.Now(),
+			Error:     err.Error(),
+			Resolved:  false,
+			Severity:  severity,
+		}
+
+		// Save incident to storage
+		if err := m.storage.SaveIncident(ctx, incident); err != nil {
+			return fmt.Errorf("failed to save incident for %s: %w", svc.Name, err)
+		}
+
+		m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventOpened, incident.Error)
+		m.countIncidentError(ctx, incident.ID, incident.Error)
+		m.publishIncident(receiver.TriggerIncidentEventTypeOpened, incident)
 	}
 
 	// Send alert notification
-	if m.notifier != nil {
-		if err := m.notifier.SendAlert(svc, incident); err != nil {
+	if m.notifier != nil && notify {
+		if err := m.sendAlert(ctx, svc, incident); err != nil {
 			err := fmt.Errorf("failed to send alert notification for %s: %w", svc.Name, err)
 			log.Println(err)
 			return nil
@@ -209,8 +384,28 @@ func (m *MonitorService) createIncident(ctx context.Context, svc *storage.Servic
 	return nil
 }
 
+// activeWarningIncident returns the active warning incident of a service if any
+func (m *MonitorService) activeWarningIncident(ctx context.Context, serviceID string) (*storage.Incident, error) {
+	incidents, err := m.storage.FindIncidents(ctx, storage.FindIncidentsParams{
+		ServiceID: serviceID,
+		Resolved:  utils.Pointer(false),
+		Severity:  storage.IncidentSeverityWarning,
+		Source:    storage.IncidentSourceMonitor,
+		PageSize:  utils.Pointer(uint32(1)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active incidents: %w", err)
+	}
+
+	if len(incidents.Items) == 0 {
+		return nil, nil
+	}
+
+	return incidents.Items[0], nil
+}
+
 // resolveActiveIncidents resolves the active incident when a service recovers
-func (m *MonitorService) resolveActiveIncidents(ctx context.Context, serviceID string) error {
+func (m *MonitorService) resolveActiveIncidents(ctx context.Context, serviceID string, notify bool) error {
 	// Get service
 	svc, err := m.storage.GetServiceByID(ctx, serviceID)
 	if err != nil {
@@ -223,10 +418,15 @@ func (m *MonitorService) resolveActiveIncidents(ctx context.Context, serviceID s
 		return fmt.Errorf("failed to resolve incidents: %w", err)
 	}
 
+	for _, incident := range incidents {
+		m.recordIncidentEvent(ctx, incident.ID, storage.IncidentEventResolved, "")
+		m.publishIncident(receiver.TriggerIncidentEventTypeResolved, incident)
+	}
+
 	for _, incident := range incidents {
 		// Send recovery notification
-		if m.notifier != nil {
-			if err := m.notifier.SendRecovery(svc, incident); err != nil {
+		if m.notifier != nil && notify {
+			if err := m.sendRecovery(ctx, svc, incident); err != nil {
 				err := fmt.Errorf("failed to send recovery notification for %s: %w", svc.Name, err)
 				log.Println(err)
 				return nil
@@ -237,6 +437,11 @@ func (m *MonitorService) resolveActiveIncidents(ctx context.Context, serviceID s
 	return nil
 }
 
+// SaveCheckResult saves a check in the service check history
+func (m *MonitorService) SaveCheckResult(ctx context.Context, result *storage.CheckResult) error {
+	return m.storage.SaveCheckResult(ctx, result)
+}
+
 // DeleteIncident deletes a specific incident
 func (m *MonitorService) DeleteIncident(ctx context.Context, serviceID, incidentID string) error {
 	// Delete the incident
@@ -248,12 +453,7 @@ func (m *MonitorService) DeleteIncident(ctx context.Context, serviceID, incident
 }
 
 // GetServiceStats gets statistics for a service
-func (m *MonitorService) GetServiceStats(ctx context.Context, serviceID string, since time.Time) (*storage.ServiceStats, error) {
-	params := storage.FindIncidentsParams{
-		ServiceID: serviceID,
-		StartTime: utils.Pointer(since),
-	}
-
+func (m *MonitorService) GetServiceStats(ctx context.Context, params storage.ServiceStatsParams) (*storage.ServiceStats, error) {
 	return m.storage.GetServiceStats(ctx, params)
 }
 
@@ -275,7 +475,7 @@ func (m *MonitorService) TriggerCheck(ctx context.Context, serviceID string) err
 
 // resolveAllActiveIncidents resolves all active incidents for a service
 func (m *MonitorService) resolveAllActiveIncidents(ctx context.Context, serviceID string) error {
-	return m.resolveActiveIncidents(ctx, serviceID)
+	return m.resolveActiveIncidents(ctx, serviceID, true)
 }
 
 // ForceResolveIncidents manually resolves all active incidents for a service
@@ -321,7 +521,7 @@ func (m *MonitorService) CheckService(ctx context.Context, service *storage.Serv
 
 	// Resolve incident if service was down before
 	if wasDown {
-		if err := m.resolveActiveIncidents(ctx, service.ID); err != nil {
+		if err := m.resolveActiveIncidents(ctx, service.ID, true); err != nil {
 			return fmt.Errorf("failed to resolve incident: %w", err)
 		}
 	}
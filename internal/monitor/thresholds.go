@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"strings"
+
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+const (
+	// maxRecentResults is the number of recent check results kept in the service state
+	maxRecentResults = 100
+	// defaultFlapWindow is the number of recent checks used for flapping detection by default
+	defaultFlapWindow = 20
+
+	checkResultSuccess = '1'
+	checkResultFailure = '0'
+)
+
+// thresholdPolicyOrDefault returns the service threshold policy with defaults applied
+func thresholdPolicyOrDefault(policy *storage.ThresholdPolicy) storage.ThresholdPolicy {
+	var p storage.ThresholdPolicy
+	if policy != nil {
+		p = *policy
+	}
+
+	if p.FailThreshold <= 0 {
+		p.FailThreshold = 1
+	}
+	if p.RecoveryThreshold <= 0 {
+		p.RecoveryThreshold = 1
+	}
+	if p.Window > maxRecentResults {
+		p.Window = maxRecentResults
+	}
+	if p.FlapWindow <= 0 || p.FlapWindow > maxRecentResults {
+		p.FlapWindow = defaultFlapWindow
+	}
+
+	return p
+}
+
+// appendCheckResult appends a check result to the recent results keeping the latest ones
+func appendCheckResult(recent string, success bool) string {
+	result := checkResultFailure
+	if success {
+		result = checkResultSuccess
+	}
+
+	recent += string(result)
+	if len(recent) > maxRecentResults {
+		recent = recent[len(recent)-maxRecentResults:]
+	}
+
+	return recent
+}
+
+// lastResults returns at most n latest check results
+func lastResults(recent string, n int) string {
+	if n <= 0 || len(recent) <= n {
+		return recent
+	}
+	return recent[len(recent)-n:]
+}
+
+// failThresholdReached reports whether the service should be marked down
+func failThresholdReached(policy storage.ThresholdPolicy, state *storage.ServiceStateRecord) bool {
+	if policy.Window > 0 {
+		failures := strings.Count(lastResults(state.RecentResults, policy.Window), string(checkResultFailure))
+		return failures >= policy.FailThreshold
+	}
+
+	return state.ConsecutiveFails >= policy.FailThreshold
+}
+
+// recoveryThresholdReached reports whether the service should be marked up
+func recoveryThresholdReached(policy storage.ThresholdPolicy, state *storage.ServiceStateRecord) bool {
+	if policy.Window > 0 {
+		successes := strings.Count(lastResults(state.RecentResults, policy.Window), string(checkResultSuccess))
+		return successes >= policy.RecoveryThreshold
+	}
+
+	return state.ConsecutiveSuccess >= policy.RecoveryThreshold
+}
+
+// isFlapping reports whether the check results oscillate within the flap window
+func isFlapping(policy storage.ThresholdPolicy, recent string) bool {
+	if policy.FlapThreshold <= 0 {
+		return false
+	}
+
+	results := lastResults(recent, policy.FlapWindow)
+
+	changes := 0
+	for i := 1; i < len(results); i++ {
+		if results[i] != results[i-1] {
+			changes++
+		}
+	}
+
+	return changes >= policy.FlapThreshold
+}
@@ -0,0 +1,189 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/notifier"
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+func TestThresholds(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    storage.ThresholdPolicy
+		state     storage.ServiceStateRecord
+		failed    bool
+		recovered bool
+	}{
+		{
+			name:      "Defaults mark the first failure and success",
+			state:     storage.ServiceStateRecord{ConsecutiveFails: 1, ConsecutiveSuccess: 1, RecentResults: "01"},
+			failed:    true,
+			recovered: true,
+		},
+		{
+			name:   "Consecutive failures below the threshold",
+			policy: storage.ThresholdPolicy{FailThreshold: 3, RecoveryThreshold: 2},
+			state:  storage.ServiceStateRecord{ConsecutiveFails: 2, ConsecutiveSuccess: 1, RecentResults: "1100"},
+		},
+		{
+			name:      "Consecutive counts reach the thresholds",
+			policy:    storage.ThresholdPolicy{FailThreshold: 3, RecoveryThreshold: 2},
+			state:     storage.ServiceStateRecord{ConsecutiveFails: 3, ConsecutiveSuccess: 2},
+			failed:    true,
+			recovered: true,
+		},
+		{
+			name:   "M-of-N counts failures that are not consecutive",
+			policy: storage.ThresholdPolicy{FailThreshold: 3, RecoveryThreshold: 4, Window: 5},
+			state:  storage.ServiceStateRecord{ConsecutiveFails: 1, RecentResults: "1101010"},
+			failed: true,
+		},
+		{
+			name:      "M-of-N ignores results outside the window",
+			policy:    storage.ThresholdPolicy{FailThreshold: 3, RecoveryThreshold: 3, Window: 4},
+			state:     storage.ServiceStateRecord{ConsecutiveFails: 3, RecentResults: "0001110"},
+			recovered: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := thresholdPolicyOrDefault(&tt.policy)
+			assert.Equal(t, tt.failed, failThresholdReached(policy, &tt.state))
+			assert.Equal(t, tt.recovered, recoveryThresholdReached(policy, &tt.state))
+		})
+	}
+}
+
+func TestThresholdPolicyOrDefault(t *testing.T) {
+	assert.Equal(t, storage.ThresholdPolicy{FailThreshold: 1, RecoveryThreshold: 1, FlapWindow: defaultFlapWindow}, thresholdPolicyOrDefault(nil))
+
+	policy := thresholdPolicyOrDefault(&storage.ThresholdPolicy{Window: 500, FlapWindow: 500})
+	assert.Equal(t, maxRecentResults, policy.Window)
+	assert.Equal(t, defaultFlapWindow, policy.FlapWindow)
+}
+
+func TestIsFlapping(t *testing.T) {
+	policy := storage.ThresholdPolicy{FlapThreshold: 3, FlapWindow: 5}
+
+	assert.False(t, isFlapping(storage.ThresholdPolicy{FlapWindow: 5}, "01010"), "disabled")
+	assert.False(t, isFlapping(policy, "0110"), "two changes")
+	assert.True(t, isFlapping(policy, "01011"), "three changes")
+
+	// Flapping stops once the changes move out of the window
+	assert.True(t, isFlapping(policy, "0101"+"1"))
+	assert.False(t, isFlapping(policy, "0101"+"11"))
+	assert.False(t, isFlapping(policy, "000000111111"))
+}
+
+func TestAppendCheckResult(t *testing.T) {
+	assert.Equal(t, "1", appendCheckResult("", true))
+	assert.Equal(t, "10", appendCheckResult("1", false))
+
+	recent := strings.Repeat("0", maxRecentResults)
+	recent = appendCheckResult(recent, true)
+	assert.Len(t, recent, maxRecentResults)
+	assert.Equal(t, strings.Repeat("0", maxRecentResults-1)+"1", recent)
+}
+
+func TestFlappingMutedRecovery(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	conf := &config.Config{Notifications: config.NotificationsConfig{
+		Enabled:  true,
+		URLs:     []string{"logger://"},
+		Delivery: config.DeliveryConfig{MaxAttempts: 1, InitialBackoff: time.Second, MaxBackoff: time.Second},
+	}}
+
+	notif, err := notifier.New(logger.Default(), conf, store)
+	require.NoError(t, err)
+	require.NoError(t, notif.Start(ctx))
+	t.Cleanup(func() { _ = notif.Stop(ctx) })
+
+	ms := NewMonitorService(store, conf, notif, rc)
+
+	svc, err := ms.CreateService(ctx, storage.CreateUpdateServiceRequest{
+		Name:      "API",
+		Protocol:  storage.ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Tags:      []string{},
+		Config:    map[string]any{"tcp": map[string]any{"endpoint": "localhost:1"}},
+		IsEnabled: true,
+		ThresholdPolicy: &storage.ThresholdPolicy{
+			RecoveryThreshold: 2,
+			FlapThreshold:     3,
+			FlapWindow:        5,
+		},
+	})
+	require.NoError(t, err)
+
+	checkErr := errors.New("connection refused")
+
+	// The alert is sent before the service starts flapping
+	require.NoError(t, ms.RecordFailure(ctx, svc.ID, checkErr, time.Millisecond))
+
+	incidents, err := store.FindIncidents(ctx, storage.FindIncidentsParams{ServiceID: svc.ID})
+	require.NoError(t, err)
+	require.Len(t, incidents.Items, 1)
+	incidentID := incidents.Items[0].ID
+	assert.True(t, incidents.Items[0].AlertSent)
+
+	require.NoError(t, ms.RecordSuccess(ctx, svc.ID, time.Millisecond))
+	require.NoError(t, ms.RecordFailure(ctx, svc.ID, checkErr, time.Millisecond))
+	require.NoError(t, ms.RecordSuccess(ctx, svc.ID, time.Millisecond))
+
+	// The service recovers while flapping, the recovery is muted
+	require.NoError(t, ms.RecordSuccess(ctx, svc.ID, time.Millisecond))
+
+	state, err := store.GetServiceState(ctx, svc.ID)
+	require.NoError(t, err)
+	assert.True(t, state.IsFlapping)
+
+	incident, err := store.GetIncidentByID(ctx, incidentID)
+	require.NoError(t, err)
+	assert.True(t, incident.Resolved)
+	assert.False(t, incident.RecoverySent)
+
+	// The muted recovery is sent once flapping stops
+	require.NoError(t, ms.RecordSuccess(ctx, svc.ID, time.Millisecond))
+
+	state, err = store.GetServiceState(ctx, svc.ID)
+	require.NoError(t, err)
+	assert.False(t, state.IsFlapping)
+
+	incident, err = store.GetIncidentByID(ctx, incidentID)
+	require.NoError(t, err)
+	assert.True(t, incident.RecoverySent)
+
+	// The alert is not sent again
+	events, err := ms.FindIncidentEvents(ctx, svc.ID, incidentID)
+	require.NoError(t, err)
+
+	sent := []string{}
+	for _, event := range events {
+		if event.Type == storage.IncidentEventNotificationSent {
+			sent = append(sent, event.Message)
+		}
+	}
+	assert.Equal(t, []string{"critical alert sent", "recovery notification sent"}, sent)
+}
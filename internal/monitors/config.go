@@ -3,6 +3,8 @@ package monitors
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/sxwebdev/sentinel/internal/storage"
@@ -107,3 +109,47 @@ func ConvertFromMap(cfg map[string]any) (Config, error) {
 
 	return conf, nil
 }
+
+// TargetHost returns the host checked by the service or an empty string
+// if it cannot be determined. For HTTP services the first endpoint is used.
+func TargetHost(svc storage.Service) string {
+	switch svc.Protocol {
+	case storage.ServiceProtocolTypeHTTP:
+		conf, err := GetConfig[HTTPConfig](svc.Config, svc.Protocol)
+		if err != nil || len(conf.Endpoints) == 0 {
+			return ""
+		}
+
+		u, err := url.Parse(conf.Endpoints[0].URL)
+		if err != nil {
+			return ""
+		}
+
+		return u.Hostname()
+	case storage.ServiceProtocolTypeTCP:
+		conf, err := GetConfig[TCPConfig](svc.Config, svc.Protocol)
+		if err != nil {
+			return ""
+		}
+
+		return hostFromEndpoint(conf.Endpoint)
+	case storage.ServiceProtocolTypeGRPC:
+		conf, err := GetConfig[GRPCConfig](svc.Config, svc.Protocol)
+		if err != nil {
+			return ""
+		}
+
+		return hostFromEndpoint(conf.Endpoint)
+	default:
+		return ""
+	}
+}
+
+// hostFromEndpoint extracts the host part from a host:port endpoint
+func hostFromEndpoint(endpoint string) string {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	return host
+}
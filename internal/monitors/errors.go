@@ -0,0 +1,86 @@
+package monitors
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckError is a failed check with its error class
+type CheckError struct {
+	Class storage.CheckErrorClass
+	Err   error
+}
+
+// NewCheckError wraps err with the given error class
+func NewCheckError(class storage.CheckErrorClass, err error) error {
+	return &CheckError{Class: class, Err: err}
+}
+
+// newAssertionError wraps err as an assertion failure
+func newAssertionError(err error) error {
+	return NewCheckError(storage.CheckErrorClassAssertion, err)
+}
+
+func (e *CheckError) Error() string { return e.Err.Error() }
+
+func (e *CheckError) Unwrap() error { return e.Err }
+
+// WarningError is a successful check that reported a warning,
+// the service is considered degraded
+type WarningError struct {
+	Err error
+}
+
+// NewWarningError wraps err as a warning
+func NewWarningError(err error) error {
+	return &WarningError{Err: err}
+}
+
+func (e *WarningError) Error() string { return e.Err.Error() }
+
+func (e *WarningError) Unwrap() error { return e.Err }
+
+// IsWarning reports whether err is a warning rather than a failure
+func IsWarning(err error) bool {
+	var warnErr *WarningError
+	return errors.As(err, &warnErr)
+}
+
+// ClassifyError returns the error class of a failed check.
+// Errors not explicitly classified by a monitor are treated as
+// timeouts or connection failures.
+func ClassifyError(err error) storage.CheckErrorClass {
+	var checkErr *CheckError
+	if errors.As(err, &checkErr) {
+		return checkErr.Class
+	}
+
+	if isTimeout(err) {
+		return storage.CheckErrorClassTimeout
+	}
+
+	return storage.CheckErrorClassConnection
+}
+
+// isTimeout reports whether err is caused by a timeout
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if s, ok := status.FromError(err); ok && s.Code() == codes.DeadlineExceeded {
+		return true
+	}
+
+	return false
+}
@@ -155,7 +155,7 @@ func (g *GRPCMonitor) performHealthCheck(ctx context.Context) error {
 	}
 
 	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
-		return fmt.Errorf("service not serving, status: %s", resp.Status)
+		return newAssertionError(fmt.Errorf("service not serving, status: %s", resp.Status))
 	}
 
 	return nil
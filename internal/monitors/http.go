@@ -43,6 +43,8 @@ type EndpointResult struct {
 	Error    string        `json:"error,omitempty"`
 	Response string        `json:"response,omitempty"`
 	Duration time.Duration `json:"duration" swaggertype:"primitive,integer" example:"30000000000"`
+
+	errorClass storage.CheckErrorClass
 }
 
 // HTTPMonitor monitors HTTP/HTTPS endpoints
@@ -50,6 +52,8 @@ type HTTPMonitor struct {
 	BaseMonitor
 	conf    HTTPConfig
 	retries int
+
+	lastResults []EndpointResult
 }
 
 // NewHTTPMonitor creates a new HTTP monitor
@@ -73,6 +77,19 @@ func (h *HTTPMonitor) Check(ctx context.Context) error {
 	return h.checkEndpoints(ctx)
 }
 
+// Endpoints returns the endpoint results of the last check
+func (h *HTTPMonitor) Endpoints() []storage.EndpointCheck {
+	endpoints := make([]storage.EndpointCheck, 0, len(h.lastResults))
+	for _, result := range h.lastResults {
+		endpoints = append(endpoints, storage.EndpointCheck{
+			Name:     result.Name,
+			Success:  result.Success,
+			Duration: result.Duration,
+		})
+	}
+	return endpoints
+}
+
 // Close implements io.Closer for HTTP monitor (no-op since HTTP doesn't maintain persistent connections)
 func (h *HTTPMonitor) Close() error {
 	return nil
@@ -109,32 +126,43 @@ func (h *HTTPMonitor) checkEndpoints(ctx context.Context) error {
 		case result := <-resultChan:
 			results = append(results, result.result)
 		case <-ctx.Done():
-			return fmt.Errorf("context cancelled during multi-endpoint check")
+			return fmt.Errorf("context cancelled during multi-endpoint check: %w", ctx.Err())
 		}
 	}
 
+	h.lastResults = results
+
 	var errsCount int
 	errs := make([]string, 0, len(results))
+	errorClass := storage.CheckErrorClassAssertion
 	for _, result := range results {
 		if !result.Success {
 			errs = append(errs, fmt.Sprintf("%s (%s): %s", result.Name, result.URL, result.Error))
 			errsCount++
+
+			// Any network level failure makes the whole check retryable
+			if errorClass != storage.CheckErrorClassTimeout && result.errorClass != storage.CheckErrorClassAssertion {
+				errorClass = result.errorClass
+			}
 		}
 	}
 
 	if errsCount > 0 {
-		return fmt.Errorf("check endpoints failed: %d errors: %s", errsCount, strings.Join(errs, "; "))
+		return NewCheckError(errorClass, fmt.Errorf("check endpoints failed: %d errors: %s", errsCount, strings.Join(errs, "; ")))
 	}
 
 	// Evaluate condition
 	if h.conf.Condition != "" {
-		conditionMet, err := evaluateCondition(h.conf.Condition, results)
+		result, err := evaluateCondition(h.conf.Condition, results)
 		if err != nil {
-			return fmt.Errorf("failed to evaluate condition: %w", err)
+			return newAssertionError(fmt.Errorf("failed to evaluate condition: %w", err))
 		}
 
-		if conditionMet {
-			return fmt.Errorf("%v", results)
+		switch result {
+		case conditionFailed:
+			return newAssertionError(fmt.Errorf("%v", results))
+		case conditionWarning:
+			return NewWarningError(fmt.Errorf("%v", results))
 		}
 	}
 
@@ -156,6 +184,8 @@ func (h *HTTPMonitor) checkEndpoint(ctx context.Context, endpoint EndpointConfig
 			Success:  false,
 			Error:    fmt.Sprintf("failed to create request: %v", err),
 			Duration: time.Since(start),
+
+			errorClass: storage.CheckErrorClassAssertion,
 		}
 	}
 
@@ -184,6 +214,8 @@ func (h *HTTPMonitor) checkEndpoint(ctx context.Context, endpoint EndpointConfig
 			Success:  false,
 			Error:    err.Error(),
 			Duration: duration,
+
+			errorClass: ClassifyError(err),
 		}
 	}
 	defer resp.Body.Close()
@@ -197,6 +229,8 @@ func (h *HTTPMonitor) checkEndpoint(ctx context.Context, endpoint EndpointConfig
 			Success:  false,
 			Error:    fmt.Sprintf("failed to read response body: %v", err),
 			Duration: duration,
+
+			errorClass: ClassifyError(err),
 		}
 	}
 
@@ -208,6 +242,8 @@ func (h *HTTPMonitor) checkEndpoint(ctx context.Context, endpoint EndpointConfig
 			Error:    fmt.Sprintf("expected status %d, got %d", endpoint.ExpectedStatus, resp.StatusCode),
 			Response: string(body),
 			Duration: duration,
+
+			errorClass: storage.CheckErrorClassAssertion,
 		}
 	}
 
@@ -223,6 +259,8 @@ func (h *HTTPMonitor) checkEndpoint(ctx context.Context, endpoint EndpointConfig
 				Error:    fmt.Sprintf("failed to extract value from JSON: %v", err),
 				Response: string(body),
 				Duration: duration,
+
+				errorClass: storage.CheckErrorClassAssertion,
 			}
 		}
 	}
@@ -282,8 +320,21 @@ func parseInt(s string) (int, error) {
 	return i, err
 }
 
-// evaluateCondition evaluates JavaScript condition with endpoint results
-func evaluateCondition(condition string, results []EndpointResult) (bool, error) {
+// conditionResult is the outcome of a JavaScript condition
+type conditionResult int
+
+const (
+	conditionPassed conditionResult = iota
+	conditionWarning
+	conditionFailed
+)
+
+// conditionWarningValue is returned by a condition to mark the service as degraded
+const conditionWarningValue = "warning"
+
+// evaluateCondition evaluates JavaScript condition with endpoint results.
+// A truthy value means the check failed, the "warning" string means the service is degraded.
+func evaluateCondition(condition string, results []EndpointResult) (conditionResult, error) {
 	vm := goja.New()
 
 	// Create results object for JavaScript
@@ -309,9 +360,17 @@ func evaluateCondition(condition string, results []EndpointResult) (bool, error)
 	// Execute condition
 	value, err := vm.RunString(condition)
 	if err != nil {
-		return false, fmt.Errorf("failed to execute condition: %w", err)
+		return conditionPassed, fmt.Errorf("failed to execute condition: %w", err)
+	}
+
+	if str, ok := value.Export().(string); ok && str == conditionWarningValue {
+		return conditionWarning, nil
 	}
 
 	// Convert result to boolean
-	return value.ToBoolean(), nil
+	if value.ToBoolean() {
+		return conditionFailed, nil
+	}
+
+	return conditionPassed, nil
 }
@@ -18,6 +18,12 @@ type ServiceMonitor interface {
 	Config() storage.Service
 }
 
+// EndpointReporter is implemented by monitors checking several endpoints,
+// it reports the endpoint results of the last check
+type EndpointReporter interface {
+	Endpoints() []storage.EndpointCheck
+}
+
 // NewMonitor creates a new monitor based on the service configuration
 func NewMonitor(cfg storage.Service) (ServiceMonitor, error) {
 	switch cfg.Protocol {
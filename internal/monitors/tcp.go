@@ -116,12 +116,12 @@ func (t *TCPMonitor) Check(ctx context.Context) error {
 		receivedData := response.String()
 
 		if len(receivedData) == 0 {
-			return fmt.Errorf("server sent no data, expected: '%s'", t.conf.ExpectData)
+			return newAssertionError(fmt.Errorf("server sent no data, expected: '%s'", t.conf.ExpectData))
 		}
 
 		// Check if we have the expected data
 		if !strings.Contains(receivedData, t.conf.ExpectData) {
-			return fmt.Errorf("expected data '%s' not found in response: '%s'", t.conf.ExpectData, receivedData)
+			return newAssertionError(fmt.Errorf("expected data '%s' not found in response: '%s'", t.conf.ExpectData, receivedData))
 		}
 	}
 
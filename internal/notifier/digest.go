@@ -0,0 +1,115 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// Digest is the summary of a period sent by the periodic digest
+type Digest struct {
+	From time.Time
+	To   time.Time
+	// Services is the number of enabled services
+	Services int
+	// Incidents are the most recent incidents started in the period
+	Incidents        []*storage.Incident
+	TotalIncidents   uint32
+	OngoingIncidents uint32
+	// ServiceNames are the names of the services of the incidents by ID
+	ServiceNames map[string]string
+	// LowestUptime are the services with the lowest uptime below 100%
+	LowestUptime []DigestService
+	// Slowest are the services with the highest average response time
+	Slowest []DigestService
+}
+
+// DigestService holds the stats of a service for the digest period
+type DigestService struct {
+	Name             string
+	UptimePercentage float64
+	AvgResponseTime  time.Duration
+}
+
+// SendDigest sends a digest to the given channels, or to the default URLs if no channels are given
+func (s *Notifier) SendDigest(digest *Digest, channels []string) error {
+	s.mu.RLock()
+	if !s.isStarted {
+		s.mu.RUnlock()
+		return fmt.Errorf("notification service is not started")
+	}
+	s.mu.RUnlock()
+
+	targets := []*target{s.defaultTarget}
+	if len(channels) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var err error
+		targets, err = s.channelTargets(ctx, channels, map[string]struct{}{})
+		if err != nil {
+			return fmt.Errorf("failed to find digest channels: %w", err)
+		}
+	}
+
+	message := s.formatDigestMessage(digest)
+
+	deliveries := []*storage.NotificationDelivery{}
+	for _, t := range targets {
+		deliveries = append(deliveries, s.deliveries(t, storage.NotificationDelivery{Message: message})...)
+	}
+
+	return s.enqueue(deliveries)
+}
+
+// formatDigestMessage formats a digest message
+func (s *Notifier) formatDigestMessage(digest *Digest) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "📊 [DIGEST] %s - %s\n\n", digest.From.Format("2006-01-02 15:04"), digest.To.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "• Services: %d\n", digest.Services)
+	fmt.Fprintf(&b, "• Incidents: %d, ongoing: %d\n", digest.TotalIncidents, digest.OngoingIncidents)
+
+	if len(digest.Incidents) > 0 {
+		b.WriteString("\nIncidents:\n")
+		for _, incident := range digest.Incidents {
+			name := incident.Title
+			if name == "" {
+				name = digest.ServiceNames[incident.ServiceID]
+			}
+			if name == "" {
+				name = incident.ServiceID
+			}
+
+			state := "ongoing"
+			if incident.Resolved && incident.Duration != nil {
+				state = "resolved after " + formatDuration(*incident.Duration)
+			}
+
+			fmt.Fprintf(&b, "• %s: %s since %s, %s\n", name, incident.Severity, incident.StartTime.Format("2006-01-02 15:04"), state)
+		}
+		if remaining := int(digest.TotalIncidents) - len(digest.Incidents); remaining > 0 {
+			fmt.Fprintf(&b, "• and %d more\n", remaining)
+		}
+	}
+
+	b.WriteString("\nUptime:\n")
+	if len(digest.LowestUptime) == 0 {
+		b.WriteString("• All services were up for the whole period\n")
+	}
+	for _, service := range digest.LowestUptime {
+		fmt.Fprintf(&b, "• %s: %.2f%%\n", service.Name, service.UptimePercentage)
+	}
+
+	if len(digest.Slowest) > 0 {
+		b.WriteString("\nSlowest services:\n")
+		for _, service := range digest.Slowest {
+			fmt.Fprintf(&b, "• %s: %s\n", service.Name, service.AvgResponseTime.Round(time.Millisecond))
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,156 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+const (
+	// outboxPollInterval is how often the outbox is checked for due deliveries
+	outboxPollInterval = time.Second
+	// outboxBatchSize is the maximum number of deliveries attempted at once
+	outboxBatchSize = 100
+	// outboxCleanupInterval is how often old deliveries are purged
+	outboxCleanupInterval = time.Hour
+)
+
+// deliveries returns the deliveries of a message to each provider URL of a target
+func (s *Notifier) deliveries(t *target, message storage.NotificationDelivery) []*storage.NotificationDelivery {
+	items := make([]*storage.NotificationDelivery, 0, len(t.urls))
+	for _, url := range t.urls {
+		delivery := message
+		delivery.Channel = t.channel
+		delivery.URL = url
+		items = append(items, &delivery)
+	}
+	return items
+}
+
+// enqueue adds deliveries to the outbox and wakes up the delivery loop
+func (s *Notifier) enqueue(deliveries []*storage.NotificationDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.store.CreateNotificationDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// run delivers due notifications of the outbox until the notifier is stopped.
+// Pending deliveries survive restarts and are attempted once the notifier starts again.
+func (s *Notifier) run() {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	cleanup := time.NewTicker(outboxCleanupInterval)
+	defer cleanup.Stop()
+
+	s.processOutbox()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+			s.processOutbox()
+		case <-ticker.C:
+			s.processOutbox()
+		case <-cleanup.C:
+			s.cleanupOutbox()
+		}
+	}
+}
+
+// processOutbox attempts the due deliveries. Deliveries to the same provider URL are sent in order
+// and the rest of them wait until the failed one is retried, different providers are notified concurrently.
+// Grouped incident notifications of a provider URL due at the same time are sent as one message.
+func (s *Notifier) processOutbox() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	deliveries, err := s.store.FindDueNotificationDeliveries(ctx, time.Now(), outboxBatchSize)
+	cancel()
+	if err != nil {
+		s.logger.Errorf("failed to find due notification deliveries: %v", err)
+		return
+	}
+
+	byURL := map[string][]*storage.NotificationDelivery{}
+	urls := []string{}
+	for _, delivery := range deliveries {
+		if _, ok := byURL[delivery.URL]; !ok {
+			urls = append(urls, delivery.URL)
+		}
+		byURL[delivery.URL] = append(byURL[delivery.URL], delivery)
+	}
+
+	var wg sync.WaitGroup
+	for _, url := range urls {
+		wg.Add(1)
+		go func(items []*storage.NotificationDelivery) {
+			defer wg.Done()
+			s.deliver(items)
+		}(byURL[url])
+	}
+	wg.Wait()
+}
+
+// attempt sends deliveries combined into one message and records the result, reporting whether they were sent.
+// Failed deliveries are retried with exponential backoff and dead-lettered after the maximum number of attempts.
+func (s *Notifier) attempt(batch []*storage.NotificationDelivery) bool {
+	err := sendToProvider(combineMessages(batch), batch[0].URL, batch[0].Format)
+
+	now := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, delivery := range batch {
+		delivery.Attempts++
+
+		switch {
+		case err == nil:
+			delivery.Status = storage.NotificationDeliveryStatusSent
+			delivery.LastError = ""
+			delivery.SentAt = &now
+		case delivery.Attempts >= s.delivery.MaxAttempts:
+			delivery.Status = storage.NotificationDeliveryStatusDead
+			delivery.LastError = err.Error()
+			s.logger.Errorf("notification delivery %s to %s failed after %d attempts: %v", delivery.ID, MaskURL(delivery.URL), delivery.Attempts, err)
+		default:
+			delivery.LastError = err.Error()
+			delivery.NextAttemptAt = now.Add(s.delivery.Backoff(delivery.Attempts))
+			s.logger.Warnf("notification delivery %s to %s failed, retrying at %s: %v", delivery.ID, MaskURL(delivery.URL), delivery.NextAttemptAt.Format(time.RFC3339), err)
+		}
+
+		if err := s.store.UpdateNotificationDelivery(ctx, delivery); err != nil {
+			s.logger.Errorf("failed to update notification delivery %s: %v", delivery.ID, err)
+		}
+	}
+
+	return err == nil
+}
+
+// cleanupOutbox deletes sent and dead-lettered deliveries older than the retention
+func (s *Notifier) cleanupOutbox() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deleted, err := s.store.DeleteNotificationDeliveriesBefore(ctx, time.Now().Add(-s.delivery.Retention))
+	if err != nil {
+		s.logger.Errorf("failed to delete old notification deliveries: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		s.logger.Infof("deleted %d old notification deliveries", deleted)
+	}
+}
@@ -0,0 +1,142 @@
+package notifier
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+func TestOutboxDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "db.sqlite"))
+	s := newTestNotifier(t, store, config.NotificationsConfig{
+		Delivery: config.DeliveryConfig{MaxAttempts: 2, InitialBackoff: time.Minute, MaxBackoff: time.Hour},
+	})
+
+	delivery := &storage.NotificationDelivery{URL: failingURL, Message: "failing"}
+	require.NoError(t, store.CreateNotificationDeliveries(ctx, []*storage.NotificationDelivery{delivery}))
+
+	start := time.Now()
+	s.processOutbox()
+
+	got, err := store.GetNotificationDeliveryByID(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.NotificationDeliveryStatusPending, got.Status)
+	assert.Equal(t, 1, got.Attempts)
+	assert.NotEmpty(t, got.LastError)
+	assert.WithinDuration(t, start.Add(time.Minute), got.NextAttemptAt, 5*time.Second)
+
+	// The retry is not due yet
+	s.processOutbox()
+	got, err = store.GetNotificationDeliveryByID(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Attempts)
+
+	got.NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, store.UpdateNotificationDelivery(ctx, got))
+
+	s.processOutbox()
+
+	got, err = store.GetNotificationDeliveryByID(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.NotificationDeliveryStatusDead, got.Status)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Nil(t, got.SentAt)
+
+	due, err := store.FindDueNotificationDeliveries(ctx, time.Now().Add(24*time.Hour), outboxBatchSize)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func TestOutboxOrderPerURL(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "db.sqlite"))
+	s := newTestNotifier(t, store, config.NotificationsConfig{})
+
+	deliveries := []*storage.NotificationDelivery{
+		{URL: failingURL, Message: "first failing"},
+		{URL: "logger://", Message: "first"},
+		{URL: failingURL, Message: "second failing"},
+		{URL: "logger://", Message: "second"},
+	}
+	require.NoError(t, store.CreateNotificationDeliveries(ctx, deliveries))
+
+	s.processOutbox()
+
+	get := func(delivery *storage.NotificationDelivery) *storage.NotificationDelivery {
+		got, err := store.GetNotificationDeliveryByID(ctx, delivery.ID)
+		require.NoError(t, err)
+		return got
+	}
+
+	// The first failure stops the deliveries of its URL
+	assert.Equal(t, 1, get(deliveries[0]).Attempts)
+	assert.Equal(t, storage.NotificationDeliveryStatusPending, get(deliveries[2]).Status)
+	assert.Equal(t, 0, get(deliveries[2]).Attempts)
+
+	// Other URLs are not affected
+	assert.Equal(t, storage.NotificationDeliveryStatusSent, get(deliveries[1]).Status)
+	assert.Equal(t, storage.NotificationDeliveryStatusSent, get(deliveries[3]).Status)
+	assert.NotNil(t, get(deliveries[3]).SentAt)
+
+	// The later deliveries wait for the retry of the failed one
+	s.processOutbox()
+	assert.Equal(t, 1, get(deliveries[0]).Attempts)
+	assert.Equal(t, 0, get(deliveries[2]).Attempts)
+
+	failed := get(deliveries[0])
+	failed.NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, store.UpdateNotificationDelivery(ctx, failed))
+
+	s.processOutbox()
+	assert.Equal(t, 2, get(deliveries[0]).Attempts)
+	assert.Equal(t, 0, get(deliveries[2]).Attempts)
+}
+
+func TestOutboxSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "db.sqlite")
+	notifications := config.NotificationsConfig{
+		URLs:     []string{"logger://", failingURL},
+		Delivery: config.DeliveryConfig{MaxAttempts: 5, InitialBackoff: time.Minute, MaxBackoff: time.Hour},
+	}
+
+	store, err := storage.NewSQLiteStorage(path)
+	require.NoError(t, err)
+
+	s := newTestNotifier(t, store, notifications)
+	deliveries := s.deliveries(s.defaultTarget, storage.NotificationDelivery{Message: "down"})
+	require.NoError(t, s.enqueue(deliveries))
+
+	// The failing delivery is retried later, the other one is still pending when the notifier stops
+	s.deliver([]*storage.NotificationDelivery{deliveries[1]})
+	require.NoError(t, store.Stop(ctx))
+
+	store = newTestStore(t, path)
+	s = newTestNotifier(t, store, notifications)
+
+	failed, err := store.GetNotificationDeliveryByID(ctx, deliveries[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, failed.Attempts)
+
+	failed.NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, store.UpdateNotificationDelivery(ctx, failed))
+
+	s.processOutbox()
+
+	sent, err := store.GetNotificationDeliveryByID(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.NotificationDeliveryStatusSent, sent.Status)
+	assert.Equal(t, 1, sent.Attempts)
+
+	failed, err = store.GetNotificationDeliveryByID(ctx, deliveries[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.NotificationDeliveryStatusPending, failed.Status)
+	assert.Equal(t, 2, failed.Attempts)
+}
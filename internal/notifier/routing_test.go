@@ -0,0 +1,162 @@
+package notifier
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+func TestRoute(t *testing.T) {
+	service := &storage.Service{ID: "api", Tags: []string{"prod"}, Protocol: storage.ServiceProtocolTypeHTTP}
+
+	notifications := config.NotificationsConfig{
+		URLs:        []string{"logger://?default"},
+		WarningURLs: []string{"logger://?warning"},
+		Channels: []config.NotificationChannel{
+			{Name: "ops", URLs: []string{"logger://?ops", "logger://?shared"}},
+		},
+	}
+
+	channels := []*storage.NotificationChannel{
+		{Name: "team", URLs: []string{"logger://?team", "logger://?shared"}, IsEnabled: true},
+		{Name: "muted", URLs: []string{"logger://?muted"}, IsEnabled: false},
+	}
+
+	tests := []struct {
+		name     string
+		rules    []*storage.NotificationRule
+		severity storage.IncidentSeverity
+		event    storage.NotificationEvent
+		urls     []string
+	}{
+		{
+			name:     "No rules use the default URLs",
+			severity: storage.IncidentSeverityCritical,
+			event:    storage.NotificationEventAlert,
+			urls:     []string{"logger://?default"},
+		},
+		{
+			name:     "No rules use the warning URLs for warnings",
+			severity: storage.IncidentSeverityWarning,
+			event:    storage.NotificationEventDegraded,
+			urls:     []string{"logger://?warning"},
+		},
+		{
+			name:     "Matching rule",
+			rules:    []*storage.NotificationRule{{Name: "prod", Tags: []string{"prod"}, Channels: []string{"team"}, IsEnabled: true}},
+			severity: storage.IncidentSeverityCritical,
+			event:    storage.NotificationEventAlert,
+			urls:     []string{"logger://?team", "logger://?shared"},
+		},
+		{
+			name: "No matching rule",
+			rules: []*storage.NotificationRule{
+				{Name: "staging", Tags: []string{"staging"}, Channels: []string{"team"}, IsEnabled: true},
+				{Name: "recoveries", Events: []storage.NotificationEvent{storage.NotificationEventRecovery}, Channels: []string{"team"}, IsEnabled: true},
+			},
+			severity: storage.IncidentSeverityWarning,
+			event:    storage.NotificationEventDegraded,
+			urls:     []string{"logger://?warning"},
+		},
+		{
+			name:     "Disabled rule",
+			rules:    []*storage.NotificationRule{{Name: "prod", Channels: []string{"team"}, IsEnabled: false}},
+			severity: storage.IncidentSeverityCritical,
+			event:    storage.NotificationEventAlert,
+			urls:     []string{"logger://?default"},
+		},
+		{
+			name: "Matching rules notify each URL once",
+			rules: []*storage.NotificationRule{
+				{Name: "all", Channels: []string{"ops"}, IsEnabled: true},
+				{Name: "prod", Tags: []string{"prod"}, Channels: []string{"team", "ops"}, IsEnabled: true},
+			},
+			severity: storage.IncidentSeverityCritical,
+			event:    storage.NotificationEventAlert,
+			urls:     []string{"logger://?ops", "logger://?shared", "logger://?team"},
+		},
+		{
+			name:     "Disabled channels are skipped",
+			rules:    []*storage.NotificationRule{{Name: "prod", Channels: []string{"muted", "team"}, IsEnabled: true}},
+			severity: storage.IncidentSeverityCritical,
+			event:    storage.NotificationEventAlert,
+			urls:     []string{"logger://?team", "logger://?shared"},
+		},
+		{
+			name:     "Only disabled channels use the default URLs",
+			rules:    []*storage.NotificationRule{{Name: "prod", Channels: []string{"muted"}, IsEnabled: true}},
+			severity: storage.IncidentSeverityCritical,
+			event:    storage.NotificationEventAlert,
+			urls:     []string{"logger://?default"},
+		},
+		{
+			name:     "Unknown channels use the warning URLs for warnings",
+			rules:    []*storage.NotificationRule{{Name: "prod", Channels: []string{"unknown"}, IsEnabled: true}},
+			severity: storage.IncidentSeverityWarning,
+			event:    storage.NotificationEventDegraded,
+			urls:     []string{"logger://?warning"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t, filepath.Join(t.TempDir(), "db.sqlite"))
+
+			for _, channel := range channels {
+				channel := *channel
+				require.NoError(t, store.CreateNotificationChannel(ctx, &channel))
+			}
+			for _, rule := range tt.rules {
+				require.NoError(t, store.CreateNotificationRule(ctx, rule))
+			}
+
+			s := newTestNotifier(t, store, notifications)
+
+			urls := []string{}
+			for _, target := range s.route(service, tt.severity, tt.event) {
+				urls = append(urls, target.urls...)
+			}
+			assert.Equal(t, tt.urls, urls)
+		})
+	}
+}
+
+func TestRouteWithoutConfigURLs(t *testing.T) {
+	ctx := context.Background()
+	service := &storage.Service{ID: "api", Tags: []string{"prod"}, Protocol: storage.ServiceProtocolTypeHTTP}
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, store.CreateNotificationChannel(ctx, &storage.NotificationChannel{Name: "team", URLs: []string{"logger://?team"}, IsEnabled: true}))
+	require.NoError(t, store.CreateNotificationRule(ctx, &storage.NotificationRule{Name: "prod", Tags: []string{"prod"}, Channels: []string{"team"}, IsEnabled: true}))
+
+	// The URLs of disabled notifications are not used
+	s, err := New(logger.Default(), &config.Config{Notifications: config.NotificationsConfig{
+		URLs:     []string{"logger://?default"},
+		Channels: []config.NotificationChannel{{Name: "team", URLs: []string{"logger://?config"}}},
+	}}, store)
+	require.NoError(t, err)
+
+	urls := func(targets []*target) []string {
+		urls := []string{}
+		for _, target := range targets {
+			urls = append(urls, target.urls...)
+		}
+		return urls
+	}
+
+	assert.Equal(t, []string{"logger://?team"}, urls(s.route(service, storage.IncidentSeverityCritical, storage.NotificationEventAlert)))
+	assert.Empty(t, urls(s.route(&storage.Service{ID: "other"}, storage.IncidentSeverityCritical, storage.NotificationEventAlert)))
+
+	// Notifications without URLs in the config
+	s, err = New(logger.Default(), &config.Config{Notifications: config.NotificationsConfig{Enabled: true}}, store)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logger://?team"}, urls(s.route(service, storage.IncidentSeverityWarning, storage.NotificationEventDegraded)))
+	assert.Empty(t, urls(s.route(&storage.Service{ID: "other"}, storage.IncidentSeverityWarning, storage.NotificationEventDegraded)))
+}
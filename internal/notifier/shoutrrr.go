@@ -8,39 +8,109 @@ import (
 	"time"
 
 	"github.com/containrrr/shoutrrr"
+	"github.com/sxwebdev/sentinel/internal/config"
 	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+	"github.com/sxwebdev/sentinel/pkg/dbutils"
 	"github.com/tkcrm/mx/logger"
 )
 
-// notificationRequest represents a single notification request
-type notificationRequest struct {
-	Message   string
-	CreatedAt time.Time
+// Store provides notification routing rules, the notification channels managed through the API,
+// the check results used by notification templates and the notification outbox
+type Store interface {
+	FindNotificationRules(ctx context.Context, params storage.FindNotificationRulesParams) ([]*storage.NotificationRule, error)
+	FindNotificationChannels(ctx context.Context, params storage.FindNotificationChannelsParams) ([]*storage.NotificationChannel, error)
+	FindCheckResults(ctx context.Context, params storage.FindCheckResultsParams) (dbutils.FindResponseWithCount[*storage.CheckResult], error)
+	CreateNotificationDeliveries(ctx context.Context, deliveries []*storage.NotificationDelivery) error
+	FindDueNotificationDeliveries(ctx context.Context, now time.Time, limit int) ([]*storage.NotificationDelivery, error)
+	UpdateNotificationDelivery(ctx context.Context, delivery *storage.NotificationDelivery) error
+	DeleteNotificationDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+	FindOnCallSchedules(ctx context.Context) ([]*storage.OnCallSchedule, error)
 }
 
 type Notifier struct {
-	logger    logger.Logger
-	urls      []string
-	queue     chan *notificationRequest
-	done      chan struct{}
-	wg        sync.WaitGroup
-	mu        sync.RWMutex
-	isStarted bool
-	name      string
+	logger        logger.Logger
+	defaultTarget *target
+	warningTarget *target
+	channels      map[string]*target
+	baseURL       string
+	delivery      config.DeliveryConfig
+	store         Store
+	wake          chan struct{}
+	done          chan struct{}
+	wg            sync.WaitGroup
+	mu            sync.RWMutex
+	isStarted     bool
+	name          string
+
+	grouping  config.GroupingConfig
+	rateLimit config.RateLimitConfig
+	// channelRateLimits are the rate limits overridden by channels of the configuration
+	channelRateLimits map[string]config.RateLimitConfig
+	limiter           *limiter
 }
 
-// New creates a new Notifier instance as a service
-func New(l logger.Logger, urls []string) (*Notifier, error) {
-	if len(urls) == 0 {
-		return nil, fmt.Errorf("no notification URLs provided")
+// New creates a new Notifier instance as a service.
+// Incident notifications are sent to the channels of matching routing rules, declared in the config
+// or managed through the API. Rules and channels are read on every notification. Without a matching rule,
+// warning notifications of degraded services are sent to the warning URLs,
+// or to the URLs if no warning URLs are provided, and other notifications to the URLs.
+// The URLs and channels of the config are used when notifications are enabled, without URLs
+// only the channels of matching rules are notified.
+// Notifications are stored in the outbox and delivered to each provider URL with retries,
+// incident notifications are combined within the grouping window and deliveries over the rate limit are deferred.
+func New(l logger.Logger, conf *config.Config, store Store) (*Notifier, error) {
+	notifications := conf.Notifications
+	if !notifications.Enabled {
+		notifications.URLs = nil
+		notifications.WarningURLs = nil
+		notifications.Channels = nil
+	}
+
+	if store == nil {
+		return nil, fmt.Errorf("notification store is required")
+	}
+
+	templates, err := ParseTemplates(notifications.Templates, notifications.Format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse notification templates: %w", err)
+	}
+
+	warningURLs := notifications.WarningURLs
+	if len(warningURLs) == 0 {
+		warningURLs = notifications.URLs
+	}
+
+	channels := make(map[string]*target, len(notifications.Channels))
+	channelRateLimits := map[string]config.RateLimitConfig{}
+	for _, channel := range notifications.Channels {
+		if channel.RateLimit != nil {
+			channelRateLimits[channel.Name] = *channel.RateLimit
+		}
+
+		channelTemplates, err := ParseTemplates(channel.Templates, channel.Format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse templates of notification channel %s: %w", channel.Name, err)
+		}
+		channels[channel.Name] = &target{channel: channel.Name, urls: channel.URLs, format: channel.Format, templates: channelTemplates}
 	}
 
 	notifier := &Notifier{
-		logger: l,
-		urls:   urls,
-		queue:  make(chan *notificationRequest, 500), // buffer for 500 notifications
-		done:   make(chan struct{}),
-		name:   "NotificationService",
+		logger:        l,
+		defaultTarget: &target{urls: notifications.URLs, format: notifications.Format, templates: templates},
+		warningTarget: &target{urls: warningURLs, format: notifications.Format, templates: templates},
+		channels:      channels,
+		baseURL:       conf.Server.BaseURL(),
+		delivery:      notifications.Delivery,
+		store:         store,
+		wake:          make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		name:          "NotificationService",
+
+		grouping:          notifications.Grouping,
+		rateLimit:         notifications.RateLimit,
+		channelRateLimits: channelRateLimits,
+		limiter:           newLimiter(),
 	}
 
 	return notifier, nil
@@ -63,7 +133,7 @@ func (s *Notifier) Start(ctx context.Context) error {
 
 	go func() {
 		defer s.wg.Done()
-		s.processQueue()
+		s.run()
 	}()
 
 	return nil
@@ -103,52 +173,92 @@ func (s *Notifier) Stop(ctx context.Context) error {
 	return nil
 }
 
-// processQueue processes the notification queue with 500ms delay
-func (s *Notifier) processQueue() {
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-s.done:
-			// Process remaining notifications in queue before exit
-			for {
-				select {
-				case req := <-s.queue:
-					s.processNotification(req)
-				default:
-					return
-				}
-			}
+// SendAlert sends an alert notification when a service goes down or becomes degraded
+func (s *Notifier) SendAlert(service *storage.Service, incident *storage.Incident) error {
+	s.mu.RLock()
+	if !s.isStarted {
+		s.mu.RUnlock()
+		return fmt.Errorf("notification service is not started")
+	}
+	s.mu.RUnlock()
 
-		case <-ticker.C:
-			// Check queue every 500ms
-			select {
-			case req := <-s.queue:
-				s.processNotification(req)
-			default:
-				// Queue is empty, continue
-			}
-		}
+	return s.send(&notification{
+		event:    alertEvent(incident.Severity),
+		service:  service,
+		incident: incident,
+		message:  s.formatAlertMessage(service, incident),
+	})
+}
+
+// SendRecovery sends a recovery notification when a service comes back up
+func (s *Notifier) SendRecovery(service *storage.Service, incident *storage.Incident) error {
+	s.mu.RLock()
+	if !s.isStarted {
+		s.mu.RUnlock()
+		return fmt.Errorf("notification service is not started")
 	}
+	s.mu.RUnlock()
+
+	return s.send(&notification{
+		event:    storage.NotificationEventRecovery,
+		service:  service,
+		incident: incident,
+		message:  s.formatRecoveryMessage(service, incident),
+	})
+}
+
+// SendIncidentUpdate sends a notification when the error of an ongoing incident changes
+func (s *Notifier) SendIncidentUpdate(service *storage.Service, incident *storage.Incident, previousError, currentError string) error {
+	s.mu.RLock()
+	if !s.isStarted {
+		s.mu.RUnlock()
+		return fmt.Errorf("notification service is not started")
+	}
+	s.mu.RUnlock()
+
+	return s.send(&notification{
+		event:         storage.NotificationEventUpdate,
+		service:       service,
+		incident:      incident,
+		currentError:  currentError,
+		previousError: previousError,
+		message:       s.formatUpdateMessage(service, incident, previousError, currentError),
+	})
 }
 
-// processNotification processes a single notification with simple retry
-func (s *Notifier) processNotification(req *notificationRequest) {
-	err := s.sendMessageSync(req.Message)
+// SendEscalation notifies the channels of an escalation step about an unacknowledged incident
+func (s *Notifier) SendEscalation(service *storage.Service, incident *storage.Incident, level int, channels []string) error {
+	s.mu.RLock()
+	if !s.isStarted {
+		s.mu.RUnlock()
+		return fmt.Errorf("notification service is not started")
+	}
+	s.mu.RUnlock()
 
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	targets, err := s.channelTargets(ctx, channels, map[string]struct{}{})
 	if err != nil {
-		s.logger.Errorf("failed to send notification: %v", err)
+		return fmt.Errorf("failed to find escalation channels: %w", err)
+	}
 
-		// Put back in queue for retry
-		s.queue <- req
-	} else {
-		s.logger.Info("notification sent successfully")
+	if len(targets) == 0 {
+		s.logger.Warnf("escalation channels %s of incident %s have no enabled URLs", strings.Join(channels, ", "), incident.ID)
+		return nil
 	}
+
+	return s.sendTo(&notification{
+		event:    storage.NotificationEventEscalation,
+		service:  service,
+		incident: incident,
+		level:    level,
+		message:  s.formatEscalationMessage(service, incident, level, false),
+	}, targets)
 }
 
-// SendAlert sends an alert notification when a service goes down
-func (s *Notifier) SendAlert(service *storage.Service, incident *storage.Incident) error {
+// SendReminder reminds of an unacknowledged incident the targets of its alert and the channels it was escalated to
+func (s *Notifier) SendReminder(service *storage.Service, incident *storage.Incident, level int, channels []string) error {
 	s.mu.RLock()
 	if !s.isStarted {
 		s.mu.RUnlock()
@@ -156,12 +266,37 @@ func (s *Notifier) SendAlert(service *storage.Service, incident *storage.Inciden
 	}
 	s.mu.RUnlock()
 
-	message := s.formatAlertMessage(service, incident)
-	return s.enqueueMessage(message)
+	targets := s.route(service, incident.Severity, alertEvent(incident.Severity))
+
+	if len(channels) > 0 {
+		seen := map[string]struct{}{}
+		for _, t := range targets {
+			for _, url := range t.urls {
+				seen[url] = struct{}{}
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		escalated, err := s.channelTargets(ctx, channels, seen)
+		if err != nil {
+			s.logger.Errorf("failed to find escalation channels, reminding the alert targets only: %v", err)
+		}
+		targets = append(targets, escalated...)
+	}
+
+	return s.sendTo(&notification{
+		event:    storage.NotificationEventReminder,
+		service:  service,
+		incident: incident,
+		level:    level,
+		message:  s.formatEscalationMessage(service, incident, level, true),
+	}, targets)
 }
 
-// SendRecovery sends a recovery notification when a service comes back up
-func (s *Notifier) SendRecovery(service *storage.Service, incident *storage.Incident) error {
+// SendSLOAlert sends an alert notification when the error budget of an SLO burns too fast
+func (s *Notifier) SendSLOAlert(status *storage.SLOStatus) error {
 	s.mu.RLock()
 	if !s.isStarted {
 		s.mu.RUnlock()
@@ -169,84 +304,279 @@ func (s *Notifier) SendRecovery(service *storage.Service, incident *storage.Inci
 	}
 	s.mu.RUnlock()
 
-	message := s.formatRecoveryMessage(service, incident)
-	return s.enqueueMessage(message)
+	return s.enqueue(s.deliveries(s.defaultTarget, storage.NotificationDelivery{Message: s.formatSLOMessage(status, true)}))
 }
 
-// enqueueMessage adds a message to the queue for sending
-func (s *Notifier) enqueueMessage(message string) error {
-	req := &notificationRequest{
-		Message:   message,
-		CreatedAt: time.Now(),
+// SendSLORecovery sends a notification when the burn rate of an SLO is back to normal
+func (s *Notifier) SendSLORecovery(status *storage.SLOStatus) error {
+	s.mu.RLock()
+	if !s.isStarted {
+		s.mu.RUnlock()
+		return fmt.Errorf("notification service is not started")
 	}
+	s.mu.RUnlock()
 
-	select {
-	case s.queue <- req:
-		return nil
-	default:
-		return fmt.Errorf("notification queue is full")
+	return s.enqueue(s.deliveries(s.defaultTarget, storage.NotificationDelivery{Message: s.formatSLOMessage(status, false)}))
+}
+
+// send renders an incident notification for each of its routed targets and adds it to the outbox
+func (s *Notifier) send(n *notification) error {
+	return s.sendTo(n, s.route(n.service, n.incident.Severity, n.event))
+}
+
+// sendTo renders an incident notification for each target and adds it to the outbox
+func (s *Notifier) sendTo(n *notification, targets []*target) error {
+	deliveries := []*storage.NotificationDelivery{}
+	for _, t := range targets {
+		message, format := s.render(t, n)
+		deliveries = append(deliveries, s.deliveries(t, storage.NotificationDelivery{
+			Event:      n.event,
+			ServiceID:  n.service.ID,
+			IncidentID: n.incident.ID,
+			Message:    message,
+			Format:     format,
+		})...)
 	}
+
+	s.group(deliveries, time.Now())
+
+	return s.enqueue(deliveries)
 }
 
-// sendMessageSync sends a message to all configured providers synchronously
-// If one provider fails, it continues with others and returns partial errors
-func (s *Notifier) sendMessageSync(message string) error {
-	// Send to all providers concurrently
-	var wg sync.WaitGroup
-	errors := make(chan error, len(s.urls))
+// targetForSeverity returns the default target notified about incidents of the given severity
+func (s *Notifier) targetForSeverity(severity storage.IncidentSeverity) *target {
+	if severity == storage.IncidentSeverityWarning {
+		return s.warningTarget
+	}
+	return s.defaultTarget
+}
 
-	for i, url := range s.urls {
-		wg.Add(1)
-		go func(index int, providerURL string) {
-			defer wg.Done()
+// alertEvent returns the event of the alert of an incident with the given severity
+func alertEvent(severity storage.IncidentSeverity) storage.NotificationEvent {
+	if severity == storage.IncidentSeverityWarning {
+		return storage.NotificationEventDegraded
+	}
+	return storage.NotificationEventAlert
+}
 
-			// Create individual sender for this provider
-			sender, err := shoutrrr.CreateSender(providerURL)
-			if err != nil {
-				errors <- fmt.Errorf("provider %d: failed to create sender: %w", index, err)
-				return
+// route returns the channels of enabled rules matching a notification, each provider URL is notified once.
+// Disabled channels are skipped, notifications matching no rule are sent to the default target of the severity.
+func (s *Notifier) route(service *storage.Service, severity storage.IncidentSeverity, event storage.NotificationEvent) []*target {
+	defaultTargets := []*target{s.targetForSeverity(severity)}
+	if s.store == nil {
+		return defaultTargets
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rules, err := s.store.FindNotificationRules(ctx, storage.FindNotificationRulesParams{IsEnabled: utils.Pointer(true)})
+	if err != nil {
+		s.logger.Errorf("failed to find notification rules, using default URLs: %v", err)
+		return defaultTargets
+	}
+
+	names := []string{}
+	for _, rule := range rules {
+		if rule.Matches(service, severity, event) {
+			names = append(names, rule.Channels...)
+		}
+	}
+
+	if len(names) == 0 {
+		return defaultTargets
+	}
+
+	targets, err := s.channelTargets(ctx, names, map[string]struct{}{})
+	if err != nil {
+		s.logger.Errorf("failed to find notification channels, using default URLs: %v", err)
+		return defaultTargets
+	}
+
+	if len(targets) == 0 {
+		return defaultTargets
+	}
+
+	return targets
+}
+
+// channelTargets returns the targets of channels by name, skipping disabled channels and provider URLs already seen.
+// Channels of the configuration take precedence over the channels managed through the API,
+// names with the on-call prefix target the user on call for the schedule.
+func (s *Notifier) channelTargets(ctx context.Context, names []string, seen map[string]struct{}) ([]*target, error) {
+	// Channels managed through the API and schedules are loaded once needed
+	var channels map[string]*storage.NotificationChannel
+	var schedules map[string]*storage.OnCallSchedule
+
+	targets := []*target{}
+	for _, name := range names {
+		channelTarget, ok := s.channels[name]
+		if scheduleName, isOnCall := strings.CutPrefix(name, storage.OnCallChannelPrefix); isOnCall {
+			if schedules == nil {
+				var err error
+				schedules, err = s.findSchedules(ctx)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			channelTarget = s.onCallTarget(name, schedules[scheduleName])
+			if channelTarget == nil {
+				continue
+			}
+		} else if !ok {
+			if channels == nil {
+				var err error
+				channels, err = s.findChannels(ctx)
+				if err != nil {
+					return nil, err
+				}
 			}
 
-			providerName, _, err := sender.ExtractServiceName(providerURL)
+			channel, ok := channels[name]
+			if !ok {
+				s.logger.Warnf("unknown notification channel %s", name)
+				continue
+			}
+
+			if !channel.IsEnabled {
+				continue
+			}
+
+			templates, err := ParseTemplates(channel.Templates, channel.Format)
 			if err != nil {
-				errors <- fmt.Errorf("provider %d: failed to extract service name: %w", index, err)
-				return
+				s.logger.Errorf("notification channel %s uses the default messages: %v", name, err)
 			}
+			channelTarget = &target{channel: name, urls: channel.URLs, format: channel.Format, templates: templates}
+		}
 
-			// Send message with timeout
-			timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer timeoutCancel()
-
-			done := make(chan []error, 1)
-			go func() {
-				defer func() {
-					if r := recover(); r != nil {
-						errors <- fmt.Errorf("provider %s: panic during send: %v", providerName, r)
-					}
-				}()
-				done <- sender.Send(message, nil)
-			}()
-
-			select {
-			case errs := <-done:
-				// Check if there are any actual errors (not nil)
-				hasErrors := false
-				for _, err := range errs {
-					if err != nil {
-						hasErrors = true
-						break
-					}
-				}
+		urls := []string{}
+		for _, url := range channelTarget.urls {
+			if _, ok := seen[url]; !ok {
+				seen[url] = struct{}{}
+				urls = append(urls, url)
+			}
+		}
 
-				if hasErrors {
-					errors <- fmt.Errorf("provider %s: failed to send: %v", providerName, errs)
-				}
-			case <-timeoutCtx.Done():
-				errors <- fmt.Errorf("provider %s: timeout", providerName)
-				// Note: The goroutine with sender.Send() might still be running,
-				// but we can't force-kill it. This is a limitation of the shoutrrr library.
+		if len(urls) > 0 {
+			targets = append(targets, &target{
+				channel:   channelTarget.channel,
+				urls:      urls,
+				format:    channelTarget.format,
+				templates: channelTarget.templates,
+			})
+		}
+	}
+
+	return targets, nil
+}
+
+// findSchedules returns the on-call schedules by name
+func (s *Notifier) findSchedules(ctx context.Context) (map[string]*storage.OnCallSchedule, error) {
+	items, err := s.store.FindOnCallSchedules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find on-call schedules: %w", err)
+	}
+
+	schedules := make(map[string]*storage.OnCallSchedule, len(items))
+	for _, schedule := range items {
+		schedules[schedule.Name] = schedule
+	}
+
+	return schedules, nil
+}
+
+// onCallTarget returns the personal URLs of the user on call for a schedule, nil when nobody can be notified
+func (s *Notifier) onCallTarget(channel string, schedule *storage.OnCallSchedule) *target {
+	if schedule == nil {
+		s.logger.Warnf("unknown on-call schedule of channel %s", channel)
+		return nil
+	}
+
+	shift := schedule.ShiftAt(time.Now())
+	if shift == nil {
+		s.logger.Warnf("nobody is on call for schedule %s", schedule.Name)
+		return nil
+	}
+
+	user := schedule.User(shift.User)
+	if user == nil || len(user.URLs) == 0 {
+		s.logger.Warnf("on-call user %s of schedule %s has no notification URLs", shift.User, schedule.Name)
+		return nil
+	}
+
+	return &target{channel: channel, urls: user.URLs, format: storage.NotificationFormatText}
+}
+
+// findChannels returns the notification channels managed through the API by name
+func (s *Notifier) findChannels(ctx context.Context) (map[string]*storage.NotificationChannel, error) {
+	items, err := s.store.FindNotificationChannels(ctx, storage.FindNotificationChannelsParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make(map[string]*storage.NotificationChannel, len(items))
+	for _, channel := range items {
+		channels[channel.Name] = channel
+	}
+
+	return channels, nil
+}
+
+// SendTestMessage synchronously sends a test notification to the URLs of a channel
+func SendTestMessage(channel *storage.NotificationChannel) error {
+	if len(channel.URLs) == 0 {
+		return fmt.Errorf("notification channel %s has no URLs", channel.Name)
+	}
+
+	message := fmt.Sprintf(
+		"🧪 [TEST] Test notification from Sentinel\n\n"+
+			"• Channel: %s\n"+
+			"• Sent: %s",
+		channel.Name,
+		time.Now().Format("2006-01-02 15:04:05"),
+	)
+
+	return sendMessage(message, channel.URLs, storage.NotificationFormatText)
+}
+
+// ValidateURL checks that a provider URL is supported
+func ValidateURL(raw string) error {
+	if _, err := shoutrrr.CreateSender(raw); err != nil {
+		return fmt.Errorf("invalid notification URL %s: %w", MaskURL(raw), err)
+	}
+	return nil
+}
+
+// MaskURL hides the credentials of a provider URL, keeping the scheme and the last characters
+func MaskURL(raw string) string {
+	scheme, rest, ok := strings.Cut(raw, "://")
+	if !ok {
+		return "****"
+	}
+
+	if len(rest) < 12 {
+		return scheme + "://****"
+	}
+
+	return scheme + "://****" + rest[len(rest)-4:]
+}
+
+// sendMessage sends a message in a format to the given providers synchronously
+// If one provider fails, it continues with others and returns partial errors
+func sendMessage(message string, urls []string, format storage.NotificationFormat) error {
+	// Send to all providers concurrently
+	var wg sync.WaitGroup
+	errors := make(chan error, len(urls))
+
+	for _, url := range urls {
+		wg.Add(1)
+		go func(providerURL string) {
+			defer wg.Done()
+			if err := sendToProvider(message, providerURL, format); err != nil {
+				errors <- err
 			}
-		}(i, url)
+		}(url)
 	}
 
 	wg.Wait()
@@ -259,18 +589,60 @@ func (s *Notifier) sendMessageSync(message string) error {
 	}
 
 	// If all providers failed, return an error
-	if len(allErrors) == len(s.urls) {
+	if len(allErrors) == len(urls) {
 		return fmt.Errorf("all notification providers failed: %v", allErrors)
 	}
 
 	// If some providers failed, return partial error
 	if len(allErrors) > 0 {
-		return fmt.Errorf("some notification providers failed (%d/%d): %v", len(allErrors), len(s.urls), allErrors)
+		return fmt.Errorf("some notification providers failed (%d/%d): %v", len(allErrors), len(urls), allErrors)
 	}
 
 	return nil
 }
 
+// sendToProvider sends a message in a format to a single provider with a timeout
+func sendToProvider(message, providerURL string, format storage.NotificationFormat) error {
+	sender, err := shoutrrr.CreateSender(providerURL)
+	if err != nil {
+		return fmt.Errorf("failed to create sender: %w", err)
+	}
+
+	providerName, _, err := sender.ExtractServiceName(providerURL)
+	if err != nil {
+		return fmt.Errorf("failed to extract service name: %w", err)
+	}
+
+	// Send message with timeout
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer timeoutCancel()
+
+	done := make(chan []error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- []error{fmt.Errorf("panic during send: %v", r)}
+			}
+		}()
+		done <- sender.Send(message, formatParams(providerURL, format))
+	}()
+
+	select {
+	case errs := <-done:
+		// Check if there are any actual errors (not nil)
+		for _, err := range errs {
+			if err != nil {
+				return fmt.Errorf("provider %s: failed to send: %v", providerName, errs)
+			}
+		}
+		return nil
+	case <-timeoutCtx.Done():
+		// Note: The goroutine with sender.Send() might still be running,
+		// but we can't force-kill it. This is a limitation of the shoutrrr library.
+		return fmt.Errorf("provider %s: timeout", providerName)
+	}
+}
+
 // formatAlertMessage formats an alert message
 func (s *Notifier) formatAlertMessage(service *storage.Service, incident *storage.Incident) string {
 	tags := "-"
@@ -278,6 +650,23 @@ func (s *Notifier) formatAlertMessage(service *storage.Service, incident *storag
 		tags = strings.Join(service.Tags, ", ")
 	}
 
+	if incident.Severity == storage.IncidentSeverityWarning {
+		return fmt.Sprintf(
+			"🟡 [WARNING] %s is DEGRADED\n\n"+
+				"• Service: %s\n"+
+				"• Tags: %s\n"+
+				"• Warning: %s\n"+
+				"• Started: %s\n"+
+				"• Incident ID: %s",
+			service.Name,
+			service.Name,
+			tags,
+			incident.Error,
+			incident.StartTime.Format("2006-01-02 15:04:05"),
+			incident.ID,
+		)
+	}
+
 	return fmt.Sprintf(
 		"🔴 [ALERT] %s is DOWN\n\n"+
 			"• Service: %s\n"+
@@ -294,6 +683,37 @@ func (s *Notifier) formatAlertMessage(service *storage.Service, incident *storag
 	)
 }
 
+// formatUpdateMessage formats a message of a changed incident error
+func (s *Notifier) formatUpdateMessage(service *storage.Service, incident *storage.Incident, previousError, currentError string) string {
+	tags := "-"
+	if len(service.Tags) > 0 {
+		tags = strings.Join(service.Tags, ", ")
+	}
+
+	state := "DOWN"
+	if incident.Severity == storage.IncidentSeverityWarning {
+		state = "DEGRADED"
+	}
+
+	return fmt.Sprintf(
+		"🟠 [UPDATE] %s is still %s, the error changed\n\n"+
+			"• Service: %s\n"+
+			"• Tags: %s\n"+
+			"• Error: %s\n"+
+			"• Previous error: %s\n"+
+			"• Started: %s\n"+
+			"• Incident ID: %s",
+		service.Name,
+		state,
+		service.Name,
+		tags,
+		currentError,
+		previousError,
+		incident.StartTime.Format("2006-01-02 15:04:05"),
+		incident.ID,
+	)
+}
+
 // formatRecoveryMessage formats a recovery message
 func (s *Notifier) formatRecoveryMessage(service *storage.Service, incident *storage.Incident) string {
 	var duration string
@@ -315,22 +735,111 @@ func (s *Notifier) formatRecoveryMessage(service *storage.Service, incident *sto
 		tags = strings.Join(service.Tags, ", ")
 	}
 
+	durationLabel := "Downtime"
+	if incident.Severity == storage.IncidentSeverityWarning {
+		durationLabel = "Degraded for"
+	}
+
 	return fmt.Sprintf(
 		"🟢 [RECOVERY] %s is UP\n\n"+
 			"• Service: %s\n"+
 			"• Tags: %s\n"+
-			"• Downtime: %s\n"+
+			"• %s: %s\n"+
 			"• Recovered: %s\n"+
 			"• Incident ID: %s",
 		service.Name,
 		service.Name,
 		tags,
+		durationLabel,
 		duration,
 		endTime,
 		incident.ID,
 	)
 }
 
+// formatEscalationMessage formats an escalation or reminder message of an unacknowledged incident
+func (s *Notifier) formatEscalationMessage(service *storage.Service, incident *storage.Incident, level int, reminder bool) string {
+	tags := "-"
+	if len(service.Tags) > 0 {
+		tags = strings.Join(service.Tags, ", ")
+	}
+
+	state := "DOWN"
+	if incident.Severity == storage.IncidentSeverityWarning {
+		state = "DEGRADED"
+	}
+
+	header := "🚨 [ESCALATION]"
+	if reminder {
+		header = "⏰ [REMINDER]"
+	}
+
+	return fmt.Sprintf(
+		"%s %s is still %s and unacknowledged for %s\n\n"+
+			"• Service: %s\n"+
+			"• Tags: %s\n"+
+			"• Error: %s\n"+
+			"• Started: %s\n"+
+			"• Escalation level: %d\n"+
+			"• Incident ID: %s",
+		header,
+		service.Name,
+		state,
+		formatDuration(time.Since(incident.StartTime)),
+		service.Name,
+		tags,
+		incident.Error,
+		incident.StartTime.Format("2006-01-02 15:04:05"),
+		level,
+		incident.ID,
+	)
+}
+
+// formatSLOMessage formats a burn rate alert or recovery message
+func (s *Notifier) formatSLOMessage(status *storage.SLOStatus, alerting bool) string {
+	slo := status.SLO
+
+	header := fmt.Sprintf("🔥 [SLO] %s error budget is burning", slo.Name)
+	if !alerting {
+		header = fmt.Sprintf("🟢 [SLO] %s burn rate is back to normal", slo.Name)
+	}
+
+	scope := "Service ID: " + slo.ServiceID
+	if slo.Tag != "" {
+		scope = "Tag: " + slo.Tag
+	}
+
+	var burnRate string
+	if alert := slo.BurnRateAlert; alert != nil {
+		burnRate = fmt.Sprintf(
+			"%.1fx (%s), %.1fx (%s), threshold %.1fx",
+			status.BurnRateShort,
+			formatDuration(alert.ShortWindow),
+			status.BurnRateLong,
+			formatDuration(alert.LongWindow),
+			alert.Threshold,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s\n\n"+
+			"• SLO: %s\n"+
+			"• %s\n"+
+			"• Target: %g%% over %s\n"+
+			"• SLI: %.3f%%\n"+
+			"• Error budget remaining: %.1f%%\n"+
+			"• Burn rate: %s",
+		header,
+		slo.Name,
+		scope,
+		slo.Target,
+		slo.Window,
+		status.SLI,
+		status.ErrorBudgetRemaining,
+		burnRate,
+	)
+}
+
 // formatDuration formats a duration in a human-readable way
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -0,0 +1,176 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/containrrr/shoutrrr/pkg/types"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+)
+
+// TemplateData is the data available to notification templates
+type TemplateData struct {
+	Event    storage.NotificationEvent
+	Service  *storage.Service
+	Incident *storage.Incident
+	Tags     []string
+	// Error is the current error, PreviousError is set for update notifications
+	Error         string
+	PreviousError string
+	// LastCheck is the latest check result of the service, nil if unknown
+	LastCheck *storage.CheckResult
+	// Duration is the duration of the incident so far, or its total duration once resolved
+	Duration time.Duration
+	// IncidentURL links to the page of the service incidents in the web interface
+	IncidentURL string
+	// EscalationLevel is the number of escalation steps notified, set for escalation and reminder notifications
+	EscalationLevel int
+}
+
+// templateFuncs are the functions available to notification templates in addition to the text/template builtins
+var templateFuncs = template.FuncMap{
+	"join":       strings.Join,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"duration":   formatDuration,
+	"formatTime": func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+}
+
+// MessageTemplate is a parsed notification template
+type MessageTemplate interface {
+	Execute(w io.Writer, data any) error
+}
+
+// ParseTemplates parses notification templates by event.
+// Templates of the html format escape the values they print, like html/template.
+func ParseTemplates(templates map[storage.NotificationEvent]string, format storage.NotificationFormat) (map[storage.NotificationEvent]MessageTemplate, error) {
+	parsed := make(map[storage.NotificationEvent]MessageTemplate, len(templates))
+	for event, text := range templates {
+		if text == "" {
+			continue
+		}
+
+		var (
+			tmpl MessageTemplate
+			err  error
+		)
+		if format == storage.NotificationFormatHTML {
+			tmpl, err = htmltemplate.New(string(event)).Funcs(htmltemplate.FuncMap(templateFuncs)).Parse(text)
+		} else {
+			tmpl, err = template.New(string(event)).Funcs(templateFuncs).Parse(text)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", event, err)
+		}
+		parsed[event] = tmpl
+	}
+
+	return parsed, nil
+}
+
+// target is a group of provider URLs notified with the format and templates of a channel
+type target struct {
+	// channel is the name of the channel, empty for the default URLs
+	channel   string
+	urls      []string
+	format    storage.NotificationFormat
+	templates map[storage.NotificationEvent]MessageTemplate
+}
+
+// notification is an incident notification rendered for each target
+type notification struct {
+	event         storage.NotificationEvent
+	service       *storage.Service
+	incident      *storage.Incident
+	currentError  string
+	previousError string
+	// level is the escalation level of escalation and reminder notifications
+	level int
+	// message is the default message used by targets without a template for the event
+	message string
+	data    *TemplateData
+}
+
+// render returns the message of a notification for a target and its format.
+// The default message is used as plain text when the target has no template for the event or the template fails.
+func (s *Notifier) render(t *target, n *notification) (string, storage.NotificationFormat) {
+	tmpl, ok := t.templates[n.event]
+	if !ok {
+		return n.message, storage.NotificationFormatText
+	}
+
+	if n.data == nil {
+		n.data = s.templateData(n)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, n.data); err != nil {
+		s.logger.Errorf("failed to execute %s notification template, using the default message: %v", n.event, err)
+		return n.message, storage.NotificationFormatText
+	}
+
+	return buf.String(), t.format
+}
+
+// templateData builds the template data of a notification
+func (s *Notifier) templateData(n *notification) *TemplateData {
+	data := &TemplateData{
+		Event:           n.event,
+		Service:         n.service,
+		Incident:        n.incident,
+		Tags:            n.service.Tags,
+		Error:           n.incident.Error,
+		PreviousError:   n.previousError,
+		Duration:        time.Since(n.incident.StartTime),
+		IncidentURL:     fmt.Sprintf("%s/service/%s", s.baseURL, n.service.ID),
+		EscalationLevel: n.level,
+	}
+
+	if n.currentError != "" {
+		data.Error = n.currentError
+	}
+
+	if n.incident.Duration != nil {
+		data.Duration = *n.incident.Duration
+	}
+
+	if s.store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		checks, err := s.store.FindCheckResults(ctx, storage.FindCheckResultsParams{
+			ServiceID: n.service.ID,
+			PageSize:  utils.Pointer(uint32(1)),
+		})
+		if err != nil {
+			s.logger.Errorf("failed to find the last check result of service %s: %v", n.service.ID, err)
+		} else if len(checks.Items) > 0 {
+			data.LastCheck = checks.Items[0]
+		}
+	}
+
+	return data
+}
+
+// formatParams returns the provider parameters enabling the markup of a format, for providers supporting it
+func formatParams(url string, format storage.NotificationFormat) *types.Params {
+	scheme, _, _ := strings.Cut(url, "://")
+
+	switch {
+	case scheme == "telegram" && format == storage.NotificationFormatMarkdown:
+		return &types.Params{"parsemode": "Markdown"}
+	case scheme == "telegram" && format == storage.NotificationFormatHTML:
+		return &types.Params{"parsemode": "HTML"}
+	case scheme == "smtp" && format == storage.NotificationFormatHTML:
+		return &types.Params{"usehtml": "yes"}
+	}
+
+	return nil
+}
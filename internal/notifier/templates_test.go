@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+func TestParseTemplates(t *testing.T) {
+	parsed, err := ParseTemplates(map[storage.NotificationEvent]string{
+		storage.NotificationEventAlert:    "{{ .Service.Name }} is down",
+		storage.NotificationEventRecovery: "",
+	}, storage.NotificationFormatText)
+	require.NoError(t, err)
+	assert.Len(t, parsed, 1)
+	assert.Contains(t, parsed, storage.NotificationEventAlert)
+
+	_, err = ParseTemplates(map[storage.NotificationEvent]string{
+		storage.NotificationEventAlert: "{{ .Service.Name ",
+	}, storage.NotificationFormatText)
+	assert.ErrorContains(t, err, "failed to parse alert template")
+
+	_, err = ParseTemplates(map[storage.NotificationEvent]string{
+		storage.NotificationEventAlert: "{{ unknown .Service.Name }}",
+	}, storage.NotificationFormatHTML)
+	assert.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	s := &Notifier{logger: logger.Default(), baseURL: "https://sentinel.example.com"}
+
+	duration := 90 * time.Second
+	newNotification := func() *notification {
+		return &notification{
+			event:    storage.NotificationEventAlert,
+			service:  &storage.Service{ID: "svc1", Name: "a & b", Tags: []string{"<db>", "prod"}},
+			incident: &storage.Incident{Error: "dial tcp: <nil>", StartTime: time.Now(), Duration: &duration},
+			message:  "default message",
+		}
+	}
+
+	tests := []struct {
+		name      string
+		format    storage.NotificationFormat
+		templates map[storage.NotificationEvent]string
+		event     storage.NotificationEvent
+		message   string
+		wantFmt   storage.NotificationFormat
+	}{
+		{
+			name:      "Text templates print values as is",
+			format:    storage.NotificationFormatText,
+			templates: map[storage.NotificationEvent]string{storage.NotificationEventAlert: "{{ .Service.Name }}: {{ .Error }} [{{ join .Tags \", \" }}] {{ duration .Duration }}"},
+			message:   "a & b: dial tcp: <nil> [<db>, prod] 1m 30s",
+			wantFmt:   storage.NotificationFormatText,
+		},
+		{
+			name:      "HTML templates escape values",
+			format:    storage.NotificationFormatHTML,
+			templates: map[storage.NotificationEvent]string{storage.NotificationEventAlert: "<b>{{ .Service.Name }}</b>: {{ .Error }} [{{ join .Tags \", \" }}] <a href=\"{{ .IncidentURL }}\">details</a>"},
+			message:   "<b>a &amp; b</b>: dial tcp: &lt;nil&gt; [&lt;db&gt;, prod] <a href=\"https://sentinel.example.com/service/svc1\">details</a>",
+			wantFmt:   storage.NotificationFormatHTML,
+		},
+		{
+			name:      "Events without a template use the default message",
+			format:    storage.NotificationFormatHTML,
+			templates: map[storage.NotificationEvent]string{storage.NotificationEventRecovery: "<b>{{ .Service.Name }}</b> recovered"},
+			message:   "default message",
+			wantFmt:   storage.NotificationFormatText,
+		},
+		{
+			name:      "Failing templates fall back to the default message",
+			format:    storage.NotificationFormatMarkdown,
+			templates: map[storage.NotificationEvent]string{storage.NotificationEventAlert: "*{{ .LastCheck.Status }}*"},
+			message:   "default message",
+			wantFmt:   storage.NotificationFormatText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := ParseTemplates(tt.templates, tt.format)
+			require.NoError(t, err)
+
+			message, format := s.render(&target{format: tt.format, templates: templates}, newNotification())
+			assert.Equal(t, tt.message, message)
+			assert.Equal(t, tt.wantFmt, format)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// limiter tracks the messages recently sent to each provider URL and the grouping windows of their incident notifications.
+// The state is kept in memory, a restart resets the limits.
+type limiter struct {
+	mu sync.Mutex
+	// sent holds the send times of messages within the rate limit period by URL
+	sent map[string][]time.Time
+	// groups holds the end of the current grouping window by URL
+	groups map[string]time.Time
+}
+
+func newLimiter() *limiter {
+	return &limiter{
+		sent:   map[string][]time.Time{},
+		groups: map[string]time.Time{},
+	}
+}
+
+// groupEnd returns the end of the grouping window of a URL, a new window is opened if none is open
+func (l *limiter) groupEnd(url string, window time.Duration, now time.Time) time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	end, ok := l.groups[url]
+	if !ok || !end.After(now) {
+		end = now.Add(window)
+		l.groups[url] = end
+	}
+
+	return end
+}
+
+// reserve records a message sent to a URL if the rate limit allows it,
+// otherwise it returns the time the next message is allowed at
+func (l *limiter) reserve(url string, limit config.RateLimitConfig, now time.Time) (time.Time, bool) {
+	if limit.Messages <= 0 {
+		return now, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sent := l.sent[url]
+	for len(sent) > 0 && !sent[0].After(now.Add(-limit.Period)) {
+		sent = sent[1:]
+	}
+
+	if len(sent) >= limit.Messages {
+		l.sent[url] = sent
+		return sent[len(sent)-limit.Messages].Add(limit.Period), false
+	}
+
+	l.sent[url] = append(sent, now)
+	return now, true
+}
+
+// release removes a message recorded at the given time from the rate limit of a URL, for messages that were not sent
+func (l *limiter) release(url string, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sent := l.sent[url]
+	for i := len(sent) - 1; i >= 0; i-- {
+		if sent[i].Equal(at) {
+			l.sent[url] = slices.Delete(sent, i, i+1)
+			return
+		}
+	}
+}
+
+// group delays incident deliveries to the end of the grouping window of their provider URL,
+// so the incident notifications sent within the window are combined into one message
+func (s *Notifier) group(deliveries []*storage.NotificationDelivery, now time.Time) {
+	if s.grouping.Window <= 0 {
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.IncidentID != "" {
+			delivery.NextAttemptAt = s.limiter.groupEnd(delivery.URL, s.grouping.Window, now)
+		}
+	}
+}
+
+// rateLimitOf returns the rate limit of the URLs of a channel
+func (s *Notifier) rateLimitOf(channel string) config.RateLimitConfig {
+	if limit, ok := s.channelRateLimits[channel]; ok {
+		return limit
+	}
+	return s.rateLimit
+}
+
+// deliver sends the due deliveries of a provider URL in order and stops once one fails.
+// Deliveries over the rate limit are deferred until the limit allows the next message,
+// failed messages do not count towards the limit.
+func (s *Notifier) deliver(items []*storage.NotificationDelivery) {
+	for len(items) > 0 {
+		batch := s.batch(items)
+
+		now := time.Now()
+		if next, ok := s.limiter.reserve(batch[0].URL, s.rateLimitOf(batch[0].Channel), now); !ok {
+			s.deferDeliveries(items, next)
+			return
+		}
+
+		if !s.attempt(batch) {
+			s.limiter.release(batch[0].URL, now)
+			return
+		}
+
+		items = items[len(batch):]
+	}
+}
+
+// batch returns the deliveries sent in the next message. With grouping enabled, an incident notification
+// is combined with the following incident notifications of the same format, up to the maximum group size.
+func (s *Notifier) batch(items []*storage.NotificationDelivery) []*storage.NotificationDelivery {
+	size := 1
+	if s.grouping.Window > 0 && items[0].IncidentID != "" {
+		for size < len(items) && size < s.grouping.MaxSize && items[size].IncidentID != "" && items[size].Format == items[0].Format {
+			size++
+		}
+	}
+	return items[:size]
+}
+
+// deferDeliveries postpones deliveries over the rate limit without counting an attempt
+func (s *Notifier) deferDeliveries(items []*storage.NotificationDelivery, until time.Time) {
+	s.logger.Infof("rate limit of %s reached, deferring %d notifications until %s", MaskURL(items[0].URL), len(items), until.Format(time.RFC3339))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, delivery := range items {
+		delivery.NextAttemptAt = until
+		if err := s.store.UpdateNotificationDelivery(ctx, delivery); err != nil {
+			s.logger.Errorf("failed to defer notification delivery %s: %v", delivery.ID, err)
+		}
+	}
+}
+
+// combineMessages returns the message of grouped deliveries
+func combineMessages(batch []*storage.NotificationDelivery) string {
+	if len(batch) == 1 {
+		return batch[0].Message
+	}
+
+	messages := make([]string, 0, len(batch))
+	for _, delivery := range batch {
+		messages = append(messages, delivery.Message)
+	}
+
+	return fmt.Sprintf("📦 %d notifications\n\n%s", len(batch), strings.Join(messages, "\n\n――――――――――\n\n"))
+}
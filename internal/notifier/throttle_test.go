@@ -0,0 +1,265 @@
+package notifier
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+// failingURL is a provider URL that always fails to send
+const failingURL = "unknown://failing"
+
+// newTestNotifier returns a notifier over an SQLite store that is not started, so tests run the outbox themselves
+func newTestNotifier(t *testing.T, store *storage.SQLiteStorage, notifications config.NotificationsConfig) *Notifier {
+	t.Helper()
+
+	notifications.Enabled = true
+	if len(notifications.URLs) == 0 {
+		notifications.URLs = []string{"logger://"}
+	}
+	if notifications.Delivery.MaxAttempts == 0 {
+		notifications.Delivery = config.DeliveryConfig{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Minute}
+	}
+
+	s, err := New(logger.Default(), &config.Config{Notifications: notifications}, store)
+	require.NoError(t, err)
+	return s
+}
+
+// newTestStore returns an SQLite store in a temporary directory
+func newTestStore(t *testing.T, path string) *storage.SQLiteStorage {
+	t.Helper()
+
+	store, err := storage.NewSQLiteStorage(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(context.Background()) })
+	return store
+}
+
+func TestLimiterReserve(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limit := config.RateLimitConfig{Messages: 2, Period: time.Minute}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		ok   bool
+		next time.Time
+	}{
+		{name: "First message", at: t0, ok: true, next: t0},
+		{name: "Second message", at: t0.Add(10 * time.Second), ok: true, next: t0.Add(10 * time.Second)},
+		{name: "Limit reached", at: t0.Add(20 * time.Second), ok: false, next: t0.Add(time.Minute)},
+		{name: "First message leaves the window", at: t0.Add(time.Minute), ok: true, next: t0.Add(time.Minute)},
+		{name: "Limit reached again", at: t0.Add(61 * time.Second), ok: false, next: t0.Add(70 * time.Second)},
+	}
+
+	l := newLimiter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := l.reserve("logger://", limit, tt.at)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.next, next)
+		})
+	}
+
+	// Other URLs have their own limit
+	_, ok := l.reserve("logger://other", limit, t0.Add(61*time.Second))
+	assert.True(t, ok)
+
+	// Without a limit every message is allowed
+	for range 10 {
+		_, ok := l.reserve("logger://", config.RateLimitConfig{}, t0)
+		assert.True(t, ok)
+	}
+}
+
+func TestLimiterRelease(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limit := config.RateLimitConfig{Messages: 2, Period: time.Minute}
+
+	l := newLimiter()
+	_, ok := l.reserve("logger://", limit, t0)
+	require.True(t, ok)
+	_, ok = l.reserve("logger://", limit, t0.Add(time.Second))
+	require.True(t, ok)
+
+	l.release("logger://", t0.Add(time.Second))
+	assert.Equal(t, []time.Time{t0}, l.sent["logger://"])
+
+	_, ok = l.reserve("logger://", limit, t0.Add(2*time.Second))
+	assert.True(t, ok)
+
+	// Releasing an unknown message changes nothing
+	l.release("logger://", t0.Add(time.Hour))
+	assert.Len(t, l.sent["logger://"], 2)
+}
+
+func TestLimiterGroupEnd(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		url  string
+		at   time.Time
+		end  time.Time
+	}{
+		{name: "Opens a window", url: "logger://", at: t0, end: t0.Add(time.Minute)},
+		{name: "Joins the open window", url: "logger://", at: t0.Add(30 * time.Second), end: t0.Add(time.Minute)},
+		{name: "Other URLs open their own window", url: "logger://other", at: t0.Add(30 * time.Second), end: t0.Add(90 * time.Second)},
+		{name: "Opens a new window at the end of the previous one", url: "logger://", at: t0.Add(time.Minute), end: t0.Add(2 * time.Minute)},
+	}
+
+	l := newLimiter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.end, l.groupEnd(tt.url, time.Minute, tt.at))
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newDeliveries := func() []*storage.NotificationDelivery {
+		return []*storage.NotificationDelivery{
+			{URL: "logger://", IncidentID: "incident1", NextAttemptAt: t0},
+			{URL: "logger://", NextAttemptAt: t0},
+			{URL: "logger://other", IncidentID: "incident2", NextAttemptAt: t0},
+		}
+	}
+
+	s := &Notifier{limiter: newLimiter()}
+	deliveries := newDeliveries()
+	s.group(deliveries, t0)
+	for _, delivery := range deliveries {
+		assert.Equal(t, t0, delivery.NextAttemptAt, "grouping disabled")
+	}
+
+	s.grouping = config.GroupingConfig{Window: time.Minute, MaxSize: 10}
+	deliveries = newDeliveries()
+	s.group(deliveries, t0)
+	assert.Equal(t, t0.Add(time.Minute), deliveries[0].NextAttemptAt)
+	assert.Equal(t, t0, deliveries[1].NextAttemptAt, "not an incident notification")
+	assert.Equal(t, t0.Add(time.Minute), deliveries[2].NextAttemptAt)
+
+	// Notifications within the window are delayed to the same end
+	deliveries = newDeliveries()
+	s.group(deliveries, t0.Add(30*time.Second))
+	assert.Equal(t, t0.Add(time.Minute), deliveries[0].NextAttemptAt)
+}
+
+func TestBatch(t *testing.T) {
+	incident := func(format storage.NotificationFormat) *storage.NotificationDelivery {
+		return &storage.NotificationDelivery{IncidentID: "incident", Format: format}
+	}
+	other := &storage.NotificationDelivery{Format: storage.NotificationFormatText}
+	text := storage.NotificationFormatText
+
+	tests := []struct {
+		name     string
+		grouping config.GroupingConfig
+		items    []*storage.NotificationDelivery
+		size     int
+	}{
+		{
+			name:  "Grouping disabled",
+			items: []*storage.NotificationDelivery{incident(text), incident(text)},
+			size:  1,
+		},
+		{
+			name:     "Combines incident notifications",
+			grouping: config.GroupingConfig{Window: time.Minute, MaxSize: 10},
+			items:    []*storage.NotificationDelivery{incident(text), incident(text), incident(text)},
+			size:     3,
+		},
+		{
+			name:     "Stops at the maximum group size",
+			grouping: config.GroupingConfig{Window: time.Minute, MaxSize: 2},
+			items:    []*storage.NotificationDelivery{incident(text), incident(text), incident(text)},
+			size:     2,
+		},
+		{
+			name:     "Stops at another format",
+			grouping: config.GroupingConfig{Window: time.Minute, MaxSize: 10},
+			items:    []*storage.NotificationDelivery{incident(text), incident(storage.NotificationFormatHTML), incident(text)},
+			size:     1,
+		},
+		{
+			name:     "Stops at other notifications",
+			grouping: config.GroupingConfig{Window: time.Minute, MaxSize: 10},
+			items:    []*storage.NotificationDelivery{incident(text), other, incident(text)},
+			size:     1,
+		},
+		{
+			name:     "Other notifications are not combined",
+			grouping: config.GroupingConfig{Window: time.Minute, MaxSize: 10},
+			items:    []*storage.NotificationDelivery{other, incident(text)},
+			size:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Notifier{grouping: tt.grouping}
+			assert.Len(t, s.batch(tt.items), tt.size)
+		})
+	}
+}
+
+func TestDeliverRateLimit(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "db.sqlite"))
+	s := newTestNotifier(t, store, config.NotificationsConfig{
+		RateLimit: config.RateLimitConfig{Messages: 1, Period: time.Hour},
+	})
+
+	deliveries := []*storage.NotificationDelivery{
+		{URL: "logger://", Message: "first"},
+		{URL: "logger://", Message: "second"},
+	}
+	require.NoError(t, store.CreateNotificationDeliveries(ctx, deliveries))
+
+	start := time.Now()
+	s.processOutbox()
+
+	first, err := store.GetNotificationDeliveryByID(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.NotificationDeliveryStatusSent, first.Status)
+
+	// The delivery over the limit is deferred without counting an attempt
+	second, err := store.GetNotificationDeliveryByID(ctx, deliveries[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.NotificationDeliveryStatusPending, second.Status)
+	assert.Equal(t, 0, second.Attempts)
+	assert.Empty(t, second.LastError)
+	assert.WithinDuration(t, start.Add(time.Hour), second.NextAttemptAt, 5*time.Second)
+}
+
+func TestDeliverFailureReleasesRateLimit(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "db.sqlite"))
+	s := newTestNotifier(t, store, config.NotificationsConfig{
+		RateLimit: config.RateLimitConfig{Messages: 1, Period: time.Hour},
+	})
+
+	delivery := &storage.NotificationDelivery{URL: failingURL, Message: "failing"}
+	require.NoError(t, store.CreateNotificationDeliveries(ctx, []*storage.NotificationDelivery{delivery}))
+
+	s.processOutbox()
+
+	failed, err := store.GetNotificationDeliveryByID(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.NotEmpty(t, failed.LastError)
+
+	// The failed message does not count towards the limit
+	_, ok := s.limiter.reserve(failingURL, s.rateLimitOf(""), time.Now())
+	assert.True(t, ok)
+}
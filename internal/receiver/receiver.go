@@ -51,13 +51,55 @@ func NewTriggerServiceData(
 	}
 }
 
+// TriggerIncidentEventType is the type of an incident change
+type TriggerIncidentEventType int
+
+const (
+	TriggerIncidentEventTypeUnknown TriggerIncidentEventType = iota
+	TriggerIncidentEventTypeOpened
+	TriggerIncidentEventTypeUpdated
+	TriggerIncidentEventTypeResolved
+)
+
+// String returns a string representation of the TriggerIncidentEventType
+func (e TriggerIncidentEventType) String() string {
+	switch e {
+	case TriggerIncidentEventTypeOpened:
+		return "opened"
+	case TriggerIncidentEventTypeUpdated:
+		return "updated"
+	case TriggerIncidentEventTypeResolved:
+		return "resolved"
+	default:
+		return "unknown"
+	}
+}
+
+// TriggerIncidentData is an incident change published by the monitor service
+type TriggerIncidentData struct {
+	EventType TriggerIncidentEventType
+	Incident  *storage.Incident
+}
+
+func NewTriggerIncidentData(
+	eventType TriggerIncidentEventType,
+	incident *storage.Incident,
+) *TriggerIncidentData {
+	return &TriggerIncidentData{
+		EventType: eventType,
+		Incident:  incident,
+	}
+}
+
 type Receiver struct {
-	triggerService *broker.Broker[TriggerServiceData]
+	triggerService  *broker.Broker[TriggerServiceData]
+	triggerIncident *broker.Broker[TriggerIncidentData]
 }
 
 func New() *Receiver {
 	return &Receiver{
-		triggerService: broker.NewBroker[TriggerServiceData](),
+		triggerService:  broker.NewBroker[TriggerServiceData](),
+		triggerIncident: broker.NewBroker[TriggerIncidentData](),
 	}
 }
 
@@ -65,12 +107,16 @@ func (s Receiver) Name() string { return "receiver" }
 
 func (s *Receiver) Start(_ context.Context) error {
 	go s.triggerService.Start()
+	go s.triggerIncident.Start()
 	return nil
 }
 
 func (s *Receiver) Stop(_ context.Context) error {
 	s.triggerService.Stop()
+	s.triggerIncident.Stop()
 	return nil
 }
 
 func (s *Receiver) TriggerService() *broker.Broker[TriggerServiceData] { return s.triggerService }
+
+func (s *Receiver) TriggerIncident() *broker.Broker[TriggerIncidentData] { return s.triggerIncident }
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// limiter bounds the number of concurrent checks globally,
+// per protocol and per target host
+type limiter struct {
+	slots chan struct{}
+
+	protocolLimits map[storage.ServiceProtocolType]int
+	hostLimit      int
+
+	mu            sync.Mutex
+	protocolInUse map[storage.ServiceProtocolType]int
+	hostInUse     map[string]int
+}
+
+func newLimiter(cfg config.SchedulerConfig) *limiter {
+	maxConcurrency := cfg.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+
+	protocolLimits := make(map[storage.ServiceProtocolType]int, len(cfg.ProtocolLimits))
+	for protocol, limit := range cfg.ProtocolLimits {
+		if limit > 0 {
+			protocolLimits[storage.ServiceProtocolType(protocol)] = limit
+		}
+	}
+
+	return &limiter{
+		slots:          make(chan struct{}, maxConcurrency),
+		protocolLimits: protocolLimits,
+		hostLimit:      cfg.HostLimit,
+		protocolInUse:  make(map[storage.ServiceProtocolType]int),
+		hostInUse:      make(map[string]int),
+	}
+}
+
+// acquire waits for a global slot and then tries to take the protocol and host slots.
+// It returns false without holding anything if the protocol or host limit is reached.
+func (l *limiter) acquire(ctx context.Context, j *job) (bool, error) {
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	if !l.tryAcquireTarget(j) {
+		<-l.slots
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// release frees all slots taken by acquire
+func (l *limiter) release(j *job) {
+	l.mu.Lock()
+	if _, ok := l.protocolLimits[j.protocol]; ok {
+		l.protocolInUse[j.protocol]--
+	}
+	if l.hostLimit > 0 && j.host != "" {
+		l.hostInUse[j.host]--
+		if l.hostInUse[j.host] <= 0 {
+			delete(l.hostInUse, j.host)
+		}
+	}
+	l.mu.Unlock()
+
+	<-l.slots
+}
+
+// tryAcquireTarget takes the protocol and host slots if both are available
+func (l *limiter) tryAcquireTarget(j *job) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	protocolLimit, hasProtocolLimit := l.protocolLimits[j.protocol]
+	if hasProtocolLimit && l.protocolInUse[j.protocol] >= protocolLimit {
+		return false
+	}
+
+	hasHostLimit := l.hostLimit > 0 && j.host != ""
+	if hasHostLimit && l.hostInUse[j.host] >= l.hostLimit {
+		return false
+	}
+
+	if hasProtocolLimit {
+		l.protocolInUse[j.protocol]++
+	}
+	if hasHostLimit {
+		l.hostInUse[j.host]++
+	}
+
+	return true
+}
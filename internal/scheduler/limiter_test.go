@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	tcpJob := func(host string) *job {
+		return &job{protocol: storage.ServiceProtocolTypeTCP, host: host, index: -1}
+	}
+	httpJob := func(host string) *job {
+		return &job{protocol: storage.ServiceProtocolTypeHTTP, host: host, index: -1}
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.SchedulerConfig
+		// held are acquired before the job
+		held     []*job
+		job      *job
+		acquired bool
+	}{
+		{
+			name:     "No limits",
+			cfg:      config.SchedulerConfig{MaxConcurrency: 10},
+			held:     []*job{tcpJob("a"), tcpJob("a")},
+			job:      tcpJob("a"),
+			acquired: true,
+		},
+		{
+			name:     "Protocol limit reached",
+			cfg:      config.SchedulerConfig{MaxConcurrency: 10, ProtocolLimits: map[string]int{"tcp": 2}},
+			held:     []*job{tcpJob("a"), tcpJob("b")},
+			job:      tcpJob("c"),
+			acquired: false,
+		},
+		{
+			name:     "Protocol limit of another protocol",
+			cfg:      config.SchedulerConfig{MaxConcurrency: 10, ProtocolLimits: map[string]int{"tcp": 2}},
+			held:     []*job{tcpJob("a"), tcpJob("b")},
+			job:      httpJob("c"),
+			acquired: true,
+		},
+		{
+			name:     "Host limit reached",
+			cfg:      config.SchedulerConfig{MaxConcurrency: 10, HostLimit: 1},
+			held:     []*job{tcpJob("a")},
+			job:      httpJob("a"),
+			acquired: false,
+		},
+		{
+			name:     "Host limit of another host",
+			cfg:      config.SchedulerConfig{MaxConcurrency: 10, HostLimit: 1},
+			held:     []*job{tcpJob("a")},
+			job:      tcpJob("b"),
+			acquired: true,
+		},
+		{
+			name:     "Jobs without a host are not limited by host",
+			cfg:      config.SchedulerConfig{MaxConcurrency: 10, HostLimit: 1},
+			held:     []*job{tcpJob("")},
+			job:      tcpJob(""),
+			acquired: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiter(tt.cfg)
+			for _, j := range tt.held {
+				acquired, err := l.acquire(ctx, j)
+				require.NoError(t, err)
+				require.True(t, acquired)
+			}
+
+			acquired, err := l.acquire(ctx, tt.job)
+			require.NoError(t, err)
+			assert.Equal(t, tt.acquired, acquired)
+
+			// A rejected job does not hold a global slot
+			if acquired {
+				l.release(tt.job)
+			} else {
+				assert.Len(t, l.slots, len(tt.held))
+			}
+
+			// Releasing the held jobs frees their slots
+			for _, j := range tt.held {
+				l.release(j)
+			}
+			acquired, err = l.acquire(ctx, tt.job)
+			require.NoError(t, err)
+			assert.True(t, acquired)
+			assert.Empty(t, l.hostInUse[""])
+		})
+	}
+}
+
+func TestLimiterMaxConcurrency(t *testing.T) {
+	l := newLimiter(config.SchedulerConfig{MaxConcurrency: 1})
+	j := &job{protocol: storage.ServiceProtocolTypeTCP, index: -1}
+
+	acquired, err := l.acquire(context.Background(), j)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// The next check waits for a global slot
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	acquired, err = l.acquire(ctx, j)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, acquired)
+
+	l.release(j)
+
+	acquired, err = l.acquire(context.Background(), j)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Without a configured concurrency one check runs at a time
+	assert.Equal(t, 1, cap(newLimiter(config.SchedulerConfig{}).slots))
+}
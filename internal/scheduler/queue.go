@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"container/heap"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// dueQueue is a priority queue of jobs ordered by the time they are due
+type dueQueue struct {
+	mu    sync.Mutex
+	items jobHeap
+	wake  chan struct{}
+}
+
+func newDueQueue() *dueQueue {
+	return &dueQueue{
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// push schedules the job to run at the given time.
+// If the job is already queued its run time is updated.
+func (q *dueQueue) push(j *job, at time.Time) {
+	q.mu.Lock()
+	j.nextRun = at
+	j.retryAt = time.Time{}
+	q.fix(j)
+	q.mu.Unlock()
+
+	q.notify()
+}
+
+// retry queues a job delayed by the limits to run again at the given time.
+// The scheduled run time is kept, so the delay does not shift the cadence of the job.
+func (q *dueQueue) retry(j *job, at time.Time) {
+	q.mu.Lock()
+	j.retryAt = at
+	q.fix(j)
+	q.mu.Unlock()
+
+	q.notify()
+}
+
+// fix queues the job or updates its position, the queue mutex must be held
+func (q *dueQueue) fix(j *job) {
+	if j.index >= 0 {
+		heap.Fix(&q.items, j.index)
+	} else {
+		heap.Push(&q.items, j)
+	}
+}
+
+// reschedule queues the job for its next run after a completed check.
+// The job keeps its cadence unless it fell behind, in which case it runs
+// one interval from now.
+func (q *dueQueue) reschedule(j *job) {
+	q.mu.Lock()
+	next := j.nextRun.Add(j.interval)
+	if now := time.Now(); next.Before(now) {
+		next = now.Add(j.interval)
+	}
+	q.mu.Unlock()
+
+	q.push(j, next)
+}
+
+// remove removes the job from the queue if it is queued
+func (q *dueQueue) remove(j *job) {
+	q.mu.Lock()
+	if j.index >= 0 {
+		heap.Remove(&q.items, j.index)
+	}
+	q.mu.Unlock()
+}
+
+// popDue returns the earliest job if it is due. Otherwise it returns nil
+// and the duration until the earliest job is due, or -1 if the queue is empty.
+func (q *dueQueue) popDue(now time.Time) (*job, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return nil, -1
+	}
+
+	next := q.items[0]
+	if wait := next.dueAt().Sub(now); wait > 0 {
+		return nil, wait
+	}
+
+	return heap.Pop(&q.items).(*job), 0
+}
+
+// len returns the number of queued jobs
+func (q *dueQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// notify wakes up the dispatcher without blocking
+func (q *dueQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dueAt returns the time the job is due, its scheduled run time or the time its delayed run is retried at
+func (j *job) dueAt() time.Time {
+	if j.retryAt.After(j.nextRun) {
+		return j.retryAt
+	}
+	return j.nextRun
+}
+
+// jobHeap implements heap.Interface for jobs
+type jobHeap []*job
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool { return h[i].dueAt().Before(h[j].dueAt()) }
+
+func (h jobHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *jobHeap) Push(x any) {
+	j := x.(*job)
+	j.index = len(*h)
+	*h = append(*h, j)
+}
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	n := len(old)
+	j := old[n-1]
+	old[n-1] = nil
+	j.index = -1
+	*h = old[:n-1]
+	return j
+}
+
+// startupJitter returns a deterministic delay for the initial check of a service.
+// The delay is derived from the service ID, so restarts spread checks the same way.
+func startupJitter(serviceID string, interval, maxJitter time.Duration) time.Duration {
+	window := interval
+	if maxJitter < window {
+		window = maxJitter
+	}
+	if window <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(serviceID))
+
+	return time.Duration(h.Sum64() % uint64(window))
+}
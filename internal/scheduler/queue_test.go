@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJob(id string, interval time.Duration) *job {
+	return &job{serviceID: id, interval: interval, index: -1}
+}
+
+func TestDueQueuePopDue(t *testing.T) {
+	now := time.Now()
+	q := newDueQueue()
+
+	job, wait := q.popDue(now)
+	assert.Nil(t, job)
+	assert.Equal(t, time.Duration(-1), wait)
+
+	first := newTestJob("first", time.Minute)
+	second := newTestJob("second", time.Minute)
+	third := newTestJob("third", time.Minute)
+
+	q.push(third, now.Add(3*time.Second))
+	q.push(first, now.Add(-time.Second))
+	q.push(second, now)
+	assert.Equal(t, 3, q.len())
+
+	job, _ = q.popDue(now)
+	assert.Same(t, first, job)
+	job, _ = q.popDue(now)
+	assert.Same(t, second, job)
+
+	job, wait = q.popDue(now)
+	assert.Nil(t, job)
+	assert.Equal(t, 3*time.Second, wait)
+
+	// Pushing a queued job updates its run time
+	q.push(third, now.Add(time.Second))
+	assert.Equal(t, 1, q.len())
+	_, wait = q.popDue(now)
+	assert.Equal(t, time.Second, wait)
+
+	q.remove(third)
+	assert.Equal(t, 0, q.len())
+	assert.Equal(t, -1, third.index)
+
+	// Removing a job that is not queued does nothing
+	q.remove(third)
+	assert.Equal(t, 0, q.len())
+}
+
+func TestDueQueueReschedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		nextRun time.Duration
+		want    time.Duration
+	}{
+		{
+			name:    "Keeps the cadence",
+			nextRun: -10 * time.Second,
+			want:    50 * time.Second,
+		},
+		{
+			name:    "Runs one interval from now when behind",
+			nextRun: -3 * time.Minute,
+			want:    time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			q := newDueQueue()
+			j := newTestJob("job", time.Minute)
+
+			q.push(j, now.Add(tt.nextRun))
+			job, _ := q.popDue(now)
+			require.Same(t, j, job)
+
+			q.reschedule(j)
+			assert.WithinDuration(t, now.Add(tt.want), j.nextRun, time.Second)
+		})
+	}
+}
+
+func TestDueQueueRetry(t *testing.T) {
+	now := time.Now()
+	q := newDueQueue()
+
+	j := newTestJob("job", time.Minute)
+	other := newTestJob("other", time.Minute)
+
+	slot := now.Add(-10 * time.Millisecond)
+	q.push(j, slot)
+	q.push(other, now.Add(100*time.Millisecond))
+
+	job, _ := q.popDue(now)
+	require.Same(t, j, job)
+
+	// A run delayed by the limits keeps its scheduled slot
+	q.retry(j, now.Add(limitBackoff))
+	assert.Equal(t, slot, j.nextRun)
+
+	// The retry is ordered by the time it is due
+	job, wait := q.popDue(now)
+	assert.Nil(t, job)
+	assert.Equal(t, 100*time.Millisecond, wait)
+
+	job, _ = q.popDue(now.Add(100 * time.Millisecond))
+	assert.Same(t, other, job)
+
+	job, wait = q.popDue(now.Add(100 * time.Millisecond))
+	assert.Nil(t, job)
+	assert.Equal(t, limitBackoff-100*time.Millisecond, wait)
+
+	job, _ = q.popDue(now.Add(limitBackoff))
+	require.Same(t, j, job)
+
+	// The next run follows the scheduled slot, not the retry
+	q.reschedule(j)
+	assert.Equal(t, slot.Add(time.Minute), j.nextRun)
+	assert.True(t, j.retryAt.IsZero())
+}
+
+func TestStartupJitter(t *testing.T) {
+	tests := []struct {
+		name      string
+		interval  time.Duration
+		maxJitter time.Duration
+		bound     time.Duration
+	}{
+		{name: "Bounded by the max jitter", interval: time.Hour, maxJitter: 10 * time.Second, bound: 10 * time.Second},
+		{name: "Bounded by the interval", interval: 5 * time.Second, maxJitter: time.Minute, bound: 5 * time.Second},
+		{name: "Disabled", interval: time.Minute, maxJitter: 0, bound: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, id := range []string{"a", "b", "service-1", "01HZX3J4K5M6N7P8Q9R0S1T2V3"} {
+				jitter := startupJitter(id, tt.interval, tt.maxJitter)
+				assert.Equal(t, jitter, startupJitter(id, tt.interval, tt.maxJitter), "deterministic")
+				assert.GreaterOrEqual(t, jitter, time.Duration(0))
+				if tt.bound == 0 {
+					assert.Zero(t, jitter)
+				} else {
+					assert.Less(t, jitter, tt.bound)
+				}
+			}
+		})
+	}
+
+	// Services are spread across the window
+	assert.NotEqual(t, startupJitter("a", time.Hour, time.Minute), startupJitter("b", time.Hour, time.Minute))
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// defaultRetryPolicy is used for services without a retry policy
+var defaultRetryPolicy = storage.RetryPolicy{
+	Strategy:  storage.RetryStrategyLinear,
+	BaseDelay: 500 * time.Millisecond,
+	MaxDelay:  30 * time.Second,
+}
+
+// retryPolicyOrDefault returns the service retry policy with defaults applied
+func retryPolicyOrDefault(policy *storage.RetryPolicy) storage.RetryPolicy {
+	if policy == nil {
+		return defaultRetryPolicy
+	}
+
+	p := *policy
+	if p.Strategy == "" {
+		p.Strategy = defaultRetryPolicy.Strategy
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = defaultRetryPolicy.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaultRetryPolicy.MaxDelay
+	}
+	if p.MaxDelay < p.BaseDelay {
+		p.MaxDelay = p.BaseDelay
+	}
+
+	return p
+}
+
+// shouldRetry reports whether a failed attempt with the given error class is retried
+func shouldRetry(policy storage.RetryPolicy, class storage.CheckErrorClass) bool {
+	if len(policy.RetryOn) == 0 {
+		return true
+	}
+	return slices.Contains(policy.RetryOn, class)
+}
+
+// retryDelay returns the delay before the next attempt after the given failed attempt
+func retryDelay(policy storage.RetryPolicy, attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	var delay time.Duration
+	switch policy.Strategy {
+	case storage.RetryStrategyFixed:
+		delay = policy.BaseDelay
+	case storage.RetryStrategyExponential:
+		delay = policy.BaseDelay
+		for i := 1; i < attempt && delay < policy.MaxDelay; i++ {
+			delay *= 2
+		}
+	default:
+		delay = policy.BaseDelay * time.Duration(attempt)
+	}
+
+	if delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+
+	// Exponential backoff uses equal jitter to avoid synchronized retries
+	if policy.Strategy == storage.RetryStrategyExponential && delay > 1 {
+		half := delay / 2
+		delay = half + rand.N(delay-half)
+	}
+
+	return delay
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   storage.RetryPolicy
+		attempt  int
+		expected time.Duration
+	}{
+		{
+			name:     "Fixed",
+			policy:   storage.RetryPolicy{Strategy: storage.RetryStrategyFixed, BaseDelay: time.Second, MaxDelay: time.Minute},
+			attempt:  3,
+			expected: time.Second,
+		},
+		{
+			name:     "Linear",
+			policy:   storage.RetryPolicy{Strategy: storage.RetryStrategyLinear, BaseDelay: time.Second, MaxDelay: time.Minute},
+			attempt:  3,
+			expected: 3 * time.Second,
+		},
+		{
+			name:     "Linear capped by max delay",
+			policy:   storage.RetryPolicy{Strategy: storage.RetryStrategyLinear, BaseDelay: time.Second, MaxDelay: 2 * time.Second},
+			attempt:  5,
+			expected: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, retryDelay(tt.policy, tt.attempt))
+		})
+	}
+}
+
+func TestRetryDelayExponentialJitter(t *testing.T) {
+	policy := storage.RetryPolicy{
+		Strategy:  storage.RetryStrategyExponential,
+		BaseDelay: time.Second,
+		MaxDelay:  10 * time.Second,
+	}
+
+	for range 100 {
+		// 3rd attempt: 4s with equal jitter
+		delay := retryDelay(policy, 3)
+		assert.GreaterOrEqual(t, delay, 2*time.Second)
+		assert.Less(t, delay, 4*time.Second)
+
+		// Capped by max delay
+		delay = retryDelay(policy, 10)
+		assert.GreaterOrEqual(t, delay, 5*time.Second)
+		assert.Less(t, delay, 10*time.Second)
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	policy := retryPolicyOrDefault(nil)
+	assert.True(t, shouldRetry(policy, storage.CheckErrorClassAssertion))
+
+	policy.RetryOn = []storage.CheckErrorClass{storage.CheckErrorClassTimeout}
+	assert.True(t, shouldRetry(policy, storage.CheckErrorClassTimeout))
+	assert.False(t, shouldRetry(policy, storage.CheckErrorClassAssertion))
+}
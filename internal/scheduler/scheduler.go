@@ -9,37 +9,53 @@ import (
 	"time"
 
 	"github.com/puzpuzpuz/xsync/v3"
+	"github.com/sxwebdev/sentinel/internal/config"
 	"github.com/sxwebdev/sentinel/internal/monitor"
 	"github.com/sxwebdev/sentinel/internal/monitors"
 	"github.com/sxwebdev/sentinel/internal/receiver"
 	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
 	"github.com/tkcrm/mx/logger"
 )
 
 // ErrServiceNotFound is returned when a service is not found
 var ErrServiceNotFound = fmt.Errorf("service not found")
 
+// limitBackoff is the delay before a due check is retried
+// when its protocol or host limit is reached
+const limitBackoff = 250 * time.Millisecond
+
 // Scheduler manages the monitoring of multiple services
 type Scheduler struct {
 	logger logger.Logger
+	config config.SchedulerConfig
 
 	receiver   *receiver.Receiver
 	monitorSvc *monitor.MonitorService
 
-	jobs *xsync.MapOf[string, *job]
-	wg   sync.WaitGroup
+	jobs    *xsync.MapOf[string, *job]
+	queue   *dueQueue
+	limiter *limiter
+	stop    chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
 }
 
 // job represents a scheduled monitoring job for a service
 type job struct {
 	serviceID   string
 	serviceName string
+	protocol    storage.ServiceProtocolType
+	host        string
 	interval    time.Duration
 	timeout     time.Duration
 	retries     int
-	ticker      *time.Ticker
-	stopChan    chan struct{}
 	inProgress  atomic.Bool
+	// Next scheduled run time, time a run delayed by the limits is retried at
+	// and position in the due queue, guarded by the queue mutex
+	nextRun time.Time
+	retryAt time.Time
+	index   int
 	// Context and cancel function for canceling ongoing checks
 	checkCtx    context.Context
 	checkCancel context.CancelFunc
@@ -48,14 +64,19 @@ type job struct {
 // New creates a new scheduler
 func New(
 	l logger.Logger,
+	cfg config.SchedulerConfig,
 	monitorService *monitor.MonitorService,
 	receiver *receiver.Receiver,
 ) *Scheduler {
 	return &Scheduler{
 		logger:     l,
+		config:     cfg,
 		monitorSvc: monitorService,
 		receiver:   receiver,
 		jobs:       xsync.NewMapOf[string, *job](),
+		queue:      newDueQueue(),
+		limiter:    newLimiter(cfg),
+		stop:       make(chan struct{}),
 	}
 }
 
@@ -73,11 +94,21 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to load services: %w", err)
 	}
 
-	// Get all services under read lock
+	// Spread initial checks across the interval to avoid a thundering herd
+	now := time.Now()
 	for _, svc := range services.Items {
-		s.addService(ctx, svc)
+		jitter := startupJitter(svc.ID, svc.Interval, s.config.MaxStartupJitter)
+		s.addService(ctx, svc, now.Add(jitter))
 	}
 
+	s.logger.Infof("scheduled %d services, max concurrency: %d", s.queue.len(), s.config.MaxConcurrency)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.dispatch(ctx)
+	}()
+
 	errChan := make(chan error, 1)
 	go func() {
 		errChan <- s.subscribeEvents(ctx)
@@ -93,6 +124,7 @@ func (s *Scheduler) Start(ctx context.Context) error {
 }
 
 func (s *Scheduler) Stop(ctx context.Context) error {
+	s.once.Do(func() { close(s.stop) })
 	s.stopAll()
 	s.wg.Wait()
 	return nil
@@ -101,26 +133,21 @@ func (s *Scheduler) Stop(ctx context.Context) error {
 // stopAll stops monitoring for all services
 func (s *Scheduler) stopAll() {
 	s.jobs.Range(func(key string, value *job) bool {
-		// Cancel any ongoing checks
-		if value.checkCancel != nil {
-			value.checkCancel()
-		}
-
-		select {
-		case <-value.stopChan:
-			// Channel already closed
-		default:
-			close(value.stopChan)
-		}
-		if value.ticker != nil {
-			value.ticker.Stop()
-		}
+		s.stopJob(value)
 		return true
 	})
 }
 
-// addService adds a service to be monitored
-func (s *Scheduler) addService(ctx context.Context, svc *storage.Service) {
+// stopJob cancels any ongoing check of the job and removes it from the queue
+func (s *Scheduler) stopJob(j *job) {
+	if j.checkCancel != nil {
+		j.checkCancel()
+	}
+	s.queue.remove(j)
+}
+
+// addService adds a service to be monitored with the first check at the given time
+func (s *Scheduler) addService(ctx context.Context, svc *storage.Service, firstRun time.Time) {
 	// Only add enabled services to monitoring
 	if !svc.IsEnabled {
 		s.logger.Warnf("Skipping disabled service: %s (ID: %s)", svc.Name, svc.ID)
@@ -132,72 +159,83 @@ func (s *Scheduler) addService(ctx context.Context, svc *storage.Service) {
 	job := &job{
 		serviceID:   svc.ID,
 		serviceName: svc.Name,
+		protocol:    svc.Protocol,
+		host:        monitors.TargetHost(*svc),
 		interval:    svc.Interval,
 		timeout:     svc.Timeout,
 		retries:     svc.Retries,
-		stopChan:    make(chan struct{}),
+		index:       -1,
 		checkCtx:    checkCtx,
 		checkCancel: checkCancel,
 	}
 
-	s.addJob(ctx, job)
+	s.addJob(job, firstRun)
 }
 
-// addJob adds a new job to the scheduler
-func (s *Scheduler) addJob(ctx context.Context, job *job) {
-	// Check if job already exists
+// addJob adds a new job to the scheduler, replacing the existing one
+func (s *Scheduler) addJob(job *job, firstRun time.Time) {
+	// Stop existing job gracefully
 	if existingJob, exists := s.jobs.Load(job.serviceID); exists {
-		// Cancel any ongoing checks for the existing job
-		if existingJob.checkCancel != nil {
-			existingJob.checkCancel()
-		}
-
-		// Stop existing job gracefully
-		select {
-		case <-existingJob.stopChan:
-			// Channel already closed
-		default:
-			close(existingJob.stopChan)
-		}
-		if existingJob.ticker != nil {
-			existingJob.ticker.Stop()
-		}
+		s.stopJob(existingJob)
 	}
 
-	// Store the new job
+	// Store the new job and queue its first check
 	s.jobs.Store(job.serviceID, job)
-
-	// Start monitoring in a new goroutine
-	s.wg.Add(1)
-	go s.monitorService(ctx, job)
+	s.queue.push(job, firstRun)
 }
 
-// monitorService runs the monitoring loop for a single service
-func (s *Scheduler) monitorService(ctx context.Context, job *job) {
-	defer s.wg.Done()
+// dispatch runs due checks from the queue within the concurrency limits
+func (s *Scheduler) dispatch(ctx context.Context) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
 
-	// Create ticker for regular checks
-	job.ticker = time.NewTicker(job.interval)
-	defer job.ticker.Stop()
+	for {
+		job, wait := s.queue.popDue(time.Now())
+		if job == nil {
+			if wait < 0 {
+				wait = time.Hour
+			}
+			timer.Reset(wait)
 
-	// Perform initial check
-	if err := s.performCheck(job); err != nil {
-		s.logger.Errorf("Error performing initial check for service %s: %v", job.serviceName, err)
-		return
-	}
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.stop:
+				return
+			case <-s.queue.wake:
+			case <-timer.C:
+			}
+			continue
+		}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-job.stopChan:
+		// Skip jobs that were removed or replaced while queued
+		if job.checkCtx.Err() != nil {
+			continue
+		}
+
+		acquired, err := s.limiter.acquire(ctx, job)
+		if err != nil {
 			return
-		case <-job.ticker.C:
+		}
+		if !acquired {
+			s.queue.retry(job, time.Now().Add(limitBackoff))
+			continue
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer s.limiter.release(job)
+
 			if err := s.performCheck(job); err != nil && !errors.Is(err, context.Canceled) {
 				s.logger.Errorf("error performing check for service %s: %v", job.serviceName, err)
-				continue
 			}
-		}
+
+			// Queue the next check unless the job was removed or replaced
+			if job.checkCtx.Err() == nil {
+				s.queue.reschedule(job)
+			}
+		}()
 	}
 }
 
@@ -238,10 +276,17 @@ func (s *Scheduler) performCheck(job *job) error {
 	}()
 
 	// Perform the check with retries
+	retryPolicy := retryPolicyOrDefault(service.RetryPolicy)
+
 	var lastErr error
 	var lastAttemptResponseTime time.Duration
 
+	checkStartTime := time.Now()
+	attempts := 0
+
 	for attempt := 1; attempt <= job.retries; attempt++ {
+		attempts = attempt
+
 		// Create context with timeout for this specific check
 		attemptCtx, cancel := context.WithTimeout(job.checkCtx, job.timeout)
 
@@ -254,10 +299,27 @@ func (s *Scheduler) performCheck(job *job) error {
 		// Cancel context immediately after use to avoid memory leak
 		cancel()
 
-		if err == nil {
-			// Success - record the time of this successful attempt
-			if err := s.monitorSvc.RecordSuccess(job.checkCtx, job.serviceID, attemptResponseTime); err != nil {
-				return fmt.Errorf("failed to record success for %s: %w", serviceName, err)
+		if err == nil || monitors.IsWarning(err) {
+			// Success - the service is degraded if the monitor reported a warning
+			// or the response time exceeded the latency warning threshold
+			if err == nil && service.LatencyWarning > 0 && attemptResponseTime > service.LatencyWarning {
+				err = fmt.Errorf("response time %v exceeded latency warning threshold %v", attemptResponseTime, service.LatencyWarning)
+			}
+
+			if err != nil {
+				s.recordCheckResult(job, monitor, checkStartTime, storage.StatusDegraded, err, attemptResponseTime, attempts)
+
+				if err := s.monitorSvc.RecordDegraded(job.checkCtx, job.serviceID, err, attemptResponseTime); err != nil {
+					return fmt.Errorf("failed to record degraded state for %s: %w", serviceName, err)
+				}
+
+				s.logger.Debugf("service %s check degraded in %v: %s", serviceName, attemptResponseTime, err)
+			} else {
+				s.recordCheckResult(job, monitor, checkStartTime, storage.StatusUp, nil, attemptResponseTime, attempts)
+
+				if err := s.monitorSvc.RecordSuccess(job.checkCtx, job.serviceID, attemptResponseTime); err != nil {
+					return fmt.Errorf("failed to record success for %s: %w", serviceName, err)
+				}
 			}
 
 			if attempt == 1 {
@@ -281,6 +343,14 @@ func (s *Scheduler) performCheck(job *job) error {
 		}
 
 		lastErr = err
+		errorClass := monitors.ClassifyError(err)
+
+		s.logger.Debugf("service %s check failed (attempt %d/%d, %s): %s", serviceName, attempt, job.retries, errorClass, err)
+
+		// Stop retrying if the policy does not cover this error class
+		if !shouldRetry(retryPolicy, errorClass) {
+			break
+		}
 
 		// If not the last attempt, wait a bit before retrying
 		if attempt < job.retries {
@@ -289,15 +359,15 @@ func (s *Scheduler) performCheck(job *job) error {
 			case <-job.checkCtx.Done():
 				// Job context cancelled, stop retrying
 				return job.checkCtx.Err()
-			case <-time.After(time.Millisecond * 500 * time.Duration(attempt)):
-				// Exponential backoff - continue to next attempt
+			case <-time.After(retryDelay(retryPolicy, attempt)):
+				// Backoff according to the retry policy - continue to next attempt
 			}
 		}
-
-		s.logger.Debugf("service %s check failed (attempt %d/%d): %s", serviceName, attempt, job.retries, err)
 	}
 
 	// All attempts failed - record the time of the last attempt
+	s.recordCheckResult(job, monitor, checkStartTime, storage.StatusDown, lastErr, lastAttemptResponseTime, attempts)
+
 	if err := s.monitorSvc.RecordFailure(job.checkCtx, job.serviceID, lastErr, lastAttemptResponseTime); err != nil {
 		return fmt.Errorf("failed to record failure for %s: %w", serviceName, err)
 	}
@@ -316,6 +386,30 @@ func (s *Scheduler) performCheck(job *job) error {
 	return nil
 }
 
+// recordCheckResult saves the check in the service check history.
+// Failures are logged only, they must not affect the service state.
+func (s *Scheduler) recordCheckResult(job *job, monitor monitors.ServiceMonitor, checkedAt time.Time, status storage.ServiceStatus, checkErr error, responseTime time.Duration, attempts int) {
+	result := &storage.CheckResult{
+		ServiceID:    job.serviceID,
+		CheckedAt:    checkedAt,
+		Status:       status,
+		ResponseTime: responseTime,
+		Attempts:     attempts,
+	}
+
+	if checkErr != nil {
+		result.Error = utils.Pointer(checkErr.Error())
+	}
+
+	if reporter, ok := monitor.(monitors.EndpointReporter); ok {
+		result.Endpoints = reporter.Endpoints()
+	}
+
+	if err := s.monitorSvc.SaveCheckResult(job.checkCtx, result); err != nil {
+		s.logger.Errorf("failed to save check result for %s: %v", job.serviceName, err)
+	}
+}
+
 // checkService manually triggers a check for a specific service
 func (s *Scheduler) checkService(serviceID string) error {
 	job, exists := s.jobs.Load(serviceID)
@@ -323,7 +417,10 @@ func (s *Scheduler) checkService(serviceID string) error {
 		return ErrServiceNotFound
 	}
 
-	return s.performCheck(job)
+	// Move the job to the front of the queue, it will run within the concurrency limits
+	s.queue.push(job, time.Now())
+
+	return nil
 }
 
 // removeJob removes a service dynamically (for runtime removals)
@@ -333,21 +430,8 @@ func (s *Scheduler) removeJob(serviceID string) error {
 		return ErrServiceNotFound
 	}
 
-	// Cancel any ongoing checks for this job
-	if job.checkCancel != nil {
-		job.checkCancel()
-	}
-
-	// Stop the monitoring gracefully
-	select {
-	case <-job.stopChan:
-		// Channel already closed
-	default:
-		close(job.stopChan)
-	}
-	if job.ticker != nil {
-		job.ticker.Stop()
-	}
+	// Cancel any ongoing checks and stop the monitoring
+	s.stopJob(job)
 
 	// Remove from services map
 	s.jobs.Delete(serviceID)
@@ -357,7 +441,7 @@ func (s *Scheduler) removeJob(serviceID string) error {
 
 // updateJob updates a service configuration dynamically
 func (s *Scheduler) updateJob(ctx context.Context, svc *storage.Service) error {
-	s.addService(ctx, svc)
+	s.addService(ctx, svc, time.Now())
 
 	return nil
 }
@@ -376,7 +460,7 @@ func (s *Scheduler) subscribeEvents(ctx context.Context) error {
 					s.logger.Errorf("check service error: %v", err)
 				}
 			case receiver.TriggerServiceEventTypeCreated:
-				s.addService(ctx, item.Svc)
+				s.addService(ctx, item.Svc, time.Now())
 			case receiver.TriggerServiceEventTypeUpdated:
 				// Check if service was disabled
 				if !item.Svc.IsEnabled {
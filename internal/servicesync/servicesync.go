@@ -0,0 +1,413 @@
+package servicesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/monitor"
+	"github.com/sxwebdev/sentinel/internal/monitors"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+	"github.com/tkcrm/mx/logger"
+)
+
+// findPageSize is the page size used to load all services
+const findPageSize = 100
+
+// Result describes changes made by a sync
+type Result struct {
+	Created  int
+	Updated  int
+	Deleted  int
+	Released int // services removed from the file without prune, they are editable again
+}
+
+// Syncer reconciles services declared in the config file and the services path into storage.
+// Declared services are matched to stored services by name.
+type Syncer struct {
+	logger         logger.Logger
+	config         *config.Config
+	configPath     string
+	monitorService *monitor.MonitorService
+
+	lastHash string
+	stop     chan struct{}
+}
+
+// New creates a new services sync
+func New(l logger.Logger, cfg *config.Config, configPath string, monitorService *monitor.MonitorService) *Syncer {
+	return &Syncer{
+		logger:         l,
+		config:         cfg,
+		configPath:     configPath,
+		monitorService: monitorService,
+		stop:           make(chan struct{}),
+	}
+}
+
+// Name returns the name of the service
+func (s *Syncer) Name() string { return "services-sync" }
+
+// Start syncs declared services and then syncs them again whenever they change
+func (s *Syncer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.ServicesSync.WatchInterval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the service
+func (s *Syncer) Stop(_ context.Context) error {
+	close(s.stop)
+	return nil
+}
+
+// run loads declared services and syncs them if they changed since the last successful sync.
+// Storage is left untouched when the files cannot be loaded.
+func (s *Syncer) run(ctx context.Context) {
+	declared, err := config.LoadServices(s.configPath, s.config.ServicesSync)
+	if err != nil {
+		s.logger.Errorf("failed to load declared services: %v", err)
+		return
+	}
+
+	hash, err := fingerprint(declared)
+	if err != nil {
+		s.logger.Errorf("failed to fingerprint declared services: %v", err)
+		return
+	}
+	if hash == s.lastHash {
+		return
+	}
+
+	res, err := Sync(ctx, s.monitorService, s.config, declared)
+	if err != nil {
+		s.logger.Errorf("failed to sync declared services: %v", err)
+		return
+	}
+	s.lastHash = hash
+
+	s.logger.Infof(
+		"synced %d declared services: %d created, %d updated, %d deleted, %d released",
+		len(declared), res.Created, res.Updated, res.Deleted, res.Released,
+	)
+}
+
+// Sync creates missing declared services, updates changed ones and, with prune enabled,
+// deletes services of the file that are no longer declared. Without prune such services are released
+// from the file and become editable in the API. Services created in the API or imported are kept.
+// Nothing is changed when any declared service is invalid.
+func Sync(ctx context.Context, monitorService *monitor.MonitorService, cfg *config.Config, declared []storage.CreateUpdateServiceRequest) (Result, error) {
+	res := Result{}
+
+	desired := make([]storage.CreateUpdateServiceRequest, 0, len(declared))
+	for _, item := range declared {
+		req, err := normalize(item, cfg.Monitoring.Global)
+		if err != nil {
+			return res, fmt.Errorf("invalid service %s: %w", item.Name, err)
+		}
+		req.ManagedBy = storage.ServiceManagedByFile
+		desired = append(desired, req)
+	}
+
+	existing, err := findAllServices(ctx, monitorService)
+	if err != nil {
+		return res, err
+	}
+
+	matched := map[string]bool{}
+	for _, req := range desired {
+		svc := findByName(existing, req.Name)
+		if svc == nil {
+			if _, err := monitorService.CreateService(ctx, req); err != nil {
+				return res, err
+			}
+			res.Created++
+			continue
+		}
+		matched[svc.ID] = true
+
+		current, err := requestFromService(svc)
+		if err != nil {
+			return res, fmt.Errorf("invalid stored service %s: %w", svc.Name, err)
+		}
+
+		fields, err := changedFields(current, req)
+		if err != nil {
+			return res, err
+		}
+		if len(fields) == 0 && current.ManagedBy == req.ManagedBy {
+			continue
+		}
+
+		if _, err := monitorService.UpdateService(ctx, svc.ID, req); err != nil {
+			return res, err
+		}
+		res.Updated++
+	}
+
+	for _, svc := range existing {
+		if matched[svc.ID] || svc.ManagedBy != storage.ServiceManagedByFile {
+			continue
+		}
+
+		if cfg.ServicesSync.Prune {
+			if err := monitorService.DeleteService(ctx, svc.ID); err != nil {
+				return res, err
+			}
+			res.Deleted++
+			continue
+		}
+
+		req, err := requestFromService(svc)
+		if err != nil {
+			return res, fmt.Errorf("invalid stored service %s: %w", svc.Name, err)
+		}
+		req.ManagedBy = ""
+
+		if _, err := monitorService.UpdateService(ctx, svc.ID, req); err != nil {
+			return res, err
+		}
+		res.Released++
+	}
+
+	return res, nil
+}
+
+// normalize validates a declared service, applies global defaults and
+// converts it to the form services are stored in
+func normalize(req storage.CreateUpdateServiceRequest, defaults config.GlobalConfig) (storage.CreateUpdateServiceRequest, error) {
+	if req.Interval == 0 {
+		req.Interval = defaults.DefaultInterval
+	}
+	if req.Timeout == 0 {
+		req.Timeout = defaults.DefaultTimeout
+	}
+	if req.Retries == 0 {
+		req.Retries = defaults.DefaultRetries
+	}
+
+	conf, err := monitors.ConvertFromMap(req.Config)
+	if err != nil {
+		return req, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := conf.Validate(req.Protocol); err != nil {
+		return req, err
+	}
+	req.Config = conf.ConvertToMap()
+
+	if err := validatePolicies(req); err != nil {
+		return req, err
+	}
+
+	req.Tags = normalizeTags(req.Tags)
+
+	return req, nil
+}
+
+// validatePolicies checks the optional retry and threshold policies
+func validatePolicies(req storage.CreateUpdateServiceRequest) error {
+	if policy := req.RetryPolicy; policy != nil {
+		switch policy.Strategy {
+		case "", storage.RetryStrategyFixed, storage.RetryStrategyLinear, storage.RetryStrategyExponential:
+		default:
+			return fmt.Errorf("unsupported retry strategy: %s", policy.Strategy)
+		}
+
+		for _, class := range policy.RetryOn {
+			switch class {
+			case storage.CheckErrorClassTimeout, storage.CheckErrorClassConnection, storage.CheckErrorClassAssertion:
+			default:
+				return fmt.Errorf("unsupported retry error class: %s", class)
+			}
+		}
+	}
+
+	if policy := req.ThresholdPolicy; policy != nil {
+		for _, value := range []int{policy.FailThreshold, policy.RecoveryThreshold, policy.Window, policy.FlapThreshold, policy.FlapWindow} {
+			if value < 0 || value > 100 {
+				return fmt.Errorf("threshold policy values must be between 0 and 100")
+			}
+		}
+
+		if policy.Window > 0 && (policy.FailThreshold > policy.Window || policy.RecoveryThreshold > policy.Window) {
+			return fmt.Errorf("fail and recovery thresholds cannot exceed the window size %d", policy.Window)
+		}
+	}
+
+	return nil
+}
+
+// requestFromService converts a stored service to a request in normalized form
+func requestFromService(svc *storage.Service) (storage.CreateUpdateServiceRequest, error) {
+	req := storage.CreateUpdateServiceRequest{
+		Name:            svc.Name,
+		Protocol:        svc.Protocol,
+		Interval:        svc.Interval,
+		Timeout:         svc.Timeout,
+		Retries:         svc.Retries,
+		RetryPolicy:     svc.RetryPolicy,
+		ThresholdPolicy: svc.ThresholdPolicy,
+		LatencyWarning:  svc.LatencyWarning,
+		Tags:            normalizeTags(svc.Tags),
+		IsEnabled:       svc.IsEnabled,
+		ExternalID:      svc.ExternalID,
+		ManagedBy:       svc.ManagedBy,
+	}
+
+	conf, err := monitors.ConvertFromMap(svc.Config)
+	if err != nil {
+		return req, fmt.Errorf("failed to parse config: %w", err)
+	}
+	req.Config = conf.ConvertToMap()
+
+	return req, nil
+}
+
+// changedFields returns the sorted names of fields that differ between
+// a stored service and the desired one, both in normalized form
+func changedFields(current, desired storage.CreateUpdateServiceRequest) ([]string, error) {
+	currentFields, err := toFields(current)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredFields, err := toFields(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{}
+	for name, value := range desiredFields {
+		if !reflect.DeepEqual(currentFields[name], value) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range currentFields {
+		if _, ok := desiredFields[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	slices.Sort(fields)
+
+	return fields, nil
+}
+
+// toFields converts a service request to a map of its JSON fields
+func toFields(req storage.CreateUpdateServiceRequest) (map[string]any, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal service: %w", err)
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal service: %w", err)
+	}
+
+	// Decoded documents have empty maps where stored configs have none
+	fields["config"] = pruneEmpty(fields["config"])
+
+	return fields, nil
+}
+
+// pruneEmpty removes null values and empty maps from nested maps
+func pruneEmpty(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			item = pruneEmpty(item)
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = item
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = pruneEmpty(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// normalizeTags returns sorted tags, services are stored with sorted tags
+func normalizeTags(tags []string) []string {
+	res := slices.Clone(tags)
+	if res == nil {
+		res = []string{}
+	}
+	slices.Sort(res)
+	return res
+}
+
+// findByName returns the service with the name, preferring services managed by the file
+func findByName(services []*storage.Service, name string) *storage.Service {
+	var found *storage.Service
+	for _, svc := range services {
+		if svc.Name != name {
+			continue
+		}
+		if svc.ManagedBy == storage.ServiceManagedByFile {
+			return svc
+		}
+		if found == nil {
+			found = svc
+		}
+	}
+	return found
+}
+
+// findAllServices loads all services page by page
+func findAllServices(ctx context.Context, monitorService *monitor.MonitorService) ([]*storage.Service, error) {
+	services := []*storage.Service{}
+	for page := uint32(1); ; page++ {
+		res, err := monitorService.FindServices(ctx, storage.FindServicesParams{
+			OrderBy:  "created_at",
+			Page:     utils.Pointer(page),
+			PageSize: utils.Pointer(uint32(findPageSize)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find services: %w", err)
+		}
+
+		services = append(services, res.Items...)
+		if len(res.Items) < findPageSize {
+			return services, nil
+		}
+	}
+}
+
+// fingerprint returns a hash of declared services used to detect changes
+func fingerprint(services []storage.CreateUpdateServiceRequest) (string, error) {
+	data, err := json.Marshal(services)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,163 @@
+package servicesync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/monitor"
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+const configYAML = `
+services_sync:
+  path: %s
+services:
+  - name: api
+    protocol: tcp
+    interval: 30s
+    tags: [production, api]
+    config:
+      tcp:
+        endpoint: api.example.com:443
+`
+
+const servicesYAML = `
+services:
+  - name: web
+    protocol: http
+    config:
+      http:
+        timeout: 5000
+        endpoints:
+          - name: home
+            url: https://example.com
+            method: GET
+            expected_status: 200
+`
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	servicesDir := filepath.Join(dir, "services.d")
+	require.NoError(t, os.MkdirAll(servicesDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(servicesDir, "web.yaml"), []byte(servicesYAML), 0o644))
+
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf(configYAML, servicesDir)), 0o644))
+
+	cfg, err := config.Load(configPath)
+	require.NoError(t, err)
+	require.True(t, cfg.ServicesSyncEnabled())
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(dir, "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	monitorService := monitor.NewMonitorService(store, cfg, nil, rc)
+
+	// A service created through the API
+	manual, err := monitorService.CreateService(ctx, storage.CreateUpdateServiceRequest{
+		Name:      "manual",
+		Protocol:  storage.ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Retries:   1,
+		Tags:      []string{},
+		Config:    map[string]any{"tcp": map[string]any{"endpoint": "db.example.com:5432"}},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	declared, err := config.LoadServices(configPath, cfg.ServicesSync)
+	require.NoError(t, err)
+	require.Len(t, declared, 2)
+
+	res, err := Sync(ctx, monitorService, cfg, declared)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Created: 2}, res)
+
+	services, err := findAllServices(ctx, monitorService)
+	require.NoError(t, err)
+	require.Len(t, services, 3)
+
+	api := findByName(services, "api")
+	require.NotNil(t, api)
+	assert.Equal(t, storage.ServiceManagedByFile, api.ManagedBy)
+	assert.True(t, api.IsEnabled)
+	assert.Equal(t, 30*time.Second, api.Interval)
+	assert.Equal(t, cfg.Monitoring.Global.DefaultTimeout, api.Timeout)
+	assert.Equal(t, []string{"api", "production"}, api.Tags)
+
+	// Unchanged services are not updated
+	res, err = Sync(ctx, monitorService, cfg, declared)
+	require.NoError(t, err)
+	assert.Equal(t, Result{}, res)
+
+	// Changed services are updated
+	declared[0].Interval = time.Minute
+	res, err = Sync(ctx, monitorService, cfg, declared)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Updated: 1}, res)
+
+	api, err = store.GetServiceByID(ctx, api.ID)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, api.Interval)
+
+	// Invalid services prevent any changes, api is not released
+	invalid := slices.Concat(declared[1:], []storage.CreateUpdateServiceRequest{{Name: "broken", Protocol: storage.ServiceProtocolTypeTCP}})
+	_, err = Sync(ctx, monitorService, cfg, invalid)
+	require.Error(t, err)
+	api, err = store.GetServiceByID(ctx, api.ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.ServiceManagedByFile, api.ManagedBy)
+
+	// Without prune removed services are released
+	res, err = Sync(ctx, monitorService, cfg, declared[1:])
+	require.NoError(t, err)
+	assert.Equal(t, Result{Released: 1}, res)
+
+	api, err = store.GetServiceByID(ctx, api.ID)
+	require.NoError(t, err)
+	assert.Empty(t, api.ManagedBy)
+
+	// Released services are adopted again once declared
+	res, err = Sync(ctx, monitorService, cfg, declared)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Updated: 1}, res)
+
+	// With prune the services removed from the files are deleted, other services are kept
+	cfg.ServicesSync.Prune = true
+	res, err = Sync(ctx, monitorService, cfg, declared[1:])
+	require.NoError(t, err)
+	assert.Equal(t, Result{Deleted: 1}, res)
+
+	_, err = store.GetServiceByID(ctx, api.ID)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	manual, err = store.GetServiceByID(ctx, manual.ID)
+	require.NoError(t, err)
+	assert.Empty(t, manual.ManagedBy)
+}
+
+func TestLoadServicesDuplicateNames(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(servicesYAML), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yml"), []byte(servicesYAML), 0o644))
+
+	_, err := config.LoadServices("", config.ServicesSyncConfig{Path: dir})
+	require.ErrorContains(t, err, "declared more than once")
+}
@@ -0,0 +1,278 @@
+package servicesync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/monitor"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// DocumentVersion is the current version of the import and export format
+const DocumentVersion = 1
+
+// ErrInvalidDocument is returned when a document cannot be imported
+var ErrInvalidDocument = errors.New("invalid document")
+
+// Document is the import and export format of services.
+// Durations are written as strings, e.g. 30s, in both JSON and YAML.
+type Document struct {
+	Version  int                                  `json:"version" yaml:"version"`
+	Services []storage.CreateUpdateServiceRequest `json:"services" yaml:"services"`
+}
+
+// Format is the encoding of a document
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// ImportAction is what an import does with a service
+type ImportAction string
+
+const (
+	ImportActionCreate    ImportAction = "create"
+	ImportActionUpdate    ImportAction = "update"
+	ImportActionUnchanged ImportAction = "unchanged"
+)
+
+// ImportChange describes the import of a single service
+type ImportChange struct {
+	ExternalID string       `json:"external_id" example:"api-gateway"`
+	Name       string       `json:"name" example:"API Gateway"`
+	ServiceID  string       `json:"service_id,omitempty" example:"01K0AJ7Y0ZP7S5J1E9ZK4V1H2X"`
+	Action     ImportAction `json:"action" example:"update"`
+	Fields     []string     `json:"fields,omitempty" example:"interval,tags"` // changed fields of updated services
+}
+
+// ImportResult describes the changes made by an import, or that would be made by a dry run
+type ImportResult struct {
+	DryRun    bool           `json:"dry_run" example:"true"`
+	Created   int            `json:"created" example:"1"`
+	Updated   int            `json:"updated" example:"2"`
+	Unchanged int            `json:"unchanged" example:"10"`
+	Changes   []ImportChange `json:"changes"`
+	// Error stopped the import, the changes are the ones applied before it
+	Error string `json:"error,omitempty" example:"failed to create service web: service with external ID web already exists"`
+}
+
+// ParseFormat returns the format with the given name, JSON by default
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s", name)
+	}
+}
+
+// Marshal encodes the document in the format
+func (d Document) Marshal(format Format) ([]byte, error) {
+	data, err := yaml.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	if format == FormatYAML {
+		return data, nil
+	}
+
+	// Converted from YAML to keep durations human readable
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := json.Indent(buf, data, "", "  "); err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// ParseDocument decodes a JSON or YAML document
+func ParseDocument(data []byte) (Document, error) {
+	var doc Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return doc, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+
+	if doc.Version > DocumentVersion {
+		return doc, fmt.Errorf("%w: version %d is newer than supported version %d", ErrInvalidDocument, doc.Version, DocumentVersion)
+	}
+
+	return doc, nil
+}
+
+// Export returns all services ordered by name.
+// Services without an external ID are exported with their ID as the external ID.
+func Export(ctx context.Context, monitorService *monitor.MonitorService) (Document, error) {
+	doc := Document{
+		Version:  DocumentVersion,
+		Services: []storage.CreateUpdateServiceRequest{},
+	}
+
+	services, err := findAllServices(ctx, monitorService)
+	if err != nil {
+		return doc, err
+	}
+
+	slices.SortFunc(services, func(a, b *storage.Service) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, svc := range services {
+		req, err := requestFromService(svc)
+		if err != nil {
+			return doc, fmt.Errorf("invalid service %s: %w", svc.Name, err)
+		}
+
+		if req.ExternalID == "" {
+			req.ExternalID = svc.ID
+		}
+		req.ManagedBy = ""
+
+		// Only the config of the service protocol is exported
+		req.Config = map[string]any{string(req.Protocol): req.Config[string(req.Protocol)]}
+
+		doc.Services = append(doc.Services, req)
+	}
+
+	return doc, nil
+}
+
+// Import creates and updates services of the document matched by external ID.
+// Services without an external ID also match when their ID equals it, so exported
+// services can be imported back into the same instance.
+// Nothing is changed when any service is invalid or when dryRun is set. Services are then applied one by one,
+// if one fails the result holds the changes applied before it, so the import can be repeated once the error is fixed.
+func Import(ctx context.Context, monitorService *monitor.MonitorService, cfg *config.Config, doc Document, dryRun bool) (ImportResult, error) {
+	res := ImportResult{
+		DryRun:  dryRun,
+		Changes: []ImportChange{},
+	}
+
+	existing, err := findAllServices(ctx, monitorService)
+	if err != nil {
+		return res, err
+	}
+
+	type plannedChange struct {
+		change ImportChange
+		req    storage.CreateUpdateServiceRequest
+	}
+
+	// Plan all changes first, an invalid document is rejected as a whole
+	planned := make([]plannedChange, 0, len(doc.Services))
+	externalIDs := make([]string, 0, len(doc.Services))
+	for i, item := range doc.Services {
+		if item.ExternalID == "" {
+			return res, fmt.Errorf("%w: service at index %d: external_id is required", ErrInvalidDocument, i)
+		}
+		if slices.Contains(externalIDs, item.ExternalID) {
+			return res, fmt.Errorf("%w: external_id %s is used more than once", ErrInvalidDocument, item.ExternalID)
+		}
+		externalIDs = append(externalIDs, item.ExternalID)
+
+		if item.Name == "" {
+			return res, fmt.Errorf("%w: service %s: name is required", ErrInvalidDocument, item.ExternalID)
+		}
+
+		req, err := normalize(item, cfg.Monitoring.Global)
+		if err != nil {
+			return res, fmt.Errorf("%w: service %s: %w", ErrInvalidDocument, item.ExternalID, err)
+		}
+
+		change := ImportChange{
+			ExternalID: req.ExternalID,
+			Name:       req.Name,
+			Action:     ImportActionCreate,
+		}
+
+		svc := findByExternalID(existing, req.ExternalID)
+		if svc != nil {
+			if svc.ManagedBy != "" {
+				return res, fmt.Errorf("%w: service %s is managed by the services file and is read-only", ErrInvalidDocument, svc.Name)
+			}
+
+			current, err := requestFromService(svc)
+			if err != nil {
+				return res, fmt.Errorf("invalid stored service %s: %w", svc.Name, err)
+			}
+
+			fields, err := changedFields(current, req)
+			if err != nil {
+				return res, err
+			}
+
+			change.ServiceID = svc.ID
+			change.Action = ImportActionUnchanged
+			if len(fields) > 0 {
+				change.Action = ImportActionUpdate
+				change.Fields = fields
+			}
+		}
+
+		planned = append(planned, plannedChange{change: change, req: req})
+	}
+
+	for _, item := range planned {
+		change := item.change
+
+		switch change.Action {
+		case ImportActionCreate:
+			if !dryRun {
+				svc, err := monitorService.CreateService(ctx, item.req)
+				if err != nil {
+					return res, fmt.Errorf("failed to create service %s: %w", change.ExternalID, err)
+				}
+				change.ServiceID = svc.ID
+			}
+			res.Created++
+		case ImportActionUpdate:
+			if !dryRun {
+				if _, err := monitorService.UpdateService(ctx, change.ServiceID, item.req); err != nil {
+					return res, fmt.Errorf("failed to update service %s: %w", change.ExternalID, err)
+				}
+			}
+			res.Updated++
+		default:
+			res.Unchanged++
+		}
+
+		res.Changes = append(res.Changes, change)
+	}
+
+	return res, nil
+}
+
+// findByExternalID returns the service with the external ID or,
+// if the service has no external ID, with the ID equal to it
+func findByExternalID(services []*storage.Service, externalID string) *storage.Service {
+	for _, svc := range services {
+		if svc.ExternalID == externalID {
+			return svc
+		}
+	}
+
+	for _, svc := range services {
+		if svc.ExternalID == "" && svc.ID == externalID {
+			return svc
+		}
+	}
+
+	return nil
+}
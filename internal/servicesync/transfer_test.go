@@ -0,0 +1,193 @@
+package servicesync
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/monitor"
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// newMonitorService returns a monitor service with a new SQLite storage
+func newMonitorService(t *testing.T, cfg *config.Config) (*monitor.MonitorService, storage.Storage) {
+	t.Helper()
+	ctx := context.Background()
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	return monitor.NewMonitorService(store, cfg, nil, rc), store
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	staging, _ := newMonitorService(t, cfg)
+	production, productionStore := newMonitorService(t, cfg)
+
+	api, err := staging.CreateService(ctx, storage.CreateUpdateServiceRequest{
+		Name:            "api",
+		Protocol:        storage.ServiceProtocolTypeTCP,
+		Interval:        30 * time.Second,
+		Timeout:         5 * time.Second,
+		Retries:         2,
+		ThresholdPolicy: &storage.ThresholdPolicy{FailThreshold: 3, RecoveryThreshold: 2},
+		Tags:            []string{"production", "api"},
+		Config:          map[string]any{"tcp": map[string]any{"endpoint": "api.example.com:443"}},
+		IsEnabled:       true,
+		ExternalID:      "api",
+	})
+	require.NoError(t, err)
+
+	web, err := staging.CreateService(ctx, storage.CreateUpdateServiceRequest{
+		Name:     "web",
+		Protocol: storage.ServiceProtocolTypeHTTP,
+		Interval: time.Minute,
+		Timeout:  10 * time.Second,
+		Retries:  1,
+		Tags:     []string{},
+		Config: map[string]any{"http": map[string]any{
+			"timeout":   10000,
+			"endpoints": []any{map[string]any{"name": "home", "url": "https://example.com", "method": "GET", "expected_status": 200}},
+		}},
+	})
+	require.NoError(t, err)
+
+	doc, err := Export(ctx, staging)
+	require.NoError(t, err)
+	require.Len(t, doc.Services, 2)
+	assert.Equal(t, "api", doc.Services[0].ExternalID)
+	assert.Equal(t, web.ID, doc.Services[1].ExternalID, "services without external ID are exported with their ID")
+
+	// Importing the export into the same instance only stores the exported external IDs
+	res, err := Import(ctx, staging, cfg, doc, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Unchanged)
+	assert.Equal(t, []string{"external_id"}, res.Changes[1].Fields)
+
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		data, err := doc.Marshal(format)
+		require.NoError(t, err)
+
+		parsed, err := ParseDocument(data)
+		require.NoError(t, err, string(data))
+		assert.Equal(t, doc.Services[0].Interval, parsed.Services[0].Interval)
+
+		res, err := Import(ctx, staging, cfg, parsed, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Unchanged, string(data))
+	}
+
+	// Dry run does not change anything
+	res, err = Import(ctx, production, cfg, doc, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Created)
+
+	services, err := productionStore.FindServices(ctx, storage.FindServicesParams{})
+	require.NoError(t, err)
+	assert.Empty(t, services.Items)
+
+	res, err = Import(ctx, production, cfg, doc, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Created)
+
+	imported, err := productionStore.FindServices(ctx, storage.FindServicesParams{ExternalID: "api"})
+	require.NoError(t, err)
+	require.Len(t, imported.Items, 1)
+	assert.Equal(t, api.ThresholdPolicy, imported.Items[0].ThresholdPolicy)
+	assert.Equal(t, []string{"api", "production"}, imported.Items[0].Tags)
+	assert.Equal(t, api.Config["tcp"], imported.Items[0].Config["tcp"])
+
+	// Changes are matched by external ID and reported by field
+	doc.Services[0].Interval = time.Minute
+	doc.Services[0].Tags = []string{"api"}
+	res, err = Import(ctx, production, cfg, doc, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Updated)
+	assert.Equal(t, 1, res.Unchanged)
+	assert.Equal(t, ImportChange{
+		ExternalID: "api",
+		Name:       "api",
+		ServiceID:  imported.Items[0].ID,
+		Action:     ImportActionUpdate,
+		Fields:     []string{"interval", "tags"},
+	}, res.Changes[0])
+
+	// Invalid documents are rejected as a whole
+	doc.Services = append(doc.Services, storage.CreateUpdateServiceRequest{Name: "no external id", Protocol: storage.ServiceProtocolTypeTCP})
+	_, err = Import(ctx, production, cfg, doc, false)
+	require.ErrorIs(t, err, ErrInvalidDocument)
+}
+
+func TestImportFailure(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "db.sqlite")
+	store, err := storage.NewSQLiteStorage(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	monitorService := monitor.NewMonitorService(store, cfg, nil, rc)
+
+	// The storage fails to create the second service
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	_, err = db.ExecContext(ctx, `CREATE TRIGGER fail_insert BEFORE INSERT ON services WHEN NEW.name = 'broken' BEGIN SELECT RAISE(ABORT, 'disk full'); END`)
+	require.NoError(t, err)
+
+	service := func(name string) storage.CreateUpdateServiceRequest {
+		return storage.CreateUpdateServiceRequest{
+			Name:       name,
+			Protocol:   storage.ServiceProtocolTypeTCP,
+			Interval:   time.Minute,
+			Timeout:    5 * time.Second,
+			Retries:    1,
+			Tags:       []string{},
+			Config:     map[string]any{"tcp": map[string]any{"endpoint": name + ".example.com:443"}},
+			IsEnabled:  true,
+			ExternalID: name,
+		}
+	}
+
+	doc := Document{Version: DocumentVersion, Services: []storage.CreateUpdateServiceRequest{service("first"), service("broken"), service("last")}}
+
+	// The result holds the services applied before the error
+	res, err := Import(ctx, monitorService, cfg, doc, false)
+	require.ErrorContains(t, err, "failed to create service broken")
+	assert.Equal(t, 1, res.Created)
+	require.Len(t, res.Changes, 1)
+	assert.Equal(t, "first", res.Changes[0].ExternalID)
+	assert.NotEmpty(t, res.Changes[0].ServiceID)
+
+	// The import is repeated once the error is fixed
+	_, err = db.ExecContext(ctx, `DROP TRIGGER fail_insert`)
+	require.NoError(t, err)
+
+	res, err = Import(ctx, monitorService, cfg, doc, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Created)
+	assert.Equal(t, 1, res.Unchanged)
+}
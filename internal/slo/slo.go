@@ -0,0 +1,156 @@
+package slo
+
+import (
+	"context"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/notifier"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+// bucketRetention is how long hourly SLO check counts are kept, it covers the longest SLO window
+const bucketRetention = 31 * 24 * time.Hour
+
+// Evaluator counts good and total checks of SLOs and sends burn rate alerts
+type Evaluator struct {
+	logger    logger.Logger
+	config    config.SLOConfig
+	retention time.Duration
+	storage   storage.Storage
+	notifier  *notifier.Notifier
+
+	// lastRollup holds the time of the last check count update per SLO
+	lastRollup map[string]time.Time
+	stop       chan struct{}
+}
+
+// New creates a new SLO evaluator. Check counts of new SLOs are backfilled
+// from check results kept for the given retention.
+func New(l logger.Logger, cfg config.SLOConfig, retention time.Duration, store storage.Storage, notif *notifier.Notifier) *Evaluator {
+	return &Evaluator{
+		logger:     l,
+		config:     cfg,
+		retention:  retention,
+		storage:    store,
+		notifier:   notif,
+		lastRollup: make(map[string]time.Time),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Name returns the name of the service
+func (e *Evaluator) Name() string { return "slo-evaluator" }
+
+// Start evaluates SLOs periodically until the service is stopped
+func (e *Evaluator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.config.EvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		e.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-e.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the service
+func (e *Evaluator) Stop(_ context.Context) error {
+	close(e.stop)
+	return nil
+}
+
+// run evaluates all SLOs and purges expired check counts
+func (e *Evaluator) run(ctx context.Context) {
+	now := time.Now()
+
+	slos, err := e.storage.FindSLOs(ctx, storage.FindSLOsParams{})
+	if err != nil {
+		e.logger.Errorf("failed to find SLOs: %v", err)
+		return
+	}
+
+	lastRollup := make(map[string]time.Time, len(slos))
+	for _, slo := range slos {
+		if err := e.evaluate(ctx, slo, now); err != nil {
+			e.logger.Errorf("failed to evaluate SLO %s: %v", slo.Name, err)
+			if last, ok := e.lastRollup[slo.ID]; ok {
+				lastRollup[slo.ID] = last
+			}
+			continue
+		}
+		lastRollup[slo.ID] = now
+	}
+
+	// Updated SLOs are backfilled again, their check counts may have changed
+	e.lastRollup = lastRollup
+
+	deleted, err := e.storage.DeleteSLOBucketsBefore(ctx, now.Add(-bucketRetention))
+	if err != nil {
+		e.logger.Errorf("failed to purge SLO buckets: %v", err)
+	} else if deleted > 0 {
+		e.logger.Debugf("purged %d SLO buckets", deleted)
+	}
+}
+
+// evaluate updates check counts of an SLO and its burn rate alert state
+func (e *Evaluator) evaluate(ctx context.Context, slo *storage.SLO, now time.Time) error {
+	from, ok := e.lastRollup[slo.ID]
+	if !ok || slo.UpdatedAt.After(from) {
+		from = e.backfillStart(slo, now)
+	}
+
+	if err := e.storage.RollupSLO(ctx, slo, from, now); err != nil {
+		return err
+	}
+
+	if slo.BurnRateAlert == nil {
+		if slo.Alerting {
+			return e.storage.SetSLOAlerting(ctx, slo.ID, false)
+		}
+		return nil
+	}
+
+	status, err := e.storage.GetSLOStatus(ctx, slo, now)
+	if err != nil {
+		return err
+	}
+
+	// Multi-window alert: the long window confirms the burn is significant,
+	// the short window makes the alert resolve soon after the burn stops
+	threshold := slo.BurnRateAlert.Threshold
+	alerting := status.BurnRateShort >= threshold && status.BurnRateLong >= threshold
+	if alerting == slo.Alerting {
+		return nil
+	}
+
+	if err := e.storage.SetSLOAlerting(ctx, slo.ID, alerting); err != nil {
+		return err
+	}
+	slo.Alerting = alerting
+
+	if e.notifier == nil {
+		return nil
+	}
+
+	if alerting {
+		e.logger.Warnf("SLO %s error budget is burning: %.1fx", slo.Name, status.BurnRateLong)
+		return e.notifier.SendSLOAlert(status)
+	}
+
+	e.logger.Infof("SLO %s burn rate is back to normal", slo.Name)
+	return e.notifier.SendSLORecovery(status)
+}
+
+// backfillStart returns the first complete hour of check results available for the SLO window
+func (e *Evaluator) backfillStart(slo *storage.SLO, now time.Time) time.Time {
+	period := min(slo.Window.Duration(), e.retention)
+	return now.Add(-period).Truncate(time.Hour).Add(time.Hour)
+}
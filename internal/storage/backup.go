@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Backup writes a consistent snapshot of the database to dest while the storage is in use
+func (s *SQLiteStorage) Backup(ctx context.Context, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("backup file %s already exists", dest)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, "VACUUM INTO ?", dest); err != nil {
+		_ = os.Remove(dest)
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	return nil
+}
+
+// Backup is not supported, PostgreSQL databases are backed up with pg_dump
+func (s *PostgresStorage) Backup(_ context.Context, _ string) error {
+	return fmt.Errorf("%w: use pg_dump for PostgreSQL", ErrBackupNotSupported)
+}
+
+// SchemaVersion returns the schema version of the current migrations
+func SchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// ValidateSQLiteBackup checks the integrity of a SQLite backup and returns its schema version.
+// Backups of newer schema versions than supported are rejected.
+func ValidateSQLiteBackup(ctx context.Context, path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("failed to open backup: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return 0, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer db.Close()
+
+	var integrity string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return 0, fmt.Errorf("failed to check backup integrity: %w", err)
+	}
+	if integrity != "ok" {
+		return 0, fmt.Errorf("backup is corrupted: %s", integrity)
+	}
+
+	var version int
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to get backup schema version: %w", err)
+	}
+
+	if version == 0 {
+		return 0, errors.New("backup has no schema version")
+	}
+	if version > SchemaVersion() {
+		return 0, fmt.Errorf("backup schema version %d is newer than supported version %d", version, SchemaVersion())
+	}
+
+	return version, nil
+}
+
+// RestoreSQLite replaces the database at dbPath with a validated backup.
+// The replaced database is kept next to it with the .before-restore suffix.
+// The server must not be running.
+func RestoreSQLite(ctx context.Context, backupPath, dbPath string) (int, error) {
+	version, err := ValidateSQLiteBackup(ctx, backupPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// Copy first, the database is replaced by an atomic rename
+	tmpPath := dbPath + ".restore"
+	if err := copyFile(backupPath, tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return 0, fmt.Errorf("failed to copy backup: %w", err)
+	}
+
+	if _, err := os.Stat(dbPath); err == nil {
+		// Checkpoint the WAL into the replaced database so it stays usable on its own
+		if err := checkpointSQLite(ctx, dbPath); err != nil {
+			_ = os.Remove(tmpPath)
+			return 0, err
+		}
+
+		if err := os.Rename(dbPath, dbPath+".before-restore"); err != nil {
+			_ = os.Remove(tmpPath)
+			return 0, fmt.Errorf("failed to keep current database: %w", err)
+		}
+	}
+
+	// WAL files of the replaced database must not be applied to the backup
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return 0, fmt.Errorf("failed to remove %s file: %w", suffix, err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		return 0, fmt.Errorf("failed to restore database: %w", err)
+	}
+
+	return version, nil
+}
+
+// checkpointSQLite writes the WAL of a database into the database file
+func checkpointSQLite(ctx context.Context, dbPath string) error {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+
+	return nil
+}
+
+// copyFile copies a file and syncs it to disk
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRestoreSQLite(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "db.sqlite")
+
+	store, err := NewSQLiteStorage(dbPath)
+	require.NoError(t, err)
+
+	svc, err := store.CreateService(ctx, CreateUpdateServiceRequest{
+		Name:      "before backup",
+		Protocol:  ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Retries:   1,
+		Tags:      []string{},
+		Config:    map[string]any{},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	backupPath := filepath.Join(dir, "backups", "backup.sqlite")
+	require.NoError(t, store.Backup(ctx, backupPath))
+	require.Error(t, store.Backup(ctx, backupPath), "existing backup must not be overwritten")
+
+	// Changes after the backup are lost by the restore
+	require.NoError(t, store.DeleteService(ctx, svc.ID))
+	require.NoError(t, store.Stop(ctx))
+
+	version, err := ValidateSQLiteBackup(ctx, backupPath)
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion(), version)
+
+	version, err = RestoreSQLite(ctx, backupPath, dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion(), version)
+	assert.FileExists(t, dbPath+".before-restore")
+
+	store, err = NewSQLiteStorage(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	restored, err := store.GetServiceByID(ctx, svc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "before backup", restored.Name)
+}
+
+func TestValidateSQLiteBackup(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	t.Run("not a database", func(t *testing.T) {
+		path := filepath.Join(dir, "garbage.sqlite")
+		require.NoError(t, os.WriteFile(path, []byte("definitely not sqlite"), 0o644))
+		_, err := ValidateSQLiteBackup(ctx, path)
+		require.Error(t, err)
+	})
+
+	t.Run("newer schema", func(t *testing.T) {
+		path := filepath.Join(dir, "newer.sqlite")
+		store, err := NewSQLiteStorage(path)
+		require.NoError(t, err)
+		_, err = store.db.ExecContext(ctx, "INSERT INTO schema_version (version) VALUES (?)", SchemaVersion()+1)
+		require.NoError(t, err)
+		require.NoError(t, store.Stop(ctx))
+
+		_, err = ValidateSQLiteBackup(ctx, path)
+		require.ErrorContains(t, err, "newer than supported")
+	})
+}
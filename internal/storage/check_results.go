@@ -0,0 +1,553 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/sxwebdev/sentinel/internal/utils"
+	"github.com/sxwebdev/sentinel/pkg/dbutils"
+)
+
+// maxCheckResultsPageSize is the maximum number of check results returned at once
+const maxCheckResultsPageSize = 10000
+
+// CheckResultRow represents a database row for check results
+type CheckResultRow struct {
+	ID             string    `db:"id"`
+	ServiceID      string    `db:"service_id"`
+	CheckedAt      time.Time `db:"checked_at"`
+	Status         string    `db:"status"`
+	ResponseTimeNS int64     `db:"response_time_ns"`
+	Error          *string   `db:"error"`
+	Attempts       int       `db:"attempts"`
+	Endpoints      *string   `db:"endpoints"`
+}
+
+// SaveCheckResult saves the result of a single check
+func (o *ORMStorage) SaveCheckResult(ctx context.Context, result *CheckResult) error {
+	if result.ID == "" {
+		result.ID = GenerateULID()
+	}
+
+	var endpointsJSON *string
+	if len(result.Endpoints) > 0 {
+		data, err := json.Marshal(result.Endpoints)
+		if err != nil {
+			return fmt.Errorf("failed to marshal endpoints: %w", err)
+		}
+		endpointsJSON = utils.Pointer(string(data))
+	}
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("check_results")
+	ib.Cols("id", "service_id", "checked_at", "status", "response_time_ns", "error", "attempts", "endpoints")
+	ib.Values(
+		result.ID,
+		result.ServiceID,
+		result.CheckedAt.UTC(),
+		result.Status,
+		result.ResponseTime.Nanoseconds(),
+		result.Error,
+		result.Attempts,
+		endpointsJSON,
+	)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save check result: %w", err)
+	}
+
+	return nil
+}
+
+// FindCheckResultsParams holds filters for check results
+type FindCheckResultsParams struct {
+	ServiceID string
+	StartTime *time.Time
+	EndTime   *time.Time
+	Page      *uint32
+	PageSize  *uint32
+}
+
+func findCheckResultsBuilder(params FindCheckResultsParams, col ...string) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(col...)
+	sb.From("check_results cr")
+
+	if params.ServiceID != "" {
+		sb.Where(sb.Equal("cr.service_id", params.ServiceID))
+	}
+
+	if params.StartTime != nil {
+		sb.Where(sb.GreaterEqualThan("cr.checked_at", params.StartTime.UTC()))
+	}
+
+	if params.EndTime != nil {
+		sb.Where(sb.LessThan("cr.checked_at", params.EndTime.UTC()))
+	}
+
+	return sb
+}
+
+// FindCheckResults finds check results, latest first
+func (o *ORMStorage) FindCheckResults(ctx context.Context, params FindCheckResultsParams) (dbutils.FindResponseWithCount[*CheckResult], error) {
+	sb := findCheckResultsBuilder(params,
+		"cr.id",
+		"cr.service_id",
+		"cr.checked_at",
+		"cr.status",
+		"cr.response_time_ns",
+		"cr.error",
+		"cr.attempts",
+		"cr.endpoints",
+	)
+	sb.OrderBy("cr.checked_at").Desc()
+
+	res := dbutils.FindResponseWithCount[*CheckResult]{}
+
+	limit, offset, err := dbutils.Pagination(params.Page, params.PageSize, dbutils.WithMaxLimit(maxCheckResultsPageSize))
+	if err != nil {
+		return res, fmt.Errorf("failed to apply pagination: %w", err)
+	}
+
+	sb.Limit(int(limit)).Offset(int(offset))
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return res, fmt.Errorf("failed to query check results: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*CheckResult{}
+	for rows.Next() {
+		var row CheckResultRow
+		err := rows.Scan(
+			&row.ID,
+			&row.ServiceID,
+			&row.CheckedAt,
+			&row.Status,
+			&row.ResponseTimeNS,
+			&row.Error,
+			&row.Attempts,
+			&row.Endpoints,
+		)
+		if err != nil {
+			return res, fmt.Errorf("failed to scan check result: %w", err)
+		}
+
+		item, err := rowToCheckResult(&row)
+		if err != nil {
+			return res, err
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return res, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	var totalCount uint32
+	countQuery, countArgs := findCheckResultsBuilder(params, "COUNT(*)").Build()
+	if err := o.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount); err != nil {
+		return res, fmt.Errorf("failed to count check results: %w", err)
+	}
+
+	res.Count = totalCount
+	res.Items = items
+
+	return res, nil
+}
+
+// DeleteCheckResultsBefore deletes check results older than the given time
+func (o *ORMStorage) DeleteCheckResultsBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom("check_results")
+	db.Where(db.LessThan("checked_at", before.UTC()))
+
+	query, args := db.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete check results: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// FindCheckResultRollupsParams holds filters for check result rollups
+type FindCheckResultRollupsParams struct {
+	ServiceID  string
+	Resolution RollupResolution
+	StartTime  *time.Time
+	EndTime    *time.Time
+}
+
+// FindCheckResultRollups finds check result rollups ordered by bucket start
+func (o *ORMStorage) FindCheckResultRollups(ctx context.Context, params FindCheckResultRollupsParams) ([]*CheckResultRollup, error) {
+	if params.Resolution == "" {
+		return nil, fmt.Errorf("resolution is required")
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"r.service_id",
+		"r.resolution",
+		"r.bucket_start",
+		"r.total_checks",
+		"r.up_checks",
+		"r.degraded_checks",
+		"r.down_checks",
+		"r.min_response_time_ns",
+		"r.avg_response_time_ns",
+		"r.max_response_time_ns",
+		"r.p95_response_time_ns",
+		"r.p99_response_time_ns",
+	)
+	sb.From("check_result_rollups r")
+	sb.Where(sb.Equal("r.resolution", params.Resolution))
+
+	if params.ServiceID != "" {
+		sb.Where(sb.Equal("r.service_id", params.ServiceID))
+	}
+
+	if params.StartTime != nil {
+		sb.Where(sb.GreaterEqualThan("r.bucket_start", params.StartTime.UTC()))
+	}
+
+	if params.EndTime != nil {
+		sb.Where(sb.LessThan("r.bucket_start", params.EndTime.UTC()))
+	}
+
+	sb.OrderBy("r.bucket_start").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query check result rollups: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*CheckResultRollup{}
+	for rows.Next() {
+		var (
+			item                          CheckResultRollup
+			minNS, avgNS, maxNS, p95, p99 int64
+		)
+
+		err := rows.Scan(
+			&item.ServiceID,
+			&item.Resolution,
+			&item.BucketStart,
+			&item.TotalChecks,
+			&item.UpChecks,
+			&item.DegradedChecks,
+			&item.DownChecks,
+			&minNS,
+			&avgNS,
+			&maxNS,
+			&p95,
+			&p99,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan check result rollup: %w", err)
+		}
+
+		item.MinResponseTime = time.Duration(minNS)
+		item.AvgResponseTime = time.Duration(avgNS)
+		item.MaxResponseTime = time.Duration(maxNS)
+		item.P95ResponseTime = time.Duration(p95)
+		item.P99ResponseTime = time.Duration(p99)
+
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// RollupCheckResults aggregates check results into rollups of the given resolution.
+// All buckets overlapping the [from, to) range are recomputed from the raw check results.
+func (o *ORMStorage) RollupCheckResults(ctx context.Context, resolution RollupResolution, from, to time.Time) error {
+	bucketSize := resolution.Duration()
+	from = from.UTC().Truncate(bucketSize)
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("service_id", "checked_at", "status", "response_time_ns")
+	sb.From("check_results")
+	sb.Where(
+		sb.GreaterEqualThan("checked_at", from),
+		sb.LessThan("checked_at", to.UTC()),
+	)
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query check results: %w", err)
+	}
+	defer rows.Close()
+
+	type bucketKey struct {
+		serviceID string
+		start     time.Time
+	}
+
+	type bucket struct {
+		rollup        CheckResultRollup
+		responseTimes []int64
+	}
+
+	buckets := make(map[bucketKey]*bucket)
+	for rows.Next() {
+		var (
+			serviceID      string
+			checkedAt      time.Time
+			status         ServiceStatus
+			responseTimeNS int64
+		)
+		if err := rows.Scan(&serviceID, &checkedAt, &status, &responseTimeNS); err != nil {
+			return fmt.Errorf("failed to scan check result: %w", err)
+		}
+
+		key := bucketKey{serviceID: serviceID, start: checkedAt.UTC().Truncate(bucketSize)}
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{rollup: CheckResultRollup{
+				ServiceID:   serviceID,
+				Resolution:  resolution,
+				BucketStart: key.start,
+			}}
+			buckets[key] = b
+		}
+
+		b.rollup.TotalChecks++
+		switch status {
+		case StatusUp:
+			b.rollup.UpChecks++
+		case StatusDegraded:
+			b.rollup.DegradedChecks++
+		default:
+			b.rollup.DownChecks++
+		}
+		b.responseTimes = append(b.responseTimes, responseTimeNS)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, b := range buckets {
+		applyResponseTimeStats(&b.rollup, b.responseTimes)
+
+		if err := upsertCheckResultRollup(ctx, tx, &b.rollup); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCheckResultRollupsBefore deletes rollups of the given resolution older than the given time
+func (o *ORMStorage) DeleteCheckResultRollupsBefore(ctx context.Context, resolution RollupResolution, before time.Time) (int64, error) {
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom("check_result_rollups")
+	db.Where(
+		db.Equal("resolution", resolution),
+		db.LessThan("bucket_start", before.UTC()),
+	)
+
+	query, args := db.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete check result rollups: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// checkSummary holds aggregated check results of a period
+type checkSummary struct {
+	total           int64
+	available       int64
+	avgResponseTime time.Duration
+}
+
+// summarizeChecks aggregates check results of a service in the [start, end) range.
+// Complete hours are taken from hourly rollups, the rest from raw check results.
+func (o *ORMStorage) summarizeChecks(ctx context.Context, serviceID string, start, end time.Time) (checkSummary, error) {
+	start, end = start.UTC(), end.UTC()
+
+	firstHour := start.Truncate(time.Hour)
+	if firstHour.Before(start) {
+		firstHour = firstHour.Add(time.Hour)
+	}
+
+	// The rollup of the current hour is incomplete
+	rollupEnd := end.Truncate(time.Hour)
+	if currentHour := time.Now().UTC().Truncate(time.Hour); currentHour.Before(rollupEnd) {
+		rollupEnd = currentHour
+	}
+
+	var total, available, responseTimeSum int64
+
+	rawRanges := [][2]time.Time{{start, end}}
+	if firstHour.Before(rollupEnd) {
+		rawRanges = [][2]time.Time{{start, firstHour}, {rollupEnd, end}}
+
+		rollupQuery := `
+			SELECT COALESCE(SUM(total_checks), 0), COALESCE(SUM(up_checks + degraded_checks), 0),
+				COALESCE(SUM(avg_response_time_ns * total_checks), 0)
+			FROM check_result_rollups
+			WHERE service_id = ? AND resolution = ? AND bucket_start >= ? AND bucket_start < ?`
+		err := o.db.QueryRowContext(ctx, rollupQuery, serviceID, RollupResolutionHour, firstHour, rollupEnd).
+			Scan(&total, &available, &responseTimeSum)
+		if err != nil {
+			return checkSummary{}, fmt.Errorf("failed to query check result rollups: %w", err)
+		}
+	}
+
+	rawQuery := `
+		SELECT COUNT(*), COALESCE(SUM(CASE WHEN status IN (?, ?) THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(response_time_ns), 0)
+		FROM check_results
+		WHERE service_id = ? AND checked_at >= ? AND checked_at < ?`
+	for _, r := range rawRanges {
+		if !r[0].Before(r[1]) {
+			continue
+		}
+
+		var rawTotal, rawAvailable, rawSum int64
+		err := o.db.QueryRowContext(ctx, rawQuery, StatusUp, StatusDegraded, serviceID, r[0], r[1]).
+			Scan(&rawTotal, &rawAvailable, &rawSum)
+		if err != nil {
+			return checkSummary{}, fmt.Errorf("failed to query check results: %w", err)
+		}
+
+		total += rawTotal
+		available += rawAvailable
+		responseTimeSum += rawSum
+	}
+
+	summary := checkSummary{total: total, available: available}
+	if total > 0 {
+		summary.avgResponseTime = time.Duration(responseTimeSum / total)
+	}
+
+	return summary, nil
+}
+
+// upsertCheckResultRollup inserts or replaces a rollup bucket
+func upsertCheckResultRollup(ctx context.Context, tx *sqlTx, r *CheckResultRollup) error {
+	query := `
+		INSERT INTO check_result_rollups (
+			service_id, resolution, bucket_start, total_checks, up_checks, degraded_checks, down_checks,
+			min_response_time_ns, avg_response_time_ns, max_response_time_ns, p95_response_time_ns, p99_response_time_ns
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (service_id, resolution, bucket_start) DO UPDATE SET
+			total_checks = excluded.total_checks,
+			up_checks = excluded.up_checks,
+			degraded_checks = excluded.degraded_checks,
+			down_checks = excluded.down_checks,
+			min_response_time_ns = excluded.min_response_time_ns,
+			avg_response_time_ns = excluded.avg_response_time_ns,
+			max_response_time_ns = excluded.max_response_time_ns,
+			p95_response_time_ns = excluded.p95_response_time_ns,
+			p99_response_time_ns = excluded.p99_response_time_ns`
+
+	_, err := tx.ExecContext(ctx, query,
+		r.ServiceID,
+		r.Resolution,
+		r.BucketStart,
+		r.TotalChecks,
+		r.UpChecks,
+		r.DegradedChecks,
+		r.DownChecks,
+		r.MinResponseTime.Nanoseconds(),
+		r.AvgResponseTime.Nanoseconds(),
+		r.MaxResponseTime.Nanoseconds(),
+		r.P95ResponseTime.Nanoseconds(),
+		r.P99ResponseTime.Nanoseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert check result rollup: %w", err)
+	}
+
+	return nil
+}
+
+// applyResponseTimeStats sets min/avg/max and percentile response times of a rollup
+func applyResponseTimeStats(r *CheckResultRollup, responseTimes []int64) {
+	if len(responseTimes) == 0 {
+		return
+	}
+
+	slices.Sort(responseTimes)
+
+	var sum int64
+	for _, v := range responseTimes {
+		sum += v
+	}
+
+	r.MinResponseTime = time.Duration(responseTimes[0])
+	r.MaxResponseTime = time.Duration(responseTimes[len(responseTimes)-1])
+	r.AvgResponseTime = time.Duration(sum / int64(len(responseTimes)))
+	r.P95ResponseTime = time.Duration(percentile(responseTimes, 95))
+	r.P99ResponseTime = time.Duration(percentile(responseTimes, 99))
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+// rowToCheckResult converts a CheckResultRow to CheckResult
+func rowToCheckResult(row *CheckResultRow) (*CheckResult, error) {
+	result := &CheckResult{
+		ID:           row.ID,
+		ServiceID:    row.ServiceID,
+		CheckedAt:    row.CheckedAt,
+		Status:       ServiceStatus(row.Status),
+		ResponseTime: time.Duration(row.ResponseTimeNS),
+		Error:        row.Error,
+		Attempts:     row.Attempts,
+	}
+
+	if row.Endpoints != nil && *row.Endpoints != "" {
+		if err := json.Unmarshal([]byte(*row.Endpoints), &result.Endpoints); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal endpoints: %w", err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/utils"
+)
+
+func TestRollupCheckResults(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	svc, err := store.CreateService(ctx, CreateUpdateServiceRequest{
+		Name:      "test",
+		Protocol:  ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Retries:   1,
+		Tags:      []string{},
+		Config:    map[string]any{},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	bucket := time.Now().UTC().Truncate(time.Hour).Add(-2 * time.Hour)
+	for i := range 100 {
+		status := StatusUp
+		switch {
+		case i < 5:
+			status = StatusDown
+		case i < 10:
+			status = StatusDegraded
+		}
+
+		require.NoError(t, store.SaveCheckResult(ctx, &CheckResult{
+			ServiceID:    svc.ID,
+			CheckedAt:    bucket.Add(time.Duration(i) * 30 * time.Second),
+			Status:       status,
+			ResponseTime: time.Duration(i+1) * time.Millisecond,
+			Attempts:     1,
+		}))
+	}
+
+	// Second hour bucket
+	require.NoError(t, store.SaveCheckResult(ctx, &CheckResult{
+		ServiceID:    svc.ID,
+		CheckedAt:    bucket.Add(time.Hour),
+		Status:       StatusUp,
+		ResponseTime: 500 * time.Millisecond,
+		Attempts:     2,
+		Endpoints:    []EndpointCheck{{Name: "api", Success: true, Duration: 500 * time.Millisecond}},
+	}))
+
+	require.NoError(t, store.RollupCheckResults(ctx, RollupResolutionHour, bucket.Add(30*time.Minute), time.Now()))
+
+	rollups, err := store.FindCheckResultRollups(ctx, FindCheckResultRollupsParams{
+		ServiceID:  svc.ID,
+		Resolution: RollupResolutionHour,
+	})
+	require.NoError(t, err)
+	require.Len(t, rollups, 2)
+
+	first := rollups[0]
+	assert.True(t, first.BucketStart.Equal(bucket))
+	assert.Equal(t, 100, first.TotalChecks)
+	assert.Equal(t, 90, first.UpChecks)
+	assert.Equal(t, 5, first.DegradedChecks)
+	assert.Equal(t, 5, first.DownChecks)
+	assert.Equal(t, time.Millisecond, first.MinResponseTime)
+	assert.Equal(t, 100*time.Millisecond, first.MaxResponseTime)
+	assert.Equal(t, 50500*time.Microsecond, first.AvgResponseTime)
+	assert.Equal(t, 95*time.Millisecond, first.P95ResponseTime)
+	assert.Equal(t, 99*time.Millisecond, first.P99ResponseTime)
+
+	// Rolling up again replaces the buckets
+	require.NoError(t, store.RollupCheckResults(ctx, RollupResolutionHour, bucket, time.Now()))
+	rollups, err = store.FindCheckResultRollups(ctx, FindCheckResultRollupsParams{
+		ServiceID:  svc.ID,
+		Resolution: RollupResolutionHour,
+		StartTime:  utils.Pointer(bucket.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, 1, rollups[0].TotalChecks)
+
+	results, err := store.FindCheckResults(ctx, FindCheckResultsParams{
+		ServiceID: svc.ID,
+		StartTime: utils.Pointer(bucket.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Len(t, results.Items, 1)
+	assert.Equal(t, 2, results.Items[0].Attempts)
+	assert.Equal(t, []EndpointCheck{{Name: "api", Success: true, Duration: 500 * time.Millisecond}}, results.Items[0].Endpoints)
+
+	// Retention
+	deleted, err := store.DeleteCheckResultsBefore(ctx, bucket.Add(time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, deleted)
+
+	deleted, err = store.DeleteCheckResultRollupsBefore(ctx, RollupResolutionHour, bucket.Add(time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, deleted)
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// dialect holds the SQL differences between storage backends.
+// Queries are written with ? placeholders and SQLite syntax
+// for the parts that are not covered by the dialect.
+type dialect struct {
+	name string
+	// numberedPlaceholders rewrites ? placeholders to $1, $2, ...
+	numberedPlaceholders bool
+	migrations           []Migration
+	schemaVersionTable   string
+}
+
+var (
+	sqliteDialect = dialect{
+		name:               "sqlite",
+		migrations:         migrations,
+		schemaVersionTable: schemaVersionTable,
+	}
+
+	postgresDialect = dialect{
+		name:                 "postgres",
+		numberedPlaceholders: true,
+		migrations:           postgresMigrations,
+		schemaVersionTable:   postgresSchemaVersionTable,
+	}
+)
+
+// rebind rewrites ? placeholders outside of string literals to the dialect syntax
+func (d dialect) rebind(query string) string {
+	if !d.numberedPlaceholders || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var (
+		b        strings.Builder
+		n        int
+		inString bool
+	)
+	b.Grow(len(query) + 16)
+
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			inString = !inString
+		case r == '?' && !inString:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// jsonArrayElements returns a FROM clause item with the elements of a JSON array column
+// available as json_each.value
+func (d dialect) jsonArrayElements(column string) string {
+	if d.numberedPlaceholders {
+		return fmt.Sprintf("jsonb_array_elements_text(%s) AS json_each(value)", column)
+	}
+	return fmt.Sprintf("json_each(%s)", column)
+}
+
+// jsonArrayContains returns a condition matching JSON array columns containing the value
+func (d dialect) jsonArrayContains(column, placeholder string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE json_each.value = %s)", d.jsonArrayElements(column), placeholder)
+}
+
+// secondsSince returns an expression of the number of seconds elapsed since a time column
+func (d dialect) secondsSince(column string) string {
+	if d.numberedPlaceholders {
+		return fmt.Sprintf("EXTRACT(EPOCH FROM (NOW() - %s))", column)
+	}
+	return fmt.Sprintf("(strftime('%%s', 'now') - strftime('%%s', %s))", column)
+}
+
+// unixSeconds returns an expression of a time column as whole seconds since the Unix epoch.
+// SQLite keeps times as "2006-01-02 15:04:05.999999999 -0700 MST" strings, they are parsed up to the seconds with the zone offset.
+func (d dialect) unixSeconds(column string) string {
+	if d.numberedPlaceholders {
+		return fmt.Sprintf("FLOOR(EXTRACT(EPOCH FROM %s))", column)
+	}
+	offset := fmt.Sprintf("20 + instr(substr(%s, 20), ' ')", column)
+	return fmt.Sprintf(
+		"CAST(strftime('%%s', substr(%[1]s, 1, 19) || substr(%[1]s, %[2]s, 3) || ':' || substr(%[1]s, %[2]s + 3, 2)) AS INTEGER)",
+		column, offset,
+	)
+}
+
+// sqlDB wraps a connection pool and rewrites query placeholders for the dialect
+type sqlDB struct {
+	*sql.DB
+	dialect dialect
+}
+
+func (db *sqlDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return db.DB.ExecContext(ctx, db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.DB.QueryContext(ctx, db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.DB.QueryRowContext(ctx, db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sqlTx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlTx{Tx: tx, dialect: db.dialect}, nil
+}
+
+// sqlTx wraps a transaction and rewrites query placeholders for the dialect
+type sqlTx struct {
+	*sql.Tx
+	dialect dialect
+}
+
+func (tx *sqlTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.dialect.rebind(query), args...)
+}
+
+func (tx *sqlTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.dialect.rebind(query), args...)
+}
+
+func (tx *sqlTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.dialect.rebind(query), args...)
+}
+
+// isUniqueViolation reports whether the error is a unique constraint violation of any backend
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505"
+	}
+
+	return false
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebind(t *testing.T) {
+	query := `SELECT * FROM services WHERE name = ? AND protocol = '?' AND id IN (?, ?)`
+
+	assert.Equal(t, query, sqliteDialect.rebind(query))
+	assert.Equal(t,
+		`SELECT * FROM services WHERE name = $1 AND protocol = '?' AND id IN ($2, $3)`,
+		postgresDialect.rebind(query),
+	)
+}
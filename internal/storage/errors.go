@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrBackupNotSupported is returned by storages that are backed up with external tools
+	ErrBackupNotSupported = errors.New("backups are not supported by the storage")
 )
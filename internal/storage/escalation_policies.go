@@ -0,0 +1,318 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// EscalationPolicyRow represents a database row for escalation policies
+type EscalationPolicyRow struct {
+	ID             string    `db:"id"`
+	Name           string    `db:"name"`
+	Steps          string    `db:"steps"`
+	RepeatInterval *string   `db:"repeat_interval"`
+	ServiceIDs     string    `db:"service_ids"`
+	Tags           string    `db:"tags"`
+	Severities     string    `db:"severities"`
+	IsEnabled      bool      `db:"is_enabled"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// FindEscalationPoliciesParams holds filters for escalation policies
+type FindEscalationPoliciesParams struct {
+	IsEnabled *bool
+}
+
+// escalationPolicyValues returns the JSON encoded steps and conditions of a policy in the column order
+func escalationPolicyValues(policy *EscalationPolicy) ([]any, error) {
+	lists := []any{policy.Steps, policy.ServiceIDs, policy.Tags, policy.Severities}
+
+	values := make([]any, 0, len(lists))
+	for _, list := range lists {
+		data, err := json.Marshal(list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal escalation policy: %w", err)
+		}
+		if string(data) == "null" {
+			data = []byte("[]")
+		}
+		values = append(values, string(data))
+	}
+
+	return values, nil
+}
+
+// CreateEscalationPolicy creates a new escalation policy
+func (o *ORMStorage) CreateEscalationPolicy(ctx context.Context, policy *EscalationPolicy) error {
+	if policy.ID == "" {
+		policy.ID = GenerateULID()
+	}
+
+	values, err := escalationPolicyValues(policy)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	policy.CreatedAt = now
+	policy.UpdatedAt = now
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("escalation_policies")
+	ib.Cols("id", "name", "steps", "repeat_interval", "service_ids", "tags", "severities", "is_enabled", "created_at", "updated_at")
+	ib.Values(
+		policy.ID,
+		policy.Name,
+		values[0],
+		durationToString(policy.RepeatInterval),
+		values[1],
+		values[2],
+		values[3],
+		policy.IsEnabled,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create escalation policy: %w", err)
+	}
+
+	return nil
+}
+
+func findEscalationPoliciesBuilder(params FindEscalationPoliciesParams) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "name", "steps", "repeat_interval", "service_ids", "tags", "severities", "is_enabled", "created_at", "updated_at")
+	sb.From("escalation_policies")
+
+	if params.IsEnabled != nil {
+		sb.Where(sb.Equal("is_enabled", *params.IsEnabled))
+	}
+
+	return sb
+}
+
+// scanEscalationPolicy scans an escalation policy row
+func scanEscalationPolicy(scanner interface{ Scan(dest ...any) error }) (*EscalationPolicy, error) {
+	var row EscalationPolicyRow
+	err := scanner.Scan(
+		&row.ID,
+		&row.Name,
+		&row.Steps,
+		&row.RepeatInterval,
+		&row.ServiceIDs,
+		&row.Tags,
+		&row.Severities,
+		&row.IsEnabled,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &EscalationPolicy{
+		ID:        row.ID,
+		Name:      row.Name,
+		IsEnabled: row.IsEnabled,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	if row.RepeatInterval != nil && *row.RepeatInterval != "" {
+		policy.RepeatInterval, err = time.ParseDuration(*row.RepeatInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse repeat interval: %w", err)
+		}
+	}
+
+	lists := []struct {
+		data   string
+		target any
+	}{
+		{row.Steps, &policy.Steps},
+		{row.ServiceIDs, &policy.ServiceIDs},
+		{row.Tags, &policy.Tags},
+		{row.Severities, &policy.Severities},
+	}
+
+	for _, list := range lists {
+		if err := json.Unmarshal([]byte(list.data), list.target); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal escalation policy: %w", err)
+		}
+	}
+
+	return policy, nil
+}
+
+// GetEscalationPolicyByID gets an escalation policy by ID
+func (o *ORMStorage) GetEscalationPolicyByID(ctx context.Context, id string) (*EscalationPolicy, error) {
+	sb := findEscalationPoliciesBuilder(FindEscalationPoliciesParams{})
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	policy, err := scanEscalationPolicy(o.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get escalation policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// FindEscalationPolicies finds escalation policies ordered by name
+func (o *ORMStorage) FindEscalationPolicies(ctx context.Context, params FindEscalationPoliciesParams) ([]*EscalationPolicy, error) {
+	sb := findEscalationPoliciesBuilder(params)
+	sb.OrderBy("name", "id").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query escalation policies: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*EscalationPolicy{}
+	for rows.Next() {
+		policy, err := scanEscalationPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan escalation policy: %w", err)
+		}
+		items = append(items, policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// UpdateEscalationPolicy updates an escalation policy
+func (o *ORMStorage) UpdateEscalationPolicy(ctx context.Context, policy *EscalationPolicy) error {
+	values, err := escalationPolicyValues(policy)
+	if err != nil {
+		return err
+	}
+
+	policy.UpdatedAt = time.Now()
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("escalation_policies")
+	ub.Set(
+		ub.Assign("name", policy.Name),
+		ub.Assign("steps", values[0]),
+		ub.Assign("repeat_interval", durationToString(policy.RepeatInterval)),
+		ub.Assign("service_ids", values[1]),
+		ub.Assign("tags", values[2]),
+		ub.Assign("severities", values[3]),
+		ub.Assign("is_enabled", policy.IsEnabled),
+		ub.Assign("updated_at", policy.UpdatedAt),
+	)
+	ub.Where(ub.Equal("id", policy.ID))
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update escalation policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteEscalationPolicy deletes an escalation policy with the escalation state of its incidents
+func (o *ORMStorage) DeleteEscalationPolicy(ctx context.Context, id string) error {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM incident_escalations WHERE policy_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete incident escalations: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM escalation_policies WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete escalation policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindIncidentEscalations finds the escalation state of an incident by policy ID
+func (o *ORMStorage) FindIncidentEscalations(ctx context.Context, incidentID string) (map[string]*IncidentEscalation, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("incident_id", "policy_id", "level", "notified_at")
+	sb.From("incident_escalations")
+	sb.Where(sb.Equal("incident_id", incidentID))
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident escalations: %w", err)
+	}
+	defer rows.Close()
+
+	items := map[string]*IncidentEscalation{}
+	for rows.Next() {
+		var escalation IncidentEscalation
+		if err := rows.Scan(&escalation.IncidentID, &escalation.PolicyID, &escalation.Level, &escalation.NotifiedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan incident escalation: %w", err)
+		}
+		items[escalation.PolicyID] = &escalation
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// SaveIncidentEscalation creates or updates the escalation state of an incident by a policy
+func (o *ORMStorage) SaveIncidentEscalation(ctx context.Context, escalation *IncidentEscalation) error {
+	query := `
+		INSERT INTO incident_escalations (incident_id, policy_id, level, notified_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (incident_id, policy_id) DO UPDATE SET
+			level = excluded.level,
+			notified_at = excluded.notified_at`
+	if _, err := o.db.ExecContext(ctx, query, escalation.IncidentID, escalation.PolicyID, escalation.Level, escalation.NotifiedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to save incident escalation: %w", err)
+	}
+
+	return nil
+}
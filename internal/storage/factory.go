@@ -3,20 +3,36 @@ package storage
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // StorageType represents the type of storage backend
 type StorageType string
 
 const (
-	StorageTypeSQLite StorageType = "sqlite"
+	StorageTypeSQLite   StorageType = "sqlite"
+	StorageTypePostgres StorageType = "postgres"
 )
 
-// NewStorage creates a new storage instance based on the provided type and path
-func NewStorage(storageType StorageType, dbPath string) (Storage, error) {
+// Options holds storage connection settings
+type Options struct {
+	// Path is the SQLite database file
+	Path string
+	// DSN is the PostgreSQL connection string
+	DSN string
+	// Connection pool settings of PostgreSQL
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// NewStorage creates a new storage instance based on the provided type and options
+func NewStorage(storageType StorageType, opts Options) (Storage, error) {
 	switch strings.ToLower(string(storageType)) {
 	case string(StorageTypeSQLite):
-		return NewSQLiteStorage(dbPath)
+		return NewSQLiteStorage(opts.Path)
+	case string(StorageTypePostgres):
+		return NewPostgresStorage(opts)
 	default:
 		return nil, fmt.Errorf("unsupported storage type: %s", storageType)
 	}
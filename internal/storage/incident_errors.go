@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// RecordIncidentError records an error of an incident seen at the given time.
+// The occurrences of the latest error are counted while the error stays the same,
+// a new entry is added when the error changes.
+func (o *ORMStorage) RecordIncidentError(ctx context.Context, incidentID, message string, at time.Time) (*IncidentError, error) {
+	if incidentID == "" {
+		return nil, fmt.Errorf("incident ID is required")
+	}
+
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "incident_id", "error", "first_seen_at", "last_seen_at", "occurrences")
+	sb.From("incident_errors")
+	sb.Where(sb.Equal("incident_id", incidentID))
+	sb.OrderBy("first_seen_at DESC", "id DESC")
+	sb.Limit(1)
+
+	query, args := sb.Build()
+	var latest IncidentError
+	err = tx.QueryRowContext(ctx, query, args...).Scan(
+		&latest.ID,
+		&latest.IncidentID,
+		&latest.Error,
+		&latest.FirstSeenAt,
+		&latest.LastSeenAt,
+		&latest.Occurrences,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get latest incident error: %w", err)
+	}
+
+	var item *IncidentError
+	if err == nil && latest.Error == message {
+		latest.LastSeenAt = at
+		latest.Occurrences++
+
+		ub := sqlbuilder.NewUpdateBuilder()
+		ub.Update("incident_errors")
+		ub.Set(
+			ub.Assign("last_seen_at", latest.LastSeenAt),
+			ub.Assign("occurrences", latest.Occurrences),
+		)
+		ub.Where(ub.Equal("id", latest.ID))
+
+		query, args := ub.Build()
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("failed to update incident error: %w", err)
+		}
+
+		item = &latest
+	} else {
+		item = &IncidentError{
+			ID:          GenerateULID(),
+			IncidentID:  incidentID,
+			Error:       message,
+			FirstSeenAt: at,
+			LastSeenAt:  at,
+			Occurrences: 1,
+		}
+
+		ib := sqlbuilder.NewInsertBuilder()
+		ib.InsertInto("incident_errors")
+		ib.Cols("id", "incident_id", "error", "first_seen_at", "last_seen_at", "occurrences")
+		ib.Values(item.ID, item.IncidentID, item.Error, item.FirstSeenAt, item.LastSeenAt, item.Occurrences)
+
+		query, args := ib.Build()
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("failed to create incident error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return item, nil
+}
+
+// FindIncidentErrors returns the distinct errors of an incident in the order they were seen
+func (o *ORMStorage) FindIncidentErrors(ctx context.Context, incidentID string) ([]*IncidentError, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "incident_id", "error", "first_seen_at", "last_seen_at", "occurrences")
+	sb.From("incident_errors")
+	sb.Where(sb.Equal("incident_id", incidentID))
+	sb.OrderBy("first_seen_at", "id").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident errors: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*IncidentError{}
+	for rows.Next() {
+		var item IncidentError
+		err := rows.Scan(
+			&item.ID,
+			&item.IncidentID,
+			&item.Error,
+			&item.FirstSeenAt,
+			&item.LastSeenAt,
+			&item.Occurrences,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident error: %w", err)
+		}
+
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// AcknowledgeIncident marks an active incident as taken by a user.
+// ErrNotFound is returned when the incident does not exist or is already acknowledged or resolved.
+func (o *ORMStorage) AcknowledgeIncident(ctx context.Context, id, by string, at time.Time) error {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("incidents")
+	ub.Set(
+		ub.Assign("acknowledged_at", at),
+		ub.Assign("acknowledged_by", by),
+		ub.Assign("updated_at", time.Now()),
+	)
+	ub.Where(
+		ub.Equal("id", id),
+		ub.Equal("resolved", false),
+		ub.IsNull("acknowledged_at"),
+	)
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge incident: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateIncidentEvent adds an event to the incident timeline
+func (o *ORMStorage) CreateIncidentEvent(ctx context.Context, event *IncidentEvent) error {
+	if event.IncidentID == "" {
+		return fmt.Errorf("incident ID is required")
+	}
+
+	if event.ID == "" {
+		event.ID = GenerateULID()
+	}
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("incident_events")
+	ib.Cols("id", "incident_id", "type", "message", "author", "created_at")
+	ib.Values(event.ID, event.IncidentID, event.Type, event.Message, event.Author, event.CreatedAt)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create incident event: %w", err)
+	}
+
+	return nil
+}
+
+// FindIncidentEvents returns the timeline of an incident, oldest events first
+func (o *ORMStorage) FindIncidentEvents(ctx context.Context, incidentID string) ([]*IncidentEvent, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "incident_id", "type", "message", "author", "created_at")
+	sb.From("incident_events")
+	sb.Where(sb.Equal("incident_id", incidentID))
+	sb.OrderBy("created_at", "id").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident events: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*IncidentEvent{}
+	for rows.Next() {
+		var item IncidentEvent
+		err := rows.Scan(
+			&item.ID,
+			&item.IncidentID,
+			&item.Type,
+			&item.Message,
+			&item.Author,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident event: %w", err)
+		}
+
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
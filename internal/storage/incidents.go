@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -14,41 +15,52 @@ import (
 
 // IncidentRow represents a database row for incidents
 type IncidentRow struct {
-	ID         string     `db:"id"`
-	ServiceID  string     `db:"service_id"`
-	StartTime  time.Time  `db:"start_time"`
-	EndTime    *time.Time `db:"end_time"`
-	Error      string     `db:"error"`
-	DurationNS *int64     `db:"duration_ns"`
-	Resolved   bool       `db:"resolved"`
-	CreatedAt  time.Time  `db:"created_at"`
-	UpdatedAt  time.Time  `db:"updated_at"`
+	ID               string     `db:"id"`
+	ServiceID        string     `db:"service_id"`
+	StartTime        time.Time  `db:"start_time"`
+	EndTime          *time.Time `db:"end_time"`
+	Error            string     `db:"error"`
+	DurationNS       *int64     `db:"duration_ns"`
+	Resolved         bool       `db:"resolved"`
+	Severity         string     `db:"severity"`
+	Source           string     `db:"source"`
+	Title            string     `db:"title"`
+	AffectedServices string     `db:"affected_services"`
+	AcknowledgedAt   *time.Time `db:"acknowledged_at"`
+	AcknowledgedBy   string     `db:"acknowledged_by"`
+	Postmortem       *string    `db:"postmortem"`
+	AlertKey         string     `db:"alert_key"`
+	AlertSent        bool       `db:"alert_sent"`
+	RecoverySent     bool       `db:"recovery_sent"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
 }
 
-// GetIncidentByID retrieves an incident by ID
-func (o *ORMStorage) GetIncidentByID(ctx context.Context, id string) (*Incident, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
-	}
-
-	sb := sqlbuilder.NewSelectBuilder()
-	sb.Select(
-		"i.id",
-		"i.service_id",
-		"i.start_time",
-		"i.end_time",
-		"i.error",
-		"i.duration_ns",
-		"i.resolved",
-		"i.created_at",
-		"i.updated_at",
-	)
-	sb.From("incidents i")
-	sb.Where(sb.Equal("i.id", id))
-
-	query, args := sb.Build()
-	row := o.db.QueryRowContext(ctx, query, args...)
+// incidentColumns are the selected incident columns in the order of scanIncident
+var incidentColumns = []string{
+	"i.id",
+	"i.service_id",
+	"i.start_time",
+	"i.end_time",
+	"i.error",
+	"i.duration_ns",
+	"i.resolved",
+	"i.severity",
+	"i.source",
+	"i.title",
+	"i.affected_services",
+	"i.acknowledged_at",
+	"i.acknowledged_by",
+	"i.postmortem",
+	"i.alert_key",
+	"i.alert_sent",
+	"i.recovery_sent",
+	"i.created_at",
+	"i.updated_at",
+}
 
+// scanIncident scans a row of incidentColumns
+func (o *ORMStorage) scanIncident(row interface{ Scan(dest ...any) error }) (*Incident, error) {
 	var incidentRow IncidentRow
 	err := row.Scan(
 		&incidentRow.ID,
@@ -58,9 +70,39 @@ func (o *ORMStorage) GetIncidentByID(ctx context.Context, id string) (*Incident,
 		&incidentRow.Error,
 		&incidentRow.DurationNS,
 		&incidentRow.Resolved,
+		&incidentRow.Severity,
+		&incidentRow.Source,
+		&incidentRow.Title,
+		&incidentRow.AffectedServices,
+		&incidentRow.AcknowledgedAt,
+		&incidentRow.AcknowledgedBy,
+		&incidentRow.Postmortem,
+		&incidentRow.AlertKey,
+		&incidentRow.AlertSent,
+		&incidentRow.RecoverySent,
 		&incidentRow.CreatedAt,
 		&incidentRow.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.rowToIncident(&incidentRow)
+}
+
+// GetIncidentByID retrieves an incident by ID
+func (o *ORMStorage) GetIncidentByID(ctx context.Context, id string) (*Incident, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(incidentColumns...)
+	sb.From("incidents i")
+	sb.Where(sb.Equal("i.id", id))
+
+	query, args := sb.Build()
+	incident, err := o.scanIncident(o.db.QueryRowContext(ctx, query, args...))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -68,22 +110,30 @@ func (o *ORMStorage) GetIncidentByID(ctx context.Context, id string) (*Incident,
 		return nil, fmt.Errorf("failed to scan incident: %w", err)
 	}
 
-	return o.rowToIncident(&incidentRow), nil
+	return incident, nil
 }
 
 type FindIncidentsParams struct {
-	// Search by service id or incident id
-	Search    string
-	ID        string
+	// Search by service id, incident id or title
+	Search string
+	ID     string
+	// ServiceID matches incidents of the service and manual incidents affecting it
 	ServiceID string
 	Resolved  *bool
+	Severity  IncidentSeverity
+	Source    IncidentSource
+	AlertKey  string
 	StartTime *time.Time
 	EndTime   *time.Time
 	Page      *uint32
 	PageSize  *uint32
+
+	// AlertSent and RecoverySent filter by the sent notifications
+	AlertSent    *bool
+	RecoverySent *bool
 }
 
-func findIncidentsBuilder(params FindIncidentsParams, col ...string) *sqlbuilder.SelectBuilder {
+func (o *ORMStorage) findIncidentsBuilder(params FindIncidentsParams, col ...string) *sqlbuilder.SelectBuilder {
 	sb := sqlbuilder.NewSelectBuilder()
 	sb.Select(col...)
 	sb.From("incidents i")
@@ -93,7 +143,10 @@ func findIncidentsBuilder(params FindIncidentsParams, col ...string) *sqlbuilder
 	}
 
 	if params.ServiceID != "" {
-		sb.Where(sb.Equal("i.service_id", params.ServiceID))
+		sb.Where(sb.Or(
+			sb.Equal("i.service_id", params.ServiceID),
+			o.db.dialect.jsonArrayContains("i.affected_services", sb.Var(params.ServiceID)),
+		))
 	}
 
 	if params.Search != "" {
@@ -101,6 +154,7 @@ func findIncidentsBuilder(params FindIncidentsParams, col ...string) *sqlbuilder
 		sb.Where(sb.Or(
 			sb.Like("i.id", likeCondition),
 			sb.Like("i.service_id", likeCondition),
+			sb.Like("i.title", likeCondition),
 		))
 	}
 
@@ -108,6 +162,26 @@ func findIncidentsBuilder(params FindIncidentsParams, col ...string) *sqlbuilder
 		sb.Where(sb.Equal("i.resolved", *params.Resolved))
 	}
 
+	if params.Severity != "" {
+		sb.Where(sb.Equal("i.severity", params.Severity))
+	}
+
+	if params.Source != "" {
+		sb.Where(sb.Equal("i.source", params.Source))
+	}
+
+	if params.AlertKey != "" {
+		sb.Where(sb.Equal("i.alert_key", params.AlertKey))
+	}
+
+	if params.AlertSent != nil {
+		sb.Where(sb.Equal("i.alert_sent", *params.AlertSent))
+	}
+
+	if params.RecoverySent != nil {
+		sb.Where(sb.Equal("i.recovery_sent", *params.RecoverySent))
+	}
+
 	if params.StartTime != nil {
 		sb.Where(sb.GreaterEqualThan("i.start_time", *params.StartTime))
 	}
@@ -121,17 +195,7 @@ func findIncidentsBuilder(params FindIncidentsParams, col ...string) *sqlbuilder
 
 // FindIncidents finds incidents
 func (o *ORMStorage) FindIncidents(ctx context.Context, params FindIncidentsParams) (dbutils.FindResponseWithCount[*Incident], error) {
-	sb := findIncidentsBuilder(params,
-		"i.id",
-		"i.service_id",
-		"i.start_time",
-		"i.end_time",
-		"i.error",
-		"i.duration_ns",
-		"i.resolved",
-		"i.created_at",
-		"i.updated_at",
-	)
+	sb := o.findIncidentsBuilder(params, incidentColumns...)
 	sb.OrderBy("i.start_time").Desc()
 
 	res := dbutils.FindResponseWithCount[*Incident]{}
@@ -152,23 +216,12 @@ func (o *ORMStorage) FindIncidents(ctx context.Context, params FindIncidentsPara
 
 	incidents := []*Incident{}
 	for rows.Next() {
-		var incidentRow IncidentRow
-		err := rows.Scan(
-			&incidentRow.ID,
-			&incidentRow.ServiceID,
-			&incidentRow.StartTime,
-			&incidentRow.EndTime,
-			&incidentRow.Error,
-			&incidentRow.DurationNS,
-			&incidentRow.Resolved,
-			&incidentRow.CreatedAt,
-			&incidentRow.UpdatedAt,
-		)
+		incident, err := o.scanIncident(rows)
 		if err != nil {
 			return res, fmt.Errorf("failed to scan incident: %w", err)
 		}
 
-		incidents = append(incidents, o.rowToIncident(&incidentRow))
+		incidents = append(incidents, incident)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -177,7 +230,7 @@ func (o *ORMStorage) FindIncidents(ctx context.Context, params FindIncidentsPara
 
 	// Get total count of incidents
 	var totalCount uint32
-	countBuilder := findIncidentsBuilder(params, "COUNT(*)")
+	countBuilder := o.findIncidentsBuilder(params, "COUNT(*)")
 
 	countQuery, countArgs := countBuilder.Build()
 	err = o.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount)
@@ -195,7 +248,7 @@ func (o *ORMStorage) FindIncidents(ctx context.Context, params FindIncidentsPara
 func (o *ORMStorage) IncidentsCount(ctx context.Context, params FindIncidentsParams) (uint32, error) {
 	// Get total count of incidents
 	var totalCount uint32
-	countBuilder := findIncidentsBuilder(params, "COUNT(*)")
+	countBuilder := o.findIncidentsBuilder(params, "COUNT(*)")
 
 	countQuery, countArgs := countBuilder.Build()
 	err := o.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount)
@@ -206,7 +259,8 @@ func (o *ORMStorage) IncidentsCount(ctx context.Context, params FindIncidentsPar
 	return totalCount, nil
 }
 
-// ResolveAllIncidents resolves all incidents for a service
+// ResolveAllIncidents resolves all incidents opened by the monitor for a service,
+// manual incidents are only resolved by users
 func (o *ORMStorage) ResolveAllIncidents(ctx context.Context, serviceID string) ([]*Incident, error) {
 	if serviceID == "" {
 		return nil, fmt.Errorf("serviceID is required")
@@ -215,6 +269,7 @@ func (o *ORMStorage) ResolveAllIncidents(ctx context.Context, serviceID string)
 	items, err := o.FindIncidents(ctx, FindIncidentsParams{
 		ServiceID: serviceID,
 		Resolved:  utils.Pointer(false),
+		Source:    IncidentSourceMonitor,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to find incidents: %w", err)
@@ -269,9 +324,20 @@ func (o *ORMStorage) ResolveAllIncidents(ctx context.Context, serviceID string)
 
 // CreateIncident creates a new incident using ORM with retry logic
 func (o *ORMStorage) CreateIncident(ctx context.Context, incident *Incident) error {
+	affectedServicesJSON, err := marshalAffectedServices(incident.AffectedServices)
+	if err != nil {
+		return err
+	}
+
+	postmortemJSON, err := marshalNullableJSON(incident.Postmortem)
+	if err != nil {
+		return fmt.Errorf("failed to marshal postmortem: %w", err)
+	}
+
 	ib := sqlbuilder.NewInsertBuilder()
 	ib.InsertInto("incidents")
-	ib.Cols("id", "service_id", "start_time", "end_time", "error", "duration_ns", "resolved")
+	ib.Cols("id", "service_id", "start_time", "end_time", "error", "duration_ns", "resolved", "severity",
+		"source", "title", "affected_services", "acknowledged_at", "acknowledged_by", "postmortem", "alert_key", "alert_sent", "recovery_sent")
 
 	ib.Values(
 		incident.ID,
@@ -281,10 +347,20 @@ func (o *ORMStorage) CreateIncident(ctx context.Context, incident *Incident) err
 		incident.Error,
 		durationToNS(incident.Duration),
 		incident.Resolved,
+		incidentSeverityOrDefault(incident.Severity),
+		incidentSourceOrDefault(incident.Source),
+		incident.Title,
+		affectedServicesJSON,
+		incident.AcknowledgedAt,
+		incident.AcknowledgedBy,
+		postmortemJSON,
+		incident.AlertKey,
+		incident.AlertSent,
+		incident.RecoverySent,
 	)
 
 	sql, args := ib.Build()
-	_, err := o.db.ExecContext(ctx, sql, args...)
+	_, err = o.db.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return fmt.Errorf("failed to create incident: %w", err)
 	}
@@ -292,8 +368,15 @@ func (o *ORMStorage) CreateIncident(ctx context.Context, incident *Incident) err
 	return nil
 }
 
-// UpdateIncident updates an existing incident using ORM with retry logic
+// UpdateIncident updates an existing incident using ORM with retry logic.
+// The acknowledgement is only set by AcknowledgeIncident,
+// the title and postmortem by UpdateIncidentDetails.
 func (o *ORMStorage) UpdateIncident(ctx context.Context, incident *Incident) error {
+	affectedServicesJSON, err := marshalAffectedServices(incident.AffectedServices)
+	if err != nil {
+		return err
+	}
+
 	ub := sqlbuilder.NewUpdateBuilder()
 	ub.Update("incidents")
 	ub.Set(
@@ -303,12 +386,14 @@ func (o *ORMStorage) UpdateIncident(ctx context.Context, incident *Incident) err
 		ub.Assign("error", incident.Error),
 		ub.Assign("duration_ns", durationToNS(incident.Duration)),
 		ub.Assign("resolved", incident.Resolved),
+		ub.Assign("severity", incidentSeverityOrDefault(incident.Severity)),
+		ub.Assign("affected_services", affectedServicesJSON),
 		ub.Assign("updated_at", time.Now()),
 	)
 	ub.Where(ub.Equal("id", incident.ID))
 
 	sql, args := ub.Build()
-	_, err := o.db.ExecContext(ctx, sql, args...)
+	_, err = o.db.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update incident: %w", err)
 	}
@@ -316,18 +401,93 @@ func (o *ORMStorage) UpdateIncident(ctx context.Context, incident *Incident) err
 	return nil
 }
 
-// DeleteIncident deletes an incident by ID using ORM with retry logic
+// UpdateIncidentDetails updates the title and postmortem edited by users,
+// so checks updating the incident state do not overwrite them
+func (o *ORMStorage) UpdateIncidentDetails(ctx context.Context, incident *Incident) error {
+	postmortemJSON, err := marshalNullableJSON(incident.Postmortem)
+	if err != nil {
+		return fmt.Errorf("failed to marshal postmortem: %w", err)
+	}
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("incidents")
+	ub.Set(
+		ub.Assign("title", incident.Title),
+		ub.Assign("postmortem", postmortemJSON),
+		ub.Assign("updated_at", time.Now()),
+	)
+	ub.Where(ub.Equal("id", incident.ID))
+
+	sql, args := ub.Build()
+	res, err := o.db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update incident details: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateIncidentNotifications records the sent alert and recovery notifications of an incident
+func (o *ORMStorage) UpdateIncidentNotifications(ctx context.Context, incident *Incident) error {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("incidents")
+	ub.Set(
+		ub.Assign("alert_sent", incident.AlertSent),
+		ub.Assign("recovery_sent", incident.RecoverySent),
+		ub.Assign("updated_at", time.Now()),
+	)
+	ub.Where(ub.Equal("id", incident.ID))
+
+	sql, args := ub.Build()
+	if _, err := o.db.ExecContext(ctx, sql, args...); err != nil {
+		return fmt.Errorf("failed to update incident notifications: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteIncident deletes an incident by ID with its timeline, errors and escalation state
 func (o *ORMStorage) DeleteIncident(ctx context.Context, incidentID string) error {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM incident_events WHERE incident_id = ?`, incidentID); err != nil {
+		return fmt.Errorf("failed to delete incident events: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM incident_errors WHERE incident_id = ?`, incidentID); err != nil {
+		return fmt.Errorf("failed to delete incident errors: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM incident_escalations WHERE incident_id = ?`, incidentID); err != nil {
+		return fmt.Errorf("failed to delete incident escalations: %w", err)
+	}
+
 	db := sqlbuilder.NewDeleteBuilder()
 	db.DeleteFrom("incidents")
 	db.Where(db.Equal("id", incidentID))
 
 	sql, args := db.Build()
-	_, err := o.db.ExecContext(ctx, sql, args...)
-	if err != nil {
+	if _, err := tx.ExecContext(ctx, sql, args...); err != nil {
 		return fmt.Errorf("failed to delete incident: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -352,11 +512,12 @@ func (o *ORMStorage) GetIncidentsStatsByDateRange(ctx context.Context, startTime
 		dayEnd := time.Date(d.Year(), d.Month(), d.Day(), 23, 59, 59, 999999999, d.Location())
 
 		// Query incidents for this specific date range
+		ongoingDuration := o.db.dialect.secondsSince("start_time") + " * 1000000000"
 		sb := sqlbuilder.NewSelectBuilder()
 		sb.Select(
 			"COUNT(*) as count",
-			"AVG(CASE WHEN resolved = true THEN duration_ns ELSE (strftime('%s', 'now') - strftime('%s', start_time)) * 1000000000 END) as avg_duration",
-			"SUM(CASE WHEN resolved = true THEN duration_ns ELSE (strftime('%s', 'now') - strftime('%s', start_time)) * 1000000000 END) as total_duration",
+			"AVG(CASE WHEN resolved = true THEN duration_ns ELSE "+ongoingDuration+" END) as avg_duration",
+			"SUM(CASE WHEN resolved = true THEN duration_ns ELSE "+ongoingDuration+" END) as total_duration",
 		)
 		sb.From("incidents")
 		sb.Where(sb.GreaterEqualThan("start_time", dayStart))
@@ -392,3 +553,33 @@ func (o *ORMStorage) GetIncidentsStatsByDateRange(ctx context.Context, startTime
 
 	return result, nil
 }
+
+// incidentSourceOrDefault returns the incident source, incidents are opened by the monitor by default
+func incidentSourceOrDefault(source IncidentSource) IncidentSource {
+	if source == "" {
+		return IncidentSourceMonitor
+	}
+	return source
+}
+
+// marshalAffectedServices marshals affected services of an incident as a JSON array
+func marshalAffectedServices(serviceIDs []string) (string, error) {
+	if serviceIDs == nil {
+		serviceIDs = []string{}
+	}
+
+	data, err := json.Marshal(serviceIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal affected services: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// incidentSeverityOrDefault returns the incident severity, incidents are critical by default
+func incidentSeverityOrDefault(severity IncidentSeverity) IncidentSeverity {
+	if severity == "" {
+		return IncidentSeverityCritical
+	}
+	return severity
+}
@@ -1,7 +1,7 @@
 package storage
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 )
 
@@ -76,6 +76,344 @@ var migrations = []Migration{
 		CREATE INDEX IF NOT EXISTS idx_service_states_next_check ON service_states(next_check);
 		`,
 	},
+	{
+		Version: 2,
+		SQL: `
+		-- Add per-service retry policy
+		ALTER TABLE services ADD COLUMN retry_policy jsonb;
+		`,
+	},
+	{
+		Version: 3,
+		SQL: `
+		-- Add failure/recovery thresholds and flapping detection
+		ALTER TABLE services ADD COLUMN threshold_policy jsonb;
+		ALTER TABLE service_states ADD COLUMN recent_results TEXT NOT NULL DEFAULT '';
+		ALTER TABLE service_states ADD COLUMN is_flapping BOOLEAN NOT NULL DEFAULT FALSE;
+		`,
+	},
+	{
+		Version: 4,
+		SQL: `
+		-- Add degraded status support: latency warning threshold and incident severity
+		ALTER TABLE services ADD COLUMN latency_warning TEXT;
+		ALTER TABLE incidents ADD COLUMN severity TEXT NOT NULL DEFAULT 'critical';
+
+		CREATE INDEX IF NOT EXISTS idx_incidents_severity ON incidents(severity);
+		`,
+	},
+	{
+		Version: 5,
+		SQL: `
+		-- Create check_results table for the history of individual checks
+		CREATE TABLE IF NOT EXISTS check_results (
+			id TEXT PRIMARY KEY,
+			service_id TEXT NOT NULL REFERENCES services(id),
+			checked_at DATETIME NOT NULL,
+			status TEXT NOT NULL,
+			response_time_ns INTEGER NOT NULL DEFAULT 0,
+			error TEXT,
+			attempts INTEGER NOT NULL DEFAULT 1,
+			endpoints jsonb
+		);
+
+		-- Create check_result_rollups table for hourly and daily aggregates
+		CREATE TABLE IF NOT EXISTS check_result_rollups (
+			service_id TEXT NOT NULL REFERENCES services(id),
+			resolution TEXT NOT NULL,
+			bucket_start DATETIME NOT NULL,
+			total_checks INTEGER NOT NULL DEFAULT 0,
+			up_checks INTEGER NOT NULL DEFAULT 0,
+			degraded_checks INTEGER NOT NULL DEFAULT 0,
+			down_checks INTEGER NOT NULL DEFAULT 0,
+			min_response_time_ns INTEGER NOT NULL DEFAULT 0,
+			avg_response_time_ns INTEGER NOT NULL DEFAULT 0,
+			max_response_time_ns INTEGER NOT NULL DEFAULT 0,
+			p95_response_time_ns INTEGER NOT NULL DEFAULT 0,
+			p99_response_time_ns INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (service_id, resolution, bucket_start)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_check_results_service_checked_at ON check_results(service_id, checked_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_check_results_checked_at ON check_results(checked_at);
+		CREATE INDEX IF NOT EXISTS idx_check_result_rollups_bucket_start ON check_result_rollups(resolution, bucket_start);
+		`,
+	},
+	{
+		Version: 6,
+		SQL: `
+		-- Create service_pauses table for disabled periods and maintenance windows
+		CREATE TABLE IF NOT EXISTS service_pauses (
+			id TEXT PRIMARY KEY,
+			service_id TEXT NOT NULL REFERENCES services(id),
+			reason TEXT NOT NULL,
+			start_time DATETIME NOT NULL,
+			end_time DATETIME,
+			description TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_service_pauses_service_id ON service_pauses(service_id, reason);
+
+		-- Services disabled before the upgrade are paused since their last update
+		INSERT INTO service_pauses (id, service_id, reason, start_time)
+		SELECT lower(hex(randomblob(16))), id, 'disabled', COALESCE(updated_at, CURRENT_TIMESTAMP)
+		FROM services WHERE is_enabled = FALSE;
+		`,
+	},
+	{
+		Version: 7,
+		SQL: `
+		-- Create slos table for service level objectives
+		CREATE TABLE IF NOT EXISTS slos (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			service_id TEXT,
+			tag TEXT,
+			target REAL NOT NULL,
+			latency_target TEXT,
+			compliance_window TEXT NOT NULL,
+			burn_rate_alert TEXT,
+			alerting BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_slos_service_id ON slos(service_id);
+
+		-- Hourly good and total check counts of SLOs, kept longer than raw check results
+		CREATE TABLE IF NOT EXISTS slo_buckets (
+			slo_id TEXT NOT NULL,
+			bucket_start DATETIME NOT NULL,
+			total_checks INTEGER NOT NULL,
+			good_checks INTEGER NOT NULL,
+			PRIMARY KEY (slo_id, bucket_start)
+		);
+		`,
+	},
+	{
+		Version: 8,
+		SQL: `
+		-- Services declared in the services file are read-only in the API
+		ALTER TABLE services ADD COLUMN managed_by TEXT NOT NULL DEFAULT '';
+		`,
+	},
+	{
+		Version: 9,
+		SQL: `
+		-- Stable user supplied service IDs used by import and export
+		ALTER TABLE services ADD COLUMN external_id TEXT;
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_services_external_id ON services(external_id) WHERE external_id IS NOT NULL;
+		`,
+	},
+	{
+		Version: 10,
+		SQL: `
+		-- Incident acknowledgement and timeline
+		ALTER TABLE incidents ADD COLUMN acknowledged_at DATETIME;
+		ALTER TABLE incidents ADD COLUMN acknowledged_by TEXT NOT NULL DEFAULT '';
+
+		CREATE TABLE IF NOT EXISTS incident_events (
+			id TEXT PRIMARY KEY,
+			incident_id TEXT NOT NULL REFERENCES incidents(id),
+			type TEXT NOT NULL,
+			message TEXT NOT NULL DEFAULT '',
+			author TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_incident_events_incident_id ON incident_events(incident_id, created_at);
+		`,
+	},
+	{
+		Version: 11,
+		SQL: `
+		-- Manual incidents and postmortems
+		ALTER TABLE incidents ADD COLUMN source TEXT NOT NULL DEFAULT 'monitor';
+		ALTER TABLE incidents ADD COLUMN title TEXT NOT NULL DEFAULT '';
+		ALTER TABLE incidents ADD COLUMN affected_services jsonb NOT NULL DEFAULT '[]';
+		ALTER TABLE incidents ADD COLUMN postmortem jsonb;
+		`,
+	},
+	{
+		Version: 12,
+		SQL: `
+		-- Error transitions within incidents
+		CREATE TABLE IF NOT EXISTS incident_errors (
+			id TEXT PRIMARY KEY,
+			incident_id TEXT NOT NULL REFERENCES incidents(id),
+			error TEXT NOT NULL,
+			first_seen_at DATETIME NOT NULL,
+			last_seen_at DATETIME NOT NULL,
+			occurrences INTEGER NOT NULL DEFAULT 1
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_incident_errors_incident_id ON incident_errors(incident_id, first_seen_at);
+
+		-- The first error of existing incidents
+		INSERT INTO incident_errors (id, incident_id, error, first_seen_at, last_seen_at, occurrences)
+		SELECT id, id, error, start_time, start_time, 1 FROM incidents WHERE source = 'monitor';
+		`,
+	},
+	{
+		Version: 13,
+		SQL: `
+		-- Notification routing rules
+		CREATE TABLE IF NOT EXISTS notification_rules (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			channels jsonb NOT NULL DEFAULT '[]',
+			service_ids jsonb NOT NULL DEFAULT '[]',
+			tags jsonb NOT NULL DEFAULT '[]',
+			protocols jsonb NOT NULL DEFAULT '[]',
+			severities jsonb NOT NULL DEFAULT '[]',
+			events jsonb NOT NULL DEFAULT '[]',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		);
+		`,
+	},
+	{
+		Version: 14,
+		SQL: `
+		-- Notification channels managed through the API
+		CREATE TABLE IF NOT EXISTS notification_channels (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			urls jsonb NOT NULL DEFAULT '[]',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		);
+		`,
+	},
+	{
+		Version: 15,
+		SQL: `
+		-- Message templates of notification channels
+		ALTER TABLE notification_channels ADD COLUMN format TEXT NOT NULL DEFAULT 'text';
+		ALTER TABLE notification_channels ADD COLUMN templates jsonb NOT NULL DEFAULT '{}';
+		`,
+	},
+	{
+		Version: 16,
+		SQL: `
+		-- Notification outbox with a delivery per provider URL
+		CREATE TABLE IF NOT EXISTS notification_deliveries (
+			id TEXT PRIMARY KEY,
+			channel TEXT NOT NULL DEFAULT '',
+			event TEXT NOT NULL DEFAULT '',
+			service_id TEXT NOT NULL DEFAULT '',
+			incident_id TEXT NOT NULL DEFAULT '',
+			url TEXT NOT NULL,
+			message TEXT NOT NULL,
+			format TEXT NOT NULL DEFAULT 'text',
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			next_attempt_at DATETIME NOT NULL,
+			created_at DATETIME NOT NULL,
+			sent_at DATETIME
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due ON notification_deliveries(status, next_attempt_at);
+		CREATE INDEX IF NOT EXISTS idx_notification_deliveries_created_at ON notification_deliveries(created_at);
+		`,
+	},
+	{
+		Version: 17,
+		SQL: `
+		-- Escalation policies and escalation state of incidents
+		CREATE TABLE IF NOT EXISTS escalation_policies (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			steps jsonb NOT NULL DEFAULT '[]',
+			repeat_interval TEXT,
+			service_ids jsonb NOT NULL DEFAULT '[]',
+			tags jsonb NOT NULL DEFAULT '[]',
+			severities jsonb NOT NULL DEFAULT '[]',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS incident_escalations (
+			incident_id TEXT NOT NULL,
+			policy_id TEXT NOT NULL,
+			level INTEGER NOT NULL DEFAULT 0,
+			notified_at DATETIME NOT NULL,
+			PRIMARY KEY (incident_id, policy_id)
+		);
+		`,
+	},
+	{
+		Version: 18,
+		SQL: `
+		-- On-call schedules with weekly rotations and overrides
+		CREATE TABLE IF NOT EXISTS oncall_schedules (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			users jsonb NOT NULL DEFAULT '[]',
+			start_date TEXT NOT NULL,
+			handoff_time TEXT NOT NULL,
+			overrides jsonb NOT NULL DEFAULT '[]',
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		);
+		`,
+	},
+	{
+		Version: 19,
+		SQL: `
+		-- Outbound webhooks and their delivery log
+		CREATE TABLE IF NOT EXISTS webhooks (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			url TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			events jsonb NOT NULL DEFAULT '[]',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id TEXT PRIMARY KEY,
+			webhook_id TEXT NOT NULL,
+			event TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			response_status INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			next_attempt_at DATETIME NOT NULL,
+			created_at DATETIME NOT NULL,
+			sent_at DATETIME
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+		CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at);
+		`,
+	},
+	{
+		Version: 20,
+		SQL: `
+		-- Incidents of external alerts
+		ALTER TABLE incidents ADD COLUMN alert_key TEXT NOT NULL DEFAULT '';
+
+		CREATE INDEX IF NOT EXISTS idx_incidents_alert_key ON incidents(alert_key);
+		`,
+	},
+	{
+		Version: 21,
+		SQL: `
+		-- Sent notifications of incidents, to send recoveries muted while flapping
+		ALTER TABLE incidents ADD COLUMN alert_sent BOOLEAN NOT NULL DEFAULT FALSE;
+		ALTER TABLE incidents ADD COLUMN recovery_sent BOOLEAN NOT NULL DEFAULT FALSE;
+		`,
+	},
 }
 
 // schemaVersionTable creates the schema version tracking table
@@ -86,10 +424,12 @@ CREATE TABLE IF NOT EXISTS schema_version (
 );
 `
 
-// runMigrations runs all pending database migrations
-func runMigrations(db *sql.DB) error {
+// runMigrations runs all pending database migrations of the dialect
+func runMigrations(db *sqlDB) error {
+	ctx := context.Background()
+
 	// Create schema version table if it doesn't exist
-	if _, err := db.Exec(schemaVersionTable); err != nil {
+	if _, err := db.ExecContext(ctx, db.dialect.schemaVersionTable); err != nil {
 		return fmt.Errorf("failed to create schema version table: %w", err)
 	}
 
@@ -100,7 +440,7 @@ func runMigrations(db *sql.DB) error {
 	}
 
 	// Run pending migrations
-	for _, migration := range migrations {
+	for _, migration := range db.dialect.migrations {
 		if migration.Version > currentVersion {
 			if err := runMigration(db, migration); err != nil {
 				return fmt.Errorf("failed to run migration %d: %w", migration.Version, err)
@@ -112,9 +452,9 @@ func runMigrations(db *sql.DB) error {
 }
 
 // getCurrentSchemaVersion gets the current schema version from the database
-func getCurrentSchemaVersion(db *sql.DB) (int, error) {
+func getCurrentSchemaVersion(db *sqlDB) (int, error) {
 	var version int
-	err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+	err := db.QueryRowContext(context.Background(), "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
 	if err != nil {
 		return 0, err
 	}
@@ -122,21 +462,23 @@ func getCurrentSchemaVersion(db *sql.DB) (int, error) {
 }
 
 // runMigration runs a single migration
-func runMigration(db *sql.DB, migration Migration) error {
+func runMigration(db *sqlDB, migration Migration) error {
+	ctx := context.Background()
+
 	// Start transaction
-	tx, err := db.Begin()
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// Execute migration SQL
-	if _, err := tx.Exec(migration.SQL); err != nil {
+	if _, err := tx.Tx.ExecContext(ctx, migration.SQL); err != nil {
 		return fmt.Errorf("failed to execute migration SQL: %w", err)
 	}
 
 	// Record migration version
-	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", migration.Version); err != nil {
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_version (version) VALUES (?)", migration.Version); err != nil {
 		return fmt.Errorf("failed to record migration version: %w", err)
 	}
 
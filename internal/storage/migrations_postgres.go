@@ -0,0 +1,415 @@
+package storage
+
+// postgresMigrations contains all PostgreSQL database migrations in order.
+// Versions match the SQLite migrations.
+var postgresMigrations = []Migration{
+	{
+		Version: 1,
+		SQL: `
+		-- Create services table
+		CREATE TABLE IF NOT EXISTS services (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			protocol TEXT NOT NULL,
+			interval TEXT NOT NULL,
+			timeout TEXT NOT NULL,
+			retries INTEGER NOT NULL DEFAULT 3,
+			tags JSONB NOT NULL DEFAULT '[]',
+			config JSONB NOT NULL DEFAULT '{}',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+		);
+
+		-- Create incidents table
+		CREATE TABLE IF NOT EXISTS incidents (
+			id TEXT PRIMARY KEY,
+			service_id TEXT NOT NULL REFERENCES services(id),
+			start_time TIMESTAMPTZ NOT NULL,
+			end_time TIMESTAMPTZ,
+			error TEXT NOT NULL,
+			duration_ns BIGINT,
+			resolved BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+		);
+
+		-- Create service_states table for current service states
+		CREATE TABLE IF NOT EXISTS service_states (
+			id TEXT PRIMARY KEY,
+			service_id TEXT NOT NULL REFERENCES services(id),
+			status TEXT NOT NULL DEFAULT 'unknown',
+			last_check TIMESTAMPTZ,
+			next_check TIMESTAMPTZ,
+			last_error TEXT,
+			consecutive_fails INTEGER NOT NULL DEFAULT 0,
+			consecutive_success INTEGER NOT NULL DEFAULT 0,
+			total_checks BIGINT NOT NULL DEFAULT 0,
+			response_time_ns BIGINT,
+			created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(service_id)
+		);
+
+		-- Create indexes for performance
+		CREATE INDEX IF NOT EXISTS idx_services_name ON services(name);
+		CREATE INDEX IF NOT EXISTS idx_services_enabled ON services(is_enabled);
+		CREATE INDEX IF NOT EXISTS idx_services_created_at ON services(created_at DESC);
+
+		CREATE INDEX IF NOT EXISTS idx_incidents_service_id ON incidents(service_id);
+		CREATE INDEX IF NOT EXISTS idx_incidents_start_time_desc ON incidents(start_time DESC);
+		CREATE INDEX IF NOT EXISTS idx_incidents_resolved ON incidents(resolved);
+
+		CREATE INDEX IF NOT EXISTS idx_service_states_service_id ON service_states(service_id);
+		CREATE INDEX IF NOT EXISTS idx_service_states_status ON service_states(status);
+		CREATE INDEX IF NOT EXISTS idx_service_states_last_check ON service_states(last_check DESC);
+		CREATE INDEX IF NOT EXISTS idx_service_states_next_check ON service_states(next_check);
+		`,
+	},
+	{
+		Version: 2,
+		SQL: `
+		-- Add per-service retry policy
+		ALTER TABLE services ADD COLUMN IF NOT EXISTS retry_policy JSONB;
+		`,
+	},
+	{
+		Version: 3,
+		SQL: `
+		-- Add failure/recovery thresholds and flapping detection
+		ALTER TABLE services ADD COLUMN IF NOT EXISTS threshold_policy JSONB;
+		ALTER TABLE service_states ADD COLUMN IF NOT EXISTS recent_results TEXT NOT NULL DEFAULT '';
+		ALTER TABLE service_states ADD COLUMN IF NOT EXISTS is_flapping BOOLEAN NOT NULL DEFAULT FALSE;
+		`,
+	},
+	{
+		Version: 4,
+		SQL: `
+		-- Add degraded status support: latency warning threshold and incident severity
+		ALTER TABLE services ADD COLUMN IF NOT EXISTS latency_warning TEXT;
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS severity TEXT NOT NULL DEFAULT 'critical';
+
+		CREATE INDEX IF NOT EXISTS idx_incidents_severity ON incidents(severity);
+		`,
+	},
+	{
+		Version: 5,
+		SQL: `
+		-- Create check_results table for the history of individual checks
+		CREATE TABLE IF NOT EXISTS check_results (
+			id TEXT PRIMARY KEY,
+			service_id TEXT NOT NULL REFERENCES services(id),
+			checked_at TIMESTAMPTZ NOT NULL,
+			status TEXT NOT NULL,
+			response_time_ns BIGINT NOT NULL DEFAULT 0,
+			error TEXT,
+			attempts INTEGER NOT NULL DEFAULT 1,
+			endpoints JSONB
+		);
+
+		-- Create check_result_rollups table for hourly and daily aggregates
+		CREATE TABLE IF NOT EXISTS check_result_rollups (
+			service_id TEXT NOT NULL REFERENCES services(id),
+			resolution TEXT NOT NULL,
+			bucket_start TIMESTAMPTZ NOT NULL,
+			total_checks BIGINT NOT NULL DEFAULT 0,
+			up_checks BIGINT NOT NULL DEFAULT 0,
+			degraded_checks BIGINT NOT NULL DEFAULT 0,
+			down_checks BIGINT NOT NULL DEFAULT 0,
+			min_response_time_ns BIGINT NOT NULL DEFAULT 0,
+			avg_response_time_ns BIGINT NOT NULL DEFAULT 0,
+			max_response_time_ns BIGINT NOT NULL DEFAULT 0,
+			p95_response_time_ns BIGINT NOT NULL DEFAULT 0,
+			p99_response_time_ns BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (service_id, resolution, bucket_start)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_check_results_service_checked_at ON check_results(service_id, checked_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_check_results_checked_at ON check_results(checked_at);
+		CREATE INDEX IF NOT EXISTS idx_check_result_rollups_bucket_start ON check_result_rollups(resolution, bucket_start);
+		`,
+	},
+	{
+		Version: 6,
+		SQL: `
+		-- Create service_pauses table for disabled periods and maintenance windows
+		CREATE TABLE IF NOT EXISTS service_pauses (
+			id TEXT PRIMARY KEY,
+			service_id TEXT NOT NULL REFERENCES services(id),
+			reason TEXT NOT NULL,
+			start_time TIMESTAMPTZ NOT NULL,
+			end_time TIMESTAMPTZ,
+			description TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_service_pauses_service_id ON service_pauses(service_id, reason);
+
+		-- Services disabled before the upgrade are paused since their last update
+		INSERT INTO service_pauses (id, service_id, reason, start_time)
+		SELECT md5(random()::text || id), id, 'disabled', COALESCE(updated_at, CURRENT_TIMESTAMP)
+		FROM services WHERE is_enabled = FALSE;
+		`,
+	},
+	{
+		Version: 7,
+		SQL: `
+		-- Create slos table for service level objectives
+		CREATE TABLE IF NOT EXISTS slos (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			service_id TEXT,
+			tag TEXT,
+			target DOUBLE PRECISION NOT NULL,
+			latency_target TEXT,
+			compliance_window TEXT NOT NULL,
+			burn_rate_alert TEXT,
+			alerting BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_slos_service_id ON slos(service_id);
+
+		-- Hourly good and total check counts of SLOs, kept longer than raw check results
+		CREATE TABLE IF NOT EXISTS slo_buckets (
+			slo_id TEXT NOT NULL,
+			bucket_start TIMESTAMPTZ NOT NULL,
+			total_checks BIGINT NOT NULL,
+			good_checks BIGINT NOT NULL,
+			PRIMARY KEY (slo_id, bucket_start)
+		);
+		`,
+	},
+	{
+		Version: 8,
+		SQL: `
+		-- Services declared in the services file are read-only in the API
+		ALTER TABLE services ADD COLUMN IF NOT EXISTS managed_by TEXT NOT NULL DEFAULT '';
+		`,
+	},
+	{
+		Version: 9,
+		SQL: `
+		-- Stable user supplied service IDs used by import and export
+		ALTER TABLE services ADD COLUMN IF NOT EXISTS external_id TEXT;
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_services_external_id ON services(external_id) WHERE external_id IS NOT NULL;
+		`,
+	},
+	{
+		Version: 10,
+		SQL: `
+		-- Incident acknowledgement and timeline
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS acknowledged_at TIMESTAMPTZ;
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS acknowledged_by TEXT NOT NULL DEFAULT '';
+
+		CREATE TABLE IF NOT EXISTS incident_events (
+			id TEXT PRIMARY KEY,
+			incident_id TEXT NOT NULL REFERENCES incidents(id),
+			type TEXT NOT NULL,
+			message TEXT NOT NULL DEFAULT '',
+			author TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_incident_events_incident_id ON incident_events(incident_id, created_at);
+		`,
+	},
+	{
+		Version: 11,
+		SQL: `
+		-- Manual incidents and postmortems
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'monitor';
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS title TEXT NOT NULL DEFAULT '';
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS affected_services JSONB NOT NULL DEFAULT '[]';
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS postmortem JSONB;
+		`,
+	},
+	{
+		Version: 12,
+		SQL: `
+		-- Error transitions within incidents
+		CREATE TABLE IF NOT EXISTS incident_errors (
+			id TEXT PRIMARY KEY,
+			incident_id TEXT NOT NULL REFERENCES incidents(id),
+			error TEXT NOT NULL,
+			first_seen_at TIMESTAMPTZ NOT NULL,
+			last_seen_at TIMESTAMPTZ NOT NULL,
+			occurrences INTEGER NOT NULL DEFAULT 1
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_incident_errors_incident_id ON incident_errors(incident_id, first_seen_at);
+
+		-- The first error of existing incidents
+		INSERT INTO incident_errors (id, incident_id, error, first_seen_at, last_seen_at, occurrences)
+		SELECT id, id, error, start_time, start_time, 1 FROM incidents WHERE source = 'monitor';
+		`,
+	},
+	{
+		Version: 13,
+		SQL: `
+		-- Notification routing rules
+		CREATE TABLE IF NOT EXISTS notification_rules (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			channels JSONB NOT NULL DEFAULT '[]',
+			service_ids JSONB NOT NULL DEFAULT '[]',
+			tags JSONB NOT NULL DEFAULT '[]',
+			protocols JSONB NOT NULL DEFAULT '[]',
+			severities JSONB NOT NULL DEFAULT '[]',
+			events JSONB NOT NULL DEFAULT '[]',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+	},
+	{
+		Version: 14,
+		SQL: `
+		-- Notification channels managed through the API
+		CREATE TABLE IF NOT EXISTS notification_channels (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			urls JSONB NOT NULL DEFAULT '[]',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+	},
+	{
+		Version: 15,
+		SQL: `
+		-- Message templates of notification channels
+		ALTER TABLE notification_channels ADD COLUMN IF NOT EXISTS format TEXT NOT NULL DEFAULT 'text';
+		ALTER TABLE notification_channels ADD COLUMN IF NOT EXISTS templates JSONB NOT NULL DEFAULT '{}';
+		`,
+	},
+	{
+		Version: 16,
+		SQL: `
+		-- Notification outbox with a delivery per provider URL
+		CREATE TABLE IF NOT EXISTS notification_deliveries (
+			id TEXT PRIMARY KEY,
+			channel TEXT NOT NULL DEFAULT '',
+			event TEXT NOT NULL DEFAULT '',
+			service_id TEXT NOT NULL DEFAULT '',
+			incident_id TEXT NOT NULL DEFAULT '',
+			url TEXT NOT NULL,
+			message TEXT NOT NULL,
+			format TEXT NOT NULL DEFAULT 'text',
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			next_attempt_at TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			sent_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due ON notification_deliveries(status, next_attempt_at);
+		CREATE INDEX IF NOT EXISTS idx_notification_deliveries_created_at ON notification_deliveries(created_at);
+		`,
+	},
+	{
+		Version: 17,
+		SQL: `
+		-- Escalation policies and escalation state of incidents
+		CREATE TABLE IF NOT EXISTS escalation_policies (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			steps JSONB NOT NULL DEFAULT '[]',
+			repeat_interval TEXT,
+			service_ids JSONB NOT NULL DEFAULT '[]',
+			tags JSONB NOT NULL DEFAULT '[]',
+			severities JSONB NOT NULL DEFAULT '[]',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS incident_escalations (
+			incident_id TEXT NOT NULL,
+			policy_id TEXT NOT NULL,
+			level INTEGER NOT NULL DEFAULT 0,
+			notified_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (incident_id, policy_id)
+		);
+		`,
+	},
+	{
+		Version: 18,
+		SQL: `
+		-- On-call schedules with weekly rotations and overrides
+		CREATE TABLE IF NOT EXISTS oncall_schedules (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			users JSONB NOT NULL DEFAULT '[]',
+			start_date TEXT NOT NULL,
+			handoff_time TEXT NOT NULL,
+			overrides JSONB NOT NULL DEFAULT '[]',
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+	},
+	{
+		Version: 19,
+		SQL: `
+		-- Outbound webhooks and their delivery log
+		CREATE TABLE IF NOT EXISTS webhooks (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			url TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			events JSONB NOT NULL DEFAULT '[]',
+			is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id TEXT PRIMARY KEY,
+			webhook_id TEXT NOT NULL,
+			event TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			response_status INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			next_attempt_at TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			sent_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+		CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at);
+		`,
+	},
+	{
+		Version: 20,
+		SQL: `
+		-- Incidents of external alerts
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS alert_key TEXT NOT NULL DEFAULT '';
+
+		CREATE INDEX IF NOT EXISTS idx_incidents_alert_key ON incidents(alert_key);
+		`,
+	},
+	{
+		Version: 21,
+		SQL: `
+		-- Sent notifications of incidents, to send recoveries muted while flapping
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS alert_sent BOOLEAN NOT NULL DEFAULT FALSE;
+		ALTER TABLE incidents ADD COLUMN IF NOT EXISTS recovery_sent BOOLEAN NOT NULL DEFAULT FALSE;
+		`,
+	},
+}
+
+// postgresSchemaVersionTable creates the PostgreSQL schema version tracking table
+const postgresSchemaVersionTable = `
+CREATE TABLE IF NOT EXISTS schema_version (
+	version INTEGER PRIMARY KEY,
+	applied_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+`
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"slices"
 	"time"
 
 	"github.com/oklog/ulid/v2"
@@ -14,6 +15,9 @@ const (
 	ServiceProtocolTypeGRPC ServiceProtocolType = "grpc"
 )
 
+// ServiceManagedByFile marks services declared in the services file, they are read-only in the API
+const ServiceManagedByFile = "file"
+
 // serviceRow represents a database row for services
 type serviceRow struct {
 	ID                 string
@@ -22,9 +26,14 @@ type serviceRow struct {
 	Interval           string
 	Timeout            string
 	Retries            int
+	RetryPolicy        *string
+	ThresholdPolicy    *string
+	LatencyWarning     *string
 	Tags               string
 	Config             string
 	IsEnabled          bool
+	ManagedBy          string
+	ExternalID         *string
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 	ActiveIncidents    int
@@ -37,6 +46,7 @@ type serviceRow struct {
 	ConsecutiveSuccess int
 	TotalChecks        int
 	ResponseTimeNS     *int64
+	IsFlapping         bool
 }
 
 // Service represents a monitored service
@@ -47,9 +57,14 @@ type Service struct {
 	Interval           time.Duration       `json:"interval" swaggertype:"primitive,integer"`
 	Timeout            time.Duration       `json:"timeout" swaggertype:"primitive,integer"`
 	Retries            int                 `json:"retries"`
+	RetryPolicy        *RetryPolicy        `json:"retry_policy,omitempty"`
+	ThresholdPolicy    *ThresholdPolicy    `json:"threshold_policy,omitempty"`
+	LatencyWarning     time.Duration       `json:"latency_warning,omitempty" swaggertype:"primitive,integer"`
 	Tags               []string            `json:"tags"`
 	Config             map[string]any      `json:"config"`
 	IsEnabled          bool                `json:"is_enabled"`
+	ManagedBy          string              `json:"managed_by,omitempty"`
+	ExternalID         string              `json:"external_id,omitempty"`
 	CreatedAt          time.Time           `json:"created_at"`
 	UpdatedAt          time.Time           `json:"updated_at"`
 	ActiveIncidents    int                 `json:"active_incidents,omitempty"`
@@ -62,6 +77,54 @@ type Service struct {
 	ConsecutiveSuccess int                 `json:"consecutive_success"`
 	TotalChecks        int                 `json:"total_checks"`
 	ResponseTime       *time.Duration      `json:"response_time" swaggertype:"primitive,integer"`
+	IsFlapping         bool                `json:"is_flapping"`
+}
+
+// RetryStrategy represents the backoff strategy between check attempts
+type RetryStrategy string
+
+const (
+	RetryStrategyFixed       RetryStrategy = "fixed"
+	RetryStrategyLinear      RetryStrategy = "linear"
+	RetryStrategyExponential RetryStrategy = "exponential"
+)
+
+// CheckErrorClass represents the kind of a failed check
+type CheckErrorClass string
+
+const (
+	// CheckErrorClassTimeout is a check that did not complete in time
+	CheckErrorClassTimeout CheckErrorClass = "timeout"
+	// CheckErrorClassConnection is a network or transport level failure
+	CheckErrorClassConnection CheckErrorClass = "connection"
+	// CheckErrorClassAssertion is a check that completed but returned an unexpected result
+	CheckErrorClassAssertion CheckErrorClass = "assertion"
+)
+
+// RetryPolicy describes how failed check attempts are retried.
+// The number of attempts is limited by the service Retries field.
+type RetryPolicy struct {
+	Strategy  RetryStrategy     `json:"strategy" yaml:"strategy"`
+	BaseDelay time.Duration     `json:"base_delay" yaml:"base_delay" swaggertype:"primitive,integer"`
+	MaxDelay  time.Duration     `json:"max_delay" yaml:"max_delay" swaggertype:"primitive,integer"`
+	RetryOn   []CheckErrorClass `json:"retry_on,omitempty" yaml:"retry_on"` // empty - retry on any error
+}
+
+// ThresholdPolicy controls when consecutive check results change the service status.
+// Zero values fall back to defaults: a single failure marks the service down
+// and a single success marks it up.
+type ThresholdPolicy struct {
+	// FailThreshold is the number of failed checks required to mark the service down
+	FailThreshold int `json:"fail_threshold" yaml:"fail_threshold"`
+	// RecoveryThreshold is the number of successful checks required to mark the service up
+	RecoveryThreshold int `json:"recovery_threshold" yaml:"recovery_threshold"`
+	// Window switches thresholds from consecutive counts to M-of-N over the last Window checks
+	Window int `json:"window,omitempty" yaml:"window"`
+	// FlapThreshold is the number of status changes within FlapWindow checks
+	// that marks the service as flapping (0 - flapping detection disabled)
+	FlapThreshold int `json:"flap_threshold,omitempty" yaml:"flap_threshold"`
+	// FlapWindow is the number of recent checks used for flapping detection
+	FlapWindow int `json:"flap_window,omitempty" yaml:"flap_window"`
 }
 
 // ServiceStatus represents the current status of a service
@@ -70,6 +133,7 @@ type ServiceStatus string
 const (
 	StatusUnknown     ServiceStatus = "unknown"
 	StatusUp          ServiceStatus = "up"
+	StatusDegraded    ServiceStatus = "degraded"
 	StatusDown        ServiceStatus = "down"
 	StatusMaintenance ServiceStatus = "maintenance"
 )
@@ -78,25 +142,499 @@ func (s ServiceStatus) String() string {
 	return string(s)
 }
 
+// IncidentSeverity represents the severity of an incident
+type IncidentSeverity string
+
+const (
+	// IncidentSeverityCritical is an incident of a service that is down
+	IncidentSeverityCritical IncidentSeverity = "critical"
+	// IncidentSeverityWarning is an incident of a degraded service
+	IncidentSeverityWarning IncidentSeverity = "warning"
+)
+
+func (s IncidentSeverity) String() string {
+	return string(s)
+}
+
+// IncidentSource tells who opened an incident
+type IncidentSource string
+
+const (
+	// IncidentSourceMonitor is an incident opened by failed checks, it is resolved when the service recovers
+	IncidentSourceMonitor IncidentSource = "monitor"
+	// IncidentSourceManual is an incident opened by a user, it is only resolved by users
+	IncidentSourceManual IncidentSource = "manual"
+	// IncidentSourceAlert is an incident opened by an external alert, it is resolved when the alert resolves
+	IncidentSourceAlert IncidentSource = "alert"
+)
+
 // Incident represents a service incident
 type Incident struct {
-	ID        string         `json:"id"`
-	ServiceID string         `json:"service_id"`
-	StartTime time.Time      `json:"start_time"`
-	EndTime   *time.Time     `json:"end_time,omitempty"`
-	Error     string         `json:"error"`
-	Duration  *time.Duration `json:"duration,omitempty" swaggertype:"primitive,integer"`
-	Resolved  bool           `json:"resolved"`
+	ID        string           `json:"id"`
+	ServiceID string           `json:"service_id"`
+	StartTime time.Time        `json:"start_time"`
+	EndTime   *time.Time       `json:"end_time,omitempty"`
+	Error     string           `json:"error"`
+	Duration  *time.Duration   `json:"duration,omitempty" swaggertype:"primitive,integer"`
+	Resolved  bool             `json:"resolved"`
+	Severity  IncidentSeverity `json:"severity"`
+	Source    IncidentSource   `json:"source"`
+	Title     string           `json:"title,omitempty"`
+	// AffectedServices lists services of a manual incident, ServiceID is the first of them
+	AffectedServices []string `json:"affected_services,omitempty"`
+	// AcknowledgedAt is set when a user takes the incident, repeat alerts are not sent after it
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+	// Postmortem is editable after the incident is resolved
+	Postmortem *Postmortem `json:"postmortem,omitempty"`
+	// AlertKey identifies the external alert of an incident opened by an alert
+	AlertKey string `json:"alert_key,omitempty"`
+	// AlertSent and RecoverySent tell whether the alert and recovery notifications were sent
+	AlertSent    bool `json:"-"`
+	RecoverySent bool `json:"-"`
+}
+
+// Postmortem describes an incident after the fact
+type Postmortem struct {
+	RootCause   string   `json:"root_cause,omitempty" example:"Connection pool exhausted after the deploy"`
+	Impact      string   `json:"impact,omitempty" example:"Checkout was unavailable for 12 minutes"`
+	ActionItems []string `json:"action_items,omitempty" example:"Alert on pool usage"`
+}
+
+// IncidentEventType is the type of an incident timeline event
+type IncidentEventType string
+
+const (
+	IncidentEventOpened           IncidentEventType = "opened"
+	IncidentEventErrorChanged     IncidentEventType = "error_changed"
+	IncidentEventAcknowledged     IncidentEventType = "acknowledged"
+	IncidentEventNoteAdded        IncidentEventType = "note_added"
+	IncidentEventNotificationSent IncidentEventType = "notification_sent"
+	IncidentEventResolved         IncidentEventType = "resolved"
+	IncidentEventUpdated          IncidentEventType = "updated"
+)
+
+// IncidentEvent is an entry of the incident timeline
+type IncidentEvent struct {
+	ID         string            `json:"id"`
+	IncidentID string            `json:"incident_id"`
+	Type       IncidentEventType `json:"type"`
+	Message    string            `json:"message,omitempty"`
+	Author     string            `json:"author,omitempty"` // user of acknowledgements and notes
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// IncidentError is a distinct error of an incident, a new entry is added each time the error changes
+type IncidentError struct {
+	ID          string    `json:"id"`
+	IncidentID  string    `json:"incident_id"`
+	Error       string    `json:"error"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	Occurrences int       `json:"occurrences"`
+}
+
+// NotificationEvent is the kind of a notification of an incident
+type NotificationEvent string
+
+const (
+	// NotificationEventAlert is sent when a service goes down
+	NotificationEventAlert NotificationEvent = "alert"
+	// NotificationEventDegraded is sent when a service becomes degraded
+	NotificationEventDegraded NotificationEvent = "degraded"
+	// NotificationEventRecovery is sent when an incident is resolved
+	NotificationEventRecovery NotificationEvent = "recovery"
+	// NotificationEventUpdate is sent when the error of an ongoing incident changes
+	NotificationEventUpdate NotificationEvent = "update"
+	// NotificationEventEscalation is sent to the next step of an escalation policy
+	NotificationEventEscalation NotificationEvent = "escalation"
+	// NotificationEventReminder is sent repeatedly while an incident is unacknowledged
+	NotificationEventReminder NotificationEvent = "reminder"
+)
+
+// NotificationFormat is the markup of notification templates
+type NotificationFormat string
+
+const (
+	NotificationFormatText     NotificationFormat = "text"
+	NotificationFormatMarkdown NotificationFormat = "markdown"
+	NotificationFormatHTML     NotificationFormat = "html"
+)
+
+// NotificationChannel is a named set of notification provider URLs managed through the API
+type NotificationChannel struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	URLs      []string `json:"urls"`
+	IsEnabled bool     `json:"is_enabled"`
+	// Format is the markup of the templates, used by providers supporting it
+	Format NotificationFormat `json:"format"`
+	// Templates are Go message templates by event, escaped for the html format, events without a template use the default message
+	Templates map[NotificationEvent]string `json:"templates"`
+	CreatedAt time.Time                    `json:"created_at"`
+	UpdatedAt time.Time                    `json:"updated_at"`
+}
+
+// NotificationDeliveryStatus is the state of a notification delivery
+type NotificationDeliveryStatus string
+
+const (
+	// NotificationDeliveryStatusPending is waiting for its next attempt
+	NotificationDeliveryStatusPending NotificationDeliveryStatus = "pending"
+	// NotificationDeliveryStatusSent was delivered to the provider
+	NotificationDeliveryStatusSent NotificationDeliveryStatus = "sent"
+	// NotificationDeliveryStatusDead failed all of its attempts
+	NotificationDeliveryStatusDead NotificationDeliveryStatus = "dead"
+)
+
+// NotificationDelivery is a message of the notification outbox to a single provider URL
+type NotificationDelivery struct {
+	ID string `json:"id"`
+	// Channel is the name of the channel of the URL, empty for the default URLs
+	Channel    string            `json:"channel"`
+	Event      NotificationEvent `json:"event,omitempty"`
+	ServiceID  string            `json:"service_id,omitempty"`
+	IncidentID string            `json:"incident_id,omitempty"`
+
+	URL     string             `json:"url"`
+	Message string             `json:"message"`
+	Format  NotificationFormat `json:"format"`
+
+	Status        NotificationDeliveryStatus `json:"status"`
+	Attempts      int                        `json:"attempts"`
+	LastError     string                     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time                  `json:"next_attempt_at"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	SentAt        *time.Time                 `json:"sent_at,omitempty"`
+}
+
+// NotificationRule routes notifications of incidents to notification channels.
+// A rule matches when all of its conditions match, empty conditions match any value.
+type NotificationRule struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Channels []string `json:"channels"`
+
+	ServiceIDs []string              `json:"service_ids"`
+	Tags       []string              `json:"tags"` // the service has any of the tags
+	Protocols  []ServiceProtocolType `json:"protocols"`
+	Severities []IncidentSeverity    `json:"severities"`
+	Events     []NotificationEvent   `json:"events"`
+
+	IsEnabled bool      `json:"is_enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Matches reports whether a notification of the service matches the rule
+func (r *NotificationRule) Matches(service *Service, severity IncidentSeverity, event NotificationEvent) bool {
+	if len(r.ServiceIDs) > 0 && !slices.Contains(r.ServiceIDs, service.ID) {
+		return false
+	}
+
+	if len(r.Tags) > 0 && !slices.ContainsFunc(service.Tags, func(tag string) bool { return slices.Contains(r.Tags, tag) }) {
+		return false
+	}
+
+	if len(r.Protocols) > 0 && !slices.Contains(r.Protocols, service.Protocol) {
+		return false
+	}
+
+	if len(r.Severities) > 0 && !slices.Contains(r.Severities, severity) {
+		return false
+	}
+
+	if len(r.Events) > 0 && !slices.Contains(r.Events, event) {
+		return false
+	}
+
+	return true
+}
+
+// EscalationStep notifies channels about an incident still unacknowledged after the delay
+type EscalationStep struct {
+	// Delay is the time since the start of the incident
+	Delay    time.Duration `json:"delay" swaggertype:"primitive,integer"`
+	Channels []string      `json:"channels"`
+}
+
+// EscalationPolicy escalates unacknowledged incidents of matching services through its steps
+// and reminds of them until they are acknowledged or resolved. Empty conditions match any value.
+type EscalationPolicy struct {
+	ID    string           `json:"id"`
+	Name  string           `json:"name"`
+	Steps []EscalationStep `json:"steps"`
+	// RepeatInterval is the interval of reminders, zero disables them
+	RepeatInterval time.Duration `json:"repeat_interval" swaggertype:"primitive,integer"`
+
+	ServiceIDs []string           `json:"service_ids"`
+	Tags       []string           `json:"tags"` // the service has any of the tags
+	Severities []IncidentSeverity `json:"severities"`
+
+	IsEnabled bool      `json:"is_enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Matches reports whether an incident of the service with the severity matches the policy
+func (p *EscalationPolicy) Matches(service *Service, severity IncidentSeverity) bool {
+	if len(p.ServiceIDs) > 0 && !slices.Contains(p.ServiceIDs, service.ID) {
+		return false
+	}
+
+	if len(p.Tags) > 0 && !slices.ContainsFunc(service.Tags, func(tag string) bool { return slices.Contains(p.Tags, tag) }) {
+		return false
+	}
+
+	if len(p.Severities) > 0 && !slices.Contains(p.Severities, severity) {
+		return false
+	}
+
+	return true
+}
+
+// IncidentEscalation is the escalation state of an incident by a policy
+type IncidentEscalation struct {
+	IncidentID string `json:"incident_id"`
+	PolicyID   string `json:"policy_id"`
+	// Level is the number of steps already notified
+	Level int `json:"level"`
+	// NotifiedAt is the time of the last escalation or reminder
+	NotifiedAt time.Time `json:"notified_at"`
+}
+
+// OnCallChannelPrefix prefixes the name of a schedule in the channels of notification rules
+// and escalation steps, targeting the user on call for the schedule
+const OnCallChannelPrefix = "oncall:"
+
+// OnCallUser is a participant of an on-call schedule with personal notification provider URLs
+type OnCallUser struct {
+	Name string   `json:"name"`
+	URLs []string `json:"urls"`
+}
+
+// OnCallOverride puts a user of the schedule on call for a period instead of the rotation
+type OnCallOverride struct {
+	ID    string    `json:"id"`
+	User  string    `json:"user"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// OnCallSchedule is a weekly rotation of its users in the configured timezone.
+// Users hand off every week at HandoffTime on the weekday of StartDate.
+type OnCallSchedule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Users are on call in turn, in this order
+	Users []OnCallUser `json:"users"`
+	// StartDate is the date of the first shift of the first user, YYYY-MM-DD
+	StartDate string `json:"start_date"`
+	// HandoffTime is the time of handoffs, HH:MM
+	HandoffTime string           `json:"handoff_time"`
+	Overrides   []OnCallOverride `json:"overrides"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// OnCallShift is a period a user is on call for a schedule
+type OnCallShift struct {
+	ScheduleID   string    `json:"schedule_id"`
+	ScheduleName string    `json:"schedule_name"`
+	User         string    `json:"user"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	// Override is set when the shift is an override of the rotation
+	Override bool `json:"override"`
+}
+
+// FirstHandoff returns the start of the first shift in the local timezone
+func (s *OnCallSchedule) FirstHandoff() (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04", s.StartDate+" "+s.HandoffTime, time.Local)
+}
+
+// User returns a user of the schedule by name
+func (s *OnCallSchedule) User(name string) *OnCallUser {
+	for i := range s.Users {
+		if s.Users[i].Name == name {
+			return &s.Users[i]
+		}
+	}
+	return nil
+}
+
+// ShiftAt returns the shift of the schedule at the given time, overrides take precedence over the rotation.
+// It returns nil before the first shift or when the schedule has no users.
+func (s *OnCallSchedule) ShiftAt(at time.Time) *OnCallShift {
+	for _, override := range s.Overrides {
+		if !at.Before(override.Start) && at.Before(override.End) {
+			return &OnCallShift{
+				ScheduleID:   s.ID,
+				ScheduleName: s.Name,
+				User:         override.User,
+				Start:        override.Start,
+				End:          override.End,
+				Override:     true,
+			}
+		}
+	}
+
+	first, err := s.FirstHandoff()
+	if err != nil || len(s.Users) == 0 || at.Before(first) {
+		return nil
+	}
+
+	// Weeks are counted in calendar days, so handoffs keep their local time across DST changes
+	local := at.In(time.Local)
+	days := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC).
+		Sub(time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)) / (24 * time.Hour)
+
+	week := int(days / 7)
+	start := first.AddDate(0, 0, 7*week)
+	if start.After(at) {
+		week--
+		start = first.AddDate(0, 0, 7*week)
+	}
+
+	return &OnCallShift{
+		ScheduleID:   s.ID,
+		ScheduleName: s.Name,
+		User:         s.Users[week%len(s.Users)].Name,
+		Start:        start,
+		End:          first.AddDate(0, 0, 7*(week+1)),
+	}
+}
+
+// WebhookEvent is an event sent to outbound webhooks
+type WebhookEvent string
+
+const (
+	WebhookEventServiceCreated      WebhookEvent = "service.created"
+	WebhookEventServiceUpdated      WebhookEvent = "service.updated"
+	WebhookEventServiceDeleted      WebhookEvent = "service.deleted"
+	WebhookEventServiceStateChanged WebhookEvent = "service.state_changed"
+	WebhookEventIncidentOpened      WebhookEvent = "incident.opened"
+	WebhookEventIncidentUpdated     WebhookEvent = "incident.updated"
+	WebhookEventIncidentResolved    WebhookEvent = "incident.resolved"
+)
+
+// WebhookEvents are all events sent to outbound webhooks
+var WebhookEvents = []WebhookEvent{
+	WebhookEventServiceCreated,
+	WebhookEventServiceUpdated,
+	WebhookEventServiceDeleted,
+	WebhookEventServiceStateChanged,
+	WebhookEventIncidentOpened,
+	WebhookEventIncidentUpdated,
+	WebhookEventIncidentResolved,
+}
+
+// Webhook receives events as JSON requests signed with HMAC-SHA256 of its secret
+type Webhook struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Secret string `json:"-"`
+	// Events the webhook is subscribed to, empty for all events
+	Events    []WebhookEvent `json:"events"`
+	IsEnabled bool           `json:"is_enabled"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Subscribes reports whether the webhook receives an event
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+// WebhookDeliveryStatus is the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending is waiting for its next attempt
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryStatusSent was accepted by the webhook
+	WebhookDeliveryStatusSent WebhookDeliveryStatus = "sent"
+	// WebhookDeliveryStatusDead failed all of its attempts
+	WebhookDeliveryStatusDead WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery is a request of an event to a webhook, retried until it succeeds or runs out of attempts
+type WebhookDelivery struct {
+	ID        string       `json:"id"`
+	WebhookID string       `json:"webhook_id"`
+	Event     WebhookEvent `json:"event"`
+	// Payload is the JSON body of the request
+	Payload string `json:"payload"`
+
+	Status   WebhookDeliveryStatus `json:"status"`
+	Attempts int                   `json:"attempts"`
+	// ResponseStatus is the HTTP status of the last attempt, 0 if no response was received
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	SentAt         *time.Time `json:"sent_at,omitempty"`
 }
 
 // ServiceStats holds statistics for a service
 type ServiceStats struct {
 	ServiceID        string        `json:"service_id"`
+	StartTime        time.Time     `json:"start_time"`
+	EndTime          time.Time     `json:"end_time"`
 	TotalIncidents   int           `json:"total_incidents"`
 	TotalDowntime    time.Duration `json:"total_downtime" swaggertype:"primitive,integer"`
 	UptimePercentage float64       `json:"uptime_percentage"`
 	Period           time.Duration `json:"period" swaggertype:"primitive,integer"`
-	AvgResponseTime  time.Duration `json:"avg_response_time" swaggertype:"primitive,integer"`
+	// MonitoredTime is the period without the time before the service was created,
+	// disabled periods and maintenance windows
+	MonitoredTime   time.Duration `json:"monitored_time" swaggertype:"primitive,integer"`
+	ExcludedTime    time.Duration `json:"excluded_time" swaggertype:"primitive,integer"`
+	AvgResponseTime time.Duration `json:"avg_response_time" swaggertype:"primitive,integer"`
+	// TotalChecks and CheckUptimePercentage are computed from the check history
+	TotalChecks           int            `json:"total_checks"`
+	CheckUptimePercentage float64        `json:"check_uptime_percentage"`
+	Buckets               []UptimeBucket `json:"buckets,omitempty"`
+}
+
+// UptimeBucketSize represents the size of uptime buckets
+type UptimeBucketSize string
+
+const (
+	UptimeBucketHour  UptimeBucketSize = "hour"
+	UptimeBucketDay   UptimeBucketSize = "day"
+	UptimeBucketWeek  UptimeBucketSize = "week"
+	UptimeBucketMonth UptimeBucketSize = "month"
+)
+
+// UptimeBucket holds the uptime of a service for a part of the stats period
+type UptimeBucket struct {
+	StartTime        time.Time     `json:"start_time"`
+	EndTime          time.Time     `json:"end_time"`
+	UptimePercentage float64       `json:"uptime_percentage"`
+	Downtime         time.Duration `json:"downtime" swaggertype:"primitive,integer"`
+	MonitoredTime    time.Duration `json:"monitored_time" swaggertype:"primitive,integer"`
+	Incidents        int           `json:"incidents"`
+}
+
+// PauseReason represents the reason a service is not monitored
+type PauseReason string
+
+const (
+	PauseReasonDisabled    PauseReason = "disabled"
+	PauseReasonMaintenance PauseReason = "maintenance"
+)
+
+// ServicePause is a period excluded from the service uptime.
+// Disabled periods are recorded automatically, maintenance windows are scheduled by users.
+type ServicePause struct {
+	ID          string      `json:"id"`
+	ServiceID   string      `json:"service_id"`
+	Reason      PauseReason `json:"reason"`
+	StartTime   time.Time   `json:"start_time"`
+	EndTime     *time.Time  `json:"end_time,omitempty"`
+	Description string      `json:"description"`
+	CreatedAt   time.Time   `json:"created_at"`
 }
 
 // ServiceIncidentStats holds incident statistics for a service
@@ -110,7 +648,7 @@ type ServiceIncidentStats struct {
 type ServiceStateRecord struct {
 	ID                 string        `json:"id"`
 	ServiceID          string        `json:"service_id"`
-	Status             ServiceStatus `json:"status"` // "up", "down", "unknown"
+	Status             ServiceStatus `json:"status"` // "up", "degraded", "down", "unknown"
 	LastCheck          *time.Time    `json:"last_check,omitempty"`
 	NextCheck          *time.Time    `json:"next_check,omitempty"`
 	LastError          *string       `json:"last_error,omitempty"`
@@ -118,11 +656,128 @@ type ServiceStateRecord struct {
 	ConsecutiveSuccess int           `json:"consecutive_success"`
 	TotalChecks        int           `json:"total_checks"`
 	ResponseTimeNS     *int64        `json:"response_time_ns,omitempty"`
+	RecentResults      string        `json:"recent_results"` // "1" - success, "0" - failure, oldest first
+	IsFlapping         bool          `json:"is_flapping"`
 	CreatedAt          time.Time     `json:"created_at"`
 	UpdatedAt          time.Time     `json:"updated_at"`
 }
 
+// CheckResult represents a single check of a service
+type CheckResult struct {
+	ID           string          `json:"id"`
+	ServiceID    string          `json:"service_id"`
+	CheckedAt    time.Time       `json:"checked_at"`
+	Status       ServiceStatus   `json:"status"` // "up", "degraded", "down"
+	ResponseTime time.Duration   `json:"response_time" swaggertype:"primitive,integer"`
+	Error        *string         `json:"error,omitempty"`
+	Attempts     int             `json:"attempts"`
+	Endpoints    []EndpointCheck `json:"endpoints,omitempty"`
+}
+
+// EndpointCheck holds the result of a single endpoint within a check
+type EndpointCheck struct {
+	Name     string        `json:"name"`
+	Success  bool          `json:"success"`
+	Duration time.Duration `json:"duration" swaggertype:"primitive,integer"`
+}
+
+// RollupResolution represents the bucket size of check result rollups
+type RollupResolution string
+
+const (
+	RollupResolutionHour RollupResolution = "hour"
+	RollupResolutionDay  RollupResolution = "day"
+)
+
+// Duration returns the bucket size of the resolution
+func (r RollupResolution) Duration() time.Duration {
+	if r == RollupResolutionDay {
+		return 24 * time.Hour
+	}
+	return time.Hour
+}
+
+// CheckResultRollup holds aggregated check results of a service for a time bucket
+type CheckResultRollup struct {
+	ServiceID       string           `json:"service_id"`
+	Resolution      RollupResolution `json:"resolution"`
+	BucketStart     time.Time        `json:"bucket_start"`
+	TotalChecks     int              `json:"total_checks"`
+	UpChecks        int              `json:"up_checks"`
+	DegradedChecks  int              `json:"degraded_checks"`
+	DownChecks      int              `json:"down_checks"`
+	MinResponseTime time.Duration    `json:"min_response_time" swaggertype:"primitive,integer"`
+	AvgResponseTime time.Duration    `json:"avg_response_time" swaggertype:"primitive,integer"`
+	MaxResponseTime time.Duration    `json:"max_response_time" swaggertype:"primitive,integer"`
+	P95ResponseTime time.Duration    `json:"p95_response_time" swaggertype:"primitive,integer"`
+	P99ResponseTime time.Duration    `json:"p99_response_time" swaggertype:"primitive,integer"`
+}
+
 // GenerateULID generates a new ULID
 func GenerateULID() string {
 	return ulid.Make().String()
 }
+
+// SLOWindow represents the rolling compliance window of an SLO
+type SLOWindow string
+
+const (
+	SLOWindow7d  SLOWindow = "7d"
+	SLOWindow28d SLOWindow = "28d"
+	SLOWindow30d SLOWindow = "30d"
+)
+
+// Duration returns the window length
+func (w SLOWindow) Duration() time.Duration {
+	switch w {
+	case SLOWindow7d:
+		return 7 * 24 * time.Hour
+	case SLOWindow28d:
+		return 28 * 24 * time.Hour
+	default:
+		return 30 * 24 * time.Hour
+	}
+}
+
+// BurnRateAlert fires when the error budget burns at least Threshold times
+// faster than allowed over both the short and the long window
+type BurnRateAlert struct {
+	ShortWindow time.Duration `json:"short_window" swaggertype:"primitive,integer"`
+	LongWindow  time.Duration `json:"long_window" swaggertype:"primitive,integer"`
+	Threshold   float64       `json:"threshold"`
+}
+
+// SLO is a service level objective of a service or of all services with a tag.
+// A check is good when the service is up or degraded and responds within LatencyTarget.
+type SLO struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ServiceID string `json:"service_id,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+	// Target is the required percentage of good checks
+	Target float64 `json:"target"`
+	// LatencyTarget marks slower checks as bad (0 - availability only)
+	LatencyTarget time.Duration  `json:"latency_target" swaggertype:"primitive,integer"`
+	Window        SLOWindow      `json:"window"`
+	BurnRateAlert *BurnRateAlert `json:"burn_rate_alert,omitempty"`
+	Alerting      bool           `json:"alerting"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// SLOStatus holds the compliance of an SLO over its window
+type SLOStatus struct {
+	SLO         *SLO      `json:"slo"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	TotalChecks int64     `json:"total_checks"`
+	GoodChecks  int64     `json:"good_checks"`
+	// SLI is the percentage of good checks
+	SLI float64 `json:"sli"`
+	// ErrorBudgetRemaining is the percentage of the error budget left, negative when exhausted
+	ErrorBudgetRemaining float64 `json:"error_budget_remaining"`
+	// BurnRateShort and BurnRateLong are computed over the burn rate alert windows
+	BurnRateShort float64 `json:"burn_rate_short"`
+	BurnRateLong  float64 `json:"burn_rate_long"`
+	Compliant     bool    `json:"compliant"`
+}
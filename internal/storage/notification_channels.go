@@ -0,0 +1,264 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// NotificationChannelRow represents a database row for notification channels
+type NotificationChannelRow struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	URLs      string    `db:"urls"`
+	IsEnabled bool      `db:"is_enabled"`
+	Format    string    `db:"format"`
+	Templates string    `db:"templates"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// FindNotificationChannelsParams holds filters for notification channels
+type FindNotificationChannelsParams struct {
+	IsEnabled *bool
+}
+
+// marshalChannelURLs encodes the provider URLs of a channel
+func marshalChannelURLs(urls []string) (string, error) {
+	if urls == nil {
+		urls = []string{}
+	}
+
+	data, err := json.Marshal(urls)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal notification channel URLs: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// marshalChannelTemplates encodes the message templates of a channel
+func marshalChannelTemplates(templates map[NotificationEvent]string) (string, error) {
+	if templates == nil {
+		templates = map[NotificationEvent]string{}
+	}
+
+	data, err := json.Marshal(templates)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal notification channel templates: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// notificationFormatOrDefault returns the format of a channel, text by default
+func notificationFormatOrDefault(format NotificationFormat) NotificationFormat {
+	if format == "" {
+		return NotificationFormatText
+	}
+	return format
+}
+
+// CreateNotificationChannel creates a new notification channel
+func (o *ORMStorage) CreateNotificationChannel(ctx context.Context, channel *NotificationChannel) error {
+	if channel.ID == "" {
+		channel.ID = GenerateULID()
+	}
+
+	urls, err := marshalChannelURLs(channel.URLs)
+	if err != nil {
+		return err
+	}
+
+	templates, err := marshalChannelTemplates(channel.Templates)
+	if err != nil {
+		return err
+	}
+
+	channel.Format = notificationFormatOrDefault(channel.Format)
+
+	now := time.Now()
+	channel.CreatedAt = now
+	channel.UpdatedAt = now
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("notification_channels")
+	ib.Cols("id", "name", "urls", "is_enabled", "format", "templates", "created_at", "updated_at")
+	ib.Values(channel.ID, channel.Name, urls, channel.IsEnabled, channel.Format, templates, channel.CreatedAt, channel.UpdatedAt)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("notification channel %s %w", channel.Name, ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to create notification channel: %w", err)
+	}
+
+	return nil
+}
+
+func findNotificationChannelsBuilder(params FindNotificationChannelsParams) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "name", "urls", "is_enabled", "format", "templates", "created_at", "updated_at")
+	sb.From("notification_channels")
+
+	if params.IsEnabled != nil {
+		sb.Where(sb.Equal("is_enabled", *params.IsEnabled))
+	}
+
+	return sb
+}
+
+// scanNotificationChannel scans a notification channel row
+func scanNotificationChannel(scanner interface{ Scan(dest ...any) error }) (*NotificationChannel, error) {
+	var row NotificationChannelRow
+	err := scanner.Scan(
+		&row.ID,
+		&row.Name,
+		&row.URLs,
+		&row.IsEnabled,
+		&row.Format,
+		&row.Templates,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := &NotificationChannel{
+		ID:        row.ID,
+		Name:      row.Name,
+		IsEnabled: row.IsEnabled,
+		Format:    NotificationFormat(row.Format),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	if err := json.Unmarshal([]byte(row.URLs), &channel.URLs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification channel URLs: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(row.Templates), &channel.Templates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification channel templates: %w", err)
+	}
+
+	return channel, nil
+}
+
+// GetNotificationChannelByID gets a notification channel by ID
+func (o *ORMStorage) GetNotificationChannelByID(ctx context.Context, id string) (*NotificationChannel, error) {
+	sb := findNotificationChannelsBuilder(FindNotificationChannelsParams{})
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	channel, err := scanNotificationChannel(o.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// FindNotificationChannels finds notification channels ordered by name
+func (o *ORMStorage) FindNotificationChannels(ctx context.Context, params FindNotificationChannelsParams) ([]*NotificationChannel, error) {
+	sb := findNotificationChannelsBuilder(params)
+	sb.OrderBy("name").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification channels: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*NotificationChannel{}
+	for rows.Next() {
+		channel, err := scanNotificationChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification channel: %w", err)
+		}
+		items = append(items, channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// UpdateNotificationChannel updates a notification channel
+func (o *ORMStorage) UpdateNotificationChannel(ctx context.Context, channel *NotificationChannel) error {
+	urls, err := marshalChannelURLs(channel.URLs)
+	if err != nil {
+		return err
+	}
+
+	templates, err := marshalChannelTemplates(channel.Templates)
+	if err != nil {
+		return err
+	}
+
+	channel.Format = notificationFormatOrDefault(channel.Format)
+	channel.UpdatedAt = time.Now()
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("notification_channels")
+	ub.Set(
+		ub.Assign("name", channel.Name),
+		ub.Assign("urls", urls),
+		ub.Assign("is_enabled", channel.IsEnabled),
+		ub.Assign("format", channel.Format),
+		ub.Assign("templates", templates),
+		ub.Assign("updated_at", channel.UpdatedAt),
+	)
+	ub.Where(ub.Equal("id", channel.ID))
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("notification channel %s %w", channel.Name, ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to update notification channel: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteNotificationChannel deletes a notification channel
+func (o *ORMStorage) DeleteNotificationChannel(ctx context.Context, id string) error {
+	result, err := o.db.ExecContext(ctx, `DELETE FROM notification_channels WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
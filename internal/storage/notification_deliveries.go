@@ -0,0 +1,334 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/sxwebdev/sentinel/pkg/dbutils"
+)
+
+// maxNotificationDeliveriesPageSize is the maximum number of notification deliveries per page
+const maxNotificationDeliveriesPageSize = 1000
+
+// notificationDeliveryColumns are the columns of notification deliveries in scan order
+var notificationDeliveryColumns = []string{
+	"id",
+	"channel",
+	"event",
+	"service_id",
+	"incident_id",
+	"url",
+	"message",
+	"format",
+	"status",
+	"attempts",
+	"last_error",
+	"next_attempt_at",
+	"created_at",
+	"sent_at",
+}
+
+// NotificationDeliveryRow represents a database row for notification deliveries
+type NotificationDeliveryRow struct {
+	ID            string     `db:"id"`
+	Channel       string     `db:"channel"`
+	Event         string     `db:"event"`
+	ServiceID     string     `db:"service_id"`
+	IncidentID    string     `db:"incident_id"`
+	URL           string     `db:"url"`
+	Message       string     `db:"message"`
+	Format        string     `db:"format"`
+	Status        string     `db:"status"`
+	Attempts      int        `db:"attempts"`
+	LastError     string     `db:"last_error"`
+	NextAttemptAt time.Time  `db:"next_attempt_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+	SentAt        *time.Time `db:"sent_at"`
+}
+
+// FindNotificationDeliveriesParams holds filters for notification deliveries
+type FindNotificationDeliveriesParams struct {
+	Status     NotificationDeliveryStatus
+	Channel    *string
+	ServiceID  string
+	IncidentID string
+	Page       *uint32
+	PageSize   *uint32
+}
+
+// CreateNotificationDeliveries adds pending deliveries to the notification outbox
+func (o *ORMStorage) CreateNotificationDeliveries(ctx context.Context, deliveries []*NotificationDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("notification_deliveries")
+	ib.Cols(notificationDeliveryColumns...)
+
+	for _, delivery := range deliveries {
+		if delivery.ID == "" {
+			delivery.ID = GenerateULID()
+		}
+		delivery.Format = notificationFormatOrDefault(delivery.Format)
+		delivery.Status = NotificationDeliveryStatusPending
+		delivery.CreatedAt = now
+		if delivery.NextAttemptAt.IsZero() {
+			delivery.NextAttemptAt = now
+		}
+
+		ib.Values(
+			delivery.ID,
+			delivery.Channel,
+			string(delivery.Event),
+			delivery.ServiceID,
+			delivery.IncidentID,
+			delivery.URL,
+			delivery.Message,
+			string(delivery.Format),
+			string(delivery.Status),
+			delivery.Attempts,
+			delivery.LastError,
+			delivery.NextAttemptAt.UTC(),
+			delivery.CreatedAt,
+			nil,
+		)
+	}
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create notification deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func findNotificationDeliveriesBuilder(params FindNotificationDeliveriesParams, col ...string) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(col...)
+	sb.From("notification_deliveries")
+
+	if params.Status != "" {
+		sb.Where(sb.Equal("status", string(params.Status)))
+	}
+
+	if params.Channel != nil {
+		sb.Where(sb.Equal("channel", *params.Channel))
+	}
+
+	if params.ServiceID != "" {
+		sb.Where(sb.Equal("service_id", params.ServiceID))
+	}
+
+	if params.IncidentID != "" {
+		sb.Where(sb.Equal("incident_id", params.IncidentID))
+	}
+
+	return sb
+}
+
+// scanNotificationDelivery scans a notification delivery row
+func scanNotificationDelivery(scanner interface{ Scan(dest ...any) error }) (*NotificationDelivery, error) {
+	var row NotificationDeliveryRow
+	err := scanner.Scan(
+		&row.ID,
+		&row.Channel,
+		&row.Event,
+		&row.ServiceID,
+		&row.IncidentID,
+		&row.URL,
+		&row.Message,
+		&row.Format,
+		&row.Status,
+		&row.Attempts,
+		&row.LastError,
+		&row.NextAttemptAt,
+		&row.CreatedAt,
+		&row.SentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationDelivery{
+		ID:            row.ID,
+		Channel:       row.Channel,
+		Event:         NotificationEvent(row.Event),
+		ServiceID:     row.ServiceID,
+		IncidentID:    row.IncidentID,
+		URL:           row.URL,
+		Message:       row.Message,
+		Format:        NotificationFormat(row.Format),
+		Status:        NotificationDeliveryStatus(row.Status),
+		Attempts:      row.Attempts,
+		LastError:     row.LastError,
+		NextAttemptAt: row.NextAttemptAt,
+		CreatedAt:     row.CreatedAt,
+		SentAt:        row.SentAt,
+	}, nil
+}
+
+// queryNotificationDeliveries runs a query of notification deliveries
+func (o *ORMStorage) queryNotificationDeliveries(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]*NotificationDelivery, error) {
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*NotificationDelivery{}
+	for rows.Next() {
+		delivery, err := scanNotificationDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification delivery: %w", err)
+		}
+		items = append(items, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetNotificationDeliveryByID gets a notification delivery by ID
+func (o *ORMStorage) GetNotificationDeliveryByID(ctx context.Context, id string) (*NotificationDelivery, error) {
+	sb := findNotificationDeliveriesBuilder(FindNotificationDeliveriesParams{}, notificationDeliveryColumns...)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	delivery, err := scanNotificationDelivery(o.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// FindNotificationDeliveries finds notification deliveries, latest first
+func (o *ORMStorage) FindNotificationDeliveries(ctx context.Context, params FindNotificationDeliveriesParams) (dbutils.FindResponseWithCount[*NotificationDelivery], error) {
+	res := dbutils.FindResponseWithCount[*NotificationDelivery]{}
+
+	limit, offset, err := dbutils.Pagination(params.Page, params.PageSize, dbutils.WithMaxLimit(maxNotificationDeliveriesPageSize))
+	if err != nil {
+		return res, fmt.Errorf("failed to apply pagination: %w", err)
+	}
+
+	sb := findNotificationDeliveriesBuilder(params, notificationDeliveryColumns...)
+	sb.OrderBy("created_at DESC", "id DESC")
+	sb.Limit(int(limit)).Offset(int(offset))
+
+	items, err := o.queryNotificationDeliveries(ctx, sb)
+	if err != nil {
+		return res, err
+	}
+
+	var totalCount uint32
+	countQuery, countArgs := findNotificationDeliveriesBuilder(params, "COUNT(*)").Build()
+	if err := o.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount); err != nil {
+		return res, fmt.Errorf("failed to count notification deliveries: %w", err)
+	}
+
+	res.Count = totalCount
+	res.Items = items
+
+	return res, nil
+}
+
+// FindDueNotificationDeliveries finds pending deliveries whose next attempt is due, oldest first.
+// Deliveries wait while an older delivery to the same URL is waiting for a retry, to keep their order.
+func (o *ORMStorage) FindDueNotificationDeliveries(ctx context.Context, now time.Time, limit int) ([]*NotificationDelivery, error) {
+	waiting := sqlbuilder.NewSelectBuilder()
+	waiting.Select("1")
+	waiting.From("notification_deliveries w")
+	waiting.Where(
+		"w.url = notification_deliveries.url",
+		waiting.Equal("w.status", string(NotificationDeliveryStatusPending)),
+		waiting.GreaterThan("w.next_attempt_at", now.UTC()),
+		waiting.Or(
+			"w.created_at < notification_deliveries.created_at",
+			waiting.And("w.created_at = notification_deliveries.created_at", "w.id < notification_deliveries.id"),
+		),
+	)
+
+	sb := findNotificationDeliveriesBuilder(FindNotificationDeliveriesParams{Status: NotificationDeliveryStatusPending}, notificationDeliveryColumns...)
+	sb.Where(
+		sb.LessEqualThan("next_attempt_at", now.UTC()),
+		sb.NotExists(waiting),
+	)
+	sb.OrderBy("created_at", "id").Asc()
+	sb.Limit(limit)
+
+	return o.queryNotificationDeliveries(ctx, sb)
+}
+
+// UpdateNotificationDelivery updates the state of a notification delivery
+func (o *ORMStorage) UpdateNotificationDelivery(ctx context.Context, delivery *NotificationDelivery) error {
+	var sentAt *time.Time
+	if delivery.SentAt != nil {
+		t := delivery.SentAt.UTC()
+		sentAt = &t
+	}
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("notification_deliveries")
+	ub.Set(
+		ub.Assign("status", string(delivery.Status)),
+		ub.Assign("attempts", delivery.Attempts),
+		ub.Assign("last_error", delivery.LastError),
+		ub.Assign("next_attempt_at", delivery.NextAttemptAt.UTC()),
+		ub.Assign("sent_at", sentAt),
+	)
+	ub.Where(ub.Equal("id", delivery.ID))
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteNotificationDeliveriesBefore deletes sent and dead-lettered deliveries created before the given time
+func (o *ORMStorage) DeleteNotificationDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom("notification_deliveries")
+	db.Where(
+		db.NotEqual("status", string(NotificationDeliveryStatusPending)),
+		db.LessThan("created_at", before.UTC()),
+	)
+
+	query, args := db.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete notification deliveries: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return deleted, nil
+}
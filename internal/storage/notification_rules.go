@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// NotificationRuleRow represents a database row for notification rules
+type NotificationRuleRow struct {
+	ID         string    `db:"id"`
+	Name       string    `db:"name"`
+	Channels   string    `db:"channels"`
+	ServiceIDs string    `db:"service_ids"`
+	Tags       string    `db:"tags"`
+	Protocols  string    `db:"protocols"`
+	Severities string    `db:"severities"`
+	Events     string    `db:"events"`
+	IsEnabled  bool      `db:"is_enabled"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// FindNotificationRulesParams holds filters for notification rules
+type FindNotificationRulesParams struct {
+	IsEnabled *bool
+}
+
+// notificationRuleValues returns the JSON encoded conditions of a rule in the column order
+func notificationRuleValues(rule *NotificationRule) ([]any, error) {
+	lists := []any{rule.Channels, rule.ServiceIDs, rule.Tags, rule.Protocols, rule.Severities, rule.Events}
+
+	values := make([]any, 0, len(lists))
+	for _, list := range lists {
+		data, err := json.Marshal(list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal notification rule: %w", err)
+		}
+		if string(data) == "null" {
+			data = []byte("[]")
+		}
+		values = append(values, string(data))
+	}
+
+	return values, nil
+}
+
+// CreateNotificationRule creates a new notification rule
+func (o *ORMStorage) CreateNotificationRule(ctx context.Context, rule *NotificationRule) error {
+	if rule.ID == "" {
+		rule.ID = GenerateULID()
+	}
+
+	values, err := notificationRuleValues(rule)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("notification_rules")
+	ib.Cols("id", "name", "channels", "service_ids", "tags", "protocols", "severities", "events", "is_enabled", "created_at", "updated_at")
+	ib.Values(
+		rule.ID,
+		rule.Name,
+		values[0],
+		values[1],
+		values[2],
+		values[3],
+		values[4],
+		values[5],
+		rule.IsEnabled,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create notification rule: %w", err)
+	}
+
+	return nil
+}
+
+func findNotificationRulesBuilder(params FindNotificationRulesParams) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "name", "channels", "service_ids", "tags", "protocols", "severities", "events", "is_enabled", "created_at", "updated_at")
+	sb.From("notification_rules")
+
+	if params.IsEnabled != nil {
+		sb.Where(sb.Equal("is_enabled", *params.IsEnabled))
+	}
+
+	return sb
+}
+
+// scanNotificationRule scans a notification rule row
+func scanNotificationRule(scanner interface{ Scan(dest ...any) error }) (*NotificationRule, error) {
+	var row NotificationRuleRow
+	err := scanner.Scan(
+		&row.ID,
+		&row.Name,
+		&row.Channels,
+		&row.ServiceIDs,
+		&row.Tags,
+		&row.Protocols,
+		&row.Severities,
+		&row.Events,
+		&row.IsEnabled,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &NotificationRule{
+		ID:        row.ID,
+		Name:      row.Name,
+		IsEnabled: row.IsEnabled,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	lists := []struct {
+		data   string
+		target any
+	}{
+		{row.Channels, &rule.Channels},
+		{row.ServiceIDs, &rule.ServiceIDs},
+		{row.Tags, &rule.Tags},
+		{row.Protocols, &rule.Protocols},
+		{row.Severities, &rule.Severities},
+		{row.Events, &rule.Events},
+	}
+
+	for _, list := range lists {
+		if err := json.Unmarshal([]byte(list.data), list.target); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification rule: %w", err)
+		}
+	}
+
+	return rule, nil
+}
+
+// GetNotificationRuleByID gets a notification rule by ID
+func (o *ORMStorage) GetNotificationRuleByID(ctx context.Context, id string) (*NotificationRule, error) {
+	sb := findNotificationRulesBuilder(FindNotificationRulesParams{})
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	rule, err := scanNotificationRule(o.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// FindNotificationRules finds notification rules ordered by name
+func (o *ORMStorage) FindNotificationRules(ctx context.Context, params FindNotificationRulesParams) ([]*NotificationRule, error) {
+	sb := findNotificationRulesBuilder(params)
+	sb.OrderBy("name", "id").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification rules: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*NotificationRule{}
+	for rows.Next() {
+		rule, err := scanNotificationRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification rule: %w", err)
+		}
+		items = append(items, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// UpdateNotificationRule updates a notification rule
+func (o *ORMStorage) UpdateNotificationRule(ctx context.Context, rule *NotificationRule) error {
+	values, err := notificationRuleValues(rule)
+	if err != nil {
+		return err
+	}
+
+	rule.UpdatedAt = time.Now()
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("notification_rules")
+	ub.Set(
+		ub.Assign("name", rule.Name),
+		ub.Assign("channels", values[0]),
+		ub.Assign("service_ids", values[1]),
+		ub.Assign("tags", values[2]),
+		ub.Assign("protocols", values[3]),
+		ub.Assign("severities", values[4]),
+		ub.Assign("events", values[5]),
+		ub.Assign("is_enabled", rule.IsEnabled),
+		ub.Assign("updated_at", rule.UpdatedAt),
+	)
+	ub.Where(ub.Equal("id", rule.ID))
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update notification rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteNotificationRule deletes a notification rule
+func (o *ORMStorage) DeleteNotificationRule(ctx context.Context, id string) error {
+	result, err := o.db.ExecContext(ctx, `DELETE FROM notification_rules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
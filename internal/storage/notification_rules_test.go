@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationRuleMatches(t *testing.T) {
+	service := &Service{ID: "api", Tags: []string{"prod", "backend"}, Protocol: ServiceProtocolTypeHTTP}
+
+	tests := []struct {
+		name    string
+		rule    NotificationRule
+		matches bool
+	}{
+		{name: "Empty conditions match any notification", rule: NotificationRule{}, matches: true},
+		{name: "Service", rule: NotificationRule{ServiceIDs: []string{"db", "api"}}, matches: true},
+		{name: "Other service", rule: NotificationRule{ServiceIDs: []string{"db"}}},
+		{name: "Any of the tags", rule: NotificationRule{Tags: []string{"staging", "backend"}}, matches: true},
+		{name: "None of the tags", rule: NotificationRule{Tags: []string{"staging"}}},
+		{name: "Protocol", rule: NotificationRule{Protocols: []ServiceProtocolType{ServiceProtocolTypeHTTP}}, matches: true},
+		{name: "Other protocol", rule: NotificationRule{Protocols: []ServiceProtocolType{ServiceProtocolTypeTCP}}},
+		{name: "Severity", rule: NotificationRule{Severities: []IncidentSeverity{IncidentSeverityCritical}}, matches: true},
+		{name: "Other severity", rule: NotificationRule{Severities: []IncidentSeverity{IncidentSeverityWarning}}},
+		{name: "Event", rule: NotificationRule{Events: []NotificationEvent{NotificationEventAlert, NotificationEventRecovery}}, matches: true},
+		{name: "Other event", rule: NotificationRule{Events: []NotificationEvent{NotificationEventRecovery}}},
+		{
+			name: "All conditions match",
+			rule: NotificationRule{
+				ServiceIDs: []string{"api"},
+				Tags:       []string{"prod"},
+				Protocols:  []ServiceProtocolType{ServiceProtocolTypeHTTP},
+				Severities: []IncidentSeverity{IncidentSeverityCritical},
+				Events:     []NotificationEvent{NotificationEventAlert},
+			},
+			matches: true,
+		},
+		{
+			name: "One condition does not match",
+			rule: NotificationRule{
+				ServiceIDs: []string{"api"},
+				Tags:       []string{"prod"},
+				Protocols:  []ServiceProtocolType{ServiceProtocolTypeHTTP},
+				Severities: []IncidentSeverity{IncidentSeverityCritical},
+				Events:     []NotificationEvent{NotificationEventEscalation},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, tt.rule.Matches(service, IncidentSeverityCritical, NotificationEventAlert))
+		})
+	}
+}
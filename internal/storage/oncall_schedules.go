@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// OnCallScheduleRow represents a database row for on-call schedules
+type OnCallScheduleRow struct {
+	ID          string    `db:"id"`
+	Name        string    `db:"name"`
+	Users       string    `db:"users"`
+	StartDate   string    `db:"start_date"`
+	HandoffTime string    `db:"handoff_time"`
+	Overrides   string    `db:"overrides"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// onCallScheduleValues returns the JSON encoded users and overrides of a schedule
+func onCallScheduleValues(schedule *OnCallSchedule) (string, string, error) {
+	users := schedule.Users
+	if users == nil {
+		users = []OnCallUser{}
+	}
+
+	overrides := schedule.Overrides
+	if overrides == nil {
+		overrides = []OnCallOverride{}
+	}
+
+	usersData, err := json.Marshal(users)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal on-call users: %w", err)
+	}
+
+	overridesData, err := json.Marshal(overrides)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal on-call overrides: %w", err)
+	}
+
+	return string(usersData), string(overridesData), nil
+}
+
+// CreateOnCallSchedule creates a new on-call schedule
+func (o *ORMStorage) CreateOnCallSchedule(ctx context.Context, schedule *OnCallSchedule) error {
+	if schedule.ID == "" {
+		schedule.ID = GenerateULID()
+	}
+
+	users, overrides, err := onCallScheduleValues(schedule)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("oncall_schedules")
+	ib.Cols("id", "name", "users", "start_date", "handoff_time", "overrides", "created_at", "updated_at")
+	ib.Values(schedule.ID, schedule.Name, users, schedule.StartDate, schedule.HandoffTime, overrides, schedule.CreatedAt, schedule.UpdatedAt)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("on-call schedule %s %w", schedule.Name, ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to create on-call schedule: %w", err)
+	}
+
+	return nil
+}
+
+func findOnCallSchedulesBuilder() *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "name", "users", "start_date", "handoff_time", "overrides", "created_at", "updated_at")
+	sb.From("oncall_schedules")
+	return sb
+}
+
+// scanOnCallSchedule scans an on-call schedule row
+func scanOnCallSchedule(scanner interface{ Scan(dest ...any) error }) (*OnCallSchedule, error) {
+	var row OnCallScheduleRow
+	err := scanner.Scan(
+		&row.ID,
+		&row.Name,
+		&row.Users,
+		&row.StartDate,
+		&row.HandoffTime,
+		&row.Overrides,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &OnCallSchedule{
+		ID:          row.ID,
+		Name:        row.Name,
+		StartDate:   row.StartDate,
+		HandoffTime: row.HandoffTime,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+
+	if err := json.Unmarshal([]byte(row.Users), &schedule.Users); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal on-call users: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(row.Overrides), &schedule.Overrides); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal on-call overrides: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// GetOnCallScheduleByID gets an on-call schedule by ID
+func (o *ORMStorage) GetOnCallScheduleByID(ctx context.Context, id string) (*OnCallSchedule, error) {
+	sb := findOnCallSchedulesBuilder()
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	schedule, err := scanOnCallSchedule(o.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get on-call schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// FindOnCallSchedules finds all on-call schedules ordered by name
+func (o *ORMStorage) FindOnCallSchedules(ctx context.Context) ([]*OnCallSchedule, error) {
+	sb := findOnCallSchedulesBuilder()
+	sb.OrderBy("name").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query on-call schedules: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*OnCallSchedule{}
+	for rows.Next() {
+		schedule, err := scanOnCallSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan on-call schedule: %w", err)
+		}
+		items = append(items, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// UpdateOnCallSchedule updates an on-call schedule
+func (o *ORMStorage) UpdateOnCallSchedule(ctx context.Context, schedule *OnCallSchedule) error {
+	users, overrides, err := onCallScheduleValues(schedule)
+	if err != nil {
+		return err
+	}
+
+	schedule.UpdatedAt = time.Now()
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("oncall_schedules")
+	ub.Set(
+		ub.Assign("name", schedule.Name),
+		ub.Assign("users", users),
+		ub.Assign("start_date", schedule.StartDate),
+		ub.Assign("handoff_time", schedule.HandoffTime),
+		ub.Assign("overrides", overrides),
+		ub.Assign("updated_at", schedule.UpdatedAt),
+	)
+	ub.Where(ub.Equal("id", schedule.ID))
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("on-call schedule %s %w", schedule.Name, ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to update on-call schedule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteOnCallSchedule deletes an on-call schedule
+func (o *ORMStorage) DeleteOnCallSchedule(ctx context.Context, id string) error {
+	result, err := o.db.ExecContext(ctx, `DELETE FROM oncall_schedules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete on-call schedule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnCallScheduleShiftAt(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone database is not available: %v", err)
+	}
+
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+
+	// Mondays at 09:00, the rotation spans the DST change of 2025-03-30
+	schedule := &OnCallSchedule{
+		Name:        "primary",
+		Users:       []OnCallUser{{Name: "alice"}, {Name: "bob"}, {Name: "carol"}},
+		StartDate:   "2025-03-17",
+		HandoffTime: "09:00",
+	}
+
+	assert.Nil(t, schedule.ShiftAt(time.Date(2025, 3, 17, 8, 59, 0, 0, loc)), "before the first shift")
+
+	tests := []struct {
+		at    time.Time
+		user  string
+		start time.Time
+	}{
+		{time.Date(2025, 3, 17, 9, 0, 0, 0, loc), "alice", time.Date(2025, 3, 17, 9, 0, 0, 0, loc)},
+		{time.Date(2025, 3, 24, 8, 59, 0, 0, loc), "alice", time.Date(2025, 3, 17, 9, 0, 0, 0, loc)},
+		{time.Date(2025, 3, 24, 9, 0, 0, 0, loc), "bob", time.Date(2025, 3, 24, 9, 0, 0, 0, loc)},
+		{time.Date(2025, 3, 31, 8, 59, 0, 0, loc), "bob", time.Date(2025, 3, 24, 9, 0, 0, 0, loc)},
+		{time.Date(2025, 3, 31, 9, 0, 0, 0, loc), "carol", time.Date(2025, 3, 31, 9, 0, 0, 0, loc)},
+		{time.Date(2025, 4, 9, 12, 0, 0, 0, loc), "alice", time.Date(2025, 4, 7, 9, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		shift := schedule.ShiftAt(tt.at)
+		require.NotNil(t, shift, tt.at)
+		assert.Equal(t, tt.user, shift.User, tt.at)
+		assert.True(t, tt.start.Equal(shift.Start), "%s: start %s", tt.at, shift.Start)
+		assert.True(t, tt.start.AddDate(0, 0, 7).Equal(shift.End), "%s: end %s", tt.at, shift.End)
+		assert.False(t, shift.Override)
+	}
+
+	// Overrides take precedence over the rotation
+	schedule.Overrides = []OnCallOverride{{
+		User:  "carol",
+		Start: time.Date(2025, 3, 25, 18, 0, 0, 0, loc),
+		End:   time.Date(2025, 3, 26, 9, 0, 0, 0, loc),
+	}}
+
+	shift := schedule.ShiftAt(time.Date(2025, 3, 25, 20, 0, 0, 0, loc))
+	require.NotNil(t, shift)
+	assert.Equal(t, "carol", shift.User)
+	assert.True(t, shift.Override)
+
+	shift = schedule.ShiftAt(time.Date(2025, 3, 26, 9, 0, 0, 0, loc))
+	require.NotNil(t, shift)
+	assert.Equal(t, "bob", shift.User)
+}
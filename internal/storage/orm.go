@@ -16,113 +16,16 @@ import (
 
 // ORMStorage provides ORM-like functionality using go-sqlbuilder
 type ORMStorage struct {
-	db *sql.DB
+	db *sqlDB
 }
 
-// NewORMStorage creates a new ORM storage instance
-func NewORMStorage(db *sql.DB) *ORMStorage {
-	return &ORMStorage{db: db}
-}
-
-// GetServiceStatsWithORM calculates statistics for a service using ORM
-func (o *ORMStorage) GetServiceStatsWithORM(ctx context.Context, params FindIncidentsParams) (*ServiceStats, error) {
-	if params.ServiceID == "" || params.StartTime == nil {
-		return nil, fmt.Errorf("service ID and start time are required for stats")
-	}
-
-	// Get all incidents for the service since the specified time
-	sb := findIncidentsBuilder(params,
-		"i.id",
-		"i.service_id",
-		"i.start_time",
-		"i.end_time",
-		"i.error",
-		"i.duration_ns",
-		"i.resolved",
-	)
-
-	sql, args := sb.Build()
-	rows, err := o.db.QueryContext(ctx, sql, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query incidents: %w", err)
-	}
-	defer rows.Close()
-
-	incidents := []*Incident{}
-	for rows.Next() {
-		var incidentRow IncidentRow
-		err := rows.Scan(
-			&incidentRow.ID,
-			&incidentRow.ServiceID,
-			&incidentRow.StartTime,
-			&incidentRow.EndTime,
-			&incidentRow.Error,
-			&incidentRow.DurationNS,
-			&incidentRow.Resolved,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan incident: %w", err)
-		}
-
-		incidents = append(incidents, o.rowToIncident(&incidentRow))
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
-	}
-
-	// Calculate statistics
-	totalIncidents := len(incidents)
-	totalDowntime := time.Duration(0)
-	resolvedIncidents := 0
-
-	for _, incident := range incidents {
-		if incident.Resolved && incident.Duration != nil {
-			totalDowntime += *incident.Duration
-			resolvedIncidents++
-		}
-	}
-
-	// Calculate uptime percentage
-	period := time.Since(*params.StartTime)
-	uptimePercentage := 100.0
-	if period > 0 {
-		uptimePercentage = 100.0 - (float64(totalDowntime) / float64(period) * 100.0)
-		if uptimePercentage < 0 {
-			uptimePercentage = 0
-		}
-	}
-
-	// Get average response time from service state
-	avgResponseTime := time.Duration(0)
-	serviceState, err := o.GetServiceState(ctx, params.ServiceID)
-	if err != nil {
-		// If service state not found, return stats without response time
-		return &ServiceStats{
-			ServiceID:        params.ServiceID,
-			TotalIncidents:   totalIncidents,
-			TotalDowntime:    totalDowntime,
-			UptimePercentage: uptimePercentage,
-			Period:           period,
-			AvgResponseTime:  0,
-		}, nil
-	}
-	if serviceState != nil && serviceState.ResponseTimeNS != nil {
-		avgResponseTime = time.Duration(*serviceState.ResponseTimeNS)
-	}
-
-	return &ServiceStats{
-		ServiceID:        params.ServiceID,
-		TotalIncidents:   totalIncidents,
-		TotalDowntime:    totalDowntime,
-		UptimePercentage: uptimePercentage,
-		Period:           period,
-		AvgResponseTime:  avgResponseTime,
-	}, nil
+// NewORMStorage creates a new ORM storage instance for the given SQL dialect
+func NewORMStorage(db *sql.DB, d dialect) *ORMStorage {
+	return &ORMStorage{db: &sqlDB{DB: db, dialect: d}}
 }
 
 // rowToIncident converts an IncidentRow to Incident
-func (o *ORMStorage) rowToIncident(row *IncidentRow) *Incident {
+func (o *ORMStorage) rowToIncident(row *IncidentRow) (*Incident, error) {
 	incident := &Incident{
 		ID:        row.ID,
 		ServiceID: row.ServiceID,
@@ -130,6 +33,16 @@ func (o *ORMStorage) rowToIncident(row *IncidentRow) *Incident {
 		EndTime:   row.EndTime,
 		Error:     row.Error,
 		Resolved:  row.Resolved,
+		Severity:  IncidentSeverity(row.Severity),
+		Source:    IncidentSource(row.Source),
+		Title:     row.Title,
+		AlertKey:  row.AlertKey,
+
+		AlertSent:    row.AlertSent,
+		RecoverySent: row.RecoverySent,
+
+		AcknowledgedAt: row.AcknowledgedAt,
+		AcknowledgedBy: row.AcknowledgedBy,
 	}
 
 	if row.DurationNS != nil {
@@ -137,7 +50,23 @@ func (o *ORMStorage) rowToIncident(row *IncidentRow) *Incident {
 		incident.Duration = &duration
 	}
 
-	return incident
+	if row.AffectedServices != "" {
+		if err := json.Unmarshal([]byte(row.AffectedServices), &incident.AffectedServices); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal affected services: %w", err)
+		}
+		if len(incident.AffectedServices) == 0 {
+			incident.AffectedServices = nil
+		}
+	}
+
+	if row.Postmortem != nil && *row.Postmortem != "" {
+		incident.Postmortem = &Postmortem{}
+		if err := json.Unmarshal([]byte(*row.Postmortem), incident.Postmortem); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal postmortem: %w", err)
+		}
+	}
+
+	return incident, nil
 }
 
 // GetServiceByID finds a service by ID using ORM
@@ -150,13 +79,18 @@ func (o *ORMStorage) GetServiceByID(ctx context.Context, id string) (*Service, e
 		"s.interval",
 		"s.timeout",
 		"s.retries",
+		"s.retry_policy",
+		"s.threshold_policy",
+		"s.latency_warning",
 		"s.tags",
 		"s.config",
 		"s.is_enabled",
+		"s.managed_by",
+		"s.external_id",
 		"s.created_at",
 		"s.updated_at",
 		"count(incidents.id) as total_incidents",
-		"sum(case when incidents.resolved = 0 then 1 else 0 end) as active_incidents",
+		"sum(case when incidents.resolved = FALSE then 1 else 0 end) as active_incidents",
 		"ss.status",
 		"ss.last_check",
 		"ss.next_check",
@@ -165,12 +99,13 @@ func (o *ORMStorage) GetServiceByID(ctx context.Context, id string) (*Service, e
 		"ss.consecutive_success",
 		"ss.total_checks",
 		"ss.response_time_ns",
+		"COALESCE(ss.is_flapping, FALSE)",
 	)
 	sb.From("services s")
-	sb.JoinWithOption(sqlbuilder.LeftJoin, "incidents", "s.id = incidents.service_id")
+	sb.JoinWithOption(sqlbuilder.LeftJoin, "incidents", "s.id = incidents.service_id OR "+o.db.dialect.jsonArrayContains("incidents.affected_services", "s.id"))
 	sb.JoinWithOption(sqlbuilder.LeftJoin, "service_states ss", "s.id = ss.service_id")
 	sb.Where(sb.Equal("s.id", id))
-	sb.GroupBy("s.id")
+	sb.GroupBy("s.id", "ss.id")
 
 	query, args := sb.Build()
 	row := o.db.QueryRowContext(ctx, query, args...)
@@ -183,9 +118,14 @@ func (o *ORMStorage) GetServiceByID(ctx context.Context, id string) (*Service, e
 		&item.Interval,
 		&item.Timeout,
 		&item.Retries,
+		&item.RetryPolicy,
+		&item.ThresholdPolicy,
+		&item.LatencyWarning,
 		&item.Tags,
 		&item.Config,
 		&item.IsEnabled,
+		&item.ManagedBy,
+		&item.ExternalID,
 		&item.CreatedAt,
 		&item.UpdatedAt,
 		&item.TotalIncidents,
@@ -198,6 +138,7 @@ func (o *ORMStorage) GetServiceByID(ctx context.Context, id string) (*Service, e
 		&item.ConsecutiveSuccess,
 		&item.TotalChecks,
 		&item.ResponseTimeNS,
+		&item.IsFlapping,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -214,38 +155,36 @@ func (o *ORMStorage) GetServiceByID(ctx context.Context, id string) (*Service, e
 	return svc, nil
 }
 
-func findServicesBuilder(params FindServicesParams, col ...string) *sqlbuilder.SelectBuilder {
+func (o *ORMStorage) findServicesBuilder(params FindServicesParams, col ...string) *sqlbuilder.SelectBuilder {
 	sb := sqlbuilder.NewSelectBuilder()
 	sb.Select(col...)
 	sb.From("services s")
 
 	if params.Name != "" {
-		sb.Where(sb.Like("s.name", "%"+params.Name+"%"))
+		sb.Where(fmt.Sprintf("LOWER(s.name) LIKE LOWER(%s)", sb.Var("%"+params.Name+"%")))
 	}
 
 	if params.Protocol != "" {
 		sb.Where(sb.Equal("s.protocol", params.Protocol))
 	}
 
+	if params.ExternalID != "" {
+		sb.Where(sb.Equal("s.external_id", params.ExternalID))
+	}
+
 	if params.IsEnabled != nil {
 		sb.Where(sb.Equal("s.is_enabled", *params.IsEnabled))
 	}
 
 	if params.Status != "" {
-		switch params.Status {
-		case "up":
-			sb.Where(sb.Equal("ss.status", StatusUp))
-		case "down":
-			sb.Where(sb.Equal("ss.status", StatusDown))
-		}
+		sb.Where(sb.Equal("ss.status", ServiceStatus(params.Status)))
 	}
 
 	if len(params.Tags) > 0 {
 		var tagConditions []string
 		for _, tag := range params.Tags {
 			tagConditions = append(tagConditions,
-				fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(s.tags) WHERE json_each.value = %s)",
-					sb.Args.Add(tag)))
+				o.db.dialect.jsonArrayContains("s.tags", sb.Args.Add(tag)))
 		}
 
 		if len(tagConditions) > 0 {
@@ -257,19 +196,20 @@ func findServicesBuilder(params FindServicesParams, col ...string) *sqlbuilder.S
 }
 
 type FindServicesParams struct {
-	Name      string
-	IsEnabled *bool
-	Protocol  string
-	Tags      []string
-	Status    string // e.g. "up", "down"
-	OrderBy   string
-	Page      *uint32
-	PageSize  *uint32
+	Name       string
+	IsEnabled  *bool
+	Protocol   string
+	ExternalID string
+	Tags       []string
+	Status     string // e.g. "up", "degraded", "down"
+	OrderBy    string
+	Page       *uint32
+	PageSize   *uint32
 }
 
 // GetAllServices finds all services using ORM
 func (o *ORMStorage) FindServices(ctx context.Context, params FindServicesParams) (dbutils.FindResponseWithCount[*Service], error) {
-	sb := findServicesBuilder(
+	sb := o.findServicesBuilder(
 		params,
 		"s.id",
 		"s.name",
@@ -277,13 +217,18 @@ func (o *ORMStorage) FindServices(ctx context.Context, params FindServicesParams
 		"s.interval",
 		"s.timeout",
 		"s.retries",
+		"s.retry_policy",
+		"s.threshold_policy",
+		"s.latency_warning",
 		"s.tags",
 		"s.config",
 		"s.is_enabled",
+		"s.managed_by",
+		"s.external_id",
 		"s.created_at",
 		"s.updated_at",
 		"count(incidents.id) as total_incidents",
-		"sum(case when incidents.resolved = 0 then 1 else 0 end) as active_incidents",
+		"sum(case when incidents.resolved = FALSE then 1 else 0 end) as active_incidents",
 		"ss.status",
 		"ss.last_check",
 		"ss.next_check",
@@ -292,10 +237,11 @@ func (o *ORMStorage) FindServices(ctx context.Context, params FindServicesParams
 		"ss.consecutive_success",
 		"ss.total_checks",
 		"ss.response_time_ns",
+		"COALESCE(ss.is_flapping, FALSE)",
 	)
-	sb.JoinWithOption(sqlbuilder.LeftJoin, "incidents", "s.id = incidents.service_id")
+	sb.JoinWithOption(sqlbuilder.LeftJoin, "incidents", "s.id = incidents.service_id OR "+o.db.dialect.jsonArrayContains("incidents.affected_services", "s.id"))
 	sb.JoinWithOption(sqlbuilder.LeftJoin, "service_states ss", "s.id = ss.service_id")
-	sb.GroupBy("s.id")
+	sb.GroupBy("s.id", "ss.id")
 
 	if params.OrderBy != "" {
 		// Add table prefix for common column names to avoid ambiguity
@@ -344,9 +290,14 @@ func (o *ORMStorage) FindServices(ctx context.Context, params FindServicesParams
 			&item.Interval,
 			&item.Timeout,
 			&item.Retries,
+			&item.RetryPolicy,
+			&item.ThresholdPolicy,
+			&item.LatencyWarning,
 			&item.Tags,
 			&item.Config,
 			&item.IsEnabled,
+			&item.ManagedBy,
+			&item.ExternalID,
 			&item.CreatedAt,
 			&item.UpdatedAt,
 			&item.TotalIncidents,
@@ -359,6 +310,7 @@ func (o *ORMStorage) FindServices(ctx context.Context, params FindServicesParams
 			&item.ConsecutiveSuccess,
 			&item.TotalChecks,
 			&item.ResponseTimeNS,
+			&item.IsFlapping,
 		)
 		if err != nil {
 			return res, fmt.Errorf("failed to scan service: %w", err)
@@ -377,7 +329,7 @@ func (o *ORMStorage) FindServices(ctx context.Context, params FindServicesParams
 	}
 
 	// Get total count of services
-	countQuery := findServicesBuilder(params, "count(*)")
+	countQuery := o.findServicesBuilder(params, "count(*)")
 	countQuery.JoinWithOption(sqlbuilder.LeftJoin, "service_states ss", "s.id = ss.service_id")
 
 	countSQL, countArgs := countQuery.Build()
@@ -397,7 +349,17 @@ func (o *ORMStorage) FindServices(ctx context.Context, params FindServicesParams
 func (o *ORMStorage) CreateService(ctx context.Context, service CreateUpdateServiceRequest) (*Service, error) {
 	ib := sqlbuilder.NewInsertBuilder()
 	ib.InsertInto("services")
-	ib.Cols("id", "name", "protocol", "interval", "timeout", "retries", "tags", "config", "is_enabled")
+	ib.Cols("id", "name", "protocol", "interval", "timeout", "retries", "retry_policy", "threshold_policy", "latency_warning", "tags", "config", "is_enabled", "managed_by", "external_id")
+
+	retryPolicyJSON, err := marshalNullableJSON(service.RetryPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal retry policy: %w", err)
+	}
+
+	thresholdPolicyJSON, err := marshalNullableJSON(service.ThresholdPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal threshold policy: %w", err)
+	}
 
 	tagsJSON, err := json.Marshal(service.Tags)
 	if err != nil {
@@ -418,9 +380,14 @@ func (o *ORMStorage) CreateService(ctx context.Context, service CreateUpdateServ
 		service.Interval.String(),
 		service.Timeout.String(),
 		service.Retries,
+		retryPolicyJSON,
+		thresholdPolicyJSON,
+		durationToString(service.LatencyWarning),
 		string(tagsJSON),
 		string(configJSON),
 		service.IsEnabled,
+		service.ManagedBy,
+		nullableString(service.ExternalID),
 	)
 
 	tx, err := o.db.BeginTx(ctx, nil)
@@ -432,6 +399,9 @@ func (o *ORMStorage) CreateService(ctx context.Context, service CreateUpdateServ
 	sql, args := ib.Build()
 	_, err = tx.ExecContext(ctx, sql, args...)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("service with external ID %s %w", service.ExternalID, ErrAlreadyExists)
+		}
 		return nil, fmt.Errorf("failed to create service: %w", err)
 	}
 
@@ -447,6 +417,12 @@ func (o *ORMStorage) CreateService(ctx context.Context, service CreateUpdateServ
 		return nil, fmt.Errorf("failed to create service state: %w", err)
 	}
 
+	if !service.IsEnabled {
+		if err := updateDisabledPause(ctx, tx, serviceID, service.IsEnabled, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
@@ -460,6 +436,16 @@ func (o *ORMStorage) UpdateService(ctx context.Context, id string, service Creat
 	ub := sqlbuilder.NewUpdateBuilder()
 	ub.Update("services")
 
+	retryPolicyJSON, err := marshalNullableJSON(service.RetryPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal retry policy: %w", err)
+	}
+
+	thresholdPolicyJSON, err := marshalNullableJSON(service.ThresholdPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal threshold policy: %w", err)
+	}
+
 	tagsJSON, err := json.Marshal(service.Tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal tags: %w", err)
@@ -470,6 +456,8 @@ func (o *ORMStorage) UpdateService(ctx context.Context, id string, service Creat
 		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
 
+	now := time.Now()
+
 	// Prepare all fields for update
 	assignments := []string{
 		ub.Assign("name", service.Name),
@@ -477,19 +465,33 @@ func (o *ORMStorage) UpdateService(ctx context.Context, id string, service Creat
 		ub.Assign("interval", service.Interval.String()),
 		ub.Assign("timeout", service.Timeout.String()),
 		ub.Assign("retries", service.Retries),
+		ub.Assign("retry_policy", retryPolicyJSON),
+		ub.Assign("threshold_policy", thresholdPolicyJSON),
+		ub.Assign("latency_warning", durationToString(service.LatencyWarning)),
 		ub.Assign("tags", string(tagsJSON)),
 		ub.Assign("config", string(configJSON)),
 		ub.Assign("is_enabled", service.IsEnabled),
-		ub.Assign("updated_at", time.Now()),
+		ub.Assign("managed_by", service.ManagedBy),
+		ub.Assign("external_id", nullableString(service.ExternalID)),
+		ub.Assign("updated_at", now),
 	}
 
 	// Set all assignments at once
 	ub.Set(assignments...)
 	ub.Where(ub.Equal("id", id))
 
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	sql, args := ub.Build()
-	result, err := o.db.ExecContext(ctx, sql, args...)
+	result, err := tx.ExecContext(ctx, sql, args...)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("service with external ID %s %w", service.ExternalID, ErrAlreadyExists)
+		}
 		return nil, fmt.Errorf("failed to update service: %w", err)
 	}
 
@@ -502,6 +504,16 @@ func (o *ORMStorage) UpdateService(ctx context.Context, id string, service Creat
 		return nil, fmt.Errorf("service not found")
 	}
 
+	// Track disabled periods, they are excluded from the service uptime
+	if err := updateDisabledPause(ctx, tx, id, service.IsEnabled, now); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return o.GetServiceByID(ctx, id)
 }
 
@@ -515,12 +527,61 @@ func (o *ORMStorage) DeleteService(ctx context.Context, id string) error {
 	defer tx.Rollback()
 
 	// Delete related incidents first
+	eventsQuery := `DELETE FROM incident_events WHERE incident_id IN (SELECT id FROM incidents WHERE service_id = ?)`
+	_, err = tx.ExecContext(ctx, eventsQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete incident events: %w", err)
+	}
+
+	errorsQuery := `DELETE FROM incident_errors WHERE incident_id IN (SELECT id FROM incidents WHERE service_id = ?)`
+	_, err = tx.ExecContext(ctx, errorsQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete incident errors: %w", err)
+	}
+
+	escalationsQuery := `DELETE FROM incident_escalations WHERE incident_id IN (SELECT id FROM incidents WHERE service_id = ?)`
+	_, err = tx.ExecContext(ctx, escalationsQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete incident escalations: %w", err)
+	}
+
 	incidentsQuery := `DELETE FROM incidents WHERE service_id = ?`
 	_, err = tx.ExecContext(ctx, incidentsQuery, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete incidents: %w", err)
 	}
 
+	// Delete check history
+	checkResultsQuery := `DELETE FROM check_results WHERE service_id = ?`
+	_, err = tx.ExecContext(ctx, checkResultsQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete check results: %w", err)
+	}
+
+	rollupsQuery := `DELETE FROM check_result_rollups WHERE service_id = ?`
+	_, err = tx.ExecContext(ctx, rollupsQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete check result rollups: %w", err)
+	}
+
+	pausesQuery := `DELETE FROM service_pauses WHERE service_id = ?`
+	_, err = tx.ExecContext(ctx, pausesQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete service pauses: %w", err)
+	}
+
+	// Delete SLOs of the service, SLOs of tags are kept
+	sloBucketsQuery := `DELETE FROM slo_buckets WHERE slo_id IN (SELECT id FROM slos WHERE service_id = ?)`
+	_, err = tx.ExecContext(ctx, sloBucketsQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete SLO buckets: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM slos WHERE service_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete SLOs: %w", err)
+	}
+
 	// Delete service state
 	stateQuery := `DELETE FROM service_states WHERE service_id = ?`
 	_, err = tx.ExecContext(ctx, stateQuery, id)
@@ -559,7 +620,7 @@ func (o *ORMStorage) GetServiceState(ctx context.Context, serviceID string) (*Se
 	query := `
 		SELECT id, service_id, status, last_check, next_check, last_error, 
 		       consecutive_fails, consecutive_success, total_checks, response_time_ns,
-		       created_at, updated_at
+		       recent_results, is_flapping, created_at, updated_at
 		FROM service_states 
 		WHERE service_id = ?
 	`
@@ -576,6 +637,8 @@ func (o *ORMStorage) GetServiceState(ctx context.Context, serviceID string) (*Se
 		&state.ConsecutiveSuccess,
 		&state.TotalChecks,
 		&state.ResponseTimeNS,
+		&state.RecentResults,
+		&state.IsFlapping,
 		&state.CreatedAt,
 		&state.UpdatedAt,
 	)
@@ -590,12 +653,13 @@ func (o *ORMStorage) GetServiceState(ctx context.Context, serviceID string) (*Se
 }
 
 // CreateServiceState creates a new service state
-func (o *ORMStorage) CreateServiceState(ctx context.Context, tx *sql.Tx, state *ServiceStateRecord) error {
+func (o *ORMStorage) CreateServiceState(ctx context.Context, tx *sqlTx, state *ServiceStateRecord) error {
 	query := `
 		INSERT INTO service_states (
 			id, service_id, status, last_check, next_check, last_error,
-			consecutive_fails, consecutive_success, total_checks, response_time_ns
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			consecutive_fails, consecutive_success, total_checks, response_time_ns,
+			recent_results, is_flapping
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := tx.ExecContext(ctx, query,
@@ -609,6 +673,8 @@ func (o *ORMStorage) CreateServiceState(ctx context.Context, tx *sql.Tx, state *
 		state.ConsecutiveSuccess,
 		state.TotalChecks,
 		state.ResponseTimeNS,
+		state.RecentResults,
+		state.IsFlapping,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create service state: %w", err)
@@ -629,6 +695,8 @@ func (o *ORMStorage) UpdateServiceState(ctx context.Context, params *ServiceStat
 		ub.Assign("consecutive_success", params.ConsecutiveSuccess),
 		ub.Assign("total_checks", params.TotalChecks),
 		ub.Assign("response_time_ns", params.ResponseTimeNS),
+		ub.Assign("recent_results", params.RecentResults),
+		ub.Assign("is_flapping", params.IsFlapping),
 		ub.Assign("updated_at", time.Now()),
 	)
 
@@ -647,7 +715,7 @@ func (o *ORMStorage) GetAllServiceStates(ctx context.Context) ([]*ServiceStateRe
 	query := `
 		SELECT id, service_id, status, last_check, next_check, last_error,
 		       consecutive_fails, consecutive_success, total_checks, response_time_ns,
-		       created_at, updated_at
+		       recent_results, is_flapping, created_at, updated_at
 		FROM service_states
 		ORDER BY updated_at DESC
 	`
@@ -664,7 +732,8 @@ func (o *ORMStorage) GetAllServiceStates(ctx context.Context) ([]*ServiceStateRe
 		err := rows.Scan(
 			&state.ID, &state.ServiceID, &state.Status, &state.LastCheck, &state.NextCheck,
 			&state.LastError, &state.ConsecutiveFails, &state.ConsecutiveSuccess,
-			&state.TotalChecks, &state.ResponseTimeNS, &state.CreatedAt, &state.UpdatedAt,
+			&state.TotalChecks, &state.ResponseTimeNS, &state.RecentResults, &state.IsFlapping,
+			&state.CreatedAt, &state.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan service state: %w", err)
@@ -692,7 +761,7 @@ func (o *ORMStorage) DeleteServiceState(ctx context.Context, serviceID string) e
 func (o *ORMStorage) GetAllTags(ctx context.Context) ([]string, error) {
 	sb := sqlbuilder.NewSelectBuilder()
 	sb.Select("DISTINCT json_each.value")
-	sb.From("services, json_each(tags)")
+	sb.From("services, " + o.db.dialect.jsonArrayElements("tags"))
 	sb.OrderBy("json_each.value")
 
 	sql, args := sb.Build()
@@ -721,7 +790,7 @@ func (o *ORMStorage) GetAllTags(ctx context.Context) ([]string, error) {
 func (o *ORMStorage) GetAllTagsWithCount(ctx context.Context) (map[string]int, error) {
 	sb := sqlbuilder.NewSelectBuilder()
 	sb.Select("json_each.value, COUNT(*)")
-	sb.From("services, json_each(tags)")
+	sb.From("services, " + o.db.dialect.jsonArrayElements("tags"))
 	sb.GroupBy("json_each.value")
 	sb.OrderBy("json_each.value")
 
@@ -771,6 +840,30 @@ func rowToService(row *serviceRow) (*Service, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	var retryPolicy *RetryPolicy
+	if row.RetryPolicy != nil && *row.RetryPolicy != "" {
+		retryPolicy = &RetryPolicy{}
+		if err := json.Unmarshal([]byte(*row.RetryPolicy), retryPolicy); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal retry policy: %w", err)
+		}
+	}
+
+	var thresholdPolicy *ThresholdPolicy
+	if row.ThresholdPolicy != nil && *row.ThresholdPolicy != "" {
+		thresholdPolicy = &ThresholdPolicy{}
+		if err := json.Unmarshal([]byte(*row.ThresholdPolicy), thresholdPolicy); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal threshold policy: %w", err)
+		}
+	}
+
+	var latencyWarning time.Duration
+	if row.LatencyWarning != nil && *row.LatencyWarning != "" {
+		latencyWarning, err = time.ParseDuration(*row.LatencyWarning)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse latency warning: %w", err)
+		}
+	}
+
 	svc := &Service{
 		ID:                 row.ID,
 		Name:               row.Name,
@@ -778,9 +871,13 @@ func rowToService(row *serviceRow) (*Service, error) {
 		Interval:           interval,
 		Timeout:            timeout,
 		Retries:            row.Retries,
+		RetryPolicy:        retryPolicy,
+		ThresholdPolicy:    thresholdPolicy,
+		LatencyWarning:     latencyWarning,
 		Tags:               tags,
 		Config:             config,
 		IsEnabled:          row.IsEnabled,
+		ManagedBy:          row.ManagedBy,
 		CreatedAt:          row.CreatedAt,
 		UpdatedAt:          row.UpdatedAt,
 		TotalIncidents:     row.TotalIncidents,
@@ -792,12 +889,17 @@ func rowToService(row *serviceRow) (*Service, error) {
 		ConsecutiveFails:   row.ConsecutiveFails,
 		ConsecutiveSuccess: row.ConsecutiveSuccess,
 		TotalChecks:        row.TotalChecks,
+		IsFlapping:         row.IsFlapping,
 	}
 
 	if row.ResponseTimeNS != nil {
 		svc.ResponseTime = utils.Pointer(time.Duration(*row.ResponseTimeNS))
 	}
 
+	if row.ExternalID != nil {
+		svc.ExternalID = *row.ExternalID
+	}
+
 	return svc, nil
 }
 
@@ -809,3 +911,25 @@ func durationToNS(d *time.Duration) *int64 {
 	ns := d.Nanoseconds()
 	return &ns
 }
+
+// durationToString converts a duration to its string representation, zero durations are stored as NULL
+func durationToString(d time.Duration) *string {
+	if d <= 0 {
+		return nil
+	}
+	return utils.Pointer(d.String())
+}
+
+// marshalNullableJSON marshals a pointer value to JSON, nil values are stored as NULL
+func marshalNullableJSON[T any](v *T) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Pointer(string(data)), nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// PostgresStorage implements Storage interface using PostgreSQL
+type PostgresStorage struct {
+	db *sql.DB
+	*ORMStorage
+}
+
+var _ Storage = (*PostgresStorage)(nil)
+
+// NewPostgresStorage creates a new PostgreSQL storage instance
+func NewPostgresStorage(opts Options) (*PostgresStorage, error) {
+	if opts.DSN == "" {
+		return nil, fmt.Errorf("postgres dsn is required")
+	}
+
+	db, err := sql.Open("pgx", opts.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Set connection pool settings
+	db.SetMaxOpenConns(opts.MaxOpenConns)
+	db.SetMaxIdleConns(opts.MaxIdleConns)
+	db.SetConnMaxLifetime(opts.ConnMaxLifetime)
+
+	// Test connection
+	if err := db.PingContext(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	storage := &PostgresStorage{
+		db:         db,
+		ORMStorage: NewORMStorage(db, postgresDialect),
+	}
+
+	if err := runMigrations(storage.ORMStorage.db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return storage, nil
+}
+
+// Name returns the storage type
+func (s *PostgresStorage) Name() string {
+	return "PostgreSQL"
+}
+
+// Start initializes the storage
+func (s *PostgresStorage) Start(_ context.Context) error {
+	if s.db == nil {
+		return fmt.Errorf("storage not initialized")
+	}
+	return nil
+}
+
+// Stop closes the database connection pool
+func (s *PostgresStorage) Stop(_ context.Context) error {
+	if s.db != nil {
+		if err := s.db.Close(); err != nil {
+			return fmt.Errorf("failed to close database: %w", err)
+		}
+		s.db = nil
+	}
+	return nil
+}
+
+// SaveIncident saves a new incident to the database
+func (s *PostgresStorage) SaveIncident(ctx context.Context, incident *Incident) error {
+	incident.ID = GenerateULID()
+
+	return s.CreateIncident(ctx, incident)
+}
+
+// GetServiceStats calculates statistics for a service
+func (s *PostgresStorage) GetServiceStats(ctx context.Context, params ServiceStatsParams) (*ServiceStats, error) {
+	return s.GetServiceStatsWithORM(ctx, params)
+}
+
+// GetSQLiteVersion returns an empty version, the storage does not use SQLite
+func (s *PostgresStorage) GetSQLiteVersion(_ context.Context) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// CreateServicePause creates a period excluded from the service uptime
+func (o *ORMStorage) CreateServicePause(ctx context.Context, pause *ServicePause) error {
+	if pause.ServiceID == "" {
+		return fmt.Errorf("service ID is required")
+	}
+
+	if pause.ID == "" {
+		pause.ID = GenerateULID()
+	}
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("service_pauses")
+	ib.Cols("id", "service_id", "reason", "start_time", "end_time", "description")
+	ib.Values(pause.ID, pause.ServiceID, pause.Reason, pause.StartTime, pause.EndTime, pause.Description)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create service pause: %w", err)
+	}
+
+	return nil
+}
+
+// FindServicePausesParams holds filters for service pauses
+type FindServicePausesParams struct {
+	ServiceID string
+	Reason    PauseReason
+}
+
+// FindServicePauses finds service pauses ordered by start time
+func (o *ORMStorage) FindServicePauses(ctx context.Context, params FindServicePausesParams) ([]*ServicePause, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "service_id", "reason", "start_time", "end_time", "description", "created_at")
+	sb.From("service_pauses")
+
+	if params.ServiceID != "" {
+		sb.Where(sb.Equal("service_id", params.ServiceID))
+	}
+
+	if params.Reason != "" {
+		sb.Where(sb.Equal("reason", params.Reason))
+	}
+
+	sb.OrderBy("start_time").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query service pauses: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*ServicePause{}
+	for rows.Next() {
+		var item ServicePause
+		err := rows.Scan(
+			&item.ID,
+			&item.ServiceID,
+			&item.Reason,
+			&item.StartTime,
+			&item.EndTime,
+			&item.Description,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service pause: %w", err)
+		}
+
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// DeleteServicePause deletes a service pause
+func (o *ORMStorage) DeleteServicePause(ctx context.Context, id string) error {
+	result, err := o.db.ExecContext(ctx, `DELETE FROM service_pauses WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete service pause: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// updateDisabledPause opens a disabled period when a service is disabled
+// and closes it when the service is enabled again
+func updateDisabledPause(ctx context.Context, tx *sqlTx, serviceID string, isEnabled bool, now time.Time) error {
+	if isEnabled {
+		query := `UPDATE service_pauses SET end_time = ? WHERE service_id = ? AND reason = ? AND end_time IS NULL`
+		if _, err := tx.ExecContext(ctx, query, now, serviceID, PauseReasonDisabled); err != nil {
+			return fmt.Errorf("failed to close disabled period: %w", err)
+		}
+		return nil
+	}
+
+	var id string
+	query := `SELECT id FROM service_pauses WHERE service_id = ? AND reason = ? AND end_time IS NULL`
+	err := tx.QueryRowContext(ctx, query, serviceID, PauseReasonDisabled).Scan(&id)
+	if err == nil {
+		// Already disabled
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get disabled period: %w", err)
+	}
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("service_pauses")
+	ib.Cols("id", "service_id", "reason", "start_time")
+	ib.Values(GenerateULID(), serviceID, PauseReasonDisabled, now)
+
+	insertQuery, args := ib.Build()
+	if _, err := tx.ExecContext(ctx, insertQuery, args...); err != nil {
+		return fmt.Errorf("failed to open disabled period: %w", err)
+	}
+
+	return nil
+}
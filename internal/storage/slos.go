@@ -0,0 +1,488 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// SLORow represents a database row for SLOs
+type SLORow struct {
+	ID               string    `db:"id"`
+	Name             string    `db:"name"`
+	ServiceID        *string   `db:"service_id"`
+	Tag              *string   `db:"tag"`
+	Target           float64   `db:"target"`
+	LatencyTarget    *string   `db:"latency_target"`
+	ComplianceWindow string    `db:"compliance_window"`
+	BurnRateAlert    *string   `db:"burn_rate_alert"`
+	Alerting         bool      `db:"alerting"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+// sloCounts holds the number of checks counted by an SLO
+type sloCounts struct {
+	total int64
+	good  int64
+}
+
+// nullableString stores empty strings as NULL
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// CreateSLO creates a new SLO
+func (o *ORMStorage) CreateSLO(ctx context.Context, slo *SLO) error {
+	if slo.ID == "" {
+		slo.ID = GenerateULID()
+	}
+
+	burnRateAlertJSON, err := marshalNullableJSON(slo.BurnRateAlert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal burn rate alert: %w", err)
+	}
+
+	now := time.Now()
+	slo.CreatedAt = now
+	slo.UpdatedAt = now
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("slos")
+	ib.Cols("id", "name", "service_id", "tag", "target", "latency_target", "compliance_window", "burn_rate_alert", "alerting", "created_at", "updated_at")
+	ib.Values(
+		slo.ID,
+		slo.Name,
+		nullableString(slo.ServiceID),
+		nullableString(slo.Tag),
+		slo.Target,
+		durationToString(slo.LatencyTarget),
+		slo.Window,
+		burnRateAlertJSON,
+		slo.Alerting,
+		slo.CreatedAt,
+		slo.UpdatedAt,
+	)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create SLO: %w", err)
+	}
+
+	return nil
+}
+
+// FindSLOsParams holds filters for SLOs
+type FindSLOsParams struct {
+	ServiceID string
+	Tag       string
+}
+
+func findSLOsBuilder(params FindSLOsParams) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"id",
+		"name",
+		"service_id",
+		"tag",
+		"target",
+		"latency_target",
+		"compliance_window",
+		"burn_rate_alert",
+		"alerting",
+		"created_at",
+		"updated_at",
+	)
+	sb.From("slos")
+
+	if params.ServiceID != "" {
+		sb.Where(sb.Equal("service_id", params.ServiceID))
+	}
+
+	if params.Tag != "" {
+		sb.Where(sb.Equal("tag", params.Tag))
+	}
+
+	return sb
+}
+
+// scanSLO scans an SLO row
+func scanSLO(scanner interface{ Scan(dest ...any) error }) (*SLO, error) {
+	var row SLORow
+	err := scanner.Scan(
+		&row.ID,
+		&row.Name,
+		&row.ServiceID,
+		&row.Tag,
+		&row.Target,
+		&row.LatencyTarget,
+		&row.ComplianceWindow,
+		&row.BurnRateAlert,
+		&row.Alerting,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	slo := &SLO{
+		ID:        row.ID,
+		Name:      row.Name,
+		Target:    row.Target,
+		Window:    SLOWindow(row.ComplianceWindow),
+		Alerting:  row.Alerting,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	if row.ServiceID != nil {
+		slo.ServiceID = *row.ServiceID
+	}
+
+	if row.Tag != nil {
+		slo.Tag = *row.Tag
+	}
+
+	if row.LatencyTarget != nil && *row.LatencyTarget != "" {
+		slo.LatencyTarget, err = time.ParseDuration(*row.LatencyTarget)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse latency target: %w", err)
+		}
+	}
+
+	if row.BurnRateAlert != nil && *row.BurnRateAlert != "" {
+		slo.BurnRateAlert = &BurnRateAlert{}
+		if err := json.Unmarshal([]byte(*row.BurnRateAlert), slo.BurnRateAlert); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal burn rate alert: %w", err)
+		}
+	}
+
+	return slo, nil
+}
+
+// GetSLOByID gets an SLO by ID
+func (o *ORMStorage) GetSLOByID(ctx context.Context, id string) (*SLO, error) {
+	sb := findSLOsBuilder(FindSLOsParams{})
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	slo, err := scanSLO(o.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get SLO: %w", err)
+	}
+
+	return slo, nil
+}
+
+// FindSLOs finds SLOs ordered by name
+func (o *ORMStorage) FindSLOs(ctx context.Context, params FindSLOsParams) ([]*SLO, error) {
+	sb := findSLOsBuilder(params)
+	sb.OrderBy("name").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SLOs: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*SLO{}
+	for rows.Next() {
+		slo, err := scanSLO(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan SLO: %w", err)
+		}
+		items = append(items, slo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// UpdateSLO updates an SLO, check counts within the check history retention
+// are recomputed by the SLO evaluator
+func (o *ORMStorage) UpdateSLO(ctx context.Context, slo *SLO) error {
+	burnRateAlertJSON, err := marshalNullableJSON(slo.BurnRateAlert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal burn rate alert: %w", err)
+	}
+
+	slo.UpdatedAt = time.Now()
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("slos")
+	ub.Set(
+		ub.Assign("name", slo.Name),
+		ub.Assign("service_id", nullableString(slo.ServiceID)),
+		ub.Assign("tag", nullableString(slo.Tag)),
+		ub.Assign("target", slo.Target),
+		ub.Assign("latency_target", durationToString(slo.LatencyTarget)),
+		ub.Assign("compliance_window", slo.Window),
+		ub.Assign("burn_rate_alert", burnRateAlertJSON),
+		ub.Assign("alerting", slo.Alerting),
+		ub.Assign("updated_at", slo.UpdatedAt),
+	)
+	ub.Where(ub.Equal("id", slo.ID))
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update SLO: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetSLOAlerting updates the burn rate alert state of an SLO
+func (o *ORMStorage) SetSLOAlerting(ctx context.Context, id string, alerting bool) error {
+	query := `UPDATE slos SET alerting = ? WHERE id = ?`
+	if _, err := o.db.ExecContext(ctx, query, alerting, id); err != nil {
+		return fmt.Errorf("failed to update SLO alerting: %w", err)
+	}
+	return nil
+}
+
+// DeleteSLO deletes an SLO with its collected check counts
+func (o *ORMStorage) DeleteSLO(ctx context.Context, id string) error {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM slo_buckets WHERE slo_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete SLO buckets: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM slos WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete SLO: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// sloChecksBuilder selects check results of the services covered by an SLO in the [from, to) range
+func (o *ORMStorage) sloChecksBuilder(slo *SLO, from, to time.Time, col ...string) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(col...)
+	sb.From("check_results cr")
+	sb.Where(
+		sb.GreaterEqualThan("cr.checked_at", from.UTC()),
+		sb.LessThan("cr.checked_at", to.UTC()),
+	)
+
+	if slo.ServiceID != "" {
+		sb.Where(sb.Equal("cr.service_id", slo.ServiceID))
+	} else {
+		sb.Where(fmt.Sprintf(
+			"cr.service_id IN (SELECT s.id FROM services s WHERE %s)",
+			o.db.dialect.jsonArrayContains("s.tags", sb.Var(slo.Tag)),
+		))
+	}
+
+	return sb
+}
+
+// sloGoodCondition returns the SQL condition of good checks
+func sloGoodCondition(sb *sqlbuilder.SelectBuilder, slo *SLO) string {
+	condition := fmt.Sprintf("cr.status IN (%s, %s)", sb.Var(StatusUp), sb.Var(StatusDegraded))
+	if slo.LatencyTarget > 0 {
+		condition += fmt.Sprintf(" AND cr.response_time_ns <= %s", sb.Var(slo.LatencyTarget.Nanoseconds()))
+	}
+	return condition
+}
+
+// countSLOChecks counts raw check results of an SLO in the [from, to) range
+func (o *ORMStorage) countSLOChecks(ctx context.Context, slo *SLO, from, to time.Time) (sloCounts, error) {
+	sb := o.sloChecksBuilder(slo, from, to)
+	sb.Select("COUNT(*)", fmt.Sprintf("COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0)", sloGoodCondition(sb, slo)))
+
+	var counts sloCounts
+	query, args := sb.Build()
+	if err := o.db.QueryRowContext(ctx, query, args...).Scan(&counts.total, &counts.good); err != nil {
+		return sloCounts{}, fmt.Errorf("failed to count SLO checks: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RollupSLO recomputes hourly check counts of an SLO overlapping the [from, to) range
+// from the raw check results. Hours without check results are kept as they are.
+func (o *ORMStorage) RollupSLO(ctx context.Context, slo *SLO, from, to time.Time) error {
+	from = from.UTC().Truncate(time.Hour)
+
+	sb := o.sloChecksBuilder(slo, from, to)
+	sb.Select("cr.checked_at", fmt.Sprintf("CASE WHEN %s THEN 1 ELSE 0 END", sloGoodCondition(sb, slo)))
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query check results: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := make(map[time.Time]*sloCounts)
+	for rows.Next() {
+		var (
+			checkedAt time.Time
+			good      bool
+		)
+		if err := rows.Scan(&checkedAt, &good); err != nil {
+			return fmt.Errorf("failed to scan check result: %w", err)
+		}
+
+		start := checkedAt.UTC().Truncate(time.Hour)
+		b, ok := buckets[start]
+		if !ok {
+			b = &sloCounts{}
+			buckets[start] = b
+		}
+
+		b.total++
+		if good {
+			b.good++
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	upsertQuery := `
+		INSERT INTO slo_buckets (slo_id, bucket_start, total_checks, good_checks)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (slo_id, bucket_start) DO UPDATE SET
+			total_checks = excluded.total_checks,
+			good_checks = excluded.good_checks`
+	for start, b := range buckets {
+		if _, err := tx.ExecContext(ctx, upsertQuery, slo.ID, start, b.total, b.good); err != nil {
+			return fmt.Errorf("failed to upsert SLO bucket: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSLOBucketsBefore deletes hourly SLO check counts older than the given time
+func (o *ORMStorage) DeleteSLOBucketsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := o.db.ExecContext(ctx, `DELETE FROM slo_buckets WHERE bucket_start < ?`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete SLO buckets: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// GetSLOStatus computes the compliance of an SLO over its window ending at now.
+// Window counts are taken from hourly buckets, burn rates from raw check results.
+func (o *ORMStorage) GetSLOStatus(ctx context.Context, slo *SLO, now time.Time) (*SLOStatus, error) {
+	status := &SLOStatus{
+		SLO:         slo,
+		WindowStart: now.Add(-slo.Window.Duration()),
+		WindowEnd:   now,
+	}
+
+	// The first hour is counted only if it is complete
+	bucketsStart := status.WindowStart.UTC().Truncate(time.Hour)
+	if bucketsStart.Before(status.WindowStart) {
+		bucketsStart = bucketsStart.Add(time.Hour)
+	}
+
+	query := `
+		SELECT COALESCE(SUM(total_checks), 0), COALESCE(SUM(good_checks), 0)
+		FROM slo_buckets WHERE slo_id = ? AND bucket_start >= ?`
+	if err := o.db.QueryRowContext(ctx, query, slo.ID, bucketsStart).Scan(&status.TotalChecks, &status.GoodChecks); err != nil {
+		return nil, fmt.Errorf("failed to query SLO buckets: %w", err)
+	}
+
+	window := sloCounts{total: status.TotalChecks, good: status.GoodChecks}
+	status.SLI = window.sli()
+	status.ErrorBudgetRemaining = 100 - window.burnRate(slo.Target)*100
+	status.Compliant = status.SLI >= slo.Target
+
+	if slo.BurnRateAlert != nil {
+		short, err := o.countSLOChecks(ctx, slo, now.Add(-slo.BurnRateAlert.ShortWindow), now)
+		if err != nil {
+			return nil, err
+		}
+
+		long, err := o.countSLOChecks(ctx, slo, now.Add(-slo.BurnRateAlert.LongWindow), now)
+		if err != nil {
+			return nil, err
+		}
+
+		status.BurnRateShort = short.burnRate(slo.Target)
+		status.BurnRateLong = long.burnRate(slo.Target)
+	}
+
+	return status, nil
+}
+
+// sli returns the percentage of good checks, 100 without checks
+func (c sloCounts) sli() float64 {
+	if c.total == 0 {
+		return 100
+	}
+	return float64(c.good) / float64(c.total) * 100
+}
+
+// burnRate returns how many times faster than allowed by the target the error budget is spent
+func (c sloCounts) burnRate(target float64) float64 {
+	budget := 100 - target
+	if c.total == 0 || budget <= 0 {
+		return 0
+	}
+	return (100 - c.sli()) / budget
+}
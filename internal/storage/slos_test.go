@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSLOStatus(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	svc, err := store.CreateService(ctx, CreateUpdateServiceRequest{
+		Name:      "test",
+		Protocol:  ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Retries:   1,
+		Tags:      []string{"production"},
+		Config:    map[string]any{},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Hour)
+
+	// 1000 checks over the last 10 hours: 10 down and 10 too slow in the last hour
+	for i := range 1000 {
+		result := &CheckResult{
+			ServiceID:    svc.ID,
+			CheckedAt:    now.Add(-10 * time.Hour).Add(time.Duration(i) * 36 * time.Second),
+			Status:       StatusUp,
+			ResponseTime: 100 * time.Millisecond,
+			Attempts:     1,
+		}
+		switch {
+		case i >= 990:
+			result.Status = StatusDown
+		case i >= 980:
+			result.ResponseTime = time.Second
+		case i%2 == 0:
+			result.Status = StatusDegraded
+		}
+		require.NoError(t, store.SaveCheckResult(ctx, result))
+	}
+
+	slo := &SLO{
+		Name:          "availability",
+		Tag:           "production",
+		Target:        99,
+		LatencyTarget: 500 * time.Millisecond,
+		Window:        SLOWindow7d,
+		BurnRateAlert: &BurnRateAlert{ShortWindow: time.Hour, LongWindow: 6 * time.Hour, Threshold: 6},
+	}
+	require.NoError(t, store.CreateSLO(ctx, slo))
+	require.NoError(t, store.RollupSLO(ctx, slo, now.Add(-24*time.Hour), now))
+
+	status, err := store.GetSLOStatus(ctx, slo, now)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1000, status.TotalChecks)
+	assert.EqualValues(t, 980, status.GoodChecks)
+	assert.InDelta(t, 98, status.SLI, 0.001)
+	assert.InDelta(t, -100, status.ErrorBudgetRemaining, 0.001)
+	assert.False(t, status.Compliant)
+	// 20 of 100 checks in the last hour are bad
+	assert.InDelta(t, 20, status.BurnRateShort, 0.001)
+	// 20 of 600 checks in the last 6 hours are bad
+	assert.InDelta(t, 20.0/600*100, status.BurnRateLong, 0.001)
+
+	found, err := store.FindSLOs(ctx, FindSLOsParams{Tag: "production"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, slo.BurnRateAlert, found[0].BurnRateAlert)
+	assert.Equal(t, 500*time.Millisecond, found[0].LatencyTarget)
+
+	// SLOs of tags are kept when a service is deleted
+	require.NoError(t, store.DeleteService(ctx, svc.ID))
+	_, err = store.GetSLOByID(ctx, slo.ID)
+	require.NoError(t, err)
+}
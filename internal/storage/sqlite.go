@@ -46,10 +46,10 @@ func NewSQLiteStorage(dbPath string) (*SQLiteStorage, error) {
 
 	storage := &SQLiteStorage{
 		db:  db,
-		orm: NewORMStorage(db),
+		orm: NewORMStorage(db, sqliteDialect),
 	}
 
-	if err := runMigrations(db); err != nil {
+	if err := runMigrations(storage.orm.db); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
@@ -100,6 +100,16 @@ func (s *SQLiteStorage) UpdateIncident(ctx context.Context, incident *Incident)
 	return s.orm.UpdateIncident(ctx, incident)
 }
 
+// UpdateIncidentDetails updates the title and postmortem of an incident
+func (s *SQLiteStorage) UpdateIncidentDetails(ctx context.Context, incident *Incident) error {
+	return s.orm.UpdateIncidentDetails(ctx, incident)
+}
+
+// UpdateIncidentNotifications records the sent notifications of an incident
+func (s *SQLiteStorage) UpdateIncidentNotifications(ctx context.Context, incident *Incident) error {
+	return s.orm.UpdateIncidentNotifications(ctx, incident)
+}
+
 // DeleteIncident deletes an incident by ID
 func (s *SQLiteStorage) DeleteIncident(ctx context.Context, incidentID string) error {
 	return s.orm.DeleteIncident(ctx, incidentID)
@@ -115,11 +125,51 @@ func (s *SQLiteStorage) IncidentsCount(ctx context.Context, params FindIncidents
 	return s.orm.IncidentsCount(ctx, params)
 }
 
+// AcknowledgeIncident marks an active incident as taken by a user
+func (s *SQLiteStorage) AcknowledgeIncident(ctx context.Context, id, by string, at time.Time) error {
+	return s.orm.AcknowledgeIncident(ctx, id, by, at)
+}
+
+// CreateIncidentEvent adds an event to the incident timeline
+func (s *SQLiteStorage) CreateIncidentEvent(ctx context.Context, event *IncidentEvent) error {
+	return s.orm.CreateIncidentEvent(ctx, event)
+}
+
+// FindIncidentEvents returns the timeline of an incident
+func (s *SQLiteStorage) FindIncidentEvents(ctx context.Context, incidentID string) ([]*IncidentEvent, error) {
+	return s.orm.FindIncidentEvents(ctx, incidentID)
+}
+
+// RecordIncidentError records an error of an incident
+func (s *SQLiteStorage) RecordIncidentError(ctx context.Context, incidentID, message string, at time.Time) (*IncidentError, error) {
+	return s.orm.RecordIncidentError(ctx, incidentID, message, at)
+}
+
+// FindIncidentErrors returns the distinct errors of an incident
+func (s *SQLiteStorage) FindIncidentErrors(ctx context.Context, incidentID string) ([]*IncidentError, error) {
+	return s.orm.FindIncidentErrors(ctx, incidentID)
+}
+
 // GetServiceStats calculates statistics for a service
-func (s *SQLiteStorage) GetServiceStats(ctx context.Context, params FindIncidentsParams) (*ServiceStats, error) {
+func (s *SQLiteStorage) GetServiceStats(ctx context.Context, params ServiceStatsParams) (*ServiceStats, error) {
 	return s.orm.GetServiceStatsWithORM(ctx, params)
 }
 
+// CreateServicePause creates a period excluded from the service uptime
+func (s *SQLiteStorage) CreateServicePause(ctx context.Context, pause *ServicePause) error {
+	return s.orm.CreateServicePause(ctx, pause)
+}
+
+// FindServicePauses finds service pauses
+func (s *SQLiteStorage) FindServicePauses(ctx context.Context, params FindServicePausesParams) ([]*ServicePause, error) {
+	return s.orm.FindServicePauses(ctx, params)
+}
+
+// DeleteServicePause deletes a service pause
+func (s *SQLiteStorage) DeleteServicePause(ctx context.Context, id string) error {
+	return s.orm.DeleteServicePause(ctx, id)
+}
+
 // ResolveAllIncidents resolves all incidents for a service
 func (s *SQLiteStorage) ResolveAllIncidents(ctx context.Context, serviceID string) ([]*Incident, error) {
 	return s.orm.ResolveAllIncidents(ctx, serviceID)
@@ -174,6 +224,38 @@ func (s *SQLiteStorage) GetAllServiceStates(ctx context.Context) ([]*ServiceStat
 	return s.orm.GetAllServiceStates(ctx)
 }
 
+// Check history methods
+
+// SaveCheckResult saves the result of a single check
+func (s *SQLiteStorage) SaveCheckResult(ctx context.Context, result *CheckResult) error {
+	return s.orm.SaveCheckResult(ctx, result)
+}
+
+// FindCheckResults finds check results
+func (s *SQLiteStorage) FindCheckResults(ctx context.Context, params FindCheckResultsParams) (dbutils.FindResponseWithCount[*CheckResult], error) {
+	return s.orm.FindCheckResults(ctx, params)
+}
+
+// DeleteCheckResultsBefore deletes check results older than the given time
+func (s *SQLiteStorage) DeleteCheckResultsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return s.orm.DeleteCheckResultsBefore(ctx, before)
+}
+
+// FindCheckResultRollups finds check result rollups
+func (s *SQLiteStorage) FindCheckResultRollups(ctx context.Context, params FindCheckResultRollupsParams) ([]*CheckResultRollup, error) {
+	return s.orm.FindCheckResultRollups(ctx, params)
+}
+
+// RollupCheckResults aggregates check results into rollups
+func (s *SQLiteStorage) RollupCheckResults(ctx context.Context, resolution RollupResolution, from, to time.Time) error {
+	return s.orm.RollupCheckResults(ctx, resolution, from, to)
+}
+
+// DeleteCheckResultRollupsBefore deletes rollups older than the given time
+func (s *SQLiteStorage) DeleteCheckResultRollupsBefore(ctx context.Context, resolution RollupResolution, before time.Time) (int64, error) {
+	return s.orm.DeleteCheckResultRollupsBefore(ctx, resolution, before)
+}
+
 // GetAllTags retrieves all unique tags across services
 func (s *SQLiteStorage) GetAllTags(ctx context.Context) ([]string, error) {
 	return s.orm.GetAllTags(ctx)
@@ -193,3 +275,243 @@ func (s *SQLiteStorage) GetSQLiteVersion(ctx context.Context) (string, error) {
 	}
 	return version, nil
 }
+
+// CreateSLO creates a new SLO
+func (s *SQLiteStorage) CreateSLO(ctx context.Context, slo *SLO) error {
+	return s.orm.CreateSLO(ctx, slo)
+}
+
+// GetSLOByID gets an SLO by ID
+func (s *SQLiteStorage) GetSLOByID(ctx context.Context, id string) (*SLO, error) {
+	return s.orm.GetSLOByID(ctx, id)
+}
+
+// FindSLOs finds SLOs
+func (s *SQLiteStorage) FindSLOs(ctx context.Context, params FindSLOsParams) ([]*SLO, error) {
+	return s.orm.FindSLOs(ctx, params)
+}
+
+// UpdateSLO updates an SLO
+func (s *SQLiteStorage) UpdateSLO(ctx context.Context, slo *SLO) error {
+	return s.orm.UpdateSLO(ctx, slo)
+}
+
+// SetSLOAlerting updates the burn rate alert state of an SLO
+func (s *SQLiteStorage) SetSLOAlerting(ctx context.Context, id string, alerting bool) error {
+	return s.orm.SetSLOAlerting(ctx, id, alerting)
+}
+
+// DeleteSLO deletes an SLO
+func (s *SQLiteStorage) DeleteSLO(ctx context.Context, id string) error {
+	return s.orm.DeleteSLO(ctx, id)
+}
+
+// RollupSLO recomputes hourly check counts of an SLO
+func (s *SQLiteStorage) RollupSLO(ctx context.Context, slo *SLO, from, to time.Time) error {
+	return s.orm.RollupSLO(ctx, slo, from, to)
+}
+
+// DeleteSLOBucketsBefore deletes hourly SLO check counts older than the given time
+func (s *SQLiteStorage) DeleteSLOBucketsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return s.orm.DeleteSLOBucketsBefore(ctx, before)
+}
+
+// GetSLOStatus computes the compliance of an SLO
+func (s *SQLiteStorage) GetSLOStatus(ctx context.Context, slo *SLO, now time.Time) (*SLOStatus, error) {
+	return s.orm.GetSLOStatus(ctx, slo, now)
+}
+
+// CreateNotificationRule creates a new notification rule
+func (s *SQLiteStorage) CreateNotificationRule(ctx context.Context, rule *NotificationRule) error {
+	return s.orm.CreateNotificationRule(ctx, rule)
+}
+
+// GetNotificationRuleByID gets a notification rule by ID
+func (s *SQLiteStorage) GetNotificationRuleByID(ctx context.Context, id string) (*NotificationRule, error) {
+	return s.orm.GetNotificationRuleByID(ctx, id)
+}
+
+// FindNotificationRules finds notification rules
+func (s *SQLiteStorage) FindNotificationRules(ctx context.Context, params FindNotificationRulesParams) ([]*NotificationRule, error) {
+	return s.orm.FindNotificationRules(ctx, params)
+}
+
+// UpdateNotificationRule updates a notification rule
+func (s *SQLiteStorage) UpdateNotificationRule(ctx context.Context, rule *NotificationRule) error {
+	return s.orm.UpdateNotificationRule(ctx, rule)
+}
+
+// DeleteNotificationRule deletes a notification rule
+func (s *SQLiteStorage) DeleteNotificationRule(ctx context.Context, id string) error {
+	return s.orm.DeleteNotificationRule(ctx, id)
+}
+
+// CreateNotificationChannel creates a new notification channel
+func (s *SQLiteStorage) CreateNotificationChannel(ctx context.Context, channel *NotificationChannel) error {
+	return s.orm.CreateNotificationChannel(ctx, channel)
+}
+
+// GetNotificationChannelByID gets a notification channel by ID
+func (s *SQLiteStorage) GetNotificationChannelByID(ctx context.Context, id string) (*NotificationChannel, error) {
+	return s.orm.GetNotificationChannelByID(ctx, id)
+}
+
+// FindNotificationChannels finds notification channels
+func (s *SQLiteStorage) FindNotificationChannels(ctx context.Context, params FindNotificationChannelsParams) ([]*NotificationChannel, error) {
+	return s.orm.FindNotificationChannels(ctx, params)
+}
+
+// UpdateNotificationChannel updates a notification channel
+func (s *SQLiteStorage) UpdateNotificationChannel(ctx context.Context, channel *NotificationChannel) error {
+	return s.orm.UpdateNotificationChannel(ctx, channel)
+}
+
+// DeleteNotificationChannel deletes a notification channel
+func (s *SQLiteStorage) DeleteNotificationChannel(ctx context.Context, id string) error {
+	return s.orm.DeleteNotificationChannel(ctx, id)
+}
+
+// CreateNotificationDeliveries adds pending deliveries to the notification outbox
+func (s *SQLiteStorage) CreateNotificationDeliveries(ctx context.Context, deliveries []*NotificationDelivery) error {
+	return s.orm.CreateNotificationDeliveries(ctx, deliveries)
+}
+
+// GetNotificationDeliveryByID gets a notification delivery by ID
+func (s *SQLiteStorage) GetNotificationDeliveryByID(ctx context.Context, id string) (*NotificationDelivery, error) {
+	return s.orm.GetNotificationDeliveryByID(ctx, id)
+}
+
+// FindNotificationDeliveries finds notification deliveries
+func (s *SQLiteStorage) FindNotificationDeliveries(ctx context.Context, params FindNotificationDeliveriesParams) (dbutils.FindResponseWithCount[*NotificationDelivery], error) {
+	return s.orm.FindNotificationDeliveries(ctx, params)
+}
+
+// FindDueNotificationDeliveries finds pending deliveries whose next attempt is due
+func (s *SQLiteStorage) FindDueNotificationDeliveries(ctx context.Context, now time.Time, limit int) ([]*NotificationDelivery, error) {
+	return s.orm.FindDueNotificationDeliveries(ctx, now, limit)
+}
+
+// UpdateNotificationDelivery updates the state of a notification delivery
+func (s *SQLiteStorage) UpdateNotificationDelivery(ctx context.Context, delivery *NotificationDelivery) error {
+	return s.orm.UpdateNotificationDelivery(ctx, delivery)
+}
+
+// DeleteNotificationDeliveriesBefore deletes sent and dead-lettered deliveries created before the given time
+func (s *SQLiteStorage) DeleteNotificationDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	return s.orm.DeleteNotificationDeliveriesBefore(ctx, before)
+}
+
+// CreateEscalationPolicy creates a new escalation policy
+func (s *SQLiteStorage) CreateEscalationPolicy(ctx context.Context, policy *EscalationPolicy) error {
+	return s.orm.CreateEscalationPolicy(ctx, policy)
+}
+
+// GetEscalationPolicyByID gets an escalation policy by ID
+func (s *SQLiteStorage) GetEscalationPolicyByID(ctx context.Context, id string) (*EscalationPolicy, error) {
+	return s.orm.GetEscalationPolicyByID(ctx, id)
+}
+
+// FindEscalationPolicies finds escalation policies ordered by name
+func (s *SQLiteStorage) FindEscalationPolicies(ctx context.Context, params FindEscalationPoliciesParams) ([]*EscalationPolicy, error) {
+	return s.orm.FindEscalationPolicies(ctx, params)
+}
+
+// UpdateEscalationPolicy updates an escalation policy
+func (s *SQLiteStorage) UpdateEscalationPolicy(ctx context.Context, policy *EscalationPolicy) error {
+	return s.orm.UpdateEscalationPolicy(ctx, policy)
+}
+
+// DeleteEscalationPolicy deletes an escalation policy with the escalation state of its incidents
+func (s *SQLiteStorage) DeleteEscalationPolicy(ctx context.Context, id string) error {
+	return s.orm.DeleteEscalationPolicy(ctx, id)
+}
+
+// FindIncidentEscalations finds the escalation state of an incident by policy ID
+func (s *SQLiteStorage) FindIncidentEscalations(ctx context.Context, incidentID string) (map[string]*IncidentEscalation, error) {
+	return s.orm.FindIncidentEscalations(ctx, incidentID)
+}
+
+// SaveIncidentEscalation creates or updates the escalation state of an incident by a policy
+func (s *SQLiteStorage) SaveIncidentEscalation(ctx context.Context, escalation *IncidentEscalation) error {
+	return s.orm.SaveIncidentEscalation(ctx, escalation)
+}
+
+// CreateOnCallSchedule creates a new on-call schedule
+func (s *SQLiteStorage) CreateOnCallSchedule(ctx context.Context, schedule *OnCallSchedule) error {
+	return s.orm.CreateOnCallSchedule(ctx, schedule)
+}
+
+// GetOnCallScheduleByID gets an on-call schedule by ID
+func (s *SQLiteStorage) GetOnCallScheduleByID(ctx context.Context, id string) (*OnCallSchedule, error) {
+	return s.orm.GetOnCallScheduleByID(ctx, id)
+}
+
+// FindOnCallSchedules finds all on-call schedules ordered by name
+func (s *SQLiteStorage) FindOnCallSchedules(ctx context.Context) ([]*OnCallSchedule, error) {
+	return s.orm.FindOnCallSchedules(ctx)
+}
+
+// UpdateOnCallSchedule updates an on-call schedule
+func (s *SQLiteStorage) UpdateOnCallSchedule(ctx context.Context, schedule *OnCallSchedule) error {
+	return s.orm.UpdateOnCallSchedule(ctx, schedule)
+}
+
+// DeleteOnCallSchedule deletes an on-call schedule
+func (s *SQLiteStorage) DeleteOnCallSchedule(ctx context.Context, id string) error {
+	return s.orm.DeleteOnCallSchedule(ctx, id)
+}
+
+// CreateWebhook creates a new webhook
+func (s *SQLiteStorage) CreateWebhook(ctx context.Context, webhook *Webhook) error {
+	return s.orm.CreateWebhook(ctx, webhook)
+}
+
+// GetWebhookByID gets a webhook by ID
+func (s *SQLiteStorage) GetWebhookByID(ctx context.Context, id string) (*Webhook, error) {
+	return s.orm.GetWebhookByID(ctx, id)
+}
+
+// FindWebhooks finds webhooks ordered by name
+func (s *SQLiteStorage) FindWebhooks(ctx context.Context, params FindWebhooksParams) ([]*Webhook, error) {
+	return s.orm.FindWebhooks(ctx, params)
+}
+
+// UpdateWebhook updates a webhook
+func (s *SQLiteStorage) UpdateWebhook(ctx context.Context, webhook *Webhook) error {
+	return s.orm.UpdateWebhook(ctx, webhook)
+}
+
+// DeleteWebhook deletes a webhook with its deliveries
+func (s *SQLiteStorage) DeleteWebhook(ctx context.Context, id string) error {
+	return s.orm.DeleteWebhook(ctx, id)
+}
+
+// CreateWebhookDeliveries adds pending deliveries of webhook events
+func (s *SQLiteStorage) CreateWebhookDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error {
+	return s.orm.CreateWebhookDeliveries(ctx, deliveries)
+}
+
+// GetWebhookDeliveryByID gets a webhook delivery by ID
+func (s *SQLiteStorage) GetWebhookDeliveryByID(ctx context.Context, id string) (*WebhookDelivery, error) {
+	return s.orm.GetWebhookDeliveryByID(ctx, id)
+}
+
+// FindWebhookDeliveries finds webhook deliveries, latest first
+func (s *SQLiteStorage) FindWebhookDeliveries(ctx context.Context, params FindWebhookDeliveriesParams) (dbutils.FindResponseWithCount[*WebhookDelivery], error) {
+	return s.orm.FindWebhookDeliveries(ctx, params)
+}
+
+// FindDueWebhookDeliveries finds pending deliveries of enabled webhooks whose next attempt is due
+func (s *SQLiteStorage) FindDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error) {
+	return s.orm.FindDueWebhookDeliveries(ctx, now, limit)
+}
+
+// UpdateWebhookDelivery updates the state of a webhook delivery
+func (s *SQLiteStorage) UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	return s.orm.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// DeleteWebhookDeliveriesBefore deletes sent and dead-lettered webhook deliveries created before the given time
+func (s *SQLiteStorage) DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	return s.orm.DeleteWebhookDeliveriesBefore(ctx, before)
+}
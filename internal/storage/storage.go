@@ -16,11 +16,20 @@ type Storage interface {
 	GetIncidentByID(ctx context.Context, id string) (*Incident, error)
 	SaveIncident(ctx context.Context, incident *Incident) error
 	UpdateIncident(ctx context.Context, incident *Incident) error
+	UpdateIncidentDetails(ctx context.Context, incident *Incident) error
+	UpdateIncidentNotifications(ctx context.Context, incident *Incident) error
 	DeleteIncident(ctx context.Context, incidentID string) error
 	FindIncidents(ctx context.Context, params FindIncidentsParams) (dbutils.FindResponseWithCount[*Incident], error)
 	IncidentsCount(ctx context.Context, params FindIncidentsParams) (uint32, error)
 	ResolveAllIncidents(ctx context.Context, serviceID string) ([]*Incident, error)
 	GetIncidentsStatsByDateRange(ctx context.Context, startTime, endTime time.Time) (GetIncidentsStatsByDateRangeData, error)
+	AcknowledgeIncident(ctx context.Context, id, by string, at time.Time) error
+
+	// Incident timeline
+	CreateIncidentEvent(ctx context.Context, event *IncidentEvent) error
+	FindIncidentEvents(ctx context.Context, incidentID string) ([]*IncidentEvent, error)
+	RecordIncidentError(ctx context.Context, incidentID, message string, at time.Time) (*IncidentError, error)
+	FindIncidentErrors(ctx context.Context, incidentID string) ([]*IncidentError, error)
 
 	// Service management
 	CreateService(ctx context.Context, request CreateUpdateServiceRequest) (*Service, error)
@@ -34,12 +43,90 @@ type Storage interface {
 	UpdateServiceState(ctx context.Context, state *ServiceStateRecord) error
 	GetAllServiceStates(ctx context.Context) ([]*ServiceStateRecord, error)
 
+	// Check history
+	SaveCheckResult(ctx context.Context, result *CheckResult) error
+	FindCheckResults(ctx context.Context, params FindCheckResultsParams) (dbutils.FindResponseWithCount[*CheckResult], error)
+	DeleteCheckResultsBefore(ctx context.Context, before time.Time) (int64, error)
+	FindCheckResultRollups(ctx context.Context, params FindCheckResultRollupsParams) ([]*CheckResultRollup, error)
+	RollupCheckResults(ctx context.Context, resolution RollupResolution, from, to time.Time) error
+	DeleteCheckResultRollupsBefore(ctx context.Context, resolution RollupResolution, before time.Time) (int64, error)
+
+	// Disabled periods and maintenance windows
+	CreateServicePause(ctx context.Context, pause *ServicePause) error
+	FindServicePauses(ctx context.Context, params FindServicePausesParams) ([]*ServicePause, error)
+	DeleteServicePause(ctx context.Context, id string) error
+
+	// Service level objectives
+	CreateSLO(ctx context.Context, slo *SLO) error
+	GetSLOByID(ctx context.Context, id string) (*SLO, error)
+	FindSLOs(ctx context.Context, params FindSLOsParams) ([]*SLO, error)
+	UpdateSLO(ctx context.Context, slo *SLO) error
+	SetSLOAlerting(ctx context.Context, id string, alerting bool) error
+	DeleteSLO(ctx context.Context, id string) error
+	RollupSLO(ctx context.Context, slo *SLO, from, to time.Time) error
+	DeleteSLOBucketsBefore(ctx context.Context, before time.Time) (int64, error)
+	GetSLOStatus(ctx context.Context, slo *SLO, now time.Time) (*SLOStatus, error)
+
+	// Notification routing rules
+	CreateNotificationRule(ctx context.Context, rule *NotificationRule) error
+	GetNotificationRuleByID(ctx context.Context, id string) (*NotificationRule, error)
+	FindNotificationRules(ctx context.Context, params FindNotificationRulesParams) ([]*NotificationRule, error)
+	UpdateNotificationRule(ctx context.Context, rule *NotificationRule) error
+	DeleteNotificationRule(ctx context.Context, id string) error
+
+	// Notification channels
+	CreateNotificationChannel(ctx context.Context, channel *NotificationChannel) error
+	GetNotificationChannelByID(ctx context.Context, id string) (*NotificationChannel, error)
+	FindNotificationChannels(ctx context.Context, params FindNotificationChannelsParams) ([]*NotificationChannel, error)
+	UpdateNotificationChannel(ctx context.Context, channel *NotificationChannel) error
+	DeleteNotificationChannel(ctx context.Context, id string) error
+
+	// Notification outbox
+	CreateNotificationDeliveries(ctx context.Context, deliveries []*NotificationDelivery) error
+	GetNotificationDeliveryByID(ctx context.Context, id string) (*NotificationDelivery, error)
+	FindNotificationDeliveries(ctx context.Context, params FindNotificationDeliveriesParams) (dbutils.FindResponseWithCount[*NotificationDelivery], error)
+	FindDueNotificationDeliveries(ctx context.Context, now time.Time, limit int) ([]*NotificationDelivery, error)
+	UpdateNotificationDelivery(ctx context.Context, delivery *NotificationDelivery) error
+	DeleteNotificationDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// Escalation policies
+	CreateEscalationPolicy(ctx context.Context, policy *EscalationPolicy) error
+	GetEscalationPolicyByID(ctx context.Context, id string) (*EscalationPolicy, error)
+	FindEscalationPolicies(ctx context.Context, params FindEscalationPoliciesParams) ([]*EscalationPolicy, error)
+	UpdateEscalationPolicy(ctx context.Context, policy *EscalationPolicy) error
+	DeleteEscalationPolicy(ctx context.Context, id string) error
+	FindIncidentEscalations(ctx context.Context, incidentID string) (map[string]*IncidentEscalation, error)
+	SaveIncidentEscalation(ctx context.Context, escalation *IncidentEscalation) error
+
+	// On-call schedules
+	CreateOnCallSchedule(ctx context.Context, schedule *OnCallSchedule) error
+	GetOnCallScheduleByID(ctx context.Context, id string) (*OnCallSchedule, error)
+	FindOnCallSchedules(ctx context.Context) ([]*OnCallSchedule, error)
+	UpdateOnCallSchedule(ctx context.Context, schedule *OnCallSchedule) error
+	DeleteOnCallSchedule(ctx context.Context, id string) error
+
+	// Outbound webhooks
+	CreateWebhook(ctx context.Context, webhook *Webhook) error
+	GetWebhookByID(ctx context.Context, id string) (*Webhook, error)
+	FindWebhooks(ctx context.Context, params FindWebhooksParams) ([]*Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *Webhook) error
+	DeleteWebhook(ctx context.Context, id string) error
+	CreateWebhookDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error
+	GetWebhookDeliveryByID(ctx context.Context, id string) (*WebhookDelivery, error)
+	FindWebhookDeliveries(ctx context.Context, params FindWebhookDeliveriesParams) (dbutils.FindResponseWithCount[*WebhookDelivery], error)
+	FindDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+
 	// Tags
 	GetAllTags(ctx context.Context) ([]string, error)
 	GetAllTagsWithCount(ctx context.Context) (map[string]int, error)
 
 	// Statistics
-	GetServiceStats(ctx context.Context, params FindIncidentsParams) (*ServiceStats, error)
+	GetServiceStats(ctx context.Context, params ServiceStatsParams) (*ServiceStats, error)
+
+	// Backup writes a consistent snapshot of the database to a new file
+	Backup(ctx context.Context, dest string) error
 
 	// SQLite specific methods
 	GetSQLiteVersion(ctx context.Context) (string, error)
@@ -3,12 +3,30 @@ package storage
 import "time"
 
 type CreateUpdateServiceRequest struct {
-	Name      string              `json:"name" yaml:"name"`
-	Protocol  ServiceProtocolType `json:"protocol" yaml:"protocol"`
-	Interval  time.Duration       `json:"interval" yaml:"interval" swaggertype:"primitive,integer"`
-	Timeout   time.Duration       `json:"timeout" yaml:"timeout" swaggertype:"primitive,integer"`
-	Retries   int                 `json:"retries" yaml:"retries"`
-	Tags      []string            `json:"tags" yaml:"tags"`
-	Config    map[string]any      `json:"config" yaml:"config"`
-	IsEnabled bool                `json:"is_enabled" yaml:"is_enabled"`
+	Name            string              `json:"name" yaml:"name"`
+	Protocol        ServiceProtocolType `json:"protocol" yaml:"protocol"`
+	Interval        time.Duration       `json:"interval" yaml:"interval" swaggertype:"primitive,integer"`
+	Timeout         time.Duration       `json:"timeout" yaml:"timeout" swaggertype:"primitive,integer"`
+	Retries         int                 `json:"retries" yaml:"retries"`
+	RetryPolicy     *RetryPolicy        `json:"retry_policy,omitempty" yaml:"retry_policy,omitempty"`
+	ThresholdPolicy *ThresholdPolicy    `json:"threshold_policy,omitempty" yaml:"threshold_policy,omitempty"`
+	LatencyWarning  time.Duration       `json:"latency_warning,omitempty" yaml:"latency_warning,omitempty" swaggertype:"primitive,integer"`
+	Tags            []string            `json:"tags" yaml:"tags"`
+	Config          map[string]any      `json:"config" yaml:"config"`
+	IsEnabled       bool                `json:"is_enabled" yaml:"is_enabled"`
+	// ExternalID is a stable user supplied ID, services are matched by it on import
+	ExternalID string `json:"external_id,omitempty" yaml:"external_id,omitempty"`
+	// ManagedBy is set by the services file sync, it cannot be set through the API
+	ManagedBy string `json:"-" yaml:"-"`
+}
+
+// UnmarshalYAML enables declared services unless is_enabled is set
+func (r *CreateUpdateServiceRequest) UnmarshalYAML(unmarshal func(any) error) error {
+	type plain CreateUpdateServiceRequest
+	req := plain{IsEnabled: true}
+	if err := unmarshal(&req); err != nil {
+		return err
+	}
+	*r = CreateUpdateServiceRequest(req)
+	return nil
 }
@@ -0,0 +1,306 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ServiceStatsParams holds parameters for service stats
+type ServiceStatsParams struct {
+	ServiceID string
+	StartTime time.Time
+	EndTime   time.Time
+	// Bucket splits the period into uptime buckets, no buckets are returned if empty
+	Bucket UptimeBucketSize
+}
+
+// timeRange is a half-open [start, end) time interval
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+func (r timeRange) duration() time.Duration {
+	if !r.end.After(r.start) {
+		return 0
+	}
+	return r.end.Sub(r.start)
+}
+
+// clip returns the part of the range inside the window
+func (r timeRange) clip(window timeRange) (timeRange, bool) {
+	if r.start.Before(window.start) {
+		r.start = window.start
+	}
+	if r.end.After(window.end) {
+		r.end = window.end
+	}
+	return r, r.end.After(r.start)
+}
+
+// mergeRanges merges overlapping ranges and returns them sorted by start time
+func mergeRanges(ranges []timeRange) []timeRange {
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b timeRange) int { return a.start.Compare(b.start) })
+
+	merged := make([]timeRange, 0, len(sorted))
+	for _, r := range sorted {
+		if n := len(merged); n > 0 && !r.start.After(merged[n-1].end) {
+			if r.end.After(merged[n-1].end) {
+				merged[n-1].end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+// totalDuration returns the total duration of merged ranges inside the window
+func totalDuration(ranges []timeRange, window timeRange) time.Duration {
+	var total time.Duration
+	for _, r := range ranges {
+		if clipped, ok := r.clip(window); ok {
+			total += clipped.duration()
+		}
+	}
+	return total
+}
+
+// subtractRanges removes the excluded ranges from merged ranges
+func subtractRanges(ranges, excluded []timeRange) []timeRange {
+	result := []timeRange{}
+	for _, r := range ranges {
+		parts := []timeRange{r}
+		for _, ex := range excluded {
+			next := []timeRange{}
+			for _, p := range parts {
+				if !ex.start.Before(p.end) || !ex.end.After(p.start) {
+					next = append(next, p)
+					continue
+				}
+				if ex.start.After(p.start) {
+					next = append(next, timeRange{start: p.start, end: ex.start})
+				}
+				if ex.end.Before(p.end) {
+					next = append(next, timeRange{start: ex.end, end: p.end})
+				}
+			}
+			parts = next
+		}
+		result = append(result, parts...)
+	}
+	return result
+}
+
+// uptimeBuckets splits the window into buckets aligned to the local calendar
+func uptimeBuckets(window timeRange, size UptimeBucketSize) []timeRange {
+	if size == "" {
+		return nil
+	}
+
+	start := window.start.In(time.Local)
+	year, month, day := start.Date()
+
+	var next func(time.Time) time.Time
+	switch size {
+	case UptimeBucketHour:
+		start = start.Truncate(time.Hour)
+		next = func(t time.Time) time.Time { return t.Add(time.Hour) }
+	case UptimeBucketDay:
+		start = time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case UptimeBucketWeek:
+		// Weeks start on Monday
+		offset := (int(start.Weekday()) + 6) % 7
+		start = time.Date(year, month, day-offset, 0, 0, 0, 0, time.Local)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case UptimeBucketMonth:
+		start = time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	default:
+		return nil
+	}
+
+	buckets := []timeRange{}
+	for bucketStart := start; bucketStart.Before(window.end); bucketStart = next(bucketStart) {
+		bucket, ok := timeRange{start: bucketStart, end: next(bucketStart)}.clip(window)
+		if ok {
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	return buckets
+}
+
+// uptimePercentage returns the uptime of the monitored time
+func uptimePercentage(downtime, monitored time.Duration) float64 {
+	if monitored <= 0 {
+		return 100
+	}
+
+	uptime := 100.0 - float64(downtime)/float64(monitored)*100.0
+	return max(uptime, 0)
+}
+
+// GetServiceStatsWithORM calculates statistics for a service.
+// Downtime is computed from critical incidents clipped to the period,
+// the time before the service was created, disabled periods and maintenance windows
+// are excluded from the monitored time.
+func (o *ORMStorage) GetServiceStatsWithORM(ctx context.Context, params ServiceStatsParams) (*ServiceStats, error) {
+	if params.ServiceID == "" || params.StartTime.IsZero() {
+		return nil, fmt.Errorf("service ID and start time are required for stats")
+	}
+
+	now := time.Now()
+	if params.EndTime.IsZero() || params.EndTime.After(now) {
+		params.EndTime = now
+	}
+
+	if !params.StartTime.Before(params.EndTime) {
+		return nil, fmt.Errorf("start time must be before end time")
+	}
+
+	service, err := o.GetServiceByID(ctx, params.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	window := timeRange{start: params.StartTime, end: params.EndTime}
+
+	// The time before the service was created is not monitored
+	excluded := []timeRange{}
+	if service.CreatedAt.After(window.start) {
+		excluded = append(excluded, timeRange{start: window.start, end: service.CreatedAt})
+	}
+
+	pauses, err := o.FindServicePauses(ctx, FindServicePausesParams{ServiceID: params.ServiceID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pause := range pauses {
+		pauseRange := timeRange{start: pause.StartTime, end: now}
+		if pause.EndTime != nil && pause.EndTime.Before(now) {
+			pauseRange.end = *pause.EndTime
+		}
+		if clipped, ok := pauseRange.clip(window); ok {
+			excluded = append(excluded, clipped)
+		}
+	}
+	excluded = mergeRanges(excluded)
+
+	incidents, err := o.findIncidentRanges(ctx, params.ServiceID, window, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Incidents may overlap, downtime is counted once
+	downtimeRanges := subtractRanges(mergeRanges(incidents), excluded)
+
+	checks, err := o.summarizeChecks(ctx, params.ServiceID, window.start, window.end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize checks: %w", err)
+	}
+
+	period := window.duration()
+	excludedTime := totalDuration(excluded, window)
+	monitoredTime := period - excludedTime
+	totalDowntime := totalDuration(downtimeRanges, window)
+
+	stats := &ServiceStats{
+		ServiceID:             params.ServiceID,
+		StartTime:             window.start,
+		EndTime:               window.end,
+		TotalIncidents:        len(incidents),
+		TotalDowntime:         totalDowntime,
+		UptimePercentage:      uptimePercentage(totalDowntime, monitoredTime),
+		Period:                period,
+		MonitoredTime:         monitoredTime,
+		ExcludedTime:          excludedTime,
+		AvgResponseTime:       checks.avgResponseTime,
+		TotalChecks:           int(checks.total),
+		CheckUptimePercentage: 100,
+	}
+
+	if checks.total > 0 {
+		stats.CheckUptimePercentage = float64(checks.available) / float64(checks.total) * 100
+	}
+
+	for _, bucket := range uptimeBuckets(window, params.Bucket) {
+		bucketMonitored := bucket.duration() - totalDuration(excluded, bucket)
+		bucketDowntime := totalDuration(downtimeRanges, bucket)
+
+		bucketIncidents := 0
+		for _, incident := range incidents {
+			if _, ok := incident.clip(bucket); ok {
+				bucketIncidents++
+			}
+		}
+
+		stats.Buckets = append(stats.Buckets, UptimeBucket{
+			StartTime:        bucket.start,
+			EndTime:          bucket.end,
+			UptimePercentage: uptimePercentage(bucketDowntime, bucketMonitored),
+			Downtime:         bucketDowntime,
+			MonitoredTime:    bucketMonitored,
+			Incidents:        bucketIncidents,
+		})
+	}
+
+	return stats, nil
+}
+
+// findIncidentRanges returns critical incidents of a service overlapping the window,
+// clipped to the window. Ongoing incidents last until now.
+func (o *ORMStorage) findIncidentRanges(ctx context.Context, serviceID string, window timeRange, now time.Time) ([]timeRange, error) {
+	sb := o.findIncidentsBuilder(FindIncidentsParams{
+		ServiceID: serviceID,
+		Severity:  IncidentSeverityCritical,
+	}, "i.start_time", "i.end_time")
+
+	// Stored times may have different time zones, they are compared as seconds since the epoch.
+	// Incidents are clipped to the window below, the filter only skips the ones outside of it.
+	d := o.db.dialect
+	sb.Where(
+		fmt.Sprintf("%s <= %s", d.unixSeconds("i.start_time"), sb.Var(window.end.Unix())),
+		sb.Or(
+			sb.IsNull("i.end_time"),
+			fmt.Sprintf("%s >= %s", d.unixSeconds("i.end_time"), sb.Var(window.start.Unix())),
+		),
+	)
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents: %w", err)
+	}
+	defer rows.Close()
+
+	ranges := []timeRange{}
+	for rows.Next() {
+		var startTime time.Time
+		var endTime *time.Time
+		if err := rows.Scan(&startTime, &endTime); err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+
+		incident := timeRange{start: startTime, end: now}
+		if endTime != nil && endTime.Before(now) {
+			incident.end = *endTime
+		}
+
+		if clipped, ok := incident.clip(window); ok {
+			ranges = append(ranges, clipped)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/utils"
+)
+
+func TestGetServiceStats(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	svc, err := store.CreateService(ctx, CreateUpdateServiceRequest{
+		Name:      "test",
+		Protocol:  ServiceProtocolTypeTCP,
+		Interval:  time.Minute,
+		Timeout:   time.Second,
+		Retries:   1,
+		Tags:      []string{},
+		Config:    map[string]any{},
+		IsEnabled: true,
+	})
+	require.NoError(t, err)
+
+	end := time.Now().Truncate(time.Hour)
+	start := end.Add(-10 * time.Hour)
+
+	// The service exists for the last 8 hours of the period
+	_, err = store.db.ExecContext(ctx, `UPDATE services SET created_at = ? WHERE id = ?`, start.Add(2*time.Hour), svc.ID)
+	require.NoError(t, err)
+
+	incidents := []*Incident{
+		// Started before the service was created
+		{StartTime: start.Add(time.Hour), EndTime: utils.Pointer(start.Add(3 * time.Hour))},
+		// Overlaps with the next incident and the maintenance window
+		{StartTime: start.Add(4 * time.Hour), EndTime: utils.Pointer(start.Add(6 * time.Hour))},
+		{StartTime: start.Add(5 * time.Hour), EndTime: utils.Pointer(start.Add(7 * time.Hour))},
+		// Warning incidents are not downtime
+		{StartTime: start.Add(8 * time.Hour), EndTime: utils.Pointer(start.Add(9 * time.Hour)), Severity: IncidentSeverityWarning},
+		// Ongoing incident
+		{StartTime: end.Add(-30 * time.Minute)},
+	}
+	for _, incident := range incidents {
+		incident.ServiceID = svc.ID
+		incident.Resolved = incident.EndTime != nil
+		require.NoError(t, store.SaveIncident(ctx, incident))
+	}
+
+	require.NoError(t, store.CreateServicePause(ctx, &ServicePause{
+		ServiceID: svc.ID,
+		Reason:    PauseReasonMaintenance,
+		StartTime: start.Add(6 * time.Hour),
+		EndTime:   utils.Pointer(start.Add(8 * time.Hour)),
+	}))
+
+	stats, err := store.GetServiceStats(ctx, ServiceStatsParams{
+		ServiceID: svc.ID,
+		StartTime: start,
+		EndTime:   end,
+		Bucket:    UptimeBucketHour,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 10*time.Hour, stats.Period)
+	assert.Equal(t, 4*time.Hour, stats.ExcludedTime)
+	assert.Equal(t, 6*time.Hour, stats.MonitoredTime)
+	// 1h of the first incident, 2h of the overlapping incidents, 30m of the ongoing one
+	assert.Equal(t, 3*time.Hour+30*time.Minute, stats.TotalDowntime)
+	assert.Equal(t, 4, stats.TotalIncidents)
+	assert.InDelta(t, 100-3.5/6*100, stats.UptimePercentage, 0.001)
+
+	require.Len(t, stats.Buckets, 10)
+	assert.Equal(t, time.Duration(0), stats.Buckets[0].MonitoredTime)
+	assert.Equal(t, 100.0, stats.Buckets[0].UptimePercentage)
+	assert.Equal(t, time.Hour, stats.Buckets[2].Downtime)
+	assert.Equal(t, 0.0, stats.Buckets[2].UptimePercentage)
+	assert.Equal(t, 2, stats.Buckets[5].Incidents)
+	assert.Equal(t, 30*time.Minute, stats.Buckets[9].Downtime)
+	assert.Equal(t, 50.0, stats.Buckets[9].UptimePercentage)
+}
+
+func TestFindIncidentRanges(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(ctx) })
+
+	end := time.Now().UTC().Truncate(time.Hour)
+	start := end.Add(-10 * time.Hour)
+	now := end.Add(time.Hour)
+
+	// Stored times keep the zone they were written in
+	east := time.FixedZone("PKT", 5*60*60)
+	west := time.FixedZone("EST", -5*60*60)
+
+	incidents := []*Incident{
+		// Ended before the window
+		{StartTime: start.Add(-3 * time.Hour), EndTime: utils.Pointer(start.Add(-time.Hour))},
+		// Ended before the window, its local time is after the start of the window
+		{StartTime: start.Add(-2 * time.Hour).In(east), EndTime: utils.Pointer(start.Add(-30 * time.Minute).In(east))},
+		// Started after the window, its local time is before the end of the window
+		{StartTime: end.Add(30 * time.Minute).In(west)},
+		// Overlaps the start of the window
+		{StartTime: start.Add(-time.Hour).In(west), EndTime: utils.Pointer(start.Add(time.Hour).In(west))},
+		// Within the window
+		{StartTime: start.Add(4 * time.Hour).In(east), EndTime: utils.Pointer(start.Add(5 * time.Hour).In(east))},
+		// Ongoing since before the end of the window
+		{StartTime: end.Add(-30 * time.Minute)},
+		// Warning incidents are not downtime
+		{StartTime: start.Add(2 * time.Hour), EndTime: utils.Pointer(start.Add(3 * time.Hour)), Severity: IncidentSeverityWarning},
+	}
+	for _, incident := range incidents {
+		incident.ServiceID = "service"
+		incident.Resolved = incident.EndTime != nil
+		require.NoError(t, store.SaveIncident(ctx, incident))
+	}
+
+	ranges, err := store.orm.findIncidentRanges(ctx, "service", timeRange{start: start, end: end}, now)
+	require.NoError(t, err)
+	require.Len(t, ranges, 3)
+
+	durations := []time.Duration{}
+	for _, incident := range ranges {
+		assert.False(t, incident.start.Before(start))
+		assert.False(t, incident.end.After(end))
+		durations = append(durations, incident.end.Sub(incident.start))
+	}
+	assert.ElementsMatch(t, []time.Duration{time.Hour, time.Hour, 30 * time.Minute}, durations)
+}
@@ -0,0 +1,546 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/sxwebdev/sentinel/pkg/dbutils"
+)
+
+// maxWebhookDeliveriesPageSize is the maximum number of webhook deliveries per page
+const maxWebhookDeliveriesPageSize = 1000
+
+// webhookDeliveryColumns are the columns of webhook deliveries in scan order
+var webhookDeliveryColumns = []string{
+	"d.id",
+	"d.webhook_id",
+	"d.event",
+	"d.payload",
+	"d.status",
+	"d.attempts",
+	"d.response_status",
+	"d.last_error",
+	"d.next_attempt_at",
+	"d.created_at",
+	"d.sent_at",
+}
+
+// WebhookRow represents a database row for webhooks
+type WebhookRow struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	Events    string    `db:"events"`
+	IsEnabled bool      `db:"is_enabled"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// WebhookDeliveryRow represents a database row for webhook deliveries
+type WebhookDeliveryRow struct {
+	ID             string     `db:"id"`
+	WebhookID      string     `db:"webhook_id"`
+	Event          string     `db:"event"`
+	Payload        string     `db:"payload"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	ResponseStatus int        `db:"response_status"`
+	LastError      string     `db:"last_error"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	SentAt         *time.Time `db:"sent_at"`
+}
+
+// FindWebhooksParams holds filters for webhooks
+type FindWebhooksParams struct {
+	IsEnabled *bool
+}
+
+// FindWebhookDeliveriesParams holds filters for webhook deliveries
+type FindWebhookDeliveriesParams struct {
+	WebhookID string
+	Status    WebhookDeliveryStatus
+	Event     WebhookEvent
+	Page      *uint32
+	PageSize  *uint32
+}
+
+// webhookEvents returns the JSON encoded events of a webhook
+func webhookEvents(webhook *Webhook) (string, error) {
+	events := webhook.Events
+	if events == nil {
+		events = []WebhookEvent{}
+	}
+
+	data, err := json.Marshal(events)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal webhook events: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// CreateWebhook creates a new webhook
+func (o *ORMStorage) CreateWebhook(ctx context.Context, webhook *Webhook) error {
+	if webhook.ID == "" {
+		webhook.ID = GenerateULID()
+	}
+
+	events, err := webhookEvents(webhook)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("webhooks")
+	ib.Cols("id", "name", "url", "secret", "events", "is_enabled", "created_at", "updated_at")
+	ib.Values(webhook.ID, webhook.Name, webhook.URL, webhook.Secret, events, webhook.IsEnabled, webhook.CreatedAt, webhook.UpdatedAt)
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("webhook %s %w", webhook.Name, ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+func findWebhooksBuilder(params FindWebhooksParams) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("id", "name", "url", "secret", "events", "is_enabled", "created_at", "updated_at")
+	sb.From("webhooks")
+
+	if params.IsEnabled != nil {
+		sb.Where(sb.Equal("is_enabled", *params.IsEnabled))
+	}
+
+	return sb
+}
+
+// scanWebhook scans a webhook row
+func scanWebhook(scanner interface{ Scan(dest ...any) error }) (*Webhook, error) {
+	var row WebhookRow
+	err := scanner.Scan(
+		&row.ID,
+		&row.Name,
+		&row.URL,
+		&row.Secret,
+		&row.Events,
+		&row.IsEnabled,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &Webhook{
+		ID:        row.ID,
+		Name:      row.Name,
+		URL:       row.URL,
+		Secret:    row.Secret,
+		IsEnabled: row.IsEnabled,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	if err := json.Unmarshal([]byte(row.Events), &webhook.Events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook events: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// GetWebhookByID gets a webhook by ID
+func (o *ORMStorage) GetWebhookByID(ctx context.Context, id string) (*Webhook, error) {
+	sb := findWebhooksBuilder(FindWebhooksParams{})
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	webhook, err := scanWebhook(o.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// FindWebhooks finds webhooks ordered by name
+func (o *ORMStorage) FindWebhooks(ctx context.Context, params FindWebhooksParams) ([]*Webhook, error) {
+	sb := findWebhooksBuilder(params)
+	sb.OrderBy("name").Asc()
+
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		items = append(items, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// UpdateWebhook updates a webhook
+func (o *ORMStorage) UpdateWebhook(ctx context.Context, webhook *Webhook) error {
+	events, err := webhookEvents(webhook)
+	if err != nil {
+		return err
+	}
+
+	webhook.UpdatedAt = time.Now()
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("webhooks")
+	ub.Set(
+		ub.Assign("name", webhook.Name),
+		ub.Assign("url", webhook.URL),
+		ub.Assign("secret", webhook.Secret),
+		ub.Assign("events", events),
+		ub.Assign("is_enabled", webhook.IsEnabled),
+		ub.Assign("updated_at", webhook.UpdatedAt),
+	)
+	ub.Where(ub.Equal("id", webhook.ID))
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("webhook %s %w", webhook.Name, ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteWebhook deletes a webhook with its deliveries
+func (o *ORMStorage) DeleteWebhook(ctx context.Context, id string) error {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE webhook_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWebhookDeliveries adds pending deliveries of webhook events
+func (o *ORMStorage) CreateWebhookDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto("webhook_deliveries")
+	ib.Cols("id", "webhook_id", "event", "payload", "status", "attempts", "response_status", "last_error", "next_attempt_at", "created_at", "sent_at")
+
+	for _, delivery := range deliveries {
+		if delivery.ID == "" {
+			delivery.ID = GenerateULID()
+		}
+		delivery.Status = WebhookDeliveryStatusPending
+		delivery.CreatedAt = now
+		if delivery.NextAttemptAt.IsZero() {
+			delivery.NextAttemptAt = now
+		}
+
+		ib.Values(
+			delivery.ID,
+			delivery.WebhookID,
+			string(delivery.Event),
+			delivery.Payload,
+			string(delivery.Status),
+			delivery.Attempts,
+			delivery.ResponseStatus,
+			delivery.LastError,
+			delivery.NextAttemptAt.UTC(),
+			delivery.CreatedAt,
+			nil,
+		)
+	}
+
+	query, args := ib.Build()
+	if _, err := o.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func findWebhookDeliveriesBuilder(params FindWebhookDeliveriesParams, col ...string) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(col...)
+	sb.From("webhook_deliveries d")
+
+	if params.WebhookID != "" {
+		sb.Where(sb.Equal("d.webhook_id", params.WebhookID))
+	}
+
+	if params.Status != "" {
+		sb.Where(sb.Equal("d.status", string(params.Status)))
+	}
+
+	if params.Event != "" {
+		sb.Where(sb.Equal("d.event", string(params.Event)))
+	}
+
+	return sb
+}
+
+// scanWebhookDelivery scans a webhook delivery row
+func scanWebhookDelivery(scanner interface{ Scan(dest ...any) error }) (*WebhookDelivery, error) {
+	var row WebhookDeliveryRow
+	err := scanner.Scan(
+		&row.ID,
+		&row.WebhookID,
+		&row.Event,
+		&row.Payload,
+		&row.Status,
+		&row.Attempts,
+		&row.ResponseStatus,
+		&row.LastError,
+		&row.NextAttemptAt,
+		&row.CreatedAt,
+		&row.SentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookDelivery{
+		ID:             row.ID,
+		WebhookID:      row.WebhookID,
+		Event:          WebhookEvent(row.Event),
+		Payload:        row.Payload,
+		Status:         WebhookDeliveryStatus(row.Status),
+		Attempts:       row.Attempts,
+		ResponseStatus: row.ResponseStatus,
+		LastError:      row.LastError,
+		NextAttemptAt:  row.NextAttemptAt,
+		CreatedAt:      row.CreatedAt,
+		SentAt:         row.SentAt,
+	}, nil
+}
+
+// queryWebhookDeliveries runs a query of webhook deliveries
+func (o *ORMStorage) queryWebhookDeliveries(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]*WebhookDelivery, error) {
+	query, args := sb.Build()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		items = append(items, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetWebhookDeliveryByID gets a webhook delivery by ID
+func (o *ORMStorage) GetWebhookDeliveryByID(ctx context.Context, id string) (*WebhookDelivery, error) {
+	sb := findWebhookDeliveriesBuilder(FindWebhookDeliveriesParams{}, webhookDeliveryColumns...)
+	sb.Where(sb.Equal("d.id", id))
+
+	query, args := sb.Build()
+	delivery, err := scanWebhookDelivery(o.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// FindWebhookDeliveries finds webhook deliveries, latest first
+func (o *ORMStorage) FindWebhookDeliveries(ctx context.Context, params FindWebhookDeliveriesParams) (dbutils.FindResponseWithCount[*WebhookDelivery], error) {
+	res := dbutils.FindResponseWithCount[*WebhookDelivery]{}
+
+	limit, offset, err := dbutils.Pagination(params.Page, params.PageSize, dbutils.WithMaxLimit(maxWebhookDeliveriesPageSize))
+	if err != nil {
+		return res, fmt.Errorf("failed to apply pagination: %w", err)
+	}
+
+	sb := findWebhookDeliveriesBuilder(params, webhookDeliveryColumns...)
+	sb.OrderBy("d.created_at DESC", "d.id DESC")
+	sb.Limit(int(limit)).Offset(int(offset))
+
+	items, err := o.queryWebhookDeliveries(ctx, sb)
+	if err != nil {
+		return res, err
+	}
+
+	var totalCount uint32
+	countQuery, countArgs := findWebhookDeliveriesBuilder(params, "COUNT(*)").Build()
+	if err := o.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount); err != nil {
+		return res, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	res.Count = totalCount
+	res.Items = items
+
+	return res, nil
+}
+
+// FindDueWebhookDeliveries finds pending deliveries of enabled webhooks whose next attempt is due, oldest first.
+// Deliveries wait while an older delivery of the same webhook is waiting for a retry, to keep their order.
+func (o *ORMStorage) FindDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error) {
+	waiting := sqlbuilder.NewSelectBuilder()
+	waiting.Select("1")
+	waiting.From("webhook_deliveries p")
+	waiting.Where(
+		"p.webhook_id = d.webhook_id",
+		waiting.Equal("p.status", string(WebhookDeliveryStatusPending)),
+		waiting.GreaterThan("p.next_attempt_at", now.UTC()),
+		waiting.Or(
+			"p.created_at < d.created_at",
+			waiting.And("p.created_at = d.created_at", "p.id < d.id"),
+		),
+	)
+
+	sb := findWebhookDeliveriesBuilder(FindWebhookDeliveriesParams{Status: WebhookDeliveryStatusPending}, webhookDeliveryColumns...)
+	sb.Join("webhooks w", "w.id = d.webhook_id")
+	sb.Where(
+		sb.Equal("w.is_enabled", true),
+		sb.LessEqualThan("d.next_attempt_at", now.UTC()),
+		sb.NotExists(waiting),
+	)
+	sb.OrderBy("d.created_at", "d.id").Asc()
+	sb.Limit(limit)
+
+	return o.queryWebhookDeliveries(ctx, sb)
+}
+
+// UpdateWebhookDelivery updates the state of a webhook delivery
+func (o *ORMStorage) UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	var sentAt *time.Time
+	if delivery.SentAt != nil {
+		t := delivery.SentAt.UTC()
+		sentAt = &t
+	}
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("webhook_deliveries")
+	ub.Set(
+		ub.Assign("status", string(delivery.Status)),
+		ub.Assign("attempts", delivery.Attempts),
+		ub.Assign("response_status", delivery.ResponseStatus),
+		ub.Assign("last_error", delivery.LastError),
+		ub.Assign("next_attempt_at", delivery.NextAttemptAt.UTC()),
+		ub.Assign("sent_at", sentAt),
+	)
+	ub.Where(ub.Equal("id", delivery.ID))
+
+	query, args := ub.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteWebhookDeliveriesBefore deletes sent and dead-lettered webhook deliveries created before the given time
+func (o *ORMStorage) DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom("webhook_deliveries")
+	db.Where(
+		db.NotEqual("status", string(WebhookDeliveryStatusPending)),
+		db.LessThan("created_at", before.UTC()),
+	)
+
+	query, args := db.Build()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return deleted, nil
+}
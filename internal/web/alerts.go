@@ -0,0 +1,180 @@
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/monitor"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// AlertmanagerRequest represents the payload of the Prometheus Alertmanager webhook receiver
+type AlertmanagerRequest struct {
+	Version           string              `json:"version" example:"4"`
+	GroupKey          string              `json:"groupKey"`
+	Status            string              `json:"status" example:"firing"`
+	Receiver          string              `json:"receiver" example:"sentinel"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []AlertmanagerAlert `json:"alerts" validate:"max=1000,dive"`
+}
+
+// AlertmanagerAlert represents an alert of an Alertmanager notification
+type AlertmanagerAlert struct {
+	Status       string            `json:"status" validate:"required,oneof=firing resolved" example:"firing"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint" example:"c5a6f3b1e2d4a7f8"`
+}
+
+// AlertsRequest represents alerts of other monitoring systems
+type AlertsRequest struct {
+	Alerts []AlertRequest `json:"alerts" validate:"required,min=1,max=1000,dive"`
+}
+
+// AlertRequest represents an alert of another monitoring system
+type AlertRequest struct {
+	// Key identifies the alert, the firing and resolved notifications of an alert must use the same key
+	Key         string                   `json:"key" validate:"required,max=200" example:"db-replication-lag"`
+	Status      string                   `json:"status" validate:"required,oneof=firing resolved" example:"firing"`
+	Title       string                   `json:"title" validate:"required,max=200" example:"Replication lag"`
+	Description string                   `json:"description" validate:"max=4000" example:"Replica is 30s behind"`
+	Severity    storage.IncidentSeverity `json:"severity" validate:"omitempty,oneof=critical warning" example:"critical"`
+	// Labels are matched against the service label and the alert mappings
+	Labels map[string]string `json:"labels"`
+	// Services are names, IDs or external IDs of affected services in addition to the mapped ones
+	Services []string `json:"services" validate:"dive,required"`
+	// StartsAt defaults to now
+	StartsAt *time.Time `json:"starts_at"`
+	// EndsAt defaults to now for resolved alerts
+	EndsAt *time.Time `json:"ends_at"`
+}
+
+// handleAlertmanagerAlerts receives alerts of Prometheus Alertmanager
+//
+//	@Summary		Receive Alertmanager alerts
+//	@Description	Webhook receiver for Prometheus Alertmanager. Firing alerts open incidents on the services they map to by their labels, resolved alerts resolve them.
+//	@Description	The title of an incident is the alertname label, its description the summary or description annotation.
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		AlertmanagerRequest		true	"Alertmanager notification"
+//	@Success		200		{object}	monitor.AlertsResult	"Processed alerts"
+//	@Failure		400		{object}	ErrorResponse			"Bad request"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/alerts/alertmanager [post]
+func (s *Server) handleAlertmanagerAlerts(c *fiber.Ctx) error {
+	var req AlertmanagerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	alerts := make([]monitor.Alert, 0, len(req.Alerts))
+	for _, item := range req.Alerts {
+		description := item.Annotations["summary"]
+		if description == "" {
+			description = item.Annotations["description"]
+		}
+
+		fingerprint := item.Fingerprint
+		if fingerprint == "" {
+			fingerprint = labelsFingerprint(item.Labels)
+		}
+
+		alerts = append(alerts, monitor.Alert{
+			Key:         "alertmanager:" + fingerprint,
+			Status:      monitor.AlertStatus(item.Status),
+			Title:       item.Labels["alertname"],
+			Description: description,
+			Labels:      item.Labels,
+			StartsAt:    item.StartsAt,
+			EndsAt:      item.EndsAt,
+		})
+	}
+
+	res, err := s.monitorService.ProcessAlerts(c.Context(), alerts)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(res)
+}
+
+// handleAlerts receives alerts of other monitoring systems
+//
+//	@Summary		Receive alerts
+//	@Description	Receives alerts of other monitoring systems in a generic format. Firing alerts open incidents on the given services and the services they map to by their labels, resolved alerts resolve them.
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		AlertsRequest			true	"Alerts"
+//	@Success		200		{object}	monitor.AlertsResult	"Processed alerts"
+//	@Failure		400		{object}	ErrorResponse			"Bad request"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/alerts [post]
+func (s *Server) handleAlerts(c *fiber.Ctx) error {
+	var req AlertsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	alerts := make([]monitor.Alert, 0, len(req.Alerts))
+	for _, item := range req.Alerts {
+		alert := monitor.Alert{
+			Key:         "generic:" + item.Key,
+			Status:      monitor.AlertStatus(item.Status),
+			Title:       item.Title,
+			Description: item.Description,
+			Severity:    item.Severity,
+			Labels:      item.Labels,
+			Services:    item.Services,
+		}
+		if item.StartsAt != nil {
+			alert.StartsAt = *item.StartsAt
+		}
+		if item.EndsAt != nil {
+			alert.EndsAt = *item.EndsAt
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	res, err := s.monitorService.ProcessAlerts(c.Context(), alerts)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(res)
+}
+
+// labelsFingerprint identifies an alert by its labels for Alertmanager versions without fingerprints
+func labelsFingerprint(labels map[string]string) string {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(labels[name])
+		b.WriteByte(0)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,62 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/backup"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// handleServerBackup streams a consistent snapshot of the database
+//
+//	@Summary		Back up database
+//	@Description	Creates a consistent snapshot of the SQLite database and returns it as a file. Requires authentication to be enabled.
+//	@Tags			server
+//	@Produce		application/octet-stream
+//	@Success		200	{file}		file			"Database snapshot"
+//	@Failure		403	{object}	ErrorResponse	"Authentication is not enabled"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Failure		501	{object}	ErrorResponse	"Backups are not supported by the storage"
+//	@Security		BasicAuth
+//	@Router			/server/backup [post]
+func (s *Server) handleServerBackup(c *fiber.Ctx) error {
+	if !s.config.Server.Auth.Enabled {
+		return newErrorResponse(c, fiber.StatusForbidden, ErrBackupAuthRequired)
+	}
+
+	dir, err := os.MkdirTemp("", "sentinel-backup-")
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, fmt.Errorf("failed to create temp directory: %w", err))
+	}
+	defer os.RemoveAll(dir)
+
+	name := backup.FileName(time.Now())
+	path := filepath.Join(dir, name)
+	if err := s.storage.Backup(c.UserContext(), path); err != nil {
+		if errors.Is(err, storage.ErrBackupNotSupported) {
+			return newErrorResponse(c, fiber.StatusNotImplemented, err)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	// The open file stays readable after the temp directory is removed
+	f, err := os.Open(path)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, fmt.Errorf("failed to open backup: %w", err))
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return newErrorResponse(c, fiber.StatusInternalServerError, fmt.Errorf("failed to stat backup: %w", err))
+	}
+
+	c.Attachment(name)
+	c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	return c.SendStream(f, int(info.Size()))
+}
@@ -13,6 +13,7 @@ import (
 type DashboardStats struct {
 	TotalServices    int                                 `json:"total_services" example:"10"`
 	ServicesUp       int                                 `json:"services_up" example:"8"`
+	ServicesDegraded int                                 `json:"services_degraded" example:"1"`
 	ServicesDown     int                                 `json:"services_down" example:"1"`
 	ServicesUnknown  int                                 `json:"services_unknown" example:"1"`
 	Protocols        map[storage.ServiceProtocolType]int `json:"protocols"`
@@ -22,6 +23,9 @@ type DashboardStats struct {
 	UptimePercentage float64                             `json:"uptime_percentage" example:"95.5"`
 	LastCheckTime    *time.Time                          `json:"last_check_time"`
 	ChecksPerMinute  int                                 `json:"checks_per_minute" example:"60"`
+	SLOsTotal        int                                 `json:"slos_total" example:"4"`
+	SLOsBreached     int                                 `json:"slos_breached" example:"1"`
+	SLOsAlerting     int                                 `json:"slos_alerting" example:"0"`
 }
 
 // Incident represents an incident
@@ -51,16 +55,65 @@ type ServiceStats struct {
 	AvgResponseTime  time.Duration `json:"avg_response_time" swaggertype:"primitive,integer" example:"150000000"`
 }
 
+// TimeSeriesPoint represents aggregated checks of a service for a time bucket
+//
+//	@Description	Service check time series point
+type TimeSeriesPoint struct {
+	Timestamp        time.Time `json:"timestamp"`
+	TotalChecks      int       `json:"total_checks" example:"60"`
+	UpChecks         int       `json:"up_checks" example:"58"`
+	DegradedChecks   int       `json:"degraded_checks" example:"1"`
+	DownChecks       int       `json:"down_checks" example:"1"`
+	UptimePercentage float64   `json:"uptime_percentage" example:"98.3"`
+	MinResponseTime  uint32    `json:"min_response_time" swaggertype:"primitive,integer" example:"80"`
+	AvgResponseTime  uint32    `json:"avg_response_time" swaggertype:"primitive,integer" example:"150"`
+	MaxResponseTime  uint32    `json:"max_response_time" swaggertype:"primitive,integer" example:"900"`
+	P95ResponseTime  uint32    `json:"p95_response_time" swaggertype:"primitive,integer" example:"400"`
+	P99ResponseTime  uint32    `json:"p99_response_time" swaggertype:"primitive,integer" example:"850"`
+}
+
+// TimeSeriesResponse represents latency and status time series of a service
+//
+//	@Description	Service check time series
+type TimeSeriesResponse struct {
+	ServiceID  string            `json:"service_id" example:"service-1"`
+	Resolution string            `json:"resolution" example:"hour"`
+	StartTime  time.Time         `json:"start_time"`
+	EndTime    time.Time         `json:"end_time"`
+	Points     []TimeSeriesPoint `json:"points"`
+}
+
+// RetryPolicyDTO represents a service retry policy
+type RetryPolicyDTO struct {
+	Strategy  storage.RetryStrategy     `json:"strategy" validate:"omitempty,oneof=fixed linear exponential" example:"exponential"`
+	BaseDelay uint32                    `json:"base_delay" swaggertype:"primitive,integer" example:"500"`
+	MaxDelay  uint32                    `json:"max_delay" swaggertype:"primitive,integer" example:"30000"`
+	RetryOn   []storage.CheckErrorClass `json:"retry_on" validate:"omitempty,dive,oneof=timeout connection assertion" example:"timeout,connection"`
+}
+
+// ThresholdPolicyDTO represents service failure/recovery thresholds and flapping detection
+type ThresholdPolicyDTO struct {
+	FailThreshold     int `json:"fail_threshold" validate:"gte=0,lte=100" example:"3"`
+	RecoveryThreshold int `json:"recovery_threshold" validate:"gte=0,lte=100" example:"2"`
+	Window            int `json:"window" validate:"gte=0,lte=100" example:"5"`
+	FlapThreshold     int `json:"flap_threshold" validate:"gte=0,lte=100" example:"6"`
+	FlapWindow        int `json:"flap_window" validate:"gte=0,lte=100" example:"20"`
+}
+
 // CreateUpdateServiceRequest represents a request to create or update a service
 type CreateUpdateServiceRequest struct {
-	Name      string                      `json:"name" example:"Web Server"`
-	Protocol  storage.ServiceProtocolType `json:"protocol" example:"http"`
-	Interval  uint32                      `json:"interval" swaggertype:"primitive,integer" example:"60000"`
-	Timeout   uint32                      `json:"timeout" swaggertype:"primitive,integer" example:"10000"`
-	Retries   int                         `json:"retries" example:"5"`
-	Tags      []string                    `json:"tags" example:"web,production"`
-	Config    monitors.Config             `json:"config"`
-	IsEnabled bool                        `json:"is_enabled" example:"true"`
+	Name            string                      `json:"name" example:"Web Server"`
+	Protocol        storage.ServiceProtocolType `json:"protocol" example:"http"`
+	Interval        uint32                      `json:"interval" swaggertype:"primitive,integer" example:"60000"`
+	Timeout         uint32                      `json:"timeout" swaggertype:"primitive,integer" example:"10000"`
+	Retries         int                         `json:"retries" example:"5"`
+	RetryPolicy     *RetryPolicyDTO             `json:"retry_policy,omitempty"`
+	ThresholdPolicy *ThresholdPolicyDTO         `json:"threshold_policy,omitempty"`
+	LatencyWarning  uint32                      `json:"latency_warning" swaggertype:"primitive,integer" example:"2000"`
+	Tags            []string                    `json:"tags" example:"web,production"`
+	Config          monitors.Config             `json:"config"`
+	IsEnabled       bool                        `json:"is_enabled" example:"true"`
+	ExternalID      string                      `json:"external_id,omitempty" example:"web-server"`
 }
 
 // ServiceDTO represents a service for API responses
@@ -71,12 +124,15 @@ type ServiceDTO struct {
 	Interval           uint32                      `json:"interval" swaggertype:"primitive,integer" example:"60000"`
 	Timeout            uint32                      `json:"timeout" swaggertype:"primitive,integer" example:"10000"`
 	Retries            int                         `json:"retries" example:"5"`
+	RetryPolicy        *RetryPolicyDTO             `json:"retry_policy,omitempty"`
+	ThresholdPolicy    *ThresholdPolicyDTO         `json:"threshold_policy,omitempty"`
+	LatencyWarning     uint32                      `json:"latency_warning" swaggertype:"primitive,integer" example:"2000"`
 	Tags               []string                    `json:"tags" example:"web,production"`
 	Config             monitors.Config             `json:"config"`
 	IsEnabled          bool                        `json:"is_enabled" example:"true"`
 	ActiveIncidents    int                         `json:"active_incidents" example:"2"`
 	TotalIncidents     int                         `json:"total_incidents" example:"10"`
-	Status             storage.ServiceStatus       `json:"status" example:"up / down / unknown"`
+	Status             storage.ServiceStatus       `json:"status" example:"up / degraded / down / unknown"`
 	LastCheck          *time.Time                  `json:"last_check,omitempty" example:"2023-10-01T12:00:00Z"`
 	NextCheck          *time.Time                  `json:"next_check,omitempty" example:"2023-10-01T12:05:00Z"`
 	LastError          *string                     `json:"last_error,omitempty" example:"Connection timeout"`
@@ -84,6 +140,10 @@ type ServiceDTO struct {
 	ConsecutiveSuccess int                         `json:"consecutive_success" example:"5"`
 	TotalChecks        int                         `json:"total_checks" example:"100"`
 	ResponseTime       uint32                      `json:"response_time" swaggertype:"primitive,integer" example:"150000000"`
+	IsFlapping         bool                        `json:"is_flapping" example:"false"`
+	ExternalID         string                      `json:"external_id,omitempty" example:"web-server"`
+	ManagedBy          string                      `json:"managed_by,omitempty" example:"file"`
+	ReadOnly           bool                        `json:"read_only" example:"false"`
 }
 
 type ServerInfoResponse struct {
@@ -96,3 +156,175 @@ type ServerInfoResponse struct {
 	Arch            string           `json:"arch" example:"amd64"`
 	AvailableUpdate *AvailableUpdate `json:"available_update,omitempty"`
 }
+
+// BurnRateAlertDTO represents a multi-window SLO burn rate alert
+type BurnRateAlertDTO struct {
+	ShortWindow uint32  `json:"short_window" validate:"gte=60000" swaggertype:"primitive,integer" example:"3600000"`
+	LongWindow  uint32  `json:"long_window" validate:"gtfield=ShortWindow,lte=86400000" swaggertype:"primitive,integer" example:"21600000"`
+	Threshold   float64 `json:"threshold" validate:"gt=0" example:"6"`
+}
+
+// CreateUpdateSLORequest represents a request to create or update an SLO
+type CreateUpdateSLORequest struct {
+	Name          string            `json:"name" validate:"required" example:"API availability"`
+	ServiceID     string            `json:"service_id" validate:"required_without=Tag,excluded_with=Tag" example:"service-1"`
+	Tag           string            `json:"tag" validate:"required_without=ServiceID,excluded_with=ServiceID" example:"production"`
+	Target        float64           `json:"target" validate:"gt=0,lt=100" example:"99.9"`
+	LatencyTarget uint32            `json:"latency_target" swaggertype:"primitive,integer" example:"500"`
+	Window        storage.SLOWindow `json:"window" validate:"required,oneof=7d 28d 30d" example:"30d"`
+	BurnRateAlert *BurnRateAlertDTO `json:"burn_rate_alert,omitempty"`
+}
+
+// CreateUpdateNotificationRuleRequest represents a request to create or update a notification rule.
+// Empty conditions match any value.
+type CreateUpdateNotificationRuleRequest struct {
+	Name string `json:"name" validate:"required,max=100" example:"Database team"`
+	// Channels are names of notification channels of the configuration or the API, or oncall:<schedule name> for the user on call
+	Channels   []string                      `json:"channels" validate:"required,min=1,dive,required" example:"database"`
+	ServiceIDs []string                      `json:"service_ids" validate:"dive,required"`
+	Tags       []string                      `json:"tags" validate:"dive,required" example:"database"`
+	Protocols  []storage.ServiceProtocolType `json:"protocols" validate:"dive,oneof=http tcp grpc"`
+	Severities []storage.IncidentSeverity    `json:"severities" validate:"dive,oneof=critical warning"`
+	Events     []storage.NotificationEvent   `json:"events" validate:"dive,oneof=alert degraded recovery update"`
+	IsEnabled  bool                          `json:"is_enabled" example:"true"`
+}
+
+// EscalationStepDTO represents a step of an escalation policy
+type EscalationStepDTO struct {
+	// Delay is the time since the start of the incident in milliseconds
+	Delay uint32 `json:"delay" validate:"gte=60000" swaggertype:"primitive,integer" example:"900000"`
+	// Channels are names of notification channels of the configuration or the API, or oncall:<schedule name> for the user on call
+	Channels []string `json:"channels" validate:"required,min=1,dive,required" example:"oncall"`
+}
+
+// CreateUpdateEscalationPolicyRequest represents a request to create or update an escalation policy.
+// Empty conditions match any value.
+type CreateUpdateEscalationPolicyRequest struct {
+	Name  string              `json:"name" validate:"required,max=100" example:"Production on-call"`
+	Steps []EscalationStepDTO `json:"steps" validate:"dive"`
+	// RepeatInterval is the interval of reminders in milliseconds, 0 disables them
+	RepeatInterval uint32                     `json:"repeat_interval" validate:"omitempty,gte=60000" swaggertype:"primitive,integer" example:"1800000"`
+	ServiceIDs     []string                   `json:"service_ids" validate:"dive,required"`
+	Tags           []string                   `json:"tags" validate:"dive,required" example:"production"`
+	Severities     []storage.IncidentSeverity `json:"severities" validate:"dive,oneof=critical warning"`
+	IsEnabled      bool                       `json:"is_enabled" example:"true"`
+}
+
+// EscalationPolicyDTO represents an escalation policy for API responses
+type EscalationPolicyDTO struct {
+	ID             string                     `json:"id" example:"01HXYZ1234567890ABCDEF"`
+	Name           string                     `json:"name" example:"Production on-call"`
+	Steps          []EscalationStepDTO        `json:"steps"`
+	RepeatInterval uint32                     `json:"repeat_interval" swaggertype:"primitive,integer" example:"1800000"`
+	ServiceIDs     []string                   `json:"service_ids"`
+	Tags           []string                   `json:"tags" example:"production"`
+	Severities     []storage.IncidentSeverity `json:"severities"`
+	IsEnabled      bool                       `json:"is_enabled" example:"true"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+}
+
+// CreateNotificationChannelRequest represents a request to create a notification channel
+type CreateNotificationChannelRequest struct {
+	Name      string   `json:"name" validate:"required,max=100" example:"oncall"`
+	URLs      []string `json:"urls" validate:"required,min=1,dive,required" example:"telegram://token@telegram?chats=@oncall"`
+	IsEnabled bool     `json:"is_enabled" example:"true"`
+	// Format is the markup of the templates, text by default
+	Format storage.NotificationFormat `json:"format" validate:"omitempty,oneof=text markdown html" example:"markdown"`
+	// Templates are Go message templates by event, escaped for the html format: alert, degraded, recovery, update, escalation or reminder
+	Templates map[storage.NotificationEvent]string `json:"templates" validate:"dive,keys,oneof=alert degraded recovery update escalation reminder,endkeys"`
+}
+
+// UpdateNotificationChannelRequest represents a request to update a notification channel.
+// The URLs are kept when omitted, so masked URLs of responses never replace them.
+type UpdateNotificationChannelRequest struct {
+	Name      string   `json:"name" validate:"required,max=100" example:"oncall"`
+	URLs      []string `json:"urls" validate:"omitempty,dive,required" example:"telegram://token@telegram?chats=@oncall"`
+	IsEnabled bool     `json:"is_enabled" example:"true"`
+	// Format is the markup of the templates, text by default
+	Format storage.NotificationFormat `json:"format" validate:"omitempty,oneof=text markdown html" example:"markdown"`
+	// Templates are Go message templates by event, escaped for the html format: alert, degraded, recovery, update, escalation or reminder
+	Templates map[storage.NotificationEvent]string `json:"templates" validate:"dive,keys,oneof=alert degraded recovery update escalation reminder,endkeys"`
+}
+
+// CreateWebhookRequest represents a request to create a webhook
+type CreateWebhookRequest struct {
+	Name string `json:"name" validate:"required,max=100" example:"chatops"`
+	URL  string `json:"url" validate:"required,http_url,max=2048" example:"https://example.com/hooks/sentinel"`
+	// Secret signs the requests, a random secret is generated when omitted
+	Secret string `json:"secret" validate:"omitempty,min=16,max=256" example:"7f9c2ba4e88f827d616045507605853e"`
+	// Events are the subscribed events, all events when empty
+	Events    []storage.WebhookEvent `json:"events" validate:"dive,oneof=service.created service.updated service.deleted service.state_changed incident.opened incident.updated incident.resolved" example:"incident.opened,incident.resolved"`
+	IsEnabled bool                   `json:"is_enabled" example:"true"`
+}
+
+// UpdateWebhookRequest represents a request to update a webhook.
+// The secret is kept when omitted.
+type UpdateWebhookRequest struct {
+	Name      string                 `json:"name" validate:"required,max=100" example:"chatops"`
+	URL       string                 `json:"url" validate:"required,http_url,max=2048" example:"https://example.com/hooks/sentinel"`
+	Secret    string                 `json:"secret" validate:"omitempty,min=16,max=256" example:"7f9c2ba4e88f827d616045507605853e"`
+	Events    []storage.WebhookEvent `json:"events" validate:"dive,oneof=service.created service.updated service.deleted service.state_changed incident.opened incident.updated incident.resolved" example:"incident.opened,incident.resolved"`
+	IsEnabled bool                   `json:"is_enabled" example:"true"`
+}
+
+// CreateWebhookResponse represents a created webhook with its secret, which is not returned afterwards
+type CreateWebhookResponse struct {
+	*storage.Webhook
+	Secret string `json:"secret" example:"7f9c2ba4e88f827d616045507605853e"`
+}
+
+// OnCallUserRequest represents a user of an on-call schedule
+type OnCallUserRequest struct {
+	Name string `json:"name" validate:"required,max=100" example:"alice"`
+	// URLs are personal notification provider URLs, kept for an existing user when omitted
+	URLs []string `json:"urls" validate:"omitempty,dive,required" example:"telegram://token@telegram?chats=@alice"`
+}
+
+// OnCallOverrideRequest represents a period a user of the schedule is on call instead of the rotation
+type OnCallOverrideRequest struct {
+	User  string    `json:"user" validate:"required" example:"bob"`
+	Start time.Time `json:"start" validate:"required" example:"2025-01-10T18:00:00Z"`
+	End   time.Time `json:"end" validate:"required,gtfield=Start" example:"2025-01-11T09:00:00Z"`
+}
+
+// CreateUpdateOnCallScheduleRequest represents a request to create or update an on-call schedule.
+// Users hand off every week at the handoff time on the weekday of the start date, in the configured timezone.
+type CreateUpdateOnCallScheduleRequest struct {
+	Name        string                  `json:"name" validate:"required,max=100" example:"primary"`
+	Users       []OnCallUserRequest     `json:"users" validate:"required,min=1,dive"`
+	StartDate   string                  `json:"start_date" validate:"required,datetime=2006-01-02" example:"2025-01-06"`
+	HandoffTime string                  `json:"handoff_time" validate:"required,datetime=15:04" example:"09:00"`
+	Overrides   []OnCallOverrideRequest `json:"overrides" validate:"dive"`
+}
+
+// SLODTO represents an SLO for API responses
+type SLODTO struct {
+	ID            string            `json:"id" example:"01HXYZ1234567890ABCDEF"`
+	Name          string            `json:"name" example:"API availability"`
+	ServiceID     string            `json:"service_id,omitempty" example:"service-1"`
+	Tag           string            `json:"tag,omitempty" example:"production"`
+	Target        float64           `json:"target" example:"99.9"`
+	LatencyTarget uint32            `json:"latency_target" swaggertype:"primitive,integer" example:"500"`
+	Window        storage.SLOWindow `json:"window" example:"30d"`
+	BurnRateAlert *BurnRateAlertDTO `json:"burn_rate_alert,omitempty"`
+	Alerting      bool              `json:"alerting" example:"false"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// SLOStatusDTO represents the compliance of an SLO over its window
+//
+//	@Description	SLO compliance and error budget
+type SLOStatusDTO struct {
+	SLO                  SLODTO    `json:"slo"`
+	WindowStart          time.Time `json:"window_start"`
+	WindowEnd            time.Time `json:"window_end"`
+	TotalChecks          int64     `json:"total_checks" example:"43200"`
+	GoodChecks           int64     `json:"good_checks" example:"43190"`
+	SLI                  float64   `json:"sli" example:"99.97"`
+	ErrorBudgetRemaining float64   `json:"error_budget_remaining" example:"76.8"`
+	BurnRateShort        float64   `json:"burn_rate_short" example:"0.5"`
+	BurnRateLong         float64   `json:"burn_rate_long" example:"0.3"`
+	Compliant            bool      `json:"compliant" example:"true"`
+}
@@ -7,4 +7,30 @@ var (
 	ErrServiceNameRequired = errors.New("service name is required")
 	ErrProtocolRequired    = errors.New("protocol is required")
 	ErrIncidentIDRequired  = errors.New("incident ID is required")
+	ErrAuthorRequired      = errors.New("author is required")
+	ErrInvalidTimeRange    = errors.New("start time must be before end time")
+	ErrMaintenanceNotFound = errors.New("maintenance window not found")
+	ErrSLONotFound         = errors.New("SLO not found")
+	ErrRuleNotFound        = errors.New("notification rule not found")
+	ErrUnknownChannel      = errors.New("unknown notification channel")
+	ErrChannelNotFound     = errors.New("notification channel not found")
+	ErrDeliveryNotFound    = errors.New("notification delivery not found")
+	ErrDeliveryNotDead     = errors.New("only dead-lettered notification deliveries can be retried")
+	ErrPolicyNotFound      = errors.New("escalation policy not found")
+	ErrPolicyEmpty         = errors.New("escalation policy must have steps or a repeat interval")
+	ErrStepsNotAscending   = errors.New("escalation steps must have increasing delays")
+	ErrScheduleNotFound    = errors.New("on-call schedule not found")
+	ErrOverrideNotFound    = errors.New("on-call override not found")
+	ErrNobodyOnCall        = errors.New("nobody is on call")
+	ErrUnknownOnCallUser   = errors.New("unknown user of the on-call schedule")
+	ErrDuplicateOnCallUser = errors.New("on-call schedule user is declared more than once")
+	ErrOnCallUserURLs      = errors.New("new on-call schedule users must have URLs")
+	ErrBackupAuthRequired  = errors.New("authentication must be enabled to download backups")
+	ErrServiceReadOnly     = errors.New("service is managed by the services file and is read-only")
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryNotDead  = errors.New("only dead-lettered webhook deliveries can be retried")
 )
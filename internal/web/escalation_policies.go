@@ -0,0 +1,227 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// handleFindEscalationPolicies returns all escalation policies
+//
+//	@Summary		Get escalation policies
+//	@Description	Returns all escalation policies ordered by name
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		EscalationPolicyDTO	"List of escalation policies"
+//	@Failure		500	{object}	ErrorResponse		"Internal server error"
+//	@Router			/escalation-policies [get]
+func (s *Server) handleFindEscalationPolicies(c *fiber.Ctx) error {
+	policies, err := s.storage.FindEscalationPolicies(c.Context(), storage.FindEscalationPoliciesParams{})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	result := make([]EscalationPolicyDTO, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, convertEscalationPolicyToDTO(policy))
+	}
+
+	return c.JSON(result)
+}
+
+// handleGetEscalationPolicy returns an escalation policy
+//
+//	@Summary		Get escalation policy
+//	@Description	Returns an escalation policy
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string				true	"Escalation policy ID"
+//	@Success		200	{object}	EscalationPolicyDTO	"Escalation policy"
+//	@Failure		404	{object}	ErrorResponse		"Escalation policy not found"
+//	@Failure		500	{object}	ErrorResponse		"Internal server error"
+//	@Router			/escalation-policies/{id} [get]
+func (s *Server) handleGetEscalationPolicy(c *fiber.Ctx) error {
+	policy, err := s.storage.GetEscalationPolicyByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrPolicyNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(convertEscalationPolicyToDTO(policy))
+}
+
+// handleCreateEscalationPolicy creates a new escalation policy
+//
+//	@Summary		Create escalation policy
+//	@Description	Creates a policy notifying the channels of each step about incidents of matching services still unacknowledged after the step delay,
+//	@Description	and reminding of them every repeat interval until they are acknowledged or resolved.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateUpdateEscalationPolicyRequest	true	"Escalation policy"
+//	@Success		201		{object}	EscalationPolicyDTO					"Created escalation policy"
+//	@Failure		400		{object}	ErrorResponse						"Bad request"
+//	@Failure		500		{object}	ErrorResponse						"Internal server error"
+//	@Router			/escalation-policies [post]
+func (s *Server) handleCreateEscalationPolicy(c *fiber.Ctx) error {
+	var req CreateUpdateEscalationPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validateEscalationPolicyRequest(c.Context(), req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	policy := &storage.EscalationPolicy{}
+	applyEscalationPolicyRequest(policy, req)
+
+	if err := s.storage.CreateEscalationPolicy(c.Context(), policy); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(convertEscalationPolicyToDTO(policy))
+}
+
+// handleUpdateEscalationPolicy updates an escalation policy
+//
+//	@Summary		Update escalation policy
+//	@Description	Updates an escalation policy, incidents keep the steps already notified
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Escalation policy ID"
+//	@Param			request	body		CreateUpdateEscalationPolicyRequest	true	"Escalation policy"
+//	@Success		200		{object}	EscalationPolicyDTO					"Updated escalation policy"
+//	@Failure		400		{object}	ErrorResponse						"Bad request"
+//	@Failure		404		{object}	ErrorResponse						"Escalation policy not found"
+//	@Failure		500		{object}	ErrorResponse						"Internal server error"
+//	@Router			/escalation-policies/{id} [put]
+func (s *Server) handleUpdateEscalationPolicy(c *fiber.Ctx) error {
+	var req CreateUpdateEscalationPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validateEscalationPolicyRequest(c.Context(), req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	policy, err := s.storage.GetEscalationPolicyByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrPolicyNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	applyEscalationPolicyRequest(policy, req)
+
+	if err := s.storage.UpdateEscalationPolicy(c.Context(), policy); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(convertEscalationPolicyToDTO(policy))
+}
+
+// handleDeleteEscalationPolicy deletes an escalation policy
+//
+//	@Summary		Delete escalation policy
+//	@Description	Deletes an escalation policy with the escalation state of its incidents
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Escalation policy ID"
+//	@Success		204	"Escalation policy deleted"
+//	@Failure		404	{object}	ErrorResponse	"Escalation policy not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/escalation-policies/{id} [delete]
+func (s *Server) handleDeleteEscalationPolicy(c *fiber.Ctx) error {
+	if err := s.storage.DeleteEscalationPolicy(c.Context(), c.Params("id")); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrPolicyNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// validateEscalationPolicyRequest validates an escalation policy request, its step delays and channels
+func (s *Server) validateEscalationPolicyRequest(ctx context.Context, req CreateUpdateEscalationPolicyRequest) error {
+	if err := s.validator.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Steps) == 0 && req.RepeatInterval == 0 {
+		return ErrPolicyEmpty
+	}
+
+	for i, step := range req.Steps {
+		if i > 0 && step.Delay <= req.Steps[i-1].Delay {
+			return ErrStepsNotAscending
+		}
+
+		for _, name := range step.Channels {
+			exists, err := s.notificationChannelExists(ctx, name)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: %s", ErrUnknownChannel, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyEscalationPolicyRequest copies escalation policy request fields to a policy
+func applyEscalationPolicyRequest(policy *storage.EscalationPolicy, req CreateUpdateEscalationPolicyRequest) {
+	policy.Name = req.Name
+	policy.Steps = make([]storage.EscalationStep, 0, len(req.Steps))
+	for _, step := range req.Steps {
+		policy.Steps = append(policy.Steps, storage.EscalationStep{
+			Delay:    time.Millisecond * time.Duration(step.Delay),
+			Channels: step.Channels,
+		})
+	}
+	policy.RepeatInterval = time.Millisecond * time.Duration(req.RepeatInterval)
+	policy.ServiceIDs = req.ServiceIDs
+	policy.Tags = req.Tags
+	policy.Severities = req.Severities
+	policy.IsEnabled = req.IsEnabled
+}
+
+// convertEscalationPolicyToDTO converts an escalation policy to EscalationPolicyDTO
+func convertEscalationPolicyToDTO(policy *storage.EscalationPolicy) EscalationPolicyDTO {
+	dto := EscalationPolicyDTO{
+		ID:             policy.ID,
+		Name:           policy.Name,
+		Steps:          make([]EscalationStepDTO, 0, len(policy.Steps)),
+		RepeatInterval: uint32(policy.RepeatInterval.Milliseconds()),
+		ServiceIDs:     policy.ServiceIDs,
+		Tags:           policy.Tags,
+		Severities:     policy.Severities,
+		IsEnabled:      policy.IsEnabled,
+		CreatedAt:      policy.CreatedAt,
+		UpdatedAt:      policy.UpdatedAt,
+	}
+
+	for _, step := range policy.Steps {
+		dto.Steps = append(dto.Steps, EscalationStepDTO{
+			Delay:    uint32(step.Delay.Milliseconds()),
+			Channels: step.Channels,
+		})
+	}
+
+	return dto
+}
@@ -18,7 +18,6 @@ import (
 	"errors"
 	"fmt"
 	goHTML "html"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -166,6 +165,8 @@ func (s *Server) setupRoutes() {
 	// Service management API
 	api.Get("/services", s.handleFindServices)
 	api.Post("/services", s.handleAPICreateService)
+	api.Get("/services/export", s.handleExportServices)
+	api.Post("/services/import", s.handleImportServices)
 	api.Put("/services/:id", s.handleAPIUpdateService)
 	api.Delete("/services/:id", s.handleAPIDeleteService)
 	api.Post("/services/:id/check", s.handleAPIServiceCheck)
@@ -174,12 +175,82 @@ func (s *Server) setupRoutes() {
 	// Service detail API
 	api.Get("/services/:id", s.handleAPIServiceDetail)
 	api.Get("/services/:id/stats", s.handleAPIServiceStats)
+	api.Get("/services/:id/checks", s.handleAPIServiceChecks)
+	api.Get("/services/:id/timeseries", s.handleAPIServiceTimeSeries)
+
+	// Maintenance windows API
+	api.Get("/services/:id/maintenance", s.handleAPIServiceMaintenance)
+	api.Post("/services/:id/maintenance", s.handleAPICreateMaintenance)
+	api.Delete("/services/:id/maintenance/:windowId", s.handleAPIDeleteMaintenance)
 
 	// Incident management API
 	api.Get("/incidents", s.handleFindIncidents)
+	api.Post("/incidents", s.handleAPICreateIncident)
 	api.Get("/incidents/stats", s.handleAPIGetIncidentsStats)
 	api.Get("/services/:id/incidents", s.handleAPIServiceIncidents)
+	api.Patch("/services/:id/incidents/:incidentId", s.handleAPIUpdateIncident)
 	api.Delete("/services/:id/incidents/:incidentId", s.handleAPIDeleteIncident)
+	api.Get("/services/:id/incidents/:incidentId/events", s.handleAPIIncidentEvents)
+	api.Get("/services/:id/incidents/:incidentId/errors", s.handleAPIIncidentErrors)
+	api.Post("/services/:id/incidents/:incidentId/acknowledge", s.handleAPIAcknowledgeIncident)
+	api.Post("/services/:id/incidents/:incidentId/notes", s.handleAPIAddIncidentNote)
+
+	// Inbound alerts API
+	api.Post("/alerts", s.handleAlerts)
+	api.Post("/alerts/alertmanager", s.handleAlertmanagerAlerts)
+
+	// SLO API
+	api.Get("/slos", s.handleFindSLOs)
+	api.Post("/slos", s.handleCreateSLO)
+	api.Get("/slos/:id", s.handleGetSLO)
+	api.Put("/slos/:id", s.handleUpdateSLO)
+	api.Delete("/slos/:id", s.handleDeleteSLO)
+
+	// Notification routing API
+	api.Get("/notification-rules", s.handleFindNotificationRules)
+	api.Post("/notification-rules", s.handleCreateNotificationRule)
+	api.Get("/notification-rules/:id", s.handleGetNotificationRule)
+	api.Put("/notification-rules/:id", s.handleUpdateNotificationRule)
+	api.Delete("/notification-rules/:id", s.handleDeleteNotificationRule)
+
+	// Notification channels API
+	api.Get("/notification-channels", s.handleFindNotificationChannels)
+	api.Post("/notification-channels", s.handleCreateNotificationChannel)
+	api.Get("/notification-channels/:id", s.handleGetNotificationChannel)
+	api.Put("/notification-channels/:id", s.handleUpdateNotificationChannel)
+	api.Delete("/notification-channels/:id", s.handleDeleteNotificationChannel)
+	api.Post("/notification-channels/:id/test", s.handleTestNotificationChannel)
+
+	// Notification outbox API
+	api.Get("/notification-deliveries", s.handleFindNotificationDeliveries)
+	api.Post("/notification-deliveries/:id/retry", s.handleRetryNotificationDelivery)
+
+	// Webhooks API
+	api.Get("/webhooks", s.handleFindWebhooks)
+	api.Post("/webhooks", s.handleCreateWebhook)
+	api.Get("/webhooks/:id", s.handleGetWebhook)
+	api.Put("/webhooks/:id", s.handleUpdateWebhook)
+	api.Delete("/webhooks/:id", s.handleDeleteWebhook)
+	api.Get("/webhooks/:id/deliveries", s.handleFindWebhookDeliveries)
+	api.Post("/webhooks/:id/deliveries/:deliveryId/retry", s.handleRetryWebhookDelivery)
+
+	// Escalation policies API
+	api.Get("/escalation-policies", s.handleFindEscalationPolicies)
+	api.Post("/escalation-policies", s.handleCreateEscalationPolicy)
+	api.Get("/escalation-policies/:id", s.handleGetEscalationPolicy)
+	api.Put("/escalation-policies/:id", s.handleUpdateEscalationPolicy)
+	api.Delete("/escalation-policies/:id", s.handleDeleteEscalationPolicy)
+
+	// On-call schedules API
+	api.Get("/oncall", s.handleFindOnCall)
+	api.Get("/oncall-schedules", s.handleFindOnCallSchedules)
+	api.Post("/oncall-schedules", s.handleCreateOnCallSchedule)
+	api.Get("/oncall-schedules/:id", s.handleGetOnCallSchedule)
+	api.Put("/oncall-schedules/:id", s.handleUpdateOnCallSchedule)
+	api.Delete("/oncall-schedules/:id", s.handleDeleteOnCallSchedule)
+	api.Get("/oncall-schedules/:id/oncall", s.handleGetOnCallShift)
+	api.Post("/oncall-schedules/:id/overrides", s.handleCreateOnCallOverride)
+	api.Delete("/oncall-schedules/:id/overrides/:overrideId", s.handleDeleteOnCallOverride)
 
 	// Tags API
 	api.Get("/tags", s.handleGetAllTags)
@@ -190,6 +261,7 @@ func (s *Server) setupRoutes() {
 	serverGroup.Get("/info", s.handleAPIInfo)
 	serverGroup.Get("/upgrade", s.handleManualUpgrade)
 	serverGroup.Get("/health", s.handleHealthCheck)
+	serverGroup.Post("/backup", s.handleServerBackup)
 
 	// WebSocket endpoint
 	s.app.Use("/ws", func(c *fiber.Ctx) error {
@@ -288,7 +360,7 @@ func (s *Server) handleSPA(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			name		query		string										false	"Filter by service name"
 //	@Param			tags		query		[]string									false	"Filter by service tags"
-//	@Param			status		query		string										false	"Filter by service status"	ENUM("up", "down")
+//	@Param			status		query		string										false	"Filter by service status"	ENUM("up", "degraded", "down", "unknown")
 //	@Param			is_enabled	query		bool										false	"Filter by enabled status"
 //	@Param			protocol	query		string										false	"Filter by protocol"	ENUM("http", "tcp", "grpc")
 //	@Param			order_by	query		string										false	"Order by field"		ENUM("name", "created_at")
@@ -304,7 +376,7 @@ func (s *Server) handleFindServices(c *fiber.Ctx) error {
 	params := struct {
 		Name      string   `query:"name"`
 		Tags      []string `query:"tags"`
-		Status    string   `query:"status" validate:"omitempty,oneof=up down"`
+		Status    string   `query:"status" validate:"omitempty,oneof=up degraded down unknown"`
 		IsEnabled *bool    `query:"is_enabled"`
 		Protocol  string   `query:"protocol" validate:"omitempty,oneof=http tcp grpc"`
 		OrderBy   string   `query:"order_by" validate:"omitempty,oneof=name created_at"`
@@ -398,6 +470,7 @@ func (s *Server) handleAPIServiceDetail(c *fiber.Ctx) error {
 //	@Param			id			path		string											true	"Service ID"
 //	@Param			incident_id	query		string											false	"Filter by incident ID"
 //	@Param			resolved	query		bool											false	"Filter by resolved status"
+//	@Param			severity	query		string											false	"Filter by severity"	ENUM("critical", "warning")
 //	@Param			start_time	query		time.Time										false	"Filter by start time (RFC3339 format)"
 //	@Param			end_time	query		time.Time										false	"Filter by end time (RFC3339 format)"
 //	@Param			page		query		uint32											false	"Page number (for pagination)"
@@ -415,6 +488,7 @@ func (s *Server) handleAPIServiceIncidents(c *fiber.Ctx) error {
 	params := struct {
 		IncidentID string     `query:"incident_id"`
 		Resolved   *bool      `query:"resolved"`
+		Severity   string     `query:"severity" validate:"omitempty,oneof=critical warning"`
 		StartTime  *time.Time `query:"start_time"`
 		EndTime    *time.Time `query:"end_time"`
 		Page       *uint32    `query:"page" validate:"omitempty,gte=1"`
@@ -440,6 +514,7 @@ func (s *Server) handleAPIServiceIncidents(c *fiber.Ctx) error {
 		ID:        params.IncidentID,
 		ServiceID: serviceID,
 		Resolved:  params.Resolved,
+		Severity:  storage.IncidentSeverity(params.Severity),
 		StartTime: params.StartTime,
 		EndTime:   params.EndTime,
 		Page:      params.Page,
@@ -450,7 +525,7 @@ func (s *Server) handleAPIServiceIncidents(c *fiber.Ctx) error {
 	}
 
 	for _, incident := range incidents.Items {
-		incident.Error = goHTML.EscapeString(incident.Error)
+		escapeIncident(incident)
 	}
 
 	return c.JSON(incidents)
@@ -459,15 +534,21 @@ func (s *Server) handleAPIServiceIncidents(c *fiber.Ctx) error {
 // handleAPIServiceStats returns service statistics
 //
 //	@Summary		Get service statistics
-//	@Description	Returns service statistics for the specified period
+//	@Description	Returns service uptime statistics for the specified period.
+//	@Description	The period is set by start_time and end_time or by the number of days until now.
+//	@Description	If bucket is not set, it is chosen by the period: hourly up to 2 days, daily up to 90 days, weekly up to a year, monthly otherwise.
 //	@Tags			statistics
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		string					true	"Service ID"
-//	@Param			days	query		int						false	"Number of days (default 30)"
-//	@Success		200		{object}	storage.ServiceStats	"Service statistics"
-//	@Failure		400		{object}	ErrorResponse			"Bad request"
-//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Param			id			path		string					true	"Service ID"
+//	@Param			days		query		int						false	"Number of days (default 30)"
+//	@Param			start_time	query		time.Time				false	"Start time (RFC3339 format)"
+//	@Param			end_time	query		time.Time				false	"End time (RFC3339 format, default now)"
+//	@Param			bucket		query		string					false	"Uptime bucket size"	ENUM("hour", "day", "week", "month")
+//	@Success		200			{object}	storage.ServiceStats	"Service statistics"
+//	@Failure		400			{object}	ErrorResponse			"Bad request"
+//	@Failure		404			{object}	ErrorResponse			"Service not found"
+//	@Failure		500			{object}	ErrorResponse			"Internal server error"
 //	@Router			/services/{id}/stats [get]
 func (s *Server) handleAPIServiceStats(c *fiber.Ctx) error {
 	serviceID := c.Params("id")
@@ -475,20 +556,56 @@ func (s *Server) handleAPIServiceStats(c *fiber.Ctx) error {
 		return newErrorResponse(c, fiber.StatusBadRequest, ErrServiceIDRequired)
 	}
 
-	daysStr := c.Query("days", "30")
-	days, err := strconv.Atoi(daysStr)
-	if err != nil {
-		days = 30
+	params := struct {
+		Days      *int       `query:"days" validate:"omitempty,gte=1"`
+		StartTime *time.Time `query:"start_time"`
+		EndTime   *time.Time `query:"end_time"`
+		Bucket    string     `query:"bucket" validate:"omitempty,oneof=hour day week month"`
+	}{}
+
+	if err := c.QueryParser(&params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	endTime := time.Now()
+	if params.EndTime != nil {
+		endTime = *params.EndTime
+	}
+
+	days := 30
+	if params.Days != nil {
+		days = *params.Days
+	}
+
+	startTime := endTime.AddDate(0, 0, -days)
+	if params.StartTime != nil {
+		startTime = *params.StartTime
+	}
+
+	if !startTime.Before(endTime) {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrInvalidTimeRange)
+	}
+
+	bucket := storage.UptimeBucketSize(params.Bucket)
+	if bucket == "" {
+		bucket = uptimeBucketSizeFor(endTime.Sub(startTime))
 	}
 
 	// First check if service exists
-	_, err = s.monitorService.GetServiceByID(c.Context(), serviceID)
-	if err != nil {
-		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	if _, err := s.monitorService.GetServiceByID(c.Context(), serviceID); err != nil {
+		return newErrorResponse(c, fiber.StatusNotFound, err)
 	}
 
-	since := time.Now().AddDate(0, 0, -days)
-	stats, err := s.monitorService.GetServiceStats(c.Context(), serviceID, since)
+	stats, err := s.monitorService.GetServiceStats(c.Context(), storage.ServiceStatsParams{
+		ServiceID: serviceID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Bucket:    bucket,
+	})
 	if err != nil {
 		return newErrorResponse(c, fiber.StatusInternalServerError, err)
 	}
@@ -496,6 +613,20 @@ func (s *Server) handleAPIServiceStats(c *fiber.Ctx) error {
 	return c.JSON(stats)
 }
 
+// uptimeBucketSizeFor returns the default uptime bucket size for a period
+func uptimeBucketSizeFor(period time.Duration) storage.UptimeBucketSize {
+	switch {
+	case period <= 2*24*time.Hour:
+		return storage.UptimeBucketHour
+	case period <= 90*24*time.Hour:
+		return storage.UptimeBucketDay
+	case period <= 366*24*time.Hour:
+		return storage.UptimeBucketWeek
+	default:
+		return storage.UptimeBucketMonth
+	}
+}
+
 // handleAPIServiceCheck triggers a manual check
 //
 //	@Summary		Trigger service check
@@ -562,8 +693,10 @@ func (s *Server) handleAPIServiceResolve(c *fiber.Ctx) error {
 //	@Tags			incidents
 //	@Accept			json
 //	@Produce		json
-//	@Param			search		query		string											false	"Filter by service ID or incident ID"
+//	@Param			search		query		string											false	"Filter by service ID, incident ID or title"
 //	@Param			resolved	query		bool											false	"Filter by resolved status"
+//	@Param			severity	query		string											false	"Filter by severity"	ENUM("critical", "warning")
+//	@Param			source		query		string											false	"Filter by source"		ENUM("monitor", "manual", "alert")
 //	@Param			start_time	query		time.Time										false	"Start time for filtering (RFC3339 format)"
 //	@Param			end_time	query		time.Time										false	"End time for filtering (RFC3339 format)"
 //	@Param			page		query		uint32											false	"Page number (default 1)"
@@ -575,6 +708,8 @@ func (s *Server) handleFindIncidents(c *fiber.Ctx) error {
 	params := struct {
 		Search    string     `query:"search"`
 		Resolved  *bool      `query:"resolved"`
+		Severity  string     `query:"severity" validate:"omitempty,oneof=critical warning"`
+		Source    string     `query:"source" validate:"omitempty,oneof=monitor manual alert"`
 		StartTime *time.Time `query:"start_time"`
 		EndTime   *time.Time `query:"end_time"`
 		Page      *uint32    `query:"page" validate:"omitempty,gte=1"`
@@ -593,6 +728,8 @@ func (s *Server) handleFindIncidents(c *fiber.Ctx) error {
 	incidents, err := s.storage.FindIncidents(c.Context(), storage.FindIncidentsParams{
 		Search:    params.Search,
 		Resolved:  params.Resolved,
+		Severity:  storage.IncidentSeverity(params.Severity),
+		Source:    storage.IncidentSource(params.Source),
 		StartTime: params.StartTime,
 		EndTime:   params.EndTime,
 		Page:      params.Page,
@@ -603,7 +740,7 @@ func (s *Server) handleFindIncidents(c *fiber.Ctx) error {
 	}
 
 	for _, incident := range incidents.Items {
-		incident.Error = goHTML.EscapeString(incident.Error)
+		escapeIncident(incident)
 	}
 
 	return c.JSON(incidents)
@@ -736,15 +873,23 @@ func (s *Server) handleAPICreateService(c *fiber.Ctx) error {
 		return newErrorResponse(c, fiber.StatusBadRequest, ErrProtocolRequired)
 	}
 
+	if err := s.validateServicePolicies(serviceDTO); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
 	// Convert to storage.Service
 	createParams := storage.CreateUpdateServiceRequest{
-		Name:      serviceDTO.Name,
-		Protocol:  serviceDTO.Protocol,
-		Interval:  time.Millisecond * time.Duration(serviceDTO.Interval),
-		Timeout:   time.Millisecond * time.Duration(serviceDTO.Timeout),
-		Retries:   serviceDTO.Retries,
-		Tags:      serviceDTO.Tags,
-		IsEnabled: serviceDTO.IsEnabled,
+		Name:            serviceDTO.Name,
+		Protocol:        serviceDTO.Protocol,
+		Interval:        time.Millisecond * time.Duration(serviceDTO.Interval),
+		Timeout:         time.Millisecond * time.Duration(serviceDTO.Timeout),
+		Retries:         serviceDTO.Retries,
+		RetryPolicy:     convertRetryPolicyFromDTO(serviceDTO.RetryPolicy),
+		ThresholdPolicy: convertThresholdPolicyFromDTO(serviceDTO.ThresholdPolicy),
+		LatencyWarning:  time.Millisecond * time.Duration(serviceDTO.LatencyWarning),
+		Tags:            serviceDTO.Tags,
+		IsEnabled:       serviceDTO.IsEnabled,
+		ExternalID:      serviceDTO.ExternalID,
 	}
 
 	// Set default values
@@ -790,6 +935,7 @@ func (s *Server) handleAPICreateService(c *fiber.Ctx) error {
 //	@Param			service	body		CreateUpdateServiceRequest	true	"New service configuration"
 //	@Success		200		{object}	ServiceDTO					"Service updated"
 //	@Failure		400		{object}	ErrorResponse				"Bad request"
+//	@Failure		403		{object}	ErrorResponse				"Service is read-only"
 //	@Failure		404		{object}	ErrorResponse				"Service not found"
 //	@Failure		500		{object}	ErrorResponse				"Internal server error"
 //	@Router			/services/{id} [put]
@@ -799,6 +945,11 @@ func (s *Server) handleAPIUpdateService(c *fiber.Ctx) error {
 		return newErrorResponse(c, fiber.StatusBadRequest, ErrServiceIDRequired)
 	}
 
+	current, err := s.getWritableService(c.Context(), id)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusForbidden, err)
+	}
+
 	var serviceDTO CreateUpdateServiceRequest
 	if err := c.BodyParser(&serviceDTO); err != nil {
 		return newErrorResponse(c, fiber.StatusBadRequest, err)
@@ -807,15 +958,28 @@ func (s *Server) handleAPIUpdateService(c *fiber.Ctx) error {
 	// Debug: log the received data
 	s.logger.Debugf("update service request: %+v", serviceDTO)
 
+	if err := s.validateServicePolicies(serviceDTO); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
 	// Convert to storage.Service
 	updateParams := storage.CreateUpdateServiceRequest{
-		Name:      serviceDTO.Name,
-		Protocol:  serviceDTO.Protocol,
-		Interval:  time.Millisecond * time.Duration(serviceDTO.Interval),
-		Timeout:   time.Millisecond * time.Duration(serviceDTO.Timeout),
-		Retries:   serviceDTO.Retries,
-		Tags:      serviceDTO.Tags,
-		IsEnabled: serviceDTO.IsEnabled,
+		Name:            serviceDTO.Name,
+		Protocol:        serviceDTO.Protocol,
+		Interval:        time.Millisecond * time.Duration(serviceDTO.Interval),
+		Timeout:         time.Millisecond * time.Duration(serviceDTO.Timeout),
+		Retries:         serviceDTO.Retries,
+		RetryPolicy:     convertRetryPolicyFromDTO(serviceDTO.RetryPolicy),
+		ThresholdPolicy: convertThresholdPolicyFromDTO(serviceDTO.ThresholdPolicy),
+		LatencyWarning:  time.Millisecond * time.Duration(serviceDTO.LatencyWarning),
+		Tags:            serviceDTO.Tags,
+		IsEnabled:       serviceDTO.IsEnabled,
+		ExternalID:      serviceDTO.ExternalID,
+	}
+
+	// The external ID is kept when it is not sent, e.g. by the web interface
+	if updateParams.ExternalID == "" {
+		updateParams.ExternalID = current.ExternalID
 	}
 
 	// Convert flat config to proper MonitorConfig structure
@@ -868,6 +1032,8 @@ func (s *Server) handleAPIUpdateService(c *fiber.Ctx) error {
 //	@Param			id	path	string	true	"Service ID"
 //	@Success		204	"Service deleted"
 //	@Failure		400	{object}	ErrorResponse	"Bad request"
+//	@Failure		403	{object}	ErrorResponse	"Service is read-only"
+//	@Failure		404	{object}	ErrorResponse	"Service not found"
 //	@Failure		500	{object}	ErrorResponse	"Internal server error"
 //	@Router			/services/{id} [delete]
 func (s *Server) handleAPIDeleteService(c *fiber.Ctx) error {
@@ -876,6 +1042,10 @@ func (s *Server) handleAPIDeleteService(c *fiber.Ctx) error {
 		return newErrorResponse(c, fiber.StatusBadRequest, ErrServiceIDRequired)
 	}
 
+	if _, err := s.getWritableService(c.Context(), id); err != nil {
+		return newErrorResponse(c, fiber.StatusForbidden, err)
+	}
+
 	if err := s.monitorService.DeleteService(c.Context(), id); err != nil {
 		return newErrorResponse(c, fiber.StatusInternalServerError, err)
 	}
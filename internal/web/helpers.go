@@ -28,6 +28,7 @@ func convertServiceToDTO(service *storage.Service) (ServiceDTO, error) {
 		Interval:           uint32(service.Interval.Milliseconds()),
 		Timeout:            uint32(service.Timeout.Milliseconds()),
 		Retries:            service.Retries,
+		LatencyWarning:     uint32(service.LatencyWarning.Milliseconds()),
 		Tags:               service.Tags,
 		Config:             config,
 		IsEnabled:          service.IsEnabled,
@@ -40,15 +41,52 @@ func convertServiceToDTO(service *storage.Service) (ServiceDTO, error) {
 		ConsecutiveFails:   service.ConsecutiveFails,
 		ConsecutiveSuccess: service.ConsecutiveSuccess,
 		TotalChecks:        service.TotalChecks,
+		IsFlapping:         service.IsFlapping,
+		ExternalID:         service.ExternalID,
+		ManagedBy:          service.ManagedBy,
+		ReadOnly:           service.ManagedBy != "",
 	}
 
 	if service.ResponseTime != nil {
 		dto.ResponseTime = uint32(service.ResponseTime.Milliseconds())
 	}
 
+	if service.RetryPolicy != nil {
+		dto.RetryPolicy = &RetryPolicyDTO{
+			Strategy:  service.RetryPolicy.Strategy,
+			BaseDelay: uint32(service.RetryPolicy.BaseDelay.Milliseconds()),
+			MaxDelay:  uint32(service.RetryPolicy.MaxDelay.Milliseconds()),
+			RetryOn:   service.RetryPolicy.RetryOn,
+		}
+	}
+
+	if service.ThresholdPolicy != nil {
+		dto.ThresholdPolicy = &ThresholdPolicyDTO{
+			FailThreshold:     service.ThresholdPolicy.FailThreshold,
+			RecoveryThreshold: service.ThresholdPolicy.RecoveryThreshold,
+			Window:            service.ThresholdPolicy.Window,
+			FlapThreshold:     service.ThresholdPolicy.FlapThreshold,
+			FlapWindow:        service.ThresholdPolicy.FlapWindow,
+		}
+	}
+
 	return dto, nil
 }
 
+// convertRetryPolicyFromDTO converts a RetryPolicyDTO to storage.RetryPolicy
+func convertRetryPolicyFromDTO(dto *RetryPolicyDTO) *storage.RetryPolicy {
+	if dto == nil {
+		return nil
+	}
+
+	return &storage.RetryPolicy{
+		Strategy:  dto.Strategy,
+		BaseDelay: time.Millisecond * time.Duration(dto.BaseDelay),
+		MaxDelay:  time.Millisecond * time.Duration(dto.MaxDelay),
+		RetryOn:   dto.RetryOn,
+	}
+}
+
 // getDashboardStats calculates dashboard statistics
 func (s *Server) getDashboardStats(ctx context.Context) (*DashboardStats, error) {
 	// Get all services with their states
@@ -81,6 +119,7 @@ func (s *Server) getDashboardStats(ctx context.Context) (*DashboardStats, error)
 	stats := DashboardStats{
 		TotalServices:    int(services.Count),
 		ServicesUp:       0,
+		ServicesDegraded: 0,
 		ServicesDown:     0,
 		ServicesUnknown:  0,
 		UptimePercentage: 0.0,
@@ -113,6 +152,10 @@ func (s *Server) getDashboardStats(ctx context.Context) (*DashboardStats, error)
 			case storage.StatusUp:
 				stats.ServicesUp++
 				upServices++
+			case storage.StatusDegraded:
+				// Degraded services are still available
+				stats.ServicesDegraded++
+				upServices++
 			case storage.StatusDown:
 				stats.ServicesDown++
 			case storage.StatusUnknown:
@@ -170,5 +213,78 @@ func (s *Server) getDashboardStats(ctx context.Context) (*DashboardStats, error)
 	}
 	stats.ChecksPerMinute = checksPerMinute
 
+	// SLO compliance
+	slos, err := s.storage.FindSLOs(ctx, storage.FindSLOsParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, slo := range slos {
+		status, err := s.storage.GetSLOStatus(ctx, slo, now)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.SLOsTotal++
+		if !status.Compliant {
+			stats.SLOsBreached++
+		}
+		if slo.Alerting {
+			stats.SLOsAlerting++
+		}
+	}
+
 	return &stats, nil
 }
+
+// convertThresholdPolicyFromDTO converts a ThresholdPolicyDTO to storage.ThresholdPolicy
+func convertThresholdPolicyFromDTO(dto *ThresholdPolicyDTO) *storage.ThresholdPolicy {
+	if dto == nil {
+		return nil
+	}
+
+	return &storage.ThresholdPolicy{
+		FailThreshold:     dto.FailThreshold,
+		RecoveryThreshold: dto.RecoveryThreshold,
+		Window:            dto.Window,
+		FlapThreshold:     dto.FlapThreshold,
+		FlapWindow:        dto.FlapWindow,
+	}
+}
+
+// validateServicePolicies validates optional retry and threshold policies of a service request
+func (s *Server) validateServicePolicies(req CreateUpdateServiceRequest) error {
+	if req.RetryPolicy != nil {
+		if err := s.validator.Struct(req.RetryPolicy); err != nil {
+			return err
+		}
+	}
+
+	if req.ThresholdPolicy != nil {
+		if err := s.validator.Struct(req.ThresholdPolicy); err != nil {
+			return err
+		}
+
+		window := req.ThresholdPolicy.Window
+		if window > 0 && (req.ThresholdPolicy.FailThreshold > window || req.ThresholdPolicy.RecoveryThreshold > window) {
+			return fmt.Errorf("fail and recovery thresholds cannot exceed the window size %d", window)
+		}
+	}
+
+	return nil
+}
+
+// getWritableService returns the service or an error if it cannot be changed through the API
+func (s *Server) getWritableService(ctx context.Context, id string) (*storage.Service, error) {
+	svc, err := s.monitorService.GetServiceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if svc.ManagedBy != "" {
+		return nil, ErrServiceReadOnly
+	}
+
+	return svc, nil
+}
@@ -0,0 +1,244 @@
+package web
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+	"github.com/sxwebdev/sentinel/pkg/dbutils"
+)
+
+const (
+	// timeSeriesResolutionRaw returns individual check results
+	timeSeriesResolutionRaw = "raw"
+
+	// maxRawTimeSeriesPoints is the maximum number of raw check results in a time series
+	maxRawTimeSeriesPoints = 10000
+)
+
+// handleAPIServiceChecks returns the check history of a service
+//
+//	@Summary		Get service check history
+//	@Description	Returns individual check results of a service, latest first
+//	@Tags			statistics
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string												true	"Service ID"
+//	@Param			start_time	query		time.Time											false	"Start time for filtering (RFC3339 format)"
+//	@Param			end_time	query		time.Time											false	"End time for filtering (RFC3339 format)"
+//	@Param			page		query		uint32												false	"Page number (default 1)"
+//	@Param			page_size	query		uint32												false	"Number of items per page (default 100)"
+//	@Success		200			{object}	dbutils.FindResponseWithCount[storage.CheckResult]	"List of check results"
+//	@Failure		400			{object}	ErrorResponse										"Bad request"
+//	@Failure		404			{object}	ErrorResponse										"Service not found"
+//	@Failure		500			{object}	ErrorResponse										"Internal server error"
+//	@Router			/services/{id}/checks [get]
+func (s *Server) handleAPIServiceChecks(c *fiber.Ctx) error {
+	serviceID := c.Params("id")
+	if serviceID == "" {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrServiceIDRequired)
+	}
+
+	params := struct {
+		StartTime *time.Time `query:"start_time"`
+		EndTime   *time.Time `query:"end_time"`
+		Page      *uint32    `query:"page" validate:"omitempty,gte=1"`
+		PageSize  *uint32    `query:"page_size" validate:"omitempty,gte=1,lte=1000"`
+	}{}
+
+	if err := c.QueryParser(&params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	// First check if service exists
+	if _, err := s.monitorService.GetServiceByID(c.Context(), serviceID); err != nil {
+		return newErrorResponse(c, fiber.StatusNotFound, err)
+	}
+
+	results, err := s.storage.FindCheckResults(c.Context(), storage.FindCheckResultsParams{
+		ServiceID: serviceID,
+		StartTime: params.StartTime,
+		EndTime:   params.EndTime,
+		Page:      params.Page,
+		PageSize:  params.PageSize,
+	})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(dbutils.NewFindResponseWithCount(results.Items, results.Count))
+}
+
+// handleAPIServiceTimeSeries returns latency and status time series of a service
+//
+//	@Summary		Get service time series
+//	@Description	Returns latency and status time series of a service for charts.
+//	@Description	If resolution is not set, it is chosen by the time range: raw up to 1 day, hourly up to 31 days, daily otherwise.
+//	@Tags			statistics
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string				true	"Service ID"
+//	@Param			start_time	query		time.Time			false	"Start time (RFC3339 format, default 24 hours ago)"
+//	@Param			end_time	query		time.Time			false	"End time (RFC3339 format, default now)"
+//	@Param			resolution	query		string				false	"Time series resolution"	ENUM("raw", "hour", "day")
+//	@Success		200			{object}	TimeSeriesResponse	"Time series"
+//	@Failure		400			{object}	ErrorResponse		"Bad request"
+//	@Failure		404			{object}	ErrorResponse		"Service not found"
+//	@Failure		500			{object}	ErrorResponse		"Internal server error"
+//	@Router			/services/{id}/timeseries [get]
+func (s *Server) handleAPIServiceTimeSeries(c *fiber.Ctx) error {
+	serviceID := c.Params("id")
+	if serviceID == "" {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrServiceIDRequired)
+	}
+
+	params := struct {
+		StartTime  *time.Time `query:"start_time"`
+		EndTime    *time.Time `query:"end_time"`
+		Resolution string     `query:"resolution" validate:"omitempty,oneof=raw hour day"`
+	}{}
+
+	if err := c.QueryParser(&params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	endTime := time.Now()
+	if params.EndTime != nil {
+		endTime = *params.EndTime
+	}
+
+	startTime := endTime.Add(-24 * time.Hour)
+	if params.StartTime != nil {
+		startTime = *params.StartTime
+	}
+
+	if !startTime.Before(endTime) {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrInvalidTimeRange)
+	}
+
+	resolution := params.Resolution
+	if resolution == "" {
+		resolution = timeSeriesResolutionFor(endTime.Sub(startTime))
+	}
+
+	// First check if service exists
+	if _, err := s.monitorService.GetServiceByID(c.Context(), serviceID); err != nil {
+		return newErrorResponse(c, fiber.StatusNotFound, err)
+	}
+
+	res := TimeSeriesResponse{
+		ServiceID:  serviceID,
+		Resolution: resolution,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Points:     []TimeSeriesPoint{},
+	}
+
+	if resolution == timeSeriesResolutionRaw {
+		results, err := s.storage.FindCheckResults(c.Context(), storage.FindCheckResultsParams{
+			ServiceID: serviceID,
+			StartTime: &startTime,
+			EndTime:   &endTime,
+			PageSize:  utils.Pointer(uint32(maxRawTimeSeriesPoints)),
+		})
+		if err != nil {
+			return newErrorResponse(c, fiber.StatusInternalServerError, err)
+		}
+
+		// Check results are returned latest first
+		for _, result := range slices.Backward(results.Items) {
+			res.Points = append(res.Points, convertCheckResultToPoint(result))
+		}
+
+		return c.JSON(res)
+	}
+
+	rollups, err := s.storage.FindCheckResultRollups(c.Context(), storage.FindCheckResultRollupsParams{
+		ServiceID:  serviceID,
+		Resolution: storage.RollupResolution(resolution),
+		StartTime:  utils.Pointer(startTime.UTC().Truncate(storage.RollupResolution(resolution).Duration())),
+		EndTime:    &endTime,
+	})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	for _, rollup := range rollups {
+		res.Points = append(res.Points, convertRollupToPoint(rollup))
+	}
+
+	return c.JSON(res)
+}
+
+// timeSeriesResolutionFor returns the default time series resolution for a time range
+func timeSeriesResolutionFor(period time.Duration) string {
+	switch {
+	case period <= 24*time.Hour:
+		return timeSeriesResolutionRaw
+	case period <= 31*24*time.Hour:
+		return string(storage.RollupResolutionHour)
+	default:
+		return string(storage.RollupResolutionDay)
+	}
+}
+
+// convertCheckResultToPoint converts a single check result to a time series point
+func convertCheckResultToPoint(result *storage.CheckResult) TimeSeriesPoint {
+	responseTime := uint32(result.ResponseTime.Milliseconds())
+
+	point := TimeSeriesPoint{
+		Timestamp:       result.CheckedAt,
+		TotalChecks:     1,
+		MinResponseTime: responseTime,
+		AvgResponseTime: responseTime,
+		MaxResponseTime: responseTime,
+		P95ResponseTime: responseTime,
+		P99ResponseTime: responseTime,
+	}
+
+	switch result.Status {
+	case storage.StatusUp:
+		point.UpChecks = 1
+		point.UptimePercentage = 100
+	case storage.StatusDegraded:
+		point.DegradedChecks = 1
+		point.UptimePercentage = 100
+	default:
+		point.DownChecks = 1
+	}
+
+	return point
+}
+
+// convertRollupToPoint converts a check result rollup to a time series point
+func convertRollupToPoint(rollup *storage.CheckResultRollup) TimeSeriesPoint {
+	point := TimeSeriesPoint{
+		Timestamp:       rollup.BucketStart,
+		TotalChecks:     rollup.TotalChecks,
+		UpChecks:        rollup.UpChecks,
+		DegradedChecks:  rollup.DegradedChecks,
+		DownChecks:      rollup.DownChecks,
+		MinResponseTime: uint32(rollup.MinResponseTime.Milliseconds()),
+		AvgResponseTime: uint32(rollup.AvgResponseTime.Milliseconds()),
+		MaxResponseTime: uint32(rollup.MaxResponseTime.Milliseconds()),
+		P95ResponseTime: uint32(rollup.P95ResponseTime.Milliseconds()),
+		P99ResponseTime: uint32(rollup.P99ResponseTime.Milliseconds()),
+	}
+
+	// Degraded services are still available
+	if rollup.TotalChecks > 0 {
+		point.UptimePercentage = float64(rollup.UpChecks+rollup.DegradedChecks) / float64(rollup.TotalChecks) * 100
+	}
+
+	return point
+}
@@ -0,0 +1,204 @@
+package web
+
+import (
+	"errors"
+	goHTML "html"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/monitor"
+)
+
+// IncidentAuthorRequest identifies the user acting on an incident
+type IncidentAuthorRequest struct {
+	// Author is required when authentication is disabled, otherwise the authenticated user is used
+	Author string `json:"author" example:"alice"`
+}
+
+// AddIncidentNoteRequest represents a note added to the incident timeline
+type AddIncidentNoteRequest struct {
+	IncidentAuthorRequest
+	Message string `json:"message" validate:"required,max=4000" example:"Rolling back the last deploy"`
+}
+
+// handleAPIIncidentEvents returns the incident timeline
+//
+//	@Summary		Get incident timeline
+//	@Description	Returns events of an incident: opened, error changes, acknowledgement, notes, sent notifications and resolution
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Service ID"
+//	@Param			incidentId	path		string					true	"Incident ID"
+//	@Success		200			{array}		storage.IncidentEvent	"Incident events, oldest first"
+//	@Failure		400			{object}	ErrorResponse			"Bad request"
+//	@Failure		404			{object}	ErrorResponse			"Incident not found"
+//	@Failure		500			{object}	ErrorResponse			"Internal server error"
+//	@Router			/services/{id}/incidents/{incidentId}/events [get]
+func (s *Server) handleAPIIncidentEvents(c *fiber.Ctx) error {
+	serviceID, incidentID, err := incidentParams(c)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	events, err := s.monitorService.FindIncidentEvents(c.Context(), serviceID, incidentID)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	for _, event := range events {
+		event.Message = goHTML.EscapeString(event.Message)
+		event.Author = goHTML.EscapeString(event.Author)
+	}
+
+	return c.JSON(events)
+}
+
+// handleAPIIncidentErrors returns the errors of an incident
+//
+//	@Summary		Get incident errors
+//	@Description	Returns the distinct errors of an incident in the order they were seen, with the time of their first and last occurrence and the number of occurrences. A new entry is added each time the error changes.
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Service ID"
+//	@Param			incidentId	path		string					true	"Incident ID"
+//	@Success		200			{array}		storage.IncidentError	"Incident errors, oldest first"
+//	@Failure		400			{object}	ErrorResponse			"Bad request"
+//	@Failure		404			{object}	ErrorResponse			"Incident not found"
+//	@Failure		500			{object}	ErrorResponse			"Internal server error"
+//	@Router			/services/{id}/incidents/{incidentId}/errors [get]
+func (s *Server) handleAPIIncidentErrors(c *fiber.Ctx) error {
+	serviceID, incidentID, err := incidentParams(c)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	items, err := s.monitorService.FindIncidentErrors(c.Context(), serviceID, incidentID)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	for _, item := range items {
+		item.Error = goHTML.EscapeString(item.Error)
+	}
+
+	return c.JSON(items)
+}
+
+// handleAPIAcknowledgeIncident acknowledges an incident
+//
+//	@Summary		Acknowledge incident
+//	@Description	Marks an active incident as taken by the user, repeat alerts are not sent for acknowledged incidents
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Service ID"
+//	@Param			incidentId	path		string					true	"Incident ID"
+//	@Param			request		body		IncidentAuthorRequest	false	"Author"
+//	@Success		200			{object}	storage.Incident		"Acknowledged incident"
+//	@Failure		400			{object}	ErrorResponse			"Bad request"
+//	@Failure		404			{object}	ErrorResponse			"Incident not found"
+//	@Failure		409			{object}	ErrorResponse			"Incident is resolved or already acknowledged"
+//	@Failure		500			{object}	ErrorResponse			"Internal server error"
+//	@Router			/services/{id}/incidents/{incidentId}/acknowledge [post]
+func (s *Server) handleAPIAcknowledgeIncident(c *fiber.Ctx) error {
+	serviceID, incidentID, err := incidentParams(c)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	var req IncidentAuthorRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return newErrorResponse(c, fiber.StatusBadRequest, err)
+		}
+	}
+
+	author, err := s.incidentAuthor(c, req)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	incident, err := s.monitorService.AcknowledgeIncident(c.Context(), serviceID, incidentID, author)
+	if err != nil {
+		if errors.Is(err, monitor.ErrIncidentNotActive) {
+			return newErrorResponse(c, fiber.StatusConflict, err)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(escapeIncident(incident))
+}
+
+// handleAPIAddIncidentNote adds a note to the incident timeline
+//
+//	@Summary		Add incident note
+//	@Description	Adds a note of the user to the incident timeline
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Service ID"
+//	@Param			incidentId	path		string					true	"Incident ID"
+//	@Param			request		body		AddIncidentNoteRequest	true	"Note"
+//	@Success		201			{object}	storage.IncidentEvent	"Created note"
+//	@Failure		400			{object}	ErrorResponse			"Bad request"
+//	@Failure		404			{object}	ErrorResponse			"Incident not found"
+//	@Failure		500			{object}	ErrorResponse			"Internal server error"
+//	@Router			/services/{id}/incidents/{incidentId}/notes [post]
+func (s *Server) handleAPIAddIncidentNote(c *fiber.Ctx) error {
+	serviceID, incidentID, err := incidentParams(c)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	var req AddIncidentNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	author, err := s.incidentAuthor(c, req.IncidentAuthorRequest)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	event, err := s.monitorService.AddIncidentNote(c.Context(), serviceID, incidentID, author, req.Message)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(event)
+}
+
+// incidentParams returns the service and incident IDs of the request path
+func incidentParams(c *fiber.Ctx) (string, string, error) {
+	serviceID := c.Params("id")
+	if serviceID == "" {
+		return "", "", ErrServiceIDRequired
+	}
+
+	incidentID := c.Params("incidentId")
+	if incidentID == "" {
+		return "", "", ErrIncidentIDRequired
+	}
+
+	return serviceID, incidentID, nil
+}
+
+// incidentAuthor returns the authenticated user or, with authentication disabled, the author of the request
+func (s *Server) incidentAuthor(c *fiber.Ctx, req IncidentAuthorRequest) (string, error) {
+	if s.config.Server.Auth.Enabled {
+		if username, ok := c.Locals("username").(string); ok && username != "" {
+			return username, nil
+		}
+	}
+
+	if req.Author == "" {
+		return "", ErrAuthorRequired
+	}
+
+	return req.Author, nil
+}
@@ -0,0 +1,158 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// CreateMaintenanceRequest represents a request to schedule a maintenance window
+type CreateMaintenanceRequest struct {
+	StartTime   time.Time `json:"start_time" validate:"required"`
+	EndTime     time.Time `json:"end_time" validate:"required"`
+	Description string    `json:"description"`
+}
+
+// handleAPIServiceMaintenance returns maintenance windows of a service
+//
+//	@Summary		Get service maintenance windows
+//	@Description	Returns maintenance windows of a service, they are excluded from the service uptime
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"Service ID"
+//	@Success		200	{array}		storage.ServicePause	"List of maintenance windows"
+//	@Failure		400	{object}	ErrorResponse			"Bad request"
+//	@Failure		404	{object}	ErrorResponse			"Service not found"
+//	@Failure		500	{object}	ErrorResponse			"Internal server error"
+//	@Router			/services/{id}/maintenance [get]
+func (s *Server) handleAPIServiceMaintenance(c *fiber.Ctx) error {
+	serviceID := c.Params("id")
+	if serviceID == "" {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrServiceIDRequired)
+	}
+
+	// First check if service exists
+	if _, err := s.monitorService.GetServiceByID(c.Context(), serviceID); err != nil {
+		return newErrorResponse(c, fiber.StatusNotFound, err)
+	}
+
+	windows, err := s.storage.FindServicePauses(c.Context(), storage.FindServicePausesParams{
+		ServiceID: serviceID,
+		Reason:    storage.PauseReasonMaintenance,
+	})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(windows)
+}
+
+// handleAPICreateMaintenance schedules a maintenance window
+//
+//	@Summary		Create maintenance window
+//	@Description	Schedules a maintenance window of a service, it is excluded from the service uptime
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Service ID"
+//	@Param			request	body		CreateMaintenanceRequest	true	"Maintenance window"
+//	@Success		201		{object}	storage.ServicePause		"Created maintenance window"
+//	@Failure		400		{object}	ErrorResponse				"Bad request"
+//	@Failure		404		{object}	ErrorResponse				"Service not found"
+//	@Failure		500		{object}	ErrorResponse				"Internal server error"
+//	@Router			/services/{id}/maintenance [post]
+func (s *Server) handleAPICreateMaintenance(c *fiber.Ctx) error {
+	serviceID := c.Params("id")
+	if serviceID == "" {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrServiceIDRequired)
+	}
+
+	var req CreateMaintenanceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if !req.StartTime.Before(req.EndTime) {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrInvalidTimeRange)
+	}
+
+	// First check if service exists
+	if _, err := s.monitorService.GetServiceByID(c.Context(), serviceID); err != nil {
+		return newErrorResponse(c, fiber.StatusNotFound, err)
+	}
+
+	window := &storage.ServicePause{
+		ServiceID:   serviceID,
+		Reason:      storage.PauseReasonMaintenance,
+		StartTime:   req.StartTime,
+		EndTime:     &req.EndTime,
+		Description: req.Description,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.storage.CreateServicePause(c.Context(), window); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(window)
+}
+
+// handleAPIDeleteMaintenance deletes a maintenance window
+//
+//	@Summary		Delete maintenance window
+//	@Description	Deletes a maintenance window of a service
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	string	true	"Service ID"
+//	@Param			windowId	path	string	true	"Maintenance window ID"
+//	@Success		204			"Maintenance window deleted"
+//	@Failure		400			{object}	ErrorResponse	"Bad request"
+//	@Failure		404			{object}	ErrorResponse	"Maintenance window not found"
+//	@Failure		500			{object}	ErrorResponse	"Internal server error"
+//	@Router			/services/{id}/maintenance/{windowId} [delete]
+func (s *Server) handleAPIDeleteMaintenance(c *fiber.Ctx) error {
+	serviceID := c.Params("id")
+	windowID := c.Params("windowId")
+
+	if serviceID == "" {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrServiceIDRequired)
+	}
+
+	windows, err := s.storage.FindServicePauses(c.Context(), storage.FindServicePausesParams{
+		ServiceID: serviceID,
+		Reason:    storage.PauseReasonMaintenance,
+	})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	// Disabled periods and windows of other services can not be deleted here
+	found := false
+	for _, window := range windows {
+		if window.ID == windowID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return newErrorResponse(c, fiber.StatusNotFound, ErrMaintenanceNotFound)
+	}
+
+	if err := s.storage.DeleteServicePause(c.Context(), windowID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrMaintenanceNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,157 @@
+package web
+
+import (
+	"errors"
+	goHTML "html"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/monitor"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// CreateIncidentRequest represents an incident created by hand
+type CreateIncidentRequest struct {
+	IncidentAuthorRequest
+	Title       string                   `json:"title" validate:"required,max=200" example:"Payment provider outage"`
+	Description string                   `json:"description" validate:"max=4000" example:"Card payments fail at the provider"`
+	Severity    storage.IncidentSeverity `json:"severity" validate:"omitempty,oneof=critical warning" example:"critical"`
+	// ServiceIDs are the affected services, the first one is the primary service of the incident
+	ServiceIDs []string `json:"service_ids" validate:"required,min=1,dive,required"`
+	// StartTime defaults to now
+	StartTime *time.Time `json:"start_time"`
+	// EndTime records an already resolved incident
+	EndTime *time.Time `json:"end_time"`
+}
+
+// UpdateIncidentRequest represents edited fields of an incident, omitted fields are kept
+type UpdateIncidentRequest struct {
+	IncidentAuthorRequest
+	Title      *string             `json:"title" validate:"omitempty,max=200" example:"Payment provider outage"`
+	Postmortem *storage.Postmortem `json:"postmortem"`
+	// Description, severity, services and end time are only editable on manual incidents
+	Description *string                   `json:"description" validate:"omitempty,max=4000"`
+	Severity    *storage.IncidentSeverity `json:"severity" validate:"omitempty,oneof=critical warning" example:"warning"`
+	ServiceIDs  []string                  `json:"service_ids" validate:"omitempty,min=1,dive,required"`
+	// EndTime resolves the incident
+	EndTime *time.Time `json:"end_time"`
+}
+
+// handleAPICreateIncident creates an incident by hand
+//
+//	@Summary		Create incident
+//	@Description	Creates an incident for outages checks did not detect or planned degradations. Manual incidents are only resolved by users and do not send notifications.
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateIncidentRequest	true	"Incident"
+//	@Success		201		{object}	storage.Incident		"Created incident"
+//	@Failure		400		{object}	ErrorResponse			"Bad request"
+//	@Failure		404		{object}	ErrorResponse			"Service not found"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/incidents [post]
+func (s *Server) handleAPICreateIncident(c *fiber.Ctx) error {
+	var req CreateIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	author, err := s.incidentAuthor(c, req.IncidentAuthorRequest)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	params := monitor.CreateIncidentParams{
+		Title:       req.Title,
+		Description: req.Description,
+		Severity:    req.Severity,
+		ServiceIDs:  req.ServiceIDs,
+		EndTime:     req.EndTime,
+		Author:      author,
+	}
+	if req.StartTime != nil {
+		params.StartTime = *req.StartTime
+	}
+
+	incident, err := s.monitorService.CreateManualIncident(c.Context(), params)
+	if err != nil {
+		return newErrorResponse(c, incidentErrorStatus(err), err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(escapeIncident(incident))
+}
+
+// handleAPIUpdateIncident edits an incident
+//
+//	@Summary		Update incident
+//	@Description	Edits the title and postmortem of any incident, also after it is resolved. Description, severity, services and end time are only editable on manual incidents, setting the end time resolves the incident.
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Service ID"
+//	@Param			incidentId	path		string					true	"Incident ID"
+//	@Param			request		body		UpdateIncidentRequest	true	"Edited fields"
+//	@Success		200			{object}	storage.Incident		"Updated incident"
+//	@Failure		400			{object}	ErrorResponse			"Bad request"
+//	@Failure		404			{object}	ErrorResponse			"Incident not found"
+//	@Failure		409			{object}	ErrorResponse			"Field is not editable on incidents of the monitor"
+//	@Failure		500			{object}	ErrorResponse			"Internal server error"
+//	@Router			/services/{id}/incidents/{incidentId} [patch]
+func (s *Server) handleAPIUpdateIncident(c *fiber.Ctx) error {
+	serviceID, incidentID, err := incidentParams(c)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	var req UpdateIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	author, err := s.incidentAuthor(c, req.IncidentAuthorRequest)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	incident, err := s.monitorService.UpdateIncident(c.Context(), serviceID, incidentID, monitor.UpdateIncidentParams{
+		Title:       req.Title,
+		Postmortem:  req.Postmortem,
+		Description: req.Description,
+		Severity:    req.Severity,
+		ServiceIDs:  req.ServiceIDs,
+		EndTime:     req.EndTime,
+		Author:      author,
+	})
+	if err != nil {
+		return newErrorResponse(c, incidentErrorStatus(err), err)
+	}
+
+	return c.JSON(escapeIncident(incident))
+}
+
+// incidentErrorStatus returns the response status of an error of creating or editing an incident
+func incidentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, monitor.ErrIncidentNotManual):
+		return fiber.StatusConflict
+	case errors.Is(err, monitor.ErrIncidentEndBeforeStart), errors.Is(err, monitor.ErrIncidentServicesRequired):
+		return fiber.StatusBadRequest
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// escapeIncident escapes the user provided text of an incident
+func escapeIncident(incident *storage.Incident) *storage.Incident {
+	incident.Error = goHTML.EscapeString(incident.Error)
+	incident.Title = goHTML.EscapeString(incident.Title)
+	return incident
+}
@@ -0,0 +1,274 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/notifier"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// handleFindNotificationChannels returns all notification channels
+//
+//	@Summary		Get notification channels
+//	@Description	Returns the notification channels managed through the API ordered by name, with masked URLs
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		storage.NotificationChannel	"List of notification channels"
+//	@Failure		500	{object}	ErrorResponse				"Internal server error"
+//	@Router			/notification-channels [get]
+func (s *Server) handleFindNotificationChannels(c *fiber.Ctx) error {
+	channels, err := s.storage.FindNotificationChannels(c.Context(), storage.FindNotificationChannelsParams{})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	for i, channel := range channels {
+		channels[i] = maskNotificationChannel(channel)
+	}
+
+	return c.JSON(channels)
+}
+
+// handleGetNotificationChannel returns a notification channel
+//
+//	@Summary		Get notification channel
+//	@Description	Returns a notification channel with masked URLs
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string						true	"Notification channel ID"
+//	@Success		200	{object}	storage.NotificationChannel	"Notification channel"
+//	@Failure		404	{object}	ErrorResponse				"Notification channel not found"
+//	@Failure		500	{object}	ErrorResponse				"Internal server error"
+//	@Router			/notification-channels/{id} [get]
+func (s *Server) handleGetNotificationChannel(c *fiber.Ctx) error {
+	channel, err := s.storage.GetNotificationChannelByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrChannelNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(maskNotificationChannel(channel))
+}
+
+// handleCreateNotificationChannel creates a new notification channel
+//
+//	@Summary		Create notification channel
+//	@Description	Creates a named set of notification provider URLs targeted by notification rules
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateNotificationChannelRequest	true	"Notification channel"
+//	@Success		201		{object}	storage.NotificationChannel			"Created notification channel"
+//	@Failure		400		{object}	ErrorResponse						"Bad request"
+//	@Failure		409		{object}	ErrorResponse						"Notification channel already exists"
+//	@Failure		500		{object}	ErrorResponse						"Internal server error"
+//	@Router			/notification-channels [post]
+func (s *Server) handleCreateNotificationChannel(c *fiber.Ctx) error {
+	var req CreateNotificationChannelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validateNotificationChannel(req.Name, req.URLs, req.Format, req.Templates); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	channel := &storage.NotificationChannel{
+		Name:      req.Name,
+		URLs:      req.URLs,
+		IsEnabled: req.IsEnabled,
+		Format:    req.Format,
+		Templates: req.Templates,
+	}
+
+	if err := s.storage.CreateNotificationChannel(c.Context(), channel); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(maskNotificationChannel(channel))
+}
+
+// handleUpdateNotificationChannel updates a notification channel
+//
+//	@Summary		Update notification channel
+//	@Description	Updates a notification channel, the change applies to the next notification.
+//	@Description	The URLs are kept when omitted.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Notification channel ID"
+//	@Param			request	body		UpdateNotificationChannelRequest	true	"Notification channel"
+//	@Success		200		{object}	storage.NotificationChannel			"Updated notification channel"
+//	@Failure		400		{object}	ErrorResponse						"Bad request"
+//	@Failure		404		{object}	ErrorResponse						"Notification channel not found"
+//	@Failure		409		{object}	ErrorResponse						"Notification channel already exists"
+//	@Failure		500		{object}	ErrorResponse						"Internal server error"
+//	@Router			/notification-channels/{id} [put]
+func (s *Server) handleUpdateNotificationChannel(c *fiber.Ctx) error {
+	var req UpdateNotificationChannelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validateNotificationChannel(req.Name, req.URLs, req.Format, req.Templates); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	channel, err := s.storage.GetNotificationChannelByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrChannelNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	channel.Name = req.Name
+	channel.IsEnabled = req.IsEnabled
+	channel.Format = req.Format
+	channel.Templates = req.Templates
+	if len(req.URLs) > 0 {
+		channel.URLs = req.URLs
+	}
+
+	if err := s.storage.UpdateNotificationChannel(c.Context(), channel); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(maskNotificationChannel(channel))
+}
+
+// handleDeleteNotificationChannel deletes a notification channel
+//
+//	@Summary		Delete notification channel
+//	@Description	Deletes a notification channel, rules targeting it skip it
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Notification channel ID"
+//	@Success		204	"Notification channel deleted"
+//	@Failure		404	{object}	ErrorResponse	"Notification channel not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/notification-channels/{id} [delete]
+func (s *Server) handleDeleteNotificationChannel(c *fiber.Ctx) error {
+	if err := s.storage.DeleteNotificationChannel(c.Context(), c.Params("id")); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrChannelNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// handleTestNotificationChannel sends a test notification
+//
+//	@Summary		Send test notification
+//	@Description	Sends a test notification to the URLs of a notification channel, also when it is disabled
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string			true	"Notification channel ID"
+//	@Success		200	{object}	SuccessResponse	"Test notification sent"
+//	@Failure		404	{object}	ErrorResponse	"Notification channel not found"
+//	@Failure		502	{object}	ErrorResponse	"Failed to send the test notification"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/notification-channels/{id}/test [post]
+func (s *Server) handleTestNotificationChannel(c *fiber.Ctx) error {
+	channel, err := s.storage.GetNotificationChannelByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrChannelNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	if err := notifier.SendTestMessage(channel); err != nil {
+		return newErrorResponse(c, fiber.StatusBadGateway, err)
+	}
+
+	return newSuccessResponse(c, "Test notification sent")
+}
+
+// validateNotificationChannel checks that a channel name is not declared in the configuration
+// and that its provider URLs and templates are valid
+func (s *Server) validateNotificationChannel(name string, urls []string, format storage.NotificationFormat, templates map[storage.NotificationEvent]string) error {
+	if strings.HasPrefix(name, storage.OnCallChannelPrefix) {
+		return fmt.Errorf("notification channel name cannot use the reserved %s prefix", storage.OnCallChannelPrefix)
+	}
+
+	if slices.ContainsFunc(s.config.Notifications.Channels, func(channel config.NotificationChannel) bool {
+		return channel.Name == name
+	}) {
+		return fmt.Errorf("notification channel %s %w in the configuration", name, storage.ErrAlreadyExists)
+	}
+
+	for _, url := range urls {
+		if err := notifier.ValidateURL(url); err != nil {
+			return err
+		}
+	}
+
+	if _, err := notifier.ParseTemplates(templates, format); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// notificationChannelExists reports whether a channel is declared in the configuration or managed through the API,
+// or whether the schedule of an on-call channel exists
+func (s *Server) notificationChannelExists(ctx context.Context, name string) (bool, error) {
+	if scheduleName, ok := strings.CutPrefix(name, storage.OnCallChannelPrefix); ok {
+		schedules, err := s.storage.FindOnCallSchedules(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		return slices.ContainsFunc(schedules, func(schedule *storage.OnCallSchedule) bool {
+			return schedule.Name == scheduleName
+		}), nil
+	}
+
+	if slices.ContainsFunc(s.config.Notifications.Channels, func(channel config.NotificationChannel) bool {
+		return channel.Name == name
+	}) {
+		return true, nil
+	}
+
+	channels, err := s.storage.FindNotificationChannels(ctx, storage.FindNotificationChannelsParams{})
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(channels, func(channel *storage.NotificationChannel) bool {
+		return channel.Name == name
+	}), nil
+}
+
+// maskNotificationChannel returns a copy of a channel with masked provider URLs
+func maskNotificationChannel(channel *storage.NotificationChannel) *storage.NotificationChannel {
+	masked := *channel
+	masked.URLs = make([]string, 0, len(channel.URLs))
+	for _, url := range channel.URLs {
+		masked.URLs = append(masked.URLs, notifier.MaskURL(url))
+	}
+	return &masked
+}
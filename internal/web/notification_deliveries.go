@@ -0,0 +1,104 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/notifier"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/pkg/dbutils"
+)
+
+// handleFindNotificationDeliveries returns the delivery history of notifications
+//
+//	@Summary		Get notification deliveries
+//	@Description	Returns deliveries of the notification outbox, one per notification and provider URL, latest first, with masked URLs
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			status		query		string														false	"Filter by status"	Enums(pending, sent, dead)
+//	@Param			channel		query		string														false	"Filter by channel name, empty for the default URLs"
+//	@Param			service_id	query		string														false	"Filter by service ID"
+//	@Param			incident_id	query		string														false	"Filter by incident ID"
+//	@Param			page		query		uint32														false	"Page number (default 1)"
+//	@Param			page_size	query		uint32														false	"Number of items per page (default 100)"
+//	@Success		200			{object}	dbutils.FindResponseWithCount[storage.NotificationDelivery]	"List of notification deliveries"
+//	@Failure		400			{object}	ErrorResponse												"Bad request"
+//	@Failure		500			{object}	ErrorResponse												"Internal server error"
+//	@Router			/notification-deliveries [get]
+func (s *Server) handleFindNotificationDeliveries(c *fiber.Ctx) error {
+	params := struct {
+		Status     string  `query:"status" validate:"omitempty,oneof=pending sent dead"`
+		Channel    *string `query:"channel"`
+		ServiceID  string  `query:"service_id"`
+		IncidentID string  `query:"incident_id"`
+		Page       *uint32 `query:"page" validate:"omitempty,gte=1"`
+		PageSize   *uint32 `query:"page_size" validate:"omitempty,gte=1,lte=1000"`
+	}{}
+
+	if err := c.QueryParser(&params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	deliveries, err := s.storage.FindNotificationDeliveries(c.Context(), storage.FindNotificationDeliveriesParams{
+		Status:     storage.NotificationDeliveryStatus(params.Status),
+		Channel:    params.Channel,
+		ServiceID:  params.ServiceID,
+		IncidentID: params.IncidentID,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+	})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	for _, delivery := range deliveries.Items {
+		delivery.URL = notifier.MaskURL(delivery.URL)
+	}
+
+	return c.JSON(dbutils.NewFindResponseWithCount(deliveries.Items, deliveries.Count))
+}
+
+// handleRetryNotificationDelivery retries a dead-lettered notification delivery
+//
+//	@Summary		Retry notification delivery
+//	@Description	Moves a dead-lettered delivery back to the outbox with a new set of attempts
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string							true	"Notification delivery ID"
+//	@Success		200	{object}	storage.NotificationDelivery	"Notification delivery"
+//	@Failure		400	{object}	ErrorResponse					"The delivery is not dead-lettered"
+//	@Failure		404	{object}	ErrorResponse					"Notification delivery not found"
+//	@Failure		500	{object}	ErrorResponse					"Internal server error"
+//	@Router			/notification-deliveries/{id}/retry [post]
+func (s *Server) handleRetryNotificationDelivery(c *fiber.Ctx) error {
+	delivery, err := s.storage.GetNotificationDeliveryByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrDeliveryNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	if delivery.Status != storage.NotificationDeliveryStatusDead {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrDeliveryNotDead)
+	}
+
+	delivery.Status = storage.NotificationDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+
+	if err := s.storage.UpdateNotificationDelivery(c.Context(), delivery); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	delivery.URL = notifier.MaskURL(delivery.URL)
+
+	return c.JSON(delivery)
+}
@@ -0,0 +1,181 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// handleFindNotificationRules returns all notification rules
+//
+//	@Summary		Get notification rules
+//	@Description	Returns all notification routing rules ordered by name
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		storage.NotificationRule	"List of notification rules"
+//	@Failure		500	{object}	ErrorResponse				"Internal server error"
+//	@Router			/notification-rules [get]
+func (s *Server) handleFindNotificationRules(c *fiber.Ctx) error {
+	rules, err := s.storage.FindNotificationRules(c.Context(), storage.FindNotificationRulesParams{})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(rules)
+}
+
+// handleGetNotificationRule returns a notification rule
+//
+//	@Summary		Get notification rule
+//	@Description	Returns a notification routing rule
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string						true	"Notification rule ID"
+//	@Success		200	{object}	storage.NotificationRule	"Notification rule"
+//	@Failure		404	{object}	ErrorResponse				"Notification rule not found"
+//	@Failure		500	{object}	ErrorResponse				"Internal server error"
+//	@Router			/notification-rules/{id} [get]
+func (s *Server) handleGetNotificationRule(c *fiber.Ctx) error {
+	rule, err := s.storage.GetNotificationRuleByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrRuleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(rule)
+}
+
+// handleCreateNotificationRule creates a new notification rule
+//
+//	@Summary		Create notification rule
+//	@Description	Creates a rule routing notifications of matching incidents to notification channels.
+//	@Description	Notifications are sent to the channels of all enabled matching rules, notifications matching no rule are sent to the default URLs.
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateUpdateNotificationRuleRequest	true	"Notification rule"
+//	@Success		201		{object}	storage.NotificationRule			"Created notification rule"
+//	@Failure		400		{object}	ErrorResponse						"Bad request"
+//	@Failure		500		{object}	ErrorResponse						"Internal server error"
+//	@Router			/notification-rules [post]
+func (s *Server) handleCreateNotificationRule(c *fiber.Ctx) error {
+	var req CreateUpdateNotificationRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validateNotificationRuleRequest(c.Context(), req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	rule := &storage.NotificationRule{}
+	applyNotificationRuleRequest(rule, req)
+
+	if err := s.storage.CreateNotificationRule(c.Context(), rule); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(rule)
+}
+
+// handleUpdateNotificationRule updates a notification rule
+//
+//	@Summary		Update notification rule
+//	@Description	Updates a notification routing rule, the change applies to the next notification
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Notification rule ID"
+//	@Param			request	body		CreateUpdateNotificationRuleRequest	true	"Notification rule"
+//	@Success		200		{object}	storage.NotificationRule			"Updated notification rule"
+//	@Failure		400		{object}	ErrorResponse						"Bad request"
+//	@Failure		404		{object}	ErrorResponse						"Notification rule not found"
+//	@Failure		500		{object}	ErrorResponse						"Internal server error"
+//	@Router			/notification-rules/{id} [put]
+func (s *Server) handleUpdateNotificationRule(c *fiber.Ctx) error {
+	var req CreateUpdateNotificationRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validateNotificationRuleRequest(c.Context(), req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	rule, err := s.storage.GetNotificationRuleByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrRuleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	applyNotificationRuleRequest(rule, req)
+
+	if err := s.storage.UpdateNotificationRule(c.Context(), rule); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(rule)
+}
+
+// handleDeleteNotificationRule deletes a notification rule
+//
+//	@Summary		Delete notification rule
+//	@Description	Deletes a notification routing rule
+//	@Tags			notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Notification rule ID"
+//	@Success		204	"Notification rule deleted"
+//	@Failure		404	{object}	ErrorResponse	"Notification rule not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/notification-rules/{id} [delete]
+func (s *Server) handleDeleteNotificationRule(c *fiber.Ctx) error {
+	if err := s.storage.DeleteNotificationRule(c.Context(), c.Params("id")); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrRuleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// validateNotificationRuleRequest validates a notification rule request and checks that its channels exist
+func (s *Server) validateNotificationRuleRequest(ctx context.Context, req CreateUpdateNotificationRuleRequest) error {
+	if err := s.validator.Struct(req); err != nil {
+		return err
+	}
+
+	for _, name := range req.Channels {
+		exists, err := s.notificationChannelExists(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrUnknownChannel, name)
+		}
+	}
+
+	return nil
+}
+
+// applyNotificationRuleRequest copies notification rule request fields to a rule
+func applyNotificationRuleRequest(rule *storage.NotificationRule, req CreateUpdateNotificationRuleRequest) {
+	rule.Name = req.Name
+	rule.Channels = req.Channels
+	rule.ServiceIDs = req.ServiceIDs
+	rule.Tags = req.Tags
+	rule.Protocols = req.Protocols
+	rule.Severities = req.Severities
+	rule.Events = req.Events
+	rule.IsEnabled = req.IsEnabled
+}
@@ -0,0 +1,386 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/notifier"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// handleFindOnCall returns who is currently on call for each schedule
+//
+//	@Summary		Get current on-call
+//	@Description	Returns the current shift of every on-call schedule with somebody on call
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		storage.OnCallShift	"Current on-call shifts"
+//	@Failure		500	{object}	ErrorResponse		"Internal server error"
+//	@Router			/oncall [get]
+func (s *Server) handleFindOnCall(c *fiber.Ctx) error {
+	schedules, err := s.storage.FindOnCallSchedules(c.Context())
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	now := time.Now()
+	shifts := []*storage.OnCallShift{}
+	for _, schedule := range schedules {
+		if shift := schedule.ShiftAt(now); shift != nil {
+			shifts = append(shifts, shift)
+		}
+	}
+
+	return c.JSON(shifts)
+}
+
+// handleFindOnCallSchedules returns all on-call schedules
+//
+//	@Summary		Get on-call schedules
+//	@Description	Returns all on-call schedules ordered by name, with masked user URLs
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		storage.OnCallSchedule	"List of on-call schedules"
+//	@Failure		500	{object}	ErrorResponse			"Internal server error"
+//	@Router			/oncall-schedules [get]
+func (s *Server) handleFindOnCallSchedules(c *fiber.Ctx) error {
+	schedules, err := s.storage.FindOnCallSchedules(c.Context())
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	for i, schedule := range schedules {
+		schedules[i] = maskOnCallSchedule(schedule)
+	}
+
+	return c.JSON(schedules)
+}
+
+// handleGetOnCallSchedule returns an on-call schedule
+//
+//	@Summary		Get on-call schedule
+//	@Description	Returns an on-call schedule with masked user URLs
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"On-call schedule ID"
+//	@Success		200	{object}	storage.OnCallSchedule	"On-call schedule"
+//	@Failure		404	{object}	ErrorResponse			"On-call schedule not found"
+//	@Failure		500	{object}	ErrorResponse			"Internal server error"
+//	@Router			/oncall-schedules/{id} [get]
+func (s *Server) handleGetOnCallSchedule(c *fiber.Ctx) error {
+	schedule, err := s.storage.GetOnCallScheduleByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrScheduleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(maskOnCallSchedule(schedule))
+}
+
+// handleCreateOnCallSchedule creates a new on-call schedule
+//
+//	@Summary		Create on-call schedule
+//	@Description	Creates a weekly rotation of users in the configured timezone. Notification rules and escalation steps
+//	@Description	target the user on call with the oncall:<schedule name> channel.
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateUpdateOnCallScheduleRequest	true	"On-call schedule"
+//	@Success		201		{object}	storage.OnCallSchedule				"Created on-call schedule"
+//	@Failure		400		{object}	ErrorResponse						"Bad request"
+//	@Failure		409		{object}	ErrorResponse						"On-call schedule name is taken"
+//	@Failure		500		{object}	ErrorResponse						"Internal server error"
+//	@Router			/oncall-schedules [post]
+func (s *Server) handleCreateOnCallSchedule(c *fiber.Ctx) error {
+	var req CreateUpdateOnCallScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	schedule := &storage.OnCallSchedule{}
+	if err := s.applyOnCallScheduleRequest(schedule, req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.storage.CreateOnCallSchedule(c.Context(), schedule); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(maskOnCallSchedule(schedule))
+}
+
+// handleUpdateOnCallSchedule updates an on-call schedule
+//
+//	@Summary		Update on-call schedule
+//	@Description	Updates an on-call schedule, the URLs of existing users are kept when omitted
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"On-call schedule ID"
+//	@Param			request	body		CreateUpdateOnCallScheduleRequest	true	"On-call schedule"
+//	@Success		200		{object}	storage.OnCallSchedule				"Updated on-call schedule"
+//	@Failure		400		{object}	ErrorResponse						"Bad request"
+//	@Failure		404		{object}	ErrorResponse						"On-call schedule not found"
+//	@Failure		409		{object}	ErrorResponse						"On-call schedule name is taken"
+//	@Failure		500		{object}	ErrorResponse						"Internal server error"
+//	@Router			/oncall-schedules/{id} [put]
+func (s *Server) handleUpdateOnCallSchedule(c *fiber.Ctx) error {
+	var req CreateUpdateOnCallScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	schedule, err := s.storage.GetOnCallScheduleByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrScheduleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	if err := s.applyOnCallScheduleRequest(schedule, req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.storage.UpdateOnCallSchedule(c.Context(), schedule); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(maskOnCallSchedule(schedule))
+}
+
+// handleDeleteOnCallSchedule deletes an on-call schedule
+//
+//	@Summary		Delete on-call schedule
+//	@Description	Deletes an on-call schedule, its on-call channels are skipped afterwards
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"On-call schedule ID"
+//	@Success		204	"On-call schedule deleted"
+//	@Failure		404	{object}	ErrorResponse	"On-call schedule not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/oncall-schedules/{id} [delete]
+func (s *Server) handleDeleteOnCallSchedule(c *fiber.Ctx) error {
+	if err := s.storage.DeleteOnCallSchedule(c.Context(), c.Params("id")); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrScheduleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// handleGetOnCallShift returns who is on call for a schedule
+//
+//	@Summary		Get on-call shift
+//	@Description	Returns the shift of an on-call schedule at the given time, now by default
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string				true	"On-call schedule ID"
+//	@Param			at	query		time.Time			false	"Time of the shift (RFC3339 format)"
+//	@Success		200	{object}	storage.OnCallShift	"On-call shift"
+//	@Failure		400	{object}	ErrorResponse		"Bad request"
+//	@Failure		404	{object}	ErrorResponse		"On-call schedule not found or nobody is on call"
+//	@Failure		500	{object}	ErrorResponse		"Internal server error"
+//	@Router			/oncall-schedules/{id}/oncall [get]
+func (s *Server) handleGetOnCallShift(c *fiber.Ctx) error {
+	params := struct {
+		At *time.Time `query:"at"`
+	}{}
+
+	if err := c.QueryParser(&params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	schedule, err := s.storage.GetOnCallScheduleByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrScheduleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	at := time.Now()
+	if params.At != nil {
+		at = *params.At
+	}
+
+	shift := schedule.ShiftAt(at)
+	if shift == nil {
+		return newErrorResponse(c, fiber.StatusNotFound, ErrNobodyOnCall)
+	}
+
+	return c.JSON(shift)
+}
+
+// handleCreateOnCallOverride adds an override to an on-call schedule
+//
+//	@Summary		Create on-call override
+//	@Description	Puts a user of the schedule on call for a period instead of the rotation, expired overrides are removed
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"On-call schedule ID"
+//	@Param			request	body		OnCallOverrideRequest	true	"On-call override"
+//	@Success		201		{object}	storage.OnCallSchedule	"Updated on-call schedule"
+//	@Failure		400		{object}	ErrorResponse			"Bad request"
+//	@Failure		404		{object}	ErrorResponse			"On-call schedule not found"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/oncall-schedules/{id}/overrides [post]
+func (s *Server) handleCreateOnCallOverride(c *fiber.Ctx) error {
+	var req OnCallOverrideRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	schedule, err := s.storage.GetOnCallScheduleByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrScheduleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	if schedule.User(req.User) == nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, fmt.Errorf("%w: %s", ErrUnknownOnCallUser, req.User))
+	}
+
+	now := time.Now()
+	schedule.Overrides = slices.DeleteFunc(schedule.Overrides, func(override storage.OnCallOverride) bool {
+		return !override.End.After(now)
+	})
+	schedule.Overrides = append(schedule.Overrides, storage.OnCallOverride{
+		ID:    storage.GenerateULID(),
+		User:  req.User,
+		Start: req.Start,
+		End:   req.End,
+	})
+
+	if err := s.storage.UpdateOnCallSchedule(c.Context(), schedule); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(maskOnCallSchedule(schedule))
+}
+
+// handleDeleteOnCallOverride removes an override of an on-call schedule
+//
+//	@Summary		Delete on-call override
+//	@Description	Removes an override, the rotation applies again for its period
+//	@Tags			oncall
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	string	true	"On-call schedule ID"
+//	@Param			overrideId	path	string	true	"On-call override ID"
+//	@Success		204			"On-call override deleted"
+//	@Failure		404			{object}	ErrorResponse	"On-call schedule or override not found"
+//	@Failure		500			{object}	ErrorResponse	"Internal server error"
+//	@Router			/oncall-schedules/{id}/overrides/{overrideId} [delete]
+func (s *Server) handleDeleteOnCallOverride(c *fiber.Ctx) error {
+	schedule, err := s.storage.GetOnCallScheduleByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrScheduleNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	overrideID := c.Params("overrideId")
+	index := slices.IndexFunc(schedule.Overrides, func(override storage.OnCallOverride) bool {
+		return override.ID == overrideID
+	})
+	if index < 0 {
+		return newErrorResponse(c, fiber.StatusNotFound, ErrOverrideNotFound)
+	}
+
+	schedule.Overrides = slices.Delete(schedule.Overrides, index, index+1)
+
+	if err := s.storage.UpdateOnCallSchedule(c.Context(), schedule); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// applyOnCallScheduleRequest validates an on-call schedule request and copies its fields to a schedule.
+// Users without URLs keep the URLs they have in the schedule.
+func (s *Server) applyOnCallScheduleRequest(schedule *storage.OnCallSchedule, req CreateUpdateOnCallScheduleRequest) error {
+	if err := s.validator.Struct(req); err != nil {
+		return err
+	}
+
+	users := make([]storage.OnCallUser, 0, len(req.Users))
+	for _, item := range req.Users {
+		if slices.ContainsFunc(users, func(user storage.OnCallUser) bool { return user.Name == item.Name }) {
+			return fmt.Errorf("%w: %s", ErrDuplicateOnCallUser, item.Name)
+		}
+
+		urls := item.URLs
+		if len(urls) == 0 {
+			existing := schedule.User(item.Name)
+			if existing == nil {
+				return fmt.Errorf("%w: %s", ErrOnCallUserURLs, item.Name)
+			}
+			urls = existing.URLs
+		}
+
+		for _, url := range item.URLs {
+			if err := notifier.ValidateURL(url); err != nil {
+				return err
+			}
+		}
+
+		users = append(users, storage.OnCallUser{Name: item.Name, URLs: urls})
+	}
+
+	overrides := make([]storage.OnCallOverride, 0, len(req.Overrides))
+	for _, item := range req.Overrides {
+		if !slices.ContainsFunc(users, func(user storage.OnCallUser) bool { return user.Name == item.User }) {
+			return fmt.Errorf("%w: %s", ErrUnknownOnCallUser, item.User)
+		}
+
+		overrides = append(overrides, storage.OnCallOverride{
+			ID:    storage.GenerateULID(),
+			User:  item.User,
+			Start: item.Start,
+			End:   item.End,
+		})
+	}
+
+	schedule.Name = req.Name
+	schedule.Users = users
+	schedule.StartDate = req.StartDate
+	schedule.HandoffTime = req.HandoffTime
+	schedule.Overrides = overrides
+
+	return nil
+}
+
+// maskOnCallSchedule returns a copy of a schedule with masked user URLs
+func maskOnCallSchedule(schedule *storage.OnCallSchedule) *storage.OnCallSchedule {
+	masked := *schedule
+	masked.Users = make([]storage.OnCallUser, 0, len(schedule.Users))
+	for _, user := range schedule.Users {
+		urls := make([]string, 0, len(user.URLs))
+		for _, url := range user.URLs {
+			urls = append(urls, notifier.MaskURL(url))
+		}
+		masked.Users = append(masked.Users, storage.OnCallUser{Name: user.Name, URLs: urls})
+	}
+	return &masked
+}
@@ -0,0 +1,245 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// handleFindSLOs returns all SLOs with their compliance
+//
+//	@Summary		Get SLOs
+//	@Description	Returns all SLOs with their SLI, remaining error budget and burn rates
+//	@Tags			slos
+//	@Accept			json
+//	@Produce		json
+//	@Param			service_id	query		string			false	"Filter by service ID"
+//	@Param			tag			query		string			false	"Filter by tag"
+//	@Success		200			{array}		SLOStatusDTO	"List of SLOs"
+//	@Failure		500			{object}	ErrorResponse	"Internal server error"
+//	@Router			/slos [get]
+func (s *Server) handleFindSLOs(c *fiber.Ctx) error {
+	slos, err := s.storage.FindSLOs(c.Context(), storage.FindSLOsParams{
+		ServiceID: c.Query("service_id"),
+		Tag:       c.Query("tag"),
+	})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	now := time.Now()
+	items := make([]SLOStatusDTO, 0, len(slos))
+	for _, slo := range slos {
+		status, err := s.storage.GetSLOStatus(c.Context(), slo, now)
+		if err != nil {
+			return newErrorResponse(c, fiber.StatusInternalServerError, err)
+		}
+		items = append(items, convertSLOStatusToDTO(status))
+	}
+
+	return c.JSON(items)
+}
+
+// handleGetSLO returns an SLO with its compliance
+//
+//	@Summary		Get SLO
+//	@Description	Returns an SLO with its SLI, remaining error budget and burn rates
+//	@Tags			slos
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string			true	"SLO ID"
+//	@Success		200	{object}	SLOStatusDTO	"SLO"
+//	@Failure		404	{object}	ErrorResponse	"SLO not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/slos/{id} [get]
+func (s *Server) handleGetSLO(c *fiber.Ctx) error {
+	slo, err := s.storage.GetSLOByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrSLONotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	status, err := s.storage.GetSLOStatus(c.Context(), slo, time.Now())
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(convertSLOStatusToDTO(status))
+}
+
+// handleCreateSLO creates a new SLO
+//
+//	@Summary		Create SLO
+//	@Description	Creates an SLO of a service or of all services with a tag.
+//	@Description	Check counts are backfilled from the available check history.
+//	@Tags			slos
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateUpdateSLORequest	true	"SLO"
+//	@Success		201		{object}	SLODTO					"Created SLO"
+//	@Failure		400		{object}	ErrorResponse			"Bad request"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/slos [post]
+func (s *Server) handleCreateSLO(c *fiber.Ctx) error {
+	var req CreateUpdateSLORequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validateSLORequest(c, req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	slo := &storage.SLO{}
+	applySLORequest(slo, req)
+
+	if err := s.storage.CreateSLO(c.Context(), slo); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(convertSLOToDTO(slo))
+}
+
+// handleUpdateSLO updates an SLO
+//
+//	@Summary		Update SLO
+//	@Description	Updates an SLO, check counts are recomputed from the available check history
+//	@Tags			slos
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"SLO ID"
+//	@Param			request	body		CreateUpdateSLORequest	true	"SLO"
+//	@Success		200		{object}	SLODTO					"Updated SLO"
+//	@Failure		400		{object}	ErrorResponse			"Bad request"
+//	@Failure		404		{object}	ErrorResponse			"SLO not found"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/slos/{id} [put]
+func (s *Server) handleUpdateSLO(c *fiber.Ctx) error {
+	var req CreateUpdateSLORequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validateSLORequest(c, req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	slo, err := s.storage.GetSLOByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrSLONotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	applySLORequest(slo, req)
+
+	if err := s.storage.UpdateSLO(c.Context(), slo); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(convertSLOToDTO(slo))
+}
+
+// handleDeleteSLO deletes an SLO
+//
+//	@Summary		Delete SLO
+//	@Description	Deletes an SLO with its collected check counts
+//	@Tags			slos
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"SLO ID"
+//	@Success		204	"SLO deleted"
+//	@Failure		404	{object}	ErrorResponse	"SLO not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/slos/{id} [delete]
+func (s *Server) handleDeleteSLO(c *fiber.Ctx) error {
+	if err := s.storage.DeleteSLO(c.Context(), c.Params("id")); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrSLONotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// validateSLORequest validates an SLO request and checks that its service exists
+func (s *Server) validateSLORequest(c *fiber.Ctx, req CreateUpdateSLORequest) error {
+	if err := s.validator.Struct(req); err != nil {
+		return err
+	}
+
+	if req.ServiceID != "" {
+		if _, err := s.monitorService.GetServiceByID(c.Context(), req.ServiceID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applySLORequest copies SLO request fields to an SLO
+func applySLORequest(slo *storage.SLO, req CreateUpdateSLORequest) {
+	slo.Name = req.Name
+	slo.ServiceID = req.ServiceID
+	slo.Tag = req.Tag
+	slo.Target = req.Target
+	slo.LatencyTarget = time.Millisecond * time.Duration(req.LatencyTarget)
+	slo.Window = req.Window
+	slo.BurnRateAlert = nil
+
+	if req.BurnRateAlert != nil {
+		slo.BurnRateAlert = &storage.BurnRateAlert{
+			ShortWindow: time.Millisecond * time.Duration(req.BurnRateAlert.ShortWindow),
+			LongWindow:  time.Millisecond * time.Duration(req.BurnRateAlert.LongWindow),
+			Threshold:   req.BurnRateAlert.Threshold,
+		}
+	}
+}
+
+// convertSLOToDTO converts an SLO to SLODTO
+func convertSLOToDTO(slo *storage.SLO) SLODTO {
+	dto := SLODTO{
+		ID:            slo.ID,
+		Name:          slo.Name,
+		ServiceID:     slo.ServiceID,
+		Tag:           slo.Tag,
+		Target:        slo.Target,
+		LatencyTarget: uint32(slo.LatencyTarget.Milliseconds()),
+		Window:        slo.Window,
+		Alerting:      slo.Alerting,
+		CreatedAt:     slo.CreatedAt,
+		UpdatedAt:     slo.UpdatedAt,
+	}
+
+	if slo.BurnRateAlert != nil {
+		dto.BurnRateAlert = &BurnRateAlertDTO{
+			ShortWindow: uint32(slo.BurnRateAlert.ShortWindow.Milliseconds()),
+			LongWindow:  uint32(slo.BurnRateAlert.LongWindow.Milliseconds()),
+			Threshold:   slo.BurnRateAlert.Threshold,
+		}
+	}
+
+	return dto
+}
+
+// convertSLOStatusToDTO converts an SLO status to SLOStatusDTO
+func convertSLOStatusToDTO(status *storage.SLOStatus) SLOStatusDTO {
+	return SLOStatusDTO{
+		SLO:                  convertSLOToDTO(status.SLO),
+		WindowStart:          status.WindowStart,
+		WindowEnd:            status.WindowEnd,
+		TotalChecks:          status.TotalChecks,
+		GoodChecks:           status.GoodChecks,
+		SLI:                  status.SLI,
+		ErrorBudgetRemaining: status.ErrorBudgetRemaining,
+		BurnRateShort:        status.BurnRateShort,
+		BurnRateLong:         status.BurnRateLong,
+		Compliant:            status.Compliant,
+	}
+}
@@ -0,0 +1,86 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/servicesync"
+	"github.com/sxwebdev/sentinel/internal/storage"
+)
+
+// handleExportServices exports all services
+//
+//	@Summary		Export services
+//	@Description	Exports all services with their config and tags. Services without an external ID are exported with their ID as the external ID.
+//	@Tags			services
+//	@Produce		json,application/yaml
+//	@Param			format	query		string			false	"Document format"	Enums(json, yaml)	default(json)
+//	@Success		200		{file}		file			"Services document"
+//	@Failure		400		{object}	ErrorResponse	"Bad request"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/services/export [get]
+func (s *Server) handleExportServices(c *fiber.Ctx) error {
+	format, err := servicesync.ParseFormat(c.Query("format"))
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	doc, err := servicesync.Export(c.Context(), s.monitorService)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	data, err := doc.Marshal(format)
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	contentType := fiber.MIMEApplicationJSON
+	if format == servicesync.FormatYAML {
+		contentType = "application/yaml"
+	}
+
+	c.Attachment(fmt.Sprintf("sentinel-services-%s.%s", time.Now().UTC().Format("20060102-150405"), format))
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Send(data)
+}
+
+// handleImportServices imports services
+//
+//	@Summary		Import services
+//	@Description	Creates and updates services of a document in JSON or YAML format, as returned by the export. Services are matched by external ID. With dry_run the changes are only returned. An invalid document changes nothing, if applying a service fails the error response lists the changes applied before it.
+//	@Tags			services
+//	@Accept			json,application/yaml
+//	@Produce		json
+//	@Param			dry_run		query		bool						false	"Only return the changes"
+//	@Param			document	body		string						true	"Services document"
+//	@Success		200			{object}	servicesync.ImportResult	"Import result"
+//	@Failure		400			{object}	ErrorResponse				"Bad request"
+//	@Failure		409			{object}	servicesync.ImportResult	"External ID already exists, with the changes applied before the error"
+//	@Failure		500			{object}	servicesync.ImportResult	"Internal server error, with the changes applied before the error"
+//	@Router			/services/import [post]
+func (s *Server) handleImportServices(c *fiber.Ctx) error {
+	doc, err := servicesync.ParseDocument(c.Body())
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	res, err := servicesync.Import(c.Context(), s.monitorService, s.config, doc, c.QueryBool("dry_run"))
+	if err != nil {
+		if errors.Is(err, servicesync.ErrInvalidDocument) {
+			return newErrorResponse(c, fiber.StatusBadRequest, err)
+		}
+
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			status = fiber.StatusConflict
+		}
+
+		res.Error = err.Error()
+		return c.Status(status).JSON(res)
+	}
+
+	return c.JSON(res)
+}
@@ -0,0 +1,284 @@
+package web
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/pkg/dbutils"
+)
+
+// handleFindWebhooks returns all webhooks
+//
+//	@Summary		Get webhooks
+//	@Description	Returns the outbound webhooks ordered by name, without their secrets
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		storage.Webhook	"List of webhooks"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/webhooks [get]
+func (s *Server) handleFindWebhooks(c *fiber.Ctx) error {
+	webhooks, err := s.storage.FindWebhooks(c.Context(), storage.FindWebhooksParams{})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(webhooks)
+}
+
+// handleGetWebhook returns a webhook
+//
+//	@Summary		Get webhook
+//	@Description	Returns a webhook without its secret
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string			true	"Webhook ID"
+//	@Success		200	{object}	storage.Webhook	"Webhook"
+//	@Failure		404	{object}	ErrorResponse	"Webhook not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/webhooks/{id} [get]
+func (s *Server) handleGetWebhook(c *fiber.Ctx) error {
+	webhook, err := s.storage.GetWebhookByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrWebhookNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(webhook)
+}
+
+// handleCreateWebhook creates a new webhook
+//
+//	@Summary		Create webhook
+//	@Description	Creates a webhook receiving the subscribed events as signed JSON requests.
+//	@Description	A random secret is generated when omitted, the secret is only returned in this response.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateWebhookRequest	true	"Webhook"
+//	@Success		201		{object}	CreateWebhookResponse	"Created webhook"
+//	@Failure		400		{object}	ErrorResponse			"Bad request"
+//	@Failure		409		{object}	ErrorResponse			"Webhook already exists"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/webhooks [post]
+func (s *Server) handleCreateWebhook(c *fiber.Ctx) error {
+	var req CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if req.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return newErrorResponse(c, fiber.StatusInternalServerError, err)
+		}
+		req.Secret = secret
+	}
+
+	webhook := &storage.Webhook{
+		Name:      req.Name,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    req.Events,
+		IsEnabled: req.IsEnabled,
+	}
+
+	if err := s.storage.CreateWebhook(c.Context(), webhook); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(CreateWebhookResponse{
+		Webhook: webhook,
+		Secret:  webhook.Secret,
+	})
+}
+
+// handleUpdateWebhook updates a webhook
+//
+//	@Summary		Update webhook
+//	@Description	Updates a webhook, pending deliveries are sent to the new URL with the new secret.
+//	@Description	The secret is kept when omitted.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Webhook ID"
+//	@Param			request	body		UpdateWebhookRequest	true	"Webhook"
+//	@Success		200		{object}	storage.Webhook			"Updated webhook"
+//	@Failure		400		{object}	ErrorResponse			"Bad request"
+//	@Failure		404		{object}	ErrorResponse			"Webhook not found"
+//	@Failure		409		{object}	ErrorResponse			"Webhook already exists"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/webhooks/{id} [put]
+func (s *Server) handleUpdateWebhook(c *fiber.Ctx) error {
+	var req UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	webhook, err := s.storage.GetWebhookByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrWebhookNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	webhook.Name = req.Name
+	webhook.URL = req.URL
+	webhook.Events = req.Events
+	webhook.IsEnabled = req.IsEnabled
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+
+	if err := s.storage.UpdateWebhook(c.Context(), webhook); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(webhook)
+}
+
+// handleDeleteWebhook deletes a webhook
+//
+//	@Summary		Delete webhook
+//	@Description	Deletes a webhook with its deliveries
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Webhook ID"
+//	@Success		204	"Webhook deleted"
+//	@Failure		404	{object}	ErrorResponse	"Webhook not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/webhooks/{id} [delete]
+func (s *Server) handleDeleteWebhook(c *fiber.Ctx) error {
+	if err := s.storage.DeleteWebhook(c.Context(), c.Params("id")); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrWebhookNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// handleFindWebhookDeliveries returns the delivery history of a webhook
+//
+//	@Summary		Get webhook deliveries
+//	@Description	Returns the deliveries of a webhook with their payloads and last response status, latest first
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string													true	"Webhook ID"
+//	@Param			status		query		string													false	"Filter by status"	Enums(pending, sent, dead)
+//	@Param			event		query		string													false	"Filter by event"
+//	@Param			page		query		uint32													false	"Page number (default 1)"
+//	@Param			page_size	query		uint32													false	"Number of items per page (default 100)"
+//	@Success		200			{object}	dbutils.FindResponseWithCount[storage.WebhookDelivery]	"List of webhook deliveries"
+//	@Failure		400			{object}	ErrorResponse											"Bad request"
+//	@Failure		404			{object}	ErrorResponse											"Webhook not found"
+//	@Failure		500			{object}	ErrorResponse											"Internal server error"
+//	@Router			/webhooks/{id}/deliveries [get]
+func (s *Server) handleFindWebhookDeliveries(c *fiber.Ctx) error {
+	params := struct {
+		Status   string  `query:"status" validate:"omitempty,oneof=pending sent dead"`
+		Event    string  `query:"event" validate:"omitempty,oneof=service.created service.updated service.deleted service.state_changed incident.opened incident.updated incident.resolved"`
+		Page     *uint32 `query:"page" validate:"omitempty,gte=1"`
+		PageSize *uint32 `query:"page_size" validate:"omitempty,gte=1,lte=1000"`
+	}{}
+
+	if err := c.QueryParser(&params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := s.validator.Struct(params); err != nil {
+		return newErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+
+	webhook, err := s.storage.GetWebhookByID(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrWebhookNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	deliveries, err := s.storage.FindWebhookDeliveries(c.Context(), storage.FindWebhookDeliveriesParams{
+		WebhookID: webhook.ID,
+		Status:    storage.WebhookDeliveryStatus(params.Status),
+		Event:     storage.WebhookEvent(params.Event),
+		Page:      params.Page,
+		PageSize:  params.PageSize,
+	})
+	if err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(dbutils.NewFindResponseWithCount(deliveries.Items, deliveries.Count))
+}
+
+// handleRetryWebhookDelivery retries a dead-lettered webhook delivery
+//
+//	@Summary		Retry webhook delivery
+//	@Description	Moves a dead-lettered webhook delivery back to the queue with a new set of attempts
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Webhook ID"
+//	@Param			deliveryId	path		string					true	"Webhook delivery ID"
+//	@Success		200			{object}	storage.WebhookDelivery	"Webhook delivery"
+//	@Failure		400			{object}	ErrorResponse			"The delivery is not dead-lettered"
+//	@Failure		404			{object}	ErrorResponse			"Webhook delivery not found"
+//	@Failure		500			{object}	ErrorResponse			"Internal server error"
+//	@Router			/webhooks/{id}/deliveries/{deliveryId}/retry [post]
+func (s *Server) handleRetryWebhookDelivery(c *fiber.Ctx) error {
+	delivery, err := s.storage.GetWebhookDeliveryByID(c.Context(), c.Params("deliveryId"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newErrorResponse(c, fiber.StatusNotFound, ErrWebhookDeliveryNotFound)
+		}
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	if delivery.WebhookID != c.Params("id") {
+		return newErrorResponse(c, fiber.StatusNotFound, ErrWebhookDeliveryNotFound)
+	}
+
+	if delivery.Status != storage.WebhookDeliveryStatusDead {
+		return newErrorResponse(c, fiber.StatusBadRequest, ErrWebhookDeliveryNotDead)
+	}
+
+	delivery.Status = storage.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+
+	if err := s.storage.UpdateWebhookDelivery(c.Context(), delivery); err != nil {
+		return newErrorResponse(c, fiber.StatusInternalServerError, err)
+	}
+
+	return c.JSON(delivery)
+}
+
+// generateWebhookSecret returns a random hex encoded webhook secret
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
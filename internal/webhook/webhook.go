@@ -0,0 +1,525 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/sxwebdev/sentinel/internal/utils"
+	"github.com/tkcrm/mx/logger"
+)
+
+const (
+	// pollInterval is how often due webhook deliveries are checked
+	pollInterval = time.Second
+	// batchSize is the maximum number of deliveries attempted at once
+	batchSize = 100
+	// cleanupInterval is how often old deliveries are purged
+	cleanupInterval = time.Hour
+	// subscriptionBuffer is the number of events buffered by the broker until the dispatcher queues them,
+	// the broker drops further events instead of waiting for the dispatcher
+	subscriptionBuffer = 1024
+	// queueLimit is the number of events queued while the dispatcher stores earlier ones,
+	// further events are dropped
+	queueLimit = 10000
+	// servicesPageSize is the number of services loaded at once on start
+	servicesPageSize = 100
+)
+
+// Request headers of webhook deliveries
+const (
+	HeaderEvent     = "X-Sentinel-Event"
+	HeaderDelivery  = "X-Sentinel-Delivery"
+	HeaderTimestamp = "X-Sentinel-Timestamp"
+	HeaderSignature = "X-Sentinel-Signature"
+)
+
+// Payload is the JSON body of a webhook request
+type Payload struct {
+	// ID identifies the event, it is the same for all webhooks receiving it
+	ID        string               `json:"id"`
+	Event     storage.WebhookEvent `json:"event"`
+	CreatedAt time.Time            `json:"created_at"`
+	Data      EventData            `json:"data"`
+}
+
+// EventData holds the objects of an event
+type EventData struct {
+	Service *Service `json:"service,omitempty"`
+	// PreviousStatus is set for service state changes
+	PreviousStatus storage.ServiceStatus `json:"previous_status,omitempty"`
+	Incident       *storage.Incident     `json:"incident,omitempty"`
+}
+
+// Service is the service of an event without its check configuration, which may hold credentials
+type Service struct {
+	ID        string                      `json:"id"`
+	Name      string                      `json:"name"`
+	Protocol  storage.ServiceProtocolType `json:"protocol"`
+	Tags      []string                    `json:"tags"`
+	IsEnabled bool                        `json:"is_enabled"`
+	Status    storage.ServiceStatus       `json:"status"`
+	LastCheck *time.Time                  `json:"last_check,omitempty"`
+	LastError *string                     `json:"last_error,omitempty"`
+	// ResponseTime is the response time of the last check in milliseconds
+	ResponseTime int64 `json:"response_time"`
+}
+
+// newService returns the event data of a service
+func newService(svc *storage.Service) *Service {
+	service := &Service{
+		ID:        svc.ID,
+		Name:      svc.Name,
+		Protocol:  svc.Protocol,
+		Tags:      svc.Tags,
+		IsEnabled: svc.IsEnabled,
+		Status:    svc.Status,
+		LastCheck: svc.LastCheck,
+		LastError: svc.LastError,
+	}
+
+	if svc.ResponseTime != nil {
+		service.ResponseTime = svc.ResponseTime.Milliseconds()
+	}
+
+	return service
+}
+
+// Sign returns the signature of a webhook request: the hex encoded HMAC-SHA256 of the timestamp
+// and the body joined by a dot, keyed with the secret of the webhook
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher sends the service and incident events published to the receiver to the subscribed webhooks.
+// Events are stored as deliveries and sent in the background, a failing webhook is retried with
+// exponential backoff without delaying the other webhooks.
+type Dispatcher struct {
+	logger   logger.Logger
+	config   config.WebhooksConfig
+	storage  storage.Storage
+	receiver *receiver.Receiver
+	client   *http.Client
+
+	// statuses holds the last known status of services to detect state changes
+	statuses map[string]storage.ServiceStatus
+	wake     chan struct{}
+	stop     chan struct{}
+
+	// queue holds received events until they are stored, so storing them does not delay the broker
+	mu     sync.Mutex
+	queue  []event
+	queued chan struct{}
+}
+
+// event is a service or incident event received from the broker
+type event struct {
+	service  *receiver.TriggerServiceData
+	incident *receiver.TriggerIncidentData
+}
+
+// New creates a new webhook dispatcher
+func New(l logger.Logger, cfg config.WebhooksConfig, store storage.Storage, rc *receiver.Receiver) *Dispatcher {
+	return &Dispatcher{
+		logger:   l,
+		config:   cfg,
+		storage:  store,
+		receiver: rc,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		statuses: map[string]storage.ServiceStatus{},
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		queued:   make(chan struct{}, 1),
+	}
+}
+
+// Name returns the name of the service
+func (d *Dispatcher) Name() string { return "webhook-dispatcher" }
+
+// Start stores published events as webhook deliveries and sends them until the service is stopped
+func (d *Dispatcher) Start(ctx context.Context) error {
+	if err := d.loadStatuses(ctx); err != nil {
+		return err
+	}
+
+	services := d.receiver.TriggerService().SubscribeBuffered(subscriptionBuffer)
+	if services == nil {
+		return fmt.Errorf("failed to subscribe to service updates broker")
+	}
+	defer d.receiver.TriggerService().Unsubscribe(services)
+
+	incidents := d.receiver.TriggerIncident().SubscribeBuffered(subscriptionBuffer)
+	if incidents == nil {
+		return fmt.Errorf("failed to subscribe to incident updates broker")
+	}
+	defer d.receiver.TriggerIncident().Unsubscribe(incidents)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		d.run()
+	}()
+	go func() {
+		defer wg.Done()
+		d.store(ctx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-d.stop:
+			return nil
+		case data, ok := <-services:
+			if !ok {
+				return nil
+			}
+			d.enqueue(event{service: &data})
+		case data, ok := <-incidents:
+			if !ok {
+				return nil
+			}
+			d.enqueue(event{incident: &data})
+		}
+	}
+}
+
+// Stop stops the service
+func (d *Dispatcher) Stop(_ context.Context) error {
+	close(d.stop)
+	return nil
+}
+
+// enqueue adds a received event to the queue of events to store
+func (d *Dispatcher) enqueue(e event) {
+	d.mu.Lock()
+	if len(d.queue) >= queueLimit {
+		d.mu.Unlock()
+		d.logger.Errorf("webhook event queue is full, dropping event")
+		return
+	}
+	d.queue = append(d.queue, e)
+	d.mu.Unlock()
+
+	select {
+	case d.queued <- struct{}{}:
+	default:
+	}
+}
+
+// store stores the queued events as deliveries in the order they were received until the dispatcher is stopped
+func (d *Dispatcher) store(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stop:
+			return
+		case <-d.queued:
+		}
+
+		d.mu.Lock()
+		events := d.queue
+		d.queue = nil
+		d.mu.Unlock()
+
+		for _, e := range events {
+			switch {
+			case e.service != nil:
+				d.handleService(ctx, *e.service)
+			case e.incident != nil:
+				d.handleIncident(ctx, *e.incident)
+			}
+		}
+	}
+}
+
+// loadStatuses loads the current status of all services
+func (d *Dispatcher) loadStatuses(ctx context.Context) error {
+	for page := uint32(1); ; page++ {
+		services, err := d.storage.FindServices(ctx, storage.FindServicesParams{
+			OrderBy:  "created_at",
+			Page:     utils.Pointer(page),
+			PageSize: utils.Pointer(uint32(servicesPageSize)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find services: %w", err)
+		}
+
+		for _, svc := range services.Items {
+			d.statuses[svc.ID] = svc.Status
+		}
+
+		if len(services.Items) < servicesPageSize {
+			return nil
+		}
+	}
+}
+
+// handleService dispatches the event of a service change, state updates are dispatched when the status changes
+func (d *Dispatcher) handleService(ctx context.Context, data receiver.TriggerServiceData) {
+	svc := data.Svc
+	if svc == nil {
+		return
+	}
+
+	switch data.EventType {
+	case receiver.TriggerServiceEventTypeCreated:
+		d.statuses[svc.ID] = svc.Status
+		d.dispatch(ctx, storage.WebhookEventServiceCreated, EventData{Service: newService(svc)})
+	case receiver.TriggerServiceEventTypeUpdated:
+		d.dispatch(ctx, storage.WebhookEventServiceUpdated, EventData{Service: newService(svc)})
+	case receiver.TriggerServiceEventTypeDeleted:
+		delete(d.statuses, svc.ID)
+		d.dispatch(ctx, storage.WebhookEventServiceDeleted, EventData{Service: newService(svc)})
+	case receiver.TriggerServiceEventTypeUpdatedState:
+		previous, ok := d.statuses[svc.ID]
+		d.statuses[svc.ID] = svc.Status
+		if ok && previous != svc.Status {
+			d.dispatch(ctx, storage.WebhookEventServiceStateChanged, EventData{Service: newService(svc), PreviousStatus: previous})
+		}
+	}
+}
+
+// handleIncident dispatches the event of an incident change with the service of the incident
+func (d *Dispatcher) handleIncident(ctx context.Context, data receiver.TriggerIncidentData) {
+	var event storage.WebhookEvent
+	switch data.EventType {
+	case receiver.TriggerIncidentEventTypeOpened:
+		event = storage.WebhookEventIncidentOpened
+	case receiver.TriggerIncidentEventTypeUpdated:
+		event = storage.WebhookEventIncidentUpdated
+	case receiver.TriggerIncidentEventTypeResolved:
+		event = storage.WebhookEventIncidentResolved
+	default:
+		return
+	}
+
+	eventData := EventData{Incident: data.Incident}
+
+	svc, err := d.storage.GetServiceByID(ctx, data.Incident.ServiceID)
+	switch {
+	case err == nil:
+		eventData.Service = newService(svc)
+	case !errors.Is(err, storage.ErrNotFound):
+		d.logger.Errorf("failed to get service of incident %s: %v", data.Incident.ID, err)
+	}
+
+	d.dispatch(ctx, event, eventData)
+}
+
+// dispatch stores a delivery of an event for each enabled webhook subscribed to it
+func (d *Dispatcher) dispatch(ctx context.Context, event storage.WebhookEvent, data EventData) {
+	webhooks, err := d.storage.FindWebhooks(ctx, storage.FindWebhooksParams{IsEnabled: utils.Pointer(true)})
+	if err != nil {
+		d.logger.Errorf("failed to find webhooks: %v", err)
+		return
+	}
+
+	deliveries := []*storage.WebhookDelivery{}
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event) {
+			deliveries = append(deliveries, &storage.WebhookDelivery{WebhookID: webhook.ID, Event: event})
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(Payload{
+		ID:        storage.GenerateULID(),
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		d.logger.Errorf("failed to marshal %s webhook payload: %v", event, err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		delivery.Payload = string(body)
+	}
+
+	if err := d.storage.CreateWebhookDeliveries(ctx, deliveries); err != nil {
+		d.logger.Errorf("failed to create %s webhook deliveries: %v", event, err)
+		return
+	}
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run sends due deliveries until the dispatcher is stopped.
+// Pending deliveries survive restarts and are sent once the dispatcher starts again.
+func (d *Dispatcher) run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	d.process()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-d.wake:
+			d.process()
+		case <-ticker.C:
+			d.process()
+		case <-cleanup.C:
+			d.cleanup()
+		}
+	}
+}
+
+// process attempts the due deliveries. Deliveries to the same webhook are sent in order
+// and the rest of them wait until the failed one is retried, different webhooks are called concurrently.
+func (d *Dispatcher) process() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	deliveries, err := d.storage.FindDueWebhookDeliveries(ctx, time.Now(), batchSize)
+	cancel()
+	if err != nil {
+		d.logger.Errorf("failed to find due webhook deliveries: %v", err)
+		return
+	}
+
+	byWebhook := map[string][]*storage.WebhookDelivery{}
+	ids := []string{}
+	for _, delivery := range deliveries {
+		if _, ok := byWebhook[delivery.WebhookID]; !ok {
+			ids = append(ids, delivery.WebhookID)
+		}
+		byWebhook[delivery.WebhookID] = append(byWebhook[delivery.WebhookID], delivery)
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		webhook, err := d.storage.GetWebhookByID(ctx, id)
+		cancel()
+		if err != nil {
+			d.logger.Errorf("failed to get webhook %s: %v", id, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(items []*storage.WebhookDelivery) {
+			defer wg.Done()
+			for _, delivery := range items {
+				if !d.attempt(webhook, delivery) {
+					return
+				}
+			}
+		}(byWebhook[id])
+	}
+	wg.Wait()
+}
+
+// attempt sends a delivery and records the result, reporting whether it was sent.
+// Failed deliveries are retried with exponential backoff and dead-lettered after the maximum number of attempts.
+func (d *Dispatcher) attempt(webhook *storage.Webhook, delivery *storage.WebhookDelivery) bool {
+	status, err := d.send(webhook, delivery)
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+
+	switch {
+	case err == nil:
+		delivery.Status = storage.WebhookDeliveryStatusSent
+		delivery.LastError = ""
+		delivery.SentAt = &now
+	case delivery.Attempts >= d.config.Delivery.MaxAttempts:
+		delivery.Status = storage.WebhookDeliveryStatusDead
+		delivery.LastError = err.Error()
+		d.logger.Errorf("webhook delivery %s to %s failed after %d attempts: %v", delivery.ID, webhook.Name, delivery.Attempts, err)
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(d.config.Delivery.Backoff(delivery.Attempts))
+		d.logger.Warnf("webhook delivery %s to %s failed, retrying at %s: %v", delivery.ID, webhook.Name, delivery.NextAttemptAt.Format(time.RFC3339), err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := d.storage.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		d.logger.Errorf("failed to update webhook delivery %s: %v", delivery.ID, err)
+	}
+
+	return delivery.Status == storage.WebhookDeliveryStatusSent
+}
+
+// send posts the payload of a delivery signed with the secret of the webhook and returns the response status.
+// Responses other than 2xx are errors.
+func (d *Dispatcher) send(webhook *storage.Webhook, delivery *storage.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sentinel-Webhook")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// cleanup deletes sent and dead-lettered deliveries older than the retention
+func (d *Dispatcher) cleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deleted, err := d.storage.DeleteWebhookDeliveriesBefore(ctx, time.Now().Add(-d.config.Delivery.Retention))
+	if err != nil {
+		d.logger.Errorf("failed to delete old webhook deliveries: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		d.logger.Infof("deleted %d old webhook deliveries", deleted)
+	}
+}
@@ -0,0 +1,308 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sxwebdev/sentinel/internal/config"
+	"github.com/sxwebdev/sentinel/internal/receiver"
+	"github.com/sxwebdev/sentinel/internal/storage"
+	"github.com/tkcrm/mx/logger"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"incident.opened"}`)
+
+	assert.Equal(t, "sha256=8b5456f1f05aee9e8076eb294deb0ea98feae60eacb03b4070ed032520ac7960", Sign("secret", 1700000000, body))
+	assert.NotEqual(t, Sign("secret", 1700000000, body), Sign("secret", 1700000001, body))
+	assert.NotEqual(t, Sign("secret", 1700000000, body), Sign("other", 1700000000, body))
+}
+
+func TestSend(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		headers http.Header
+		body    []byte
+		status  = http.StatusNoContent
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		headers = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	d := New(logger.Default(), config.WebhooksConfig{Timeout: 5 * time.Second}, nil, nil)
+	webhook := &storage.Webhook{ID: "webhook1", Name: "Receiver", URL: server.URL, Secret: "secret"}
+	delivery := &storage.WebhookDelivery{ID: "delivery1", Event: storage.WebhookEventIncidentOpened, Payload: `{"event":"incident.opened"}`}
+
+	res, err := d.send(webhook, delivery)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res)
+
+	mu.Lock()
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, string(storage.WebhookEventIncidentOpened), headers.Get(HeaderEvent))
+	assert.Equal(t, "delivery1", headers.Get(HeaderDelivery))
+
+	timestamp, err := strconv.ParseInt(headers.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Unix(), timestamp, 5)
+	assert.Equal(t, Sign("secret", timestamp, body), headers.Get(HeaderSignature))
+
+	status = http.StatusInternalServerError
+	mu.Unlock()
+
+	res, err = d.send(webhook, delivery)
+	assert.ErrorContains(t, err, "unexpected status 500")
+	assert.Equal(t, http.StatusInternalServerError, res)
+}
+
+// newTestStore returns an SQLite store in a temporary directory with a webhook subscribed to all events
+func newTestStore(t *testing.T, url string) (*storage.SQLiteStorage, *storage.Webhook) {
+	t.Helper()
+
+	store, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Stop(context.Background()) })
+
+	webhook := &storage.Webhook{Name: "Receiver", URL: url, Secret: "secret", IsEnabled: true}
+	require.NoError(t, store.CreateWebhook(context.Background(), webhook))
+
+	return store, webhook
+}
+
+func TestHandleService(t *testing.T) {
+	ctx := context.Background()
+	store, webhook := newTestStore(t, "http://127.0.0.1:1")
+	d := New(logger.Default(), config.WebhooksConfig{Timeout: time.Second}, store, nil)
+
+	require.NoError(t, d.loadStatuses(ctx))
+	d.statuses["known"] = storage.StatusUp
+
+	service := func(id string, status storage.ServiceStatus) *storage.Service {
+		return &storage.Service{ID: id, Name: id, Status: status}
+	}
+
+	tests := []struct {
+		name     string
+		data     receiver.TriggerServiceData
+		event    storage.WebhookEvent
+		previous storage.ServiceStatus
+	}{
+		{
+			name: "Unchanged status",
+			data: receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeUpdatedState, Svc: service("known", storage.StatusUp)},
+		},
+		{
+			name:     "Changed status",
+			data:     receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeUpdatedState, Svc: service("known", storage.StatusDown)},
+			event:    storage.WebhookEventServiceStateChanged,
+			previous: storage.StatusUp,
+		},
+		{
+			name: "Unknown service",
+			data: receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeUpdatedState, Svc: service("new", storage.StatusDown)},
+		},
+		{
+			name: "Unknown service state is recorded",
+			data: receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeUpdatedState, Svc: service("new", storage.StatusDown)},
+		},
+		{
+			name:     "Change after the recorded state",
+			data:     receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeUpdatedState, Svc: service("new", storage.StatusUp)},
+			event:    storage.WebhookEventServiceStateChanged,
+			previous: storage.StatusDown,
+		},
+		{
+			name:  "Created service",
+			data:  receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeCreated, Svc: service("created", storage.StatusUnknown)},
+			event: storage.WebhookEventServiceCreated,
+		},
+		{
+			name:     "First check of a created service",
+			data:     receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeUpdatedState, Svc: service("created", storage.StatusUp)},
+			event:    storage.WebhookEventServiceStateChanged,
+			previous: storage.StatusUnknown,
+		},
+		{
+			name:  "Deleted service",
+			data:  receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeDeleted, Svc: service("known", storage.StatusDown)},
+			event: storage.WebhookEventServiceDeleted,
+		},
+		{
+			name: "State of a deleted service",
+			data: receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeUpdatedState, Svc: service("known", storage.StatusUp)},
+		},
+		{
+			name: "Checks are not dispatched",
+			data: receiver.TriggerServiceData{EventType: receiver.TriggerServiceEventTypeCheck, Svc: service("created", storage.StatusDown)},
+		},
+	}
+
+	count := uint32(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d.handleService(ctx, tt.data)
+
+			deliveries, err := store.FindWebhookDeliveries(ctx, storage.FindWebhookDeliveriesParams{WebhookID: webhook.ID})
+			require.NoError(t, err)
+
+			if tt.event == "" {
+				assert.Equal(t, count, deliveries.Count)
+				return
+			}
+
+			count++
+			require.Equal(t, count, deliveries.Count)
+
+			// Deliveries are ordered from the newest
+			delivery := deliveries.Items[0]
+			assert.Equal(t, tt.event, delivery.Event)
+
+			var payload Payload
+			require.NoError(t, json.Unmarshal([]byte(delivery.Payload), &payload))
+			assert.Equal(t, tt.event, payload.Event)
+			assert.Equal(t, tt.data.Svc.ID, payload.Data.Service.ID)
+			assert.Equal(t, tt.previous, payload.Data.PreviousStatus)
+		})
+	}
+}
+
+func TestDispatcherReceivesAllEvents(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	store, webhook := newTestStore(t, server.URL)
+
+	rc := receiver.New()
+	require.NoError(t, rc.Start(ctx))
+	t.Cleanup(func() { _ = rc.Stop(ctx) })
+
+	d := New(logger.Default(), config.WebhooksConfig{
+		Timeout:  5 * time.Second,
+		Delivery: config.DeliveryConfig{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Minute, Retention: time.Hour},
+	}, store, rc)
+
+	done := make(chan error, 1)
+	go func() { done <- d.Start(ctx) }()
+	t.Cleanup(func() {
+		_ = d.Stop(ctx)
+		<-done
+	})
+
+	// Wait for the subscriptions of the dispatcher
+	require.Eventually(t, func() bool {
+		rc.TriggerService().Publish(*receiver.NewTriggerServiceData(receiver.TriggerServiceEventTypeCreated, &storage.Service{ID: "probe"}))
+
+		deliveries, err := store.FindWebhookDeliveries(ctx, storage.FindWebhookDeliveriesParams{WebhookID: webhook.ID})
+		return err == nil && deliveries.Count > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Events received while earlier ones are stored are queued
+	events := subscriptionBuffer
+	for i := range events {
+		rc.TriggerService().Publish(*receiver.NewTriggerServiceData(receiver.TriggerServiceEventTypeUpdated, &storage.Service{ID: strconv.Itoa(i)}))
+	}
+
+	require.Eventually(t, func() bool {
+		deliveries, err := store.FindWebhookDeliveries(ctx, storage.FindWebhookDeliveriesParams{
+			WebhookID: webhook.ID,
+			Event:     storage.WebhookEventServiceUpdated,
+		})
+		return err == nil && deliveries.Count == uint32(events)
+	}, 30*time.Second, 50*time.Millisecond)
+}
+
+func TestProcessOrderPerWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu     sync.Mutex
+		events []storage.WebhookEvent
+		status = http.StatusInternalServerError
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		events = append(events, storage.WebhookEvent(r.Header.Get(HeaderEvent)))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	store, webhook := newTestStore(t, server.URL)
+	d := New(logger.Default(), config.WebhooksConfig{
+		Timeout:  5 * time.Second,
+		Delivery: config.DeliveryConfig{MaxAttempts: 5, InitialBackoff: time.Minute, MaxBackoff: time.Hour},
+	}, store, nil)
+
+	opened := &storage.WebhookDelivery{WebhookID: webhook.ID, Event: storage.WebhookEventIncidentOpened, Payload: `{"event":"incident.opened"}`}
+	resolved := &storage.WebhookDelivery{WebhookID: webhook.ID, Event: storage.WebhookEventIncidentResolved, Payload: `{"event":"incident.resolved"}`}
+	require.NoError(t, store.CreateWebhookDeliveries(ctx, []*storage.WebhookDelivery{opened}))
+	require.NoError(t, store.CreateWebhookDeliveries(ctx, []*storage.WebhookDelivery{resolved}))
+
+	get := func(delivery *storage.WebhookDelivery) *storage.WebhookDelivery {
+		got, err := store.GetWebhookDeliveryByID(ctx, delivery.ID)
+		require.NoError(t, err)
+		return got
+	}
+
+	// The resolution waits for the retry of the failed opening, also in later rounds
+	d.process()
+	d.process()
+	assert.Equal(t, 1, get(opened).Attempts)
+	assert.Equal(t, 0, get(resolved).Attempts)
+
+	mu.Lock()
+	status = http.StatusNoContent
+	mu.Unlock()
+
+	failed := get(opened)
+	failed.NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, store.UpdateWebhookDelivery(ctx, failed))
+
+	d.process()
+	assert.Equal(t, storage.WebhookDeliveryStatusSent, get(opened).Status)
+	assert.Equal(t, storage.WebhookDeliveryStatusSent, get(resolved).Status)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []storage.WebhookEvent{
+		storage.WebhookEventIncidentOpened,
+		storage.WebhookEventIncidentOpened,
+		storage.WebhookEventIncidentResolved,
+	}, events)
+}
+
+func TestEnqueue(t *testing.T) {
+	d := New(logger.Default(), config.WebhooksConfig{}, nil, nil)
+
+	for range queueLimit + 1 {
+		d.enqueue(event{service: &receiver.TriggerServiceData{}})
+	}
+
+	// Events over the limit are dropped instead of delaying the broker
+	assert.Len(t, d.queue, queueLimit)
+	assert.Len(t, d.queued, 1)
+}
@@ -77,10 +77,17 @@ func (b *Broker[T]) Stop() {
 // Subscribe returns a new channel for receiving messages.
 // If the broker is already closed, it returns nil.
 func (b *Broker[T]) Subscribe() chan T {
+	return b.SubscribeBuffered(8) // buffered channel to avoid blocking the broker
+}
+
+// SubscribeBuffered returns a new channel for receiving messages with the given buffer size,
+// for subscribers receiving bursts of messages. Messages are dropped once the buffer is full.
+// If the broker is already closed, it returns nil.
+func (b *Broker[T]) SubscribeBuffered(size int) chan T {
 	if b.closed.Load() {
 		return nil
 	}
-	ch := make(chan T, 8) // buffered channel to avoid blocking the broker
+	ch := make(chan T, size)
 	b.subs.Store(ch, struct{}{})
 	return ch
 }